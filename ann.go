@@ -0,0 +1,148 @@
+package geo
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// ANNOptions tunes an ANNIndex.
+type ANNOptions struct {
+	// CellSize is the width of a hash cell.  Neighbors closer than about a cell are
+	// found reliably; queries get slower as cells hold more points.  Defaults to 5km.
+	CellSize Distance
+	// Tables is the number of independently shifted and rotated hash tables.
+	// More tables improve recall at the cost of memory.  Defaults to 4.
+	Tables int
+	// Seed seeds the random shifts and rotations of the tables.
+	Seed int64
+}
+
+// An ANNIndex answers approximate nearest neighbor queries over very large point sets using
+// locality-sensitive hashing: each point is filed into a cell of several randomly shifted and
+// rotated grids over the unit sphere, and queries only measure the points sharing or bordering
+// the query's cells.  Results may miss true neighbors, in exchange for query times that do not
+// grow with the number of points.  It is immutable once built and safe for concurrent use.
+type ANNIndex struct {
+	points []Point
+	width  float64
+	tables []annTable
+}
+
+type annTable struct {
+	rotation [3][3]float64
+	offset   [3]float64
+	buckets  map[[3]int32][]int32
+}
+
+var _ KNNSearcher[int] = (*ANNIndex)(nil)
+
+// NewANNIndex returns a new ANNIndex over the passed in points.
+func NewANNIndex(points []Point, opts ANNOptions) *ANNIndex {
+	if opts.CellSize <= 0 {
+		opts.CellSize = 5 * Kilometer
+	}
+	if opts.Tables <= 0 {
+		opts.Tables = 4
+	}
+
+	r := rand.New(rand.NewSource(opts.Seed))
+	idx := &ANNIndex{
+		points: points,
+		width:  2 * math.Sin(math.Min(opts.CellSize.Kilometers()/EARTH_RADIUS, math.Pi)/2),
+		tables: make([]annTable, opts.Tables),
+	}
+
+	for t := range idx.tables {
+		table := &idx.tables[t]
+		table.rotation = randomRotation(r)
+		for i := range table.offset {
+			table.offset[i] = r.Float64() * idx.width
+		}
+		table.buckets = make(map[[3]int32][]int32)
+
+		for i, p := range points {
+			key := idx.key(table, unitVector(p))
+			table.buckets[key] = append(table.buckets[key], int32(i))
+		}
+	}
+
+	return idx
+}
+
+// Len returns the number of points in the index.
+func (idx *ANNIndex) Len() int {
+	return len(idx.points)
+}
+
+// key returns the cell of table holding the unit vector v.
+func (idx *ANNIndex) key(table *annTable, v [3]float64) [3]int32 {
+	var key [3]int32
+	for i, row := range table.rotation {
+		x := row[0]*v[0] + row[1]*v[1] + row[2]*v[2]
+		key[i] = int32(math.Floor((x + table.offset[i]) / idx.width))
+	}
+	return key
+}
+
+// KNN returns the indices of up to k points approximately nearest to Point p, nearest first,
+// skipping the indices for which filter returns false.  Only points in the cells around p are
+// considered, so fewer than k points are returned when p is far from every point.
+func (idx *ANNIndex) KNN(p Point, k int, filter func(index int) bool) []Neighbor[int] {
+	if k <= 0 {
+		return nil
+	}
+
+	v := unitVector(p)
+	seen := make(map[int32]bool)
+	var neighbors []Neighbor[int]
+
+	for t := range idx.tables {
+		table := &idx.tables[t]
+		key := idx.key(table, v)
+
+		for dx := int32(-1); dx <= 1; dx++ {
+			for dy := int32(-1); dy <= 1; dy++ {
+				for dz := int32(-1); dz <= 1; dz++ {
+					for _, i := range table.buckets[[3]int32{key[0] + dx, key[1] + dy, key[2] + dz}] {
+						if seen[i] {
+							continue
+						}
+						seen[i] = true
+
+						if filter == nil || filter(int(i)) {
+							neighbors = append(neighbors, Neighbor[int]{Item: int(i), Distance: p.GreatCircleDistance(idx.points[i])})
+						}
+					}
+				}
+			}
+		}
+	}
+
+	sort.Slice(neighbors, func(i, j int) bool {
+		return neighbors[i].Distance < neighbors[j].Distance
+	})
+	if len(neighbors) > k {
+		neighbors = neighbors[:k]
+	}
+	return neighbors
+}
+
+// randomRotation returns a uniformly random rotation matrix, built from a random unit quaternion.
+func randomRotation(r *rand.Rand) [3][3]float64 {
+	var q [4]float64
+	var norm float64
+	for norm == 0 {
+		for i := range q {
+			q[i] = r.NormFloat64()
+		}
+		norm = math.Sqrt(q[0]*q[0] + q[1]*q[1] + q[2]*q[2] + q[3]*q[3])
+	}
+	w, x, y, z := q[0]/norm, q[1]/norm, q[2]/norm, q[3]/norm
+
+	return [3][3]float64{
+		{1 - 2*(y*y+z*z), 2 * (x*y - w*z), 2 * (x*z + w*y)},
+		{2 * (x*y + w*z), 1 - 2*(x*x+z*z), 2 * (y*z - w*x)},
+		{2 * (x*z - w*y), 2 * (y*z + w*x), 1 - 2*(x*x+y*y)},
+	}
+}
@@ -0,0 +1,52 @@
+package geo
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// Ensures that approximate neighbors are close to the exact ones for a dense point set.
+func TestANNIndex(t *testing.T) {
+	r := rand.New(rand.NewSource(9))
+	points := make([]Point, 20000)
+	for i := range points {
+		// A city sized cluster spanning about 20km.
+		points[i] = NewPoint(-33.9+r.Float64()*0.2, 151.1+r.Float64()*0.2)
+	}
+
+	ann := NewANNIndex(points, ANNOptions{CellSize: 500 * Meter, Seed: 1})
+	exact := NewKDTree(points)
+
+	hits, total := 0, 0
+	for q := 0; q < 50; q++ {
+		p := NewPoint(-33.9+r.Float64()*0.2, 151.1+r.Float64()*0.2)
+
+		expected := make(map[int]bool)
+		for _, n := range exact.KNN(p, 10, nil) {
+			expected[n.Item] = true
+		}
+
+		found := ann.KNN(p, 10, nil)
+		for i, n := range found {
+			if expected[n.Item] {
+				hits++
+			}
+			if i > 0 && n.Distance < found[i-1].Distance {
+				t.Errorf("Expected neighbors of %v to be sorted by distance", p)
+			}
+		}
+		total += len(expected)
+	}
+
+	if recall := float64(hits) / float64(total); recall < 0.95 {
+		t.Errorf("Expected a recall of at least 0.95, but got %v", recall)
+	}
+
+	if found := ann.KNN(NewPoint(40, -74), 5, nil); len(found) != 0 {
+		t.Errorf("Expected no neighbors far away from every point, but got %v", found)
+	}
+
+	if found := ann.KNN(points[0], 3, func(i int) bool { return i != 0 }); len(found) > 0 && found[0].Item == 0 {
+		t.Error("Expected the filter to exclude point 0")
+	}
+}
@@ -0,0 +1,66 @@
+package geo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// A GeocodeResult is the outcome of geocoding one address of a batch.
+type GeocodeResult struct {
+	Address string
+	Point   Point
+	Err     error
+}
+
+// BatchGeocode geocodes every passed in address with g, running up to concurrency requests at once,
+// and returns their results in the same order as addresses.  Workers take the next address as soon
+// as they finish one, so slow replies do not hold up the rest of the batch.
+//
+// The returned error joins the error of every failed address, annotated with its index, and is nil
+// if they all succeeded.  Once ctx is done the remaining addresses fail with ctx.Err() without
+// being sent.
+func BatchGeocode(ctx context.Context, g Geocoder, addresses []string, concurrency int) ([]GeocodeResult, error) {
+	return BatchGeocodeWith(ctx, g, addresses, concurrency, BatchOptions{})
+}
+
+// BatchGeocodeWith is like BatchGeocode, but controlled by the passed in options.
+// Its progress is counted in addresses.
+func BatchGeocodeWith(ctx context.Context, g Geocoder, addresses []string, concurrency int, opts BatchOptions) ([]GeocodeResult, error) {
+	results := make([]GeocodeResult, len(addresses))
+	prog := newProgress(opts.Progress, len(addresses))
+
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for w := 0; w < min(max(concurrency, 1), len(addresses)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(next.Add(1) - 1)
+				if i >= len(addresses) {
+					return
+				}
+
+				result := GeocodeResult{Address: addresses[i]}
+				if result.Err = ctx.Err(); result.Err == nil {
+					result.Point, result.Err = g.Geocode(ctx, addresses[i])
+				}
+				results[i] = result
+				prog.add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	var errs []error
+	for i, result := range results {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("address %d %q: %w", i, result.Address, result.Err))
+		}
+	}
+
+	return results, errors.Join(errs...)
+}
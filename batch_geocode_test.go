@@ -0,0 +1,72 @@
+package geo
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Ensures that a batch keeps the input order, bounds its concurrency and reports every failure.
+func TestBatchGeocode(t *testing.T) {
+	var running, peak atomic.Int64
+	g := geocoderFuncs{
+		geocode: func(ctx context.Context, address string) (Point, error) {
+			n := running.Add(1)
+			defer running.Add(-1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+
+			if address == "nowhere" {
+				return Point{}, ErrNoResults
+			}
+			lat, err := strconv.ParseFloat(address, 64)
+			return NewPoint(lat, 0), err
+		},
+	}
+
+	addresses := make([]string, 40)
+	for i := range addresses {
+		addresses[i] = strconv.Itoa(i)
+	}
+	addresses[7] = "nowhere"
+
+	results, err := BatchGeocode(context.Background(), g, addresses, 4)
+	if !errors.Is(err, ErrNoResults) {
+		t.Errorf("Expected the batch error to wrap ErrNoResults, but got %v", err)
+	}
+	if peak.Load() > 4 {
+		t.Errorf("Expected at most 4 concurrent requests, but saw %d", peak.Load())
+	}
+
+	for i, result := range results {
+		if result.Address != addresses[i] {
+			t.Errorf("Expected result %d to be for %q, but got %q", i, addresses[i], result.Address)
+		}
+		if i == 7 {
+			if !errors.Is(result.Err, ErrNoResults) {
+				t.Errorf("Expected result 7 to fail with ErrNoResults, but got %v", result.Err)
+			}
+		} else if result.Err != nil || result.Point != NewPoint(float64(i), 0) {
+			t.Errorf("Expected result %d to be %v, but got %v, %v", i, NewPoint(float64(i), 0), result.Point, result.Err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results, err = BatchGeocode(ctx, g, addresses[:3], 0)
+	if !errors.Is(err, context.Canceled) || len(results) != 3 || !errors.Is(results[2].Err, context.Canceled) {
+		t.Errorf("Expected every address to fail with context.Canceled, but got %v, %v", results, err)
+	}
+
+	if results, err := BatchGeocode(context.Background(), g, nil, 4); err != nil || len(results) != 0 {
+		t.Errorf("Expected no results for no addresses, but got %v, %v", results, err)
+	}
+}
@@ -0,0 +1,38 @@
+package geo
+
+import "math"
+
+// compassPoints names the sixteen points of the compass clockwise from north.
+var compassPoints = [16]string{"N", "NNE", "NE", "ENE", "E", "ESE", "SE", "SSE", "S", "SSW", "SW", "WSW", "W", "WNW", "NW", "NNW"}
+
+// NormalizeBearing wraps a bearing in degrees into [0, 360), so that 370 becomes 10 and -90 becomes 270.
+func NormalizeBearing(bearing float64) float64 {
+	bearing = math.Mod(bearing, 360)
+	if bearing < 0 {
+		bearing += 360
+	}
+	if bearing == 360 {
+		// Adding 360 to a tiny negative bearing rounds up.
+		return 0
+	}
+	return bearing
+}
+
+// BearingDifference returns the smallest turn in degrees from bearing a to bearing b, in (-180, 180],
+// positive when clockwise.  For example, turning from 350 to 10 is 20, and from 10 to 350 is -20.
+func BearingDifference(a float64, b float64) float64 {
+	d := NormalizeBearing(b - a)
+	if d > 180 {
+		d -= 360
+	}
+	return d
+}
+
+// CompassDirection returns the nearest of the sixteen points of the compass to a bearing in degrees,
+// such as "N", "NNE" or "NE".  It returns an empty string for a NaN or infinite bearing.
+func CompassDirection(bearing float64) string {
+	if !isFinite(bearing) {
+		return ""
+	}
+	return compassPoints[int(math.Floor(NormalizeBearing(bearing)/22.5+0.5))%16]
+}
@@ -0,0 +1,72 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+// Ensures that bearings are wrapped into [0, 360).
+func TestNormalizeBearing(t *testing.T) {
+	tests := []struct {
+		bearing, expected float64
+	}{
+		{0, 0},
+		{359.5, 359.5},
+		{360, 0},
+		{370, 10},
+		{-90, 270},
+		{-720, 0},
+		{-1e-15, 0},
+	}
+	for _, tt := range tests {
+		if b := NormalizeBearing(tt.bearing); b != tt.expected {
+			t.Errorf("Expected %v normalized to %v, but got %v", tt.bearing, tt.expected, b)
+		}
+	}
+	if b := NormalizeBearing(math.NaN()); !math.IsNaN(b) {
+		t.Errorf("Expected NaN, but got %v", b)
+	}
+}
+
+// Ensures that the difference between bearings is the smallest signed turn.
+func TestBearingDifference(t *testing.T) {
+	tests := []struct {
+		a, b, expected float64
+	}{
+		{350, 10, 20},
+		{10, 350, -20},
+		{0, 180, 180},
+		{180, 0, 180},
+		{90, 90, 0},
+		{-45, 45, 90},
+		{720, 270, -90},
+	}
+	for _, tt := range tests {
+		if d := BearingDifference(tt.a, tt.b); math.Abs(d-tt.expected) > 1e-9 {
+			t.Errorf("Expected the turn from %v to %v to be %v, but got %v", tt.a, tt.b, tt.expected, d)
+		}
+	}
+}
+
+// Ensures that bearings are named by the nearest point of the compass.
+func TestCompassDirection(t *testing.T) {
+	tests := []struct {
+		bearing  float64
+		expected string
+	}{
+		{0, "N"},
+		{11.24, "N"},
+		{11.25, "NNE"},
+		{45, "NE"},
+		{100, "E"},
+		{200, "SSW"},
+		{348.75, "N"},
+		{-90, "W"},
+		{math.Inf(1), ""},
+	}
+	for _, tt := range tests {
+		if d := CompassDirection(tt.bearing); d != tt.expected {
+			t.Errorf("Expected %v to be %q, but got %q", tt.bearing, tt.expected, d)
+		}
+	}
+}
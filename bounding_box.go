@@ -0,0 +1,124 @@
+package geo
+
+import "math"
+
+// A BoundingBox is a rectangular region described by its south-west and north-east corners.
+// A box whose south-west longitude is greater than its north-east longitude
+// is considered to cross the antimeridian.
+type BoundingBox struct {
+	sw Point
+	ne Point
+}
+
+// NewBoundingBox returns a new BoundingBox spanning the passed in south-west and north-east corners.
+func NewBoundingBox(sw Point, ne Point) BoundingBox {
+	return BoundingBox{sw: sw, ne: ne}
+}
+
+// BoundsAround returns the smallest BoundingBox enclosing the circle of radius around center, for
+// prefiltering searches by distance.  Its longitudes reach to where the meridians touch the circle,
+// which lie poleward of its center, and span every longitude when the circle reaches over a pole.
+func BoundsAround(center Point, radius Distance) BoundingBox {
+	angle := math.Max(radius.Kilometers(), 0) / EARTH_RADIUS
+	dLat := angle * 180 / math.Pi
+	minLat, maxLat := center.lat-dLat, center.lat+dLat
+	if minLat <= -90 || maxLat >= 90 {
+		return NewBoundingBox(NewPoint(math.Max(minLat, -90), -180), NewPoint(math.Min(maxLat, 90), 180))
+	}
+
+	dLng := math.Asin(math.Min(math.Sin(angle)/math.Cos(center.lat*math.Pi/180), 1)) * 180 / math.Pi
+	return NewBoundingBox(NewPoint(minLat, NormalizeLng(center.lng-dLng)), NewPoint(maxLat, NormalizeLng(center.lng+dLng)))
+}
+
+// SouthWest returns the south-west corner of the BoundingBox.
+func (b BoundingBox) SouthWest() Point {
+	return b.sw
+}
+
+// NorthEast returns the north-east corner of the BoundingBox.
+func (b BoundingBox) NorthEast() Point {
+	return b.ne
+}
+
+// Bounds returns the BoundingBox itself, so that a BoundingBox can be used as a Geometry.
+func (b BoundingBox) Bounds() BoundingBox {
+	return b
+}
+
+// String renders the BoundingBox as its south-west and north-east corners, "swLat,swLng neLat,neLng".
+func (b BoundingBox) String() string {
+	return b.sw.String() + " " + b.ne.String()
+}
+
+// ToPolygon returns the BoundingBox as a closed Polygon, counterclockwise from its south-west corner.
+// Polygons do not wrap around the antimeridian, so a box crossing it is widened to every longitude.
+func (b BoundingBox) ToPolygon() Polygon {
+	sw, ne := b.sw, b.ne
+	if b.CrossesAntimeridian() {
+		sw.lng, ne.lng = -180, 180
+	}
+	return NewPolygon([]Point{sw, NewPoint(sw.lat, ne.lng), ne, NewPoint(ne.lat, sw.lng), sw})
+}
+
+// CrossesAntimeridian returns whether or not the BoundingBox wraps around the 180th meridian.
+func (b BoundingBox) CrossesAntimeridian() bool {
+	return b.sw.lng > b.ne.lng
+}
+
+// Center returns the midpoint of the BoundingBox in lat/lng space.
+func (b BoundingBox) Center() Point {
+	lat := (b.sw.lat + b.ne.lat) / 2
+	if !b.CrossesAntimeridian() {
+		return NewPoint(lat, (b.sw.lng+b.ne.lng)/2)
+	}
+
+	lng := (b.sw.lng + b.ne.lng + 360) / 2
+	if lng > 180 {
+		lng -= 360
+	}
+
+	return NewPoint(lat, lng)
+}
+
+// Contains returns whether or not the passed in Point lies within the BoundingBox, edges included.
+func (b BoundingBox) Contains(p Point) bool {
+	if !isFinite(p.lat) || !isFinite(p.lng) {
+		return false
+	}
+
+	if p.lat < b.sw.lat || p.lat > b.ne.lat {
+		return false
+	}
+
+	if b.CrossesAntimeridian() {
+		return p.lng >= b.sw.lng || p.lng <= b.ne.lng
+	}
+
+	return p.lng >= b.sw.lng && p.lng <= b.ne.lng
+}
+
+// Intersects returns whether or not the BoundingBox shares any area with the passed in BoundingBox.
+func (b BoundingBox) Intersects(o BoundingBox) bool {
+	if b.ne.lat < o.sw.lat || b.sw.lat > o.ne.lat {
+		return false
+	}
+
+	for _, x := range b.lngIntervals() {
+		for _, y := range o.lngIntervals() {
+			if x[0] <= y[1] && y[0] <= x[1] {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// lngIntervals splits the longitude span of the box into intervals that do not wrap.
+func (b BoundingBox) lngIntervals() [][2]float64 {
+	if b.CrossesAntimeridian() {
+		return [][2]float64{{b.sw.lng, 180}, {-180, b.ne.lng}}
+	}
+
+	return [][2]float64{{b.sw.lng, b.ne.lng}}
+}
@@ -0,0 +1,124 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+// Ensures that a BoundingBox reports containment of points inside, on, and outside its edges.
+func TestBoundingBoxContains(t *testing.T) {
+	b := NewBoundingBox(NewPoint(0, 0), NewPoint(1, 1))
+
+	testers := []testPoint{
+		ntp(0.5, 0.5, true),
+		ntp(0, 0, true),
+		ntp(1, 1, true),
+		ntp(1.1, 0.5, false),
+		ntp(0.5, -0.1, false),
+	}
+
+	for _, q := range testers {
+		if res := b.Contains(q.P); res != q.Expected {
+			t.Errorf("Expected Contains(%v) to be %v, but got %v instead", q.P, q.Expected, res)
+		}
+	}
+}
+
+// Ensures that a BoundingBox crossing the antimeridian handles both sides of the 180th meridian.
+func TestBoundingBoxAntimeridian(t *testing.T) {
+	b := NewBoundingBox(NewPoint(-10, 170), NewPoint(10, -170))
+
+	if !b.CrossesAntimeridian() {
+		t.Error("Expected the box to cross the antimeridian")
+	}
+
+	if !b.Contains(NewPoint(0, 179)) || !b.Contains(NewPoint(0, -179)) {
+		t.Error("Expected points on both sides of the antimeridian to be contained")
+	}
+
+	if b.Contains(NewPoint(0, 0)) {
+		t.Error("Expected the prime meridian to be outside of the box")
+	}
+
+	if c := b.Center(); c.lat != 0 || c.lng != 180 {
+		t.Errorf("Expected the center to be on the antimeridian, but got %v instead", c)
+	}
+}
+
+// Ensures that overlapping and disjoint boxes are detected.
+func TestBoundingBoxIntersects(t *testing.T) {
+	a := NewBoundingBox(NewPoint(0, 0), NewPoint(2, 2))
+	b := NewBoundingBox(NewPoint(1, 1), NewPoint(3, 3))
+	c := NewBoundingBox(NewPoint(5, 5), NewPoint(6, 6))
+	d := NewBoundingBox(NewPoint(0, 179), NewPoint(1, -179))
+	e := NewBoundingBox(NewPoint(0, -180), NewPoint(1, -179.5))
+
+	if !a.Intersects(b) || !b.Intersects(a) {
+		t.Error("Expected overlapping boxes to intersect")
+	}
+
+	if a.Intersects(c) {
+		t.Error("Expected disjoint boxes not to intersect")
+	}
+
+	if !d.Intersects(e) {
+		t.Error("Expected a box crossing the antimeridian to intersect a box on its western side")
+	}
+}
+
+// Ensures that the box around a circle encloses it and touches it on every side.
+func TestBoundsAround(t *testing.T) {
+	tests := []struct {
+		center Point
+		radius Distance
+	}{
+		{NewPoint(0, 0), 100 * Kilometer},
+		{NewPoint(60, 10), 500 * Kilometer},
+		{NewPoint(-75, 179.5), 200 * Kilometer},
+		{NewPoint(45, -90), 10 * Meter},
+	}
+	for _, tt := range tests {
+		b := BoundsAround(tt.center, tt.radius)
+		span := b.ne.lng - b.sw.lng
+		if b.CrossesAntimeridian() {
+			span += 360
+		}
+
+		// Compare the box with the extremes of points round the circle, relative to its center.
+		var west, east, south, north float64
+		for bearing := 0.0; bearing < 360; bearing += 0.05 {
+			p := destinationPoint(tt.center, bearing, tt.radius)
+			dLng := math.Mod(p.lng-tt.center.lng+540, 360) - 180
+			west, east = math.Min(west, dLng), math.Max(east, dLng)
+			south, north = math.Min(south, p.lat-tt.center.lat), math.Max(north, p.lat-tt.center.lat)
+		}
+		if east-west > span+1e-9 || south < b.sw.lat-tt.center.lat-1e-9 || north > b.ne.lat-tt.center.lat+1e-9 {
+			t.Errorf("Expected %v to enclose the circle of %v around %v", b, tt.radius, tt.center)
+		}
+		if math.Abs(span-(east-west)) > 1e-4*span || math.Abs((b.ne.lat-b.sw.lat)-(north-south)) > 1e-4*span {
+			t.Errorf("Expected %v to touch the circle of %v around %v", b, tt.radius, tt.center)
+		}
+	}
+
+	if b := BoundsAround(NewPoint(89, 0), 200*Kilometer); b.sw.lng != -180 || b.ne.lng != 180 || b.ne.lat != 90 {
+		t.Errorf("Expected a box over the pole to span every longitude, but got %v", b)
+	}
+}
+
+// Ensures that a BoundingBox becomes a Polygon covering the same points.
+func TestBoundingBoxToPolygon(t *testing.T) {
+	p := NewBoundingBox(NewPoint(1, 2), NewPoint(3, 5)).ToPolygon()
+	if !p.IsClosed() || len(p.Points()) != 5 || p.Points()[0] != p.Points()[4] {
+		t.Fatalf("Expected a closed ring of 5 points, but got %v", p.Points())
+	}
+	if p.Bounds() != NewBoundingBox(NewPoint(1, 2), NewPoint(3, 5)) {
+		t.Errorf("Expected the bounds of the box, but got %v", p.Bounds())
+	}
+	if !p.Contains(NewPoint(2, 3)) || p.Contains(NewPoint(2, 6)) {
+		t.Errorf("Expected the polygon to contain what the box does")
+	}
+
+	if b := NewBoundingBox(NewPoint(-1, 170), NewPoint(1, -170)).ToPolygon().Bounds(); b != NewBoundingBox(NewPoint(-1, -180), NewPoint(1, 180)) {
+		t.Errorf("Expected a box across the antimeridian widened to every longitude, but got %v", b)
+	}
+}
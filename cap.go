@@ -0,0 +1,81 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+)
+
+// A Cap is the region of the sphere within an angle of its center, as seen from the center of the
+// earth.  Unlike a BoundingBox it has no trouble with the poles or the antimeridian, and testing a
+// Point against it takes a single dot product, which makes it a cheap region for coverage checks.
+type Cap struct {
+	center Point
+	angle  float64
+}
+
+// NewCap returns a new Cap of the points within angle degrees of center.  A negative angle gives an
+// empty Cap, and one of 180 degrees or more the whole sphere.
+func NewCap(center Point, angle float64) Cap {
+	return Cap{center: center, angle: angle}
+}
+
+// Center returns the center of the Cap.
+func (c Cap) Center() Point {
+	return c.center
+}
+
+// Angle returns the angular radius of the Cap in degrees.
+func (c Cap) Angle() float64 {
+	return c.angle
+}
+
+// Radius returns the great circle distance from the center of the Cap to its edge.
+func (c Cap) Radius() Distance {
+	return Distance(math.Max(c.angle, 0) * math.Pi / 180 * EARTH_RADIUS * float64(Kilometer))
+}
+
+// Contains reports whether Point p lies within the Cap, edge included.
+func (c Cap) Contains(p Point) bool {
+	if c.angle < 0 || !isFinite(p.lat) || !isFinite(p.lng) {
+		return false
+	}
+	if c.angle >= 180 {
+		return true
+	}
+	return dot3(unitVector(c.center), unitVector(p)) >= math.Cos(c.angle*math.Pi/180)
+}
+
+// Intersects reports whether the Cap shares any point with the other Cap.
+func (c Cap) Intersects(other Cap) bool {
+	if c.angle < 0 || other.angle < 0 {
+		return false
+	}
+	d := math.Acos(math.Max(-1, math.Min(1, dot3(unitVector(c.center), unitVector(other.center)))))
+	return d*180/math.Pi <= c.angle+other.angle
+}
+
+// Bounds returns the BoundingBox enclosing the Cap, as BoundsAround does.
+func (c Cap) Bounds() BoundingBox {
+	return BoundsAround(c.center, c.Radius())
+}
+
+// Circle returns the Circle of the same center and radius, which holds the same points.
+func (c Cap) Circle() Circle {
+	return NewCircle(c.center, c.Radius())
+}
+
+// Polygon returns a Polygon of n points on the edge of the Cap, for drawing it on a map.
+// n is raised to at least 3.
+func (c Cap) Polygon(n int) Polygon {
+	n = max(n, 3)
+	points := make([]Point, n)
+	for i := range points {
+		points[i] = destinationPoint(c.center, 360*float64(i)/float64(n), c.Radius())
+	}
+	return NewPolygon(points)
+}
+
+// String renders the Cap as its center and angle, for example "Cap(90,0, 10°)".
+func (c Cap) String() string {
+	return fmt.Sprintf("Cap(%v, %v°)", c.center, c.angle)
+}
@@ -0,0 +1,71 @@
+package geo
+
+import (
+	"testing"
+)
+
+// Ensures that a Cap contains the points within its angle, across the poles and the antimeridian.
+func TestCapContains(t *testing.T) {
+	polar := NewCap(NewPoint(90, 0), 10)
+	tests := []struct {
+		c        Cap
+		p        Point
+		expected bool
+	}{
+		{polar, NewPoint(81, 0), true},
+		{polar, NewPoint(81, 180), true},
+		{polar, NewPoint(80.5, -90), true},
+		{polar, NewPoint(79, 45), false},
+		{NewCap(NewPoint(0, 179), 2), NewPoint(0, -179.5), true},
+		{NewCap(NewPoint(0, 179), 2), NewPoint(0, -178), false},
+		{NewCap(NewPoint(10, 10), -1), NewPoint(10, 10), false},
+		{NewCap(NewPoint(10, 10), 180), NewPoint(-10, -170), true},
+	}
+	for _, tt := range tests {
+		if tt.c.Contains(tt.p) != tt.expected {
+			t.Errorf("Expected %v containing %v to be %v", tt.c, tt.p, tt.expected)
+		}
+		if tt.c.angle >= 0 && tt.c.angle < 180 && tt.c.Circle().Contains(tt.p) != tt.expected {
+			t.Errorf("Expected the circle of %v containing %v to be %v", tt.c, tt.p, tt.expected)
+		}
+	}
+}
+
+// Ensures that caps intersect when their angles reach each other.
+func TestCapIntersects(t *testing.T) {
+	a := NewCap(NewPoint(0, 0), 5)
+	tests := []struct {
+		other    Cap
+		expected bool
+	}{
+		{NewCap(NewPoint(0, 9), 5), true},
+		{NewCap(NewPoint(0, 11), 5), false},
+		{NewCap(NewPoint(0, 0), 1), true},
+		{NewCap(NewPoint(0, 0), -1), false},
+	}
+	for _, tt := range tests {
+		if a.Intersects(tt.other) != tt.expected || tt.other.Intersects(a) != tt.expected {
+			t.Errorf("Expected %v intersecting %v to be %v", a, tt.other, tt.expected)
+		}
+	}
+}
+
+// Ensures that the polygon of a Cap lies on its edge.
+func TestCapPolygon(t *testing.T) {
+	c := NewCap(NewPoint(45, 45), 1)
+	polygon := c.Polygon(36)
+	if len(polygon.Points()) != 36 {
+		t.Fatalf("Expected 36 points, but got %v", polygon)
+	}
+	for _, p := range polygon.Points() {
+		if d := c.center.GreatCircleDistance(p); d < c.Radius()-Meter || d > c.Radius()+Meter {
+			t.Errorf("Expected %v on the edge of %v, but got %v away", p, c, d)
+		}
+		if inward := NewPoint(p.lat+(c.center.lat-p.lat)*1e-9, p.lng+(c.center.lng-p.lng)*1e-9); !c.Bounds().Contains(inward) {
+			t.Errorf("Expected the bounds of %v to contain %v", c, p)
+		}
+	}
+	if !polygon.Contains(c.center) || polygon.Contains(NewPoint(47, 45)) {
+		t.Errorf("Expected the polygon to cover the cap")
+	}
+}
@@ -0,0 +1,93 @@
+package geo
+
+import "fmt"
+
+// geometricMedianTolerance is the step below which GeometricMedian stops iterating.
+const geometricMedianTolerance = 1 * Meter / 1000
+
+// geometricMedianIterations bounds the iterations of GeometricMedian.
+const geometricMedianIterations = 1000
+
+// WeightedCentroid returns the center of mass of points on the sphere, each weighted by the weight
+// at the same index, such as the population of a town.  Without weights, every point weighs 1.
+// Unlike the mean of their coordinates, it is unaffected by the antimeridian.  It returns the zero
+// Point for no points, and an error wrapping ErrInvalidFormat unless there is one weight for each point.
+func WeightedCentroid(points []Point, weights []float64) (Point, error) {
+	if weights != nil && len(weights) != len(points) {
+		return Point{}, fmt.Errorf("%w: %d weights for %d points", ErrInvalidFormat, len(weights), len(points))
+	}
+
+	var sum [3]float64
+	for i, p := range points {
+		w := 1.0
+		if weights != nil {
+			w = weights[i]
+		}
+		v := unitVector(p)
+		sum[0], sum[1], sum[2] = sum[0]+w*v[0], sum[1]+w*v[1], sum[2]+w*v[2]
+	}
+	if sum == [3]float64{} {
+		return Point{}, nil
+	}
+	return vectorPoint(sum), nil
+}
+
+// GeometricMedian returns the point with the least total great circle distance to points, each
+// weighted by the weight at the same index, such as the site of a depot that minimizes the travel
+// to its customers.  Without weights, every point weighs 1.  Unlike the centroid, it is not pulled
+// far by a few outlying points.  It is found with Weiszfeld's algorithm, adapted to the sphere,
+// starting from the WeightedCentroid.  It returns the zero Point for no points, and an error
+// wrapping ErrInvalidFormat unless there is one weight for each point.
+func GeometricMedian(points []Point, weights []float64) (Point, error) {
+	median, err := WeightedCentroid(points, weights)
+	if err != nil || len(points) == 0 {
+		return median, err
+	}
+
+	vectors := make([][3]float64, len(points))
+	for i, p := range points {
+		vectors[i] = unitVector(p)
+	}
+	for range geometricMedianIterations {
+		var sum [3]float64
+		for i, p := range points {
+			d := median.GreatCircleDistance(p).Meters()
+			if d == 0 {
+				// Weiszfeld's step is undefined at a point itself; the others pull it away if they outweigh it.
+				continue
+			}
+			w := 1.0
+			if weights != nil {
+				w = weights[i]
+			}
+			sum[0], sum[1], sum[2] = sum[0]+w*vectors[i][0]/d, sum[1]+w*vectors[i][1]/d, sum[2]+w*vectors[i][2]/d
+		}
+		if sum == [3]float64{} {
+			break
+		}
+
+		next := vectorPoint(sum)
+		if medianCost(next, points, weights) > medianCost(median, points, weights) {
+			break
+		}
+		step := next.GreatCircleDistance(median)
+		median = next
+		if step < geometricMedianTolerance {
+			break
+		}
+	}
+	return median, nil
+}
+
+// medianCost returns the total weighted great circle distance from c to points.
+func medianCost(c Point, points []Point, weights []float64) float64 {
+	var cost float64
+	for i, p := range points {
+		w := 1.0
+		if weights != nil {
+			w = weights[i]
+		}
+		cost += w * c.GreatCircleDistance(p).Meters()
+	}
+	return cost
+}
@@ -0,0 +1,69 @@
+package geo
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// Ensures that centroids are weighted and computed on the sphere.
+func TestWeightedCentroid(t *testing.T) {
+	tests := []struct {
+		points   []Point
+		weights  []float64
+		expected Point
+	}{
+		{[]Point{NewPoint(0, 179), NewPoint(0, -179)}, nil, NewPoint(0, 180)},
+		{[]Point{NewPoint(0, 0), NewPoint(0, 1)}, []float64{3, 1}, NewPoint(0, 0.2499952)},
+		{[]Point{NewPoint(10, 10)}, nil, NewPoint(10, 10)},
+		{nil, nil, Point{}},
+	}
+	for _, tt := range tests {
+		c, err := WeightedCentroid(tt.points, tt.weights)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(c.lat-tt.expected.lat) > 1e-6 || math.Abs(math.Mod(c.lng-tt.expected.lng+540, 360)-180) > 1e-6 {
+			t.Errorf("Expected the centroid of %v at %v, but got %v", tt.points, tt.expected, c)
+		}
+	}
+
+	if _, err := WeightedCentroid([]Point{NewPoint(0, 0)}, []float64{1, 2}); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("Expected ErrInvalidFormat, but got %v", err)
+	}
+}
+
+// Ensures that the geometric median minimizes the total distance and resists outliers.
+func TestGeometricMedian(t *testing.T) {
+	square := []Point{NewPoint(-1, -1), NewPoint(-1, 1), NewPoint(1, 1), NewPoint(1, -1)}
+	tests := []struct {
+		points   []Point
+		weights  []float64
+		expected Point
+	}{
+		{square, nil, NewPoint(0, 0)},
+		{[]Point{NewPoint(0, 0), NewPoint(0, 1), NewPoint(0, 10)}, nil, NewPoint(0, 1)},
+		{[]Point{NewPoint(0, 0), NewPoint(0, 1), NewPoint(1, 0)}, []float64{10, 1, 1}, NewPoint(0, 0)},
+	}
+	for _, tt := range tests {
+		m, err := GeometricMedian(tt.points, tt.weights)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d := m.GreatCircleDistance(tt.expected); d > Meter {
+			t.Errorf("Expected the median of %v at %v, but got %v", tt.points, tt.expected, m)
+		}
+	}
+
+	// The median of a cluster with an outlier stays with the cluster, and beats the centroid.
+	points := []Point{NewPoint(0, 0), NewPoint(0, 0.01), NewPoint(0.01, 0), NewPoint(0.01, 0.01), NewPoint(20, 20)}
+	m, _ := GeometricMedian(points, nil)
+	c, _ := WeightedCentroid(points, nil)
+	if m.GreatCircleDistance(NewPoint(0.005, 0.005)) > 2*Kilometer || medianCost(m, points, nil) >= medianCost(c, points, nil) {
+		t.Errorf("Expected the median near the cluster, but got %v", m)
+	}
+
+	if _, err := GeometricMedian(points, []float64{1}); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("Expected ErrInvalidFormat, but got %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+package geo
+
+import "fmt"
+
+// A Circle is the region within a great circle distance of its center, such as
+// "within 500m of the site".  Testing a Point against a Circle takes a single haversine
+// evaluation, which is much cheaper than testing it against a Polygon approximating the Circle.
+type Circle struct {
+	center Point
+	radius Distance
+}
+
+// NewCircle returns a new Circle of the passed in radius around center.
+func NewCircle(center Point, radius Distance) Circle {
+	return Circle{center: center, radius: radius}
+}
+
+// Center returns the center of the Circle.
+func (c Circle) Center() Point {
+	return c.center
+}
+
+// Radius returns the radius of the Circle.
+func (c Circle) Radius() Distance {
+	return c.radius
+}
+
+// Bounds returns a BoundingBox enclosing the Circle, which spans every longitude
+// when the Circle reaches over a pole.
+func (c Circle) Bounds() BoundingBox {
+	return BoundsAround(c.center, c.radius)
+}
+
+// Contains reports whether Point p lies within the radius of the Circle's center.
+func (c Circle) Contains(p Point) bool {
+	return c.center.GreatCircleDistance(p) <= c.radius
+}
+
+// String renders the Circle as its center and radius, for example "Circle(-33.8688,151.2093, 500m)".
+func (c Circle) String() string {
+	return fmt.Sprintf("Circle(%v, %v)", c.center, c.radius)
+}
@@ -0,0 +1,35 @@
+package geo
+
+import "testing"
+
+// Ensures that a circle contains the points within its radius and is enclosed by its bounds.
+func TestCircle(t *testing.T) {
+	site := NewPoint(-33.8688, 151.2093)
+	c := NewCircle(site, 500*Meter)
+	if c.Center() != site || c.Radius() != 500 {
+		t.Errorf("Expected %v and 500m, but got %v and %v", site, c.Center(), c.Radius())
+	}
+	if s := c.String(); s != "Circle(-33.8688,151.2093, 500m)" {
+		t.Errorf("Expected Circle(-33.8688,151.2093, 500m), but got %s", s)
+	}
+
+	// Roughly 450m and 550m from the site in each direction.
+	for _, axis := range [][2]float64{{0.00405, 0}, {0, 0.00486}, {-0.00405, 0}, {0, -0.00486}} {
+		inside := site.Offset(axis[0], axis[1])
+		outside := site.Offset(axis[0]*11/9, axis[1]*11/9)
+		if !c.Contains(inside) || !c.Bounds().Contains(inside) {
+			t.Errorf("Expected %v, %v away, to be inside the circle", inside, site.GreatCircleDistance(inside))
+		}
+		if c.Contains(outside) {
+			t.Errorf("Expected %v, %v away, to be outside the circle", outside, site.GreatCircleDistance(outside))
+		}
+	}
+
+	polar := NewCircle(NewPoint(89.9, 0), 50*Kilometer)
+	if b := polar.Bounds(); b.SouthWest().Lng() != -180 || b.NorthEast().Lat() != 90 {
+		t.Errorf("Expected a circle over the pole to span every longitude, but got %v", b)
+	}
+	if !polar.Contains(NewPoint(89.9, 180)) {
+		t.Error("Expected the far side of the pole to be inside the circle")
+	}
+}
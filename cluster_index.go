@@ -0,0 +1,235 @@
+package geo
+
+import "math"
+
+// Defaults of ClusterIndexOptions.
+const (
+	DefaultClusterRadius  = 40
+	DefaultClusterExtent  = 512
+	DefaultClusterMaxZoom = 16
+)
+
+// ClusterIndexOptions configures a ClusterIndex.
+type ClusterIndexOptions struct {
+	// Radius is the radius in pixels within which points are merged into a cluster.
+	// Defaults to DefaultClusterRadius.
+	Radius float64
+	// Extent is the width in pixels of a map tile.  Defaults to DefaultClusterExtent.
+	Extent float64
+	// MinZoom and MaxZoom are the range of zoom levels clusters are computed for.  Above MaxZoom
+	// every point is shown on its own.  MaxZoom defaults to DefaultClusterMaxZoom.
+	MinZoom int
+	MaxZoom int
+	// MinPoints is the fewest points that form a cluster.  Defaults to 2.
+	MinPoints int
+}
+
+// A MapCluster is a marker to show on a map at some zoom level: either a cluster of points
+// or a single point.
+type MapCluster struct {
+	// Point is the location of the single point, or the center of the points of the cluster.
+	Point Point
+	// Count is the number of points in the cluster, or 1 for a single point.
+	Count int
+	// ID identifies a cluster to Children, Leaves and ExpansionZoom, and is -1 for a single point.
+	ID int
+	// Index is the index of a single point in the slice the ClusterIndex was built from,
+	// and -1 for a cluster.
+	Index int
+}
+
+// A ClusterIndex precomputes clusters of points for every zoom level of a web map, so that a map
+// showing millions of markers only has to draw the few clusters in view.  It follows the greedy
+// clustering of the supercluster library: from the highest zoom level down, each point or cluster
+// takes in those within a radius of pixels of it that are not yet taken, and the center of a cluster
+// is the mean of its points in Web Mercator.  It is immutable once built and safe for concurrent use.
+type ClusterIndex struct {
+	opts ClusterIndexOptions
+	// levels holds the markers of each zoom level from MinZoom to MaxZoom+1.
+	levels []clusterLevel
+	// clusters holds the zoom level and children of every cluster by ID.
+	clusters []clusterRecord
+}
+
+// clusterLevel holds the markers of one zoom level.
+type clusterLevel struct {
+	nodes []clusterNode
+	tree  *Quadtree[int]
+}
+
+// clusterNode is a marker of a level with its Web Mercator coordinates, from 0 to 1.
+type clusterNode struct {
+	MapCluster
+	x, y float64
+}
+
+// clusterRecord is the zoom level a cluster was formed at and the markers it was formed from,
+// which are on the level above.
+type clusterRecord struct {
+	zoom     int
+	children []int
+}
+
+// NewClusterIndex returns a ClusterIndex over the passed in points.  Invalid coordinates are left out.
+func NewClusterIndex(points []Point, opts ClusterIndexOptions) *ClusterIndex {
+	if opts.Radius <= 0 {
+		opts.Radius = DefaultClusterRadius
+	}
+	if opts.Extent <= 0 {
+		opts.Extent = DefaultClusterExtent
+	}
+	if opts.MaxZoom <= 0 {
+		opts.MaxZoom = DefaultClusterMaxZoom
+	}
+	opts.MinZoom = min(max(opts.MinZoom, 0), opts.MaxZoom)
+	if opts.MinPoints < 2 {
+		opts.MinPoints = 2
+	}
+
+	idx := &ClusterIndex{opts: opts, levels: make([]clusterLevel, opts.MaxZoom-opts.MinZoom+2)}
+	var top []clusterNode
+	for i, p := range points {
+		if !IsValidCoordinate(p.lat, p.lng) {
+			continue
+		}
+		x, y := tileFraction(p, 0)
+		top = append(top, clusterNode{MapCluster: MapCluster{Point: p, Count: 1, ID: -1, Index: i}, x: x, y: y})
+	}
+	idx.setLevel(opts.MaxZoom+1, top)
+
+	for z := opts.MaxZoom; z >= opts.MinZoom; z-- {
+		idx.setLevel(z, idx.cluster(z))
+	}
+	return idx
+}
+
+// level returns the markers of zoom level z.
+func (idx *ClusterIndex) level(z int) *clusterLevel {
+	return &idx.levels[z-idx.opts.MinZoom]
+}
+
+// setLevel indexes nodes as the markers of zoom level z.  They are indexed at their Web Mercator
+// positions, which clamp points beyond MaxTileLatitude to it, as the search boxes of cluster are.
+func (idx *ClusterIndex) setLevel(z int, nodes []clusterNode) {
+	tree := NewQuadtree[int](DefaultQuadtreeCapacity)
+	for i, n := range nodes {
+		tree.Insert(tileCornerFraction(n.x, n.y, 0), i)
+	}
+	*idx.level(z) = clusterLevel{nodes: nodes, tree: tree}
+}
+
+// cluster returns the markers of zoom level z, formed from those of the level above.
+func (idx *ClusterIndex) cluster(z int) []clusterNode {
+	above := idx.level(z + 1)
+	r := idx.opts.Radius / (idx.opts.Extent * math.Exp2(float64(z)))
+	taken := make([]bool, len(above.nodes))
+
+	var nodes []clusterNode
+	for i, n := range above.nodes {
+		if taken[i] {
+			continue
+		}
+		taken[i] = true
+
+		// Find the untaken markers within r of n in Web Mercator.
+		members := []int{i}
+		count := n.Count
+		sw := tileCornerFraction(max(n.x-r, 0), min(n.y+r, 1), 0)
+		ne := tileCornerFraction(min(n.x+r, 1), max(n.y-r, 0), 0)
+		above.tree.SearchFunc(NewBoundingBox(sw, ne), func(item QuadtreeItem[int]) bool {
+			j := item.Value
+			m := above.nodes[j]
+			if !taken[j] && (m.x-n.x)*(m.x-n.x)+(m.y-n.y)*(m.y-n.y) <= r*r {
+				members = append(members, j)
+				count += m.Count
+			}
+			return true
+		})
+
+		if len(members) == 1 || count < idx.opts.MinPoints {
+			nodes = append(nodes, n)
+			continue
+		}
+
+		var wx, wy float64
+		for _, j := range members {
+			taken[j] = true
+			m := above.nodes[j]
+			wx += m.x * float64(m.Count)
+			wy += m.y * float64(m.Count)
+		}
+		wx, wy = wx/float64(count), wy/float64(count)
+
+		id := len(idx.clusters)
+		idx.clusters = append(idx.clusters, clusterRecord{zoom: z, children: members})
+		nodes = append(nodes, clusterNode{
+			MapCluster: MapCluster{Point: tileCornerFraction(wx, wy, 0), Count: count, ID: id, Index: -1},
+			x:          wx,
+			y:          wy,
+		})
+	}
+	return nodes
+}
+
+// clampZoom returns the level holding the markers of zoom level z.
+func (idx *ClusterIndex) clampZoom(z int) int {
+	return min(max(z, idx.opts.MinZoom), idx.opts.MaxZoom+1)
+}
+
+// ClustersInBounds returns the clusters and single points inside BoundingBox b at zoom level zoom,
+// in no particular order.  As on a Web Mercator map, points beyond MaxTileLatitude count as lying on it.
+func (idx *ClusterIndex) ClustersInBounds(b BoundingBox, zoom int) []MapCluster {
+	level := idx.level(idx.clampZoom(zoom))
+	clusters := []MapCluster{}
+	swX, swY := tileFraction(b.sw, 0)
+	neX, neY := tileFraction(b.ne, 0)
+	b = NewBoundingBox(tileCornerFraction(swX, swY, 0), tileCornerFraction(neX, neY, 0))
+	level.tree.SearchFunc(b, func(item QuadtreeItem[int]) bool {
+		clusters = append(clusters, level.nodes[item.Value].MapCluster)
+		return true
+	})
+	return clusters
+}
+
+// Children returns the clusters and single points the cluster with the passed in ID splits into
+// at the next zoom level, and false if there is no such cluster.
+func (idx *ClusterIndex) Children(id int) ([]MapCluster, bool) {
+	if id < 0 || id >= len(idx.clusters) {
+		return nil, false
+	}
+	r := idx.clusters[id]
+	above := idx.level(r.zoom + 1)
+	children := make([]MapCluster, len(r.children))
+	for i, j := range r.children {
+		children[i] = above.nodes[j].MapCluster
+	}
+	return children, true
+}
+
+// Leaves returns the indices of the points in the cluster with the passed in ID, in no particular
+// order, and false if there is no such cluster.
+func (idx *ClusterIndex) Leaves(id int) ([]int, bool) {
+	children, ok := idx.Children(id)
+	if !ok {
+		return nil, false
+	}
+	var leaves []int
+	for _, c := range children {
+		if c.ID < 0 {
+			leaves = append(leaves, c.Index)
+			continue
+		}
+		more, _ := idx.Leaves(c.ID)
+		leaves = append(leaves, more...)
+	}
+	return leaves, true
+}
+
+// ExpansionZoom returns the zoom level at which the cluster with the passed in ID splits apart, for
+// zooming in on a cluster clicked on a map, and false if there is no such cluster.
+func (idx *ClusterIndex) ExpansionZoom(id int) (int, bool) {
+	if id < 0 || id >= len(idx.clusters) {
+		return 0, false
+	}
+	return idx.clusters[id].zoom + 1, true
+}
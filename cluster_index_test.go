@@ -0,0 +1,88 @@
+package geo
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// Ensures that points are clustered less as the zoom level rises, and that clusters can be expanded.
+func TestClusterIndex(t *testing.T) {
+	r := rand.New(rand.NewSource(9))
+	var points []Point
+	for range 100 {
+		points = append(points, NewPoint(-33.87+r.Float64()*0.001, 151.21+r.Float64()*0.001))
+	}
+	for range 50 {
+		points = append(points, NewPoint(-37.81+r.Float64()*0.001, 144.96+r.Float64()*0.001))
+	}
+	points = append(points, NewPoint(-31.95, 115.86), NewPoint(91, 0))
+
+	idx := NewClusterIndex(points, ClusterIndexOptions{})
+	world := NewBoundingBox(NewPoint(-90, -180), NewPoint(90, 180))
+	count := func(clusters []MapCluster) int {
+		n := 0
+		for _, c := range clusters {
+			n += c.Count
+		}
+		return n
+	}
+
+	if clusters := idx.ClustersInBounds(world, 0); len(clusters) != 2 || count(clusters) != 151 {
+		t.Errorf("Expected Perth apart from a cluster of the other valid points at zoom 0, but got %+v", clusters)
+	}
+	clusters := idx.ClustersInBounds(world, 3)
+	sort.Slice(clusters, func(i, j int) bool { return clusters[i].Count > clusters[j].Count })
+	if len(clusters) != 3 || clusters[0].Count != 100 || clusters[1].Count != 50 || clusters[2].Count != 1 || clusters[2].Index != 150 || clusters[2].ID != -1 {
+		t.Fatalf("Expected clusters of Sydney, Melbourne and Perth at zoom 3, but got %+v", clusters)
+	}
+	if d := clusters[0].Point.GreatCircleDistance(NewPoint(-33.8695, 151.2105)); d > 100*Meter {
+		t.Errorf("Expected the Sydney cluster centered on its points, but got %v", clusters[0].Point)
+	}
+	if clusters := idx.ClustersInBounds(world, 30); len(clusters) != 151 || count(clusters) != 151 {
+		t.Errorf("Expected every point on its own above the maximum zoom, but got %d markers", len(clusters))
+	}
+	sydney := NewBoundingBox(NewPoint(-34, 151), NewPoint(-33, 152))
+	if clusters := idx.ClustersInBounds(sydney, 10); len(clusters) != 1 || clusters[0].Count != 100 {
+		t.Errorf("Expected only Sydney in view, but got %+v", clusters)
+	}
+
+	leaves, ok := idx.Leaves(clusters[0].ID)
+	sort.Ints(leaves)
+	if !ok || len(leaves) != 100 || leaves[0] != 0 || leaves[99] != 99 {
+		t.Errorf("Expected the Sydney points as leaves, but got %v", leaves)
+	}
+	zoom, ok := idx.ExpansionZoom(clusters[0].ID)
+	if !ok || zoom <= 3 {
+		t.Fatalf("Expected the Sydney cluster to split above zoom 3, but got %v", zoom)
+	}
+	children, _ := idx.Children(clusters[0].ID)
+	if len(children) < 2 || count(children) != 100 {
+		t.Errorf("Expected the Sydney cluster to split into its 100 points, but got %+v", children)
+	}
+	if expanded := idx.ClustersInBounds(sydney, zoom); len(expanded) != len(children) {
+		t.Errorf("Expected %d markers at the expansion zoom, but got %d", len(children), len(expanded))
+	}
+
+	if _, ok := idx.Children(-1); ok {
+		t.Errorf("Expected no children of a single point")
+	}
+}
+
+// Ensures that points beyond the latitudes of Web Mercator cluster as those within them do.
+func TestClusterIndexPolar(t *testing.T) {
+	for _, lat := range []float64{80, 88, -88} {
+		points := []Point{NewPoint(lat, 10), NewPoint(lat, 10.001), NewPoint(lat, 10.002)}
+		idx := NewClusterIndex(points, ClusterIndexOptions{})
+
+		world := NewBoundingBox(NewPoint(-90, -180), NewPoint(90, 180))
+		if clusters := idx.ClustersInBounds(world, 0); len(clusters) != 1 || clusters[0].Count != 3 {
+			t.Errorf("Expected the points at latitude %v to form 1 cluster at zoom 0, but got %+v", lat, clusters)
+		}
+
+		around := NewBoundingBox(NewPoint(lat-1, 9), NewPoint(lat+1, 11))
+		if clusters := idx.ClustersInBounds(around, 30); len(clusters) != 3 {
+			t.Errorf("Expected the 3 points at latitude %v in view around them, but got %+v", lat, clusters)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	geo "github.com/smarteaston/golang-geo"
+)
+
+// Formats geometries can be read from and written in.
+const (
+	formatGeoJSON = "geojson"
+	formatKML     = "kml"
+	formatGPX     = "gpx"
+)
+
+// detectFormat returns the format named by the extension of path, or failing that the format the
+// data looks like.  It returns "" if neither gives it away.
+func detectFormat(path string, data []byte) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".geojson", ".json":
+		return formatGeoJSON
+	case ".kml":
+		return formatKML
+	case ".gpx":
+		return formatGPX
+	}
+
+	trimmed := bytes.TrimSpace(data)
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("{")):
+		return formatGeoJSON
+	case bytes.Contains(trimmed, []byte("<kml")):
+		return formatKML
+	case bytes.Contains(trimmed, []byte("<gpx")):
+		return formatGPX
+	}
+	return ""
+}
+
+// decode reads every geometry out of data in the passed in format.
+func decode(format string, data []byte) ([]geo.Geometry, error) {
+	switch format {
+	case formatGeoJSON:
+		return geo.DecodeGeoJSON(data)
+	case formatKML:
+		return geo.DecodeKML(data)
+	case formatGPX:
+		tracks, err := geo.DecodeGPX(data)
+		if err != nil {
+			return nil, err
+		}
+		geometries := make([]geo.Geometry, len(tracks))
+		for i, t := range tracks {
+			geometries[i] = t
+		}
+		return geometries, nil
+	}
+	return nil, fmt.Errorf("unknown format %q, expected geojson, kml or gpx", format)
+}
+
+// encode writes the geometries to w in the passed in format.
+func encode(w io.Writer, format string, geometries []geo.Geometry) error {
+	switch format {
+	case formatGeoJSON:
+		return geo.WriteGeoJSON(w, geometries...)
+	case formatKML:
+		return geo.WriteKML(w, geometries...)
+	case formatGPX:
+		tracks := make([]geo.Track, 0, len(geometries))
+		for _, g := range geometries {
+			switch g := g.(type) {
+			case geo.Track:
+				tracks = append(tracks, g)
+			case geo.LineString:
+				t := make(geo.Track, len(g.Points()))
+				for i, p := range g.Points() {
+					t[i] = geo.TrackPoint{Point: p}
+				}
+				tracks = append(tracks, t)
+			default:
+				return fmt.Errorf("%w: gpx only holds tracks, not %T", geo.ErrUnsupportedGeometry, g)
+			}
+		}
+		return geo.WriteGPX(w, tracks...)
+	}
+	return fmt.Errorf("unknown format %q, expected geojson, kml or gpx", format)
+}
@@ -0,0 +1,364 @@
+// Command geo exposes the geo package on the command line.  Every command reads from standard input
+// when no file is named, or the file is "-", and writes to standard output, so they compose in pipelines.
+//
+// Usage:
+//
+//	geo contains FENCE [--point LAT,LNG]...
+//	geo distance [--unit m|km|mi|nmi] [--method haversine|vincenty] [A B]
+//	geo convert [FILE] --to geojson|kml|gpx [--from FORMAT]
+//	geo simplify [FILE] --tolerance N [--unit m|km|mi|nmi] [--from FORMAT] [--to FORMAT]
+//
+// contains prints true or false for each point passed with --point, or for each line of standard input
+// if there are none, depending on whether it lies inside a polygon of FENCE; a FENCE read from standard
+// input needs --point.  Polygons with holes are rejected.  distance prints the great
+// circle distance between points A and B, or the length of the path through the points on the lines of
+// standard input.  Points are read by geo.ParsePoint, latitude first; put "--" before points starting
+// with a minus sign.  Geometries are read and written as GeoJSON, KML or GPX, detected from the file
+// extension or contents unless --from is given.
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	geo "github.com/smarteaston/golang-geo"
+)
+
+const usage = `usage:
+  geo contains FENCE [--point LAT,LNG]...
+  geo distance [--unit m|km|mi|nmi] [--method haversine|vincenty] [A B]
+  geo convert [FILE] --to geojson|kml|gpx [--from FORMAT]
+  geo simplify [FILE] --tolerance N [--unit m|km|mi|nmi] [--from FORMAT] [--to FORMAT]
+`
+
+// errUsage is returned for command lines that cannot be run, after the problem has been reported.
+var errUsage = errors.New("usage")
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr); err != nil {
+		if errors.Is(err, errUsage) {
+			os.Exit(2)
+		}
+		fmt.Fprintln(os.Stderr, "geo:", err)
+		os.Exit(1)
+	}
+}
+
+// run runs the command named by the first of args.
+func run(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return errUsage
+	}
+
+	commands := map[string]func([]string, io.Reader, io.Writer, io.Writer) error{
+		"contains": runContains,
+		"distance": runDistance,
+		"convert":  runConvert,
+		"simplify": runSimplify,
+	}
+	command, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "geo: unknown command %q\n%s", args[0], usage)
+		return errUsage
+	}
+	return command(args[1:], stdin, stdout, stderr)
+}
+
+func runContains(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
+	fs := newFlagSet("contains", stderr)
+	var points stringsFlag
+	fs.Var(&points, "point", "point to test, latitude first; may be repeated")
+	files, err := parseFlags(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	if files[0] == "-" && len(points) == 0 {
+		fmt.Fprintln(stderr, "geo contains: pass points with --point when reading the fence from standard input")
+		return errUsage
+	}
+
+	geometries, err := readGeometries(files[0], "", stdin)
+	if err != nil {
+		return err
+	}
+	var fence []geo.Polygon
+	for _, g := range geometries {
+		switch g := g.(type) {
+		case geo.Polygon:
+			fence = append(fence, g)
+		case geo.MultiPolygon:
+			fence = append(fence, g.Polygons()...)
+		}
+	}
+	if len(fence) == 0 {
+		return fmt.Errorf("%w: %s has no polygons", geo.ErrUnsupportedGeometry, files[0])
+	}
+	fences := geo.NewMultiPolygon(fence...)
+
+	w := bufio.NewWriter(stdout)
+	test := func(s string) error {
+		p, err := geo.ParsePoint(s)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, fences.Contains(p))
+		return err
+	}
+	if len(points) > 0 {
+		for _, s := range points {
+			if err := test(s); err != nil {
+				return err
+			}
+		}
+	} else if err := eachLine(stdin, test); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+func runDistance(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
+	fs := newFlagSet("distance", stderr)
+	unit := fs.String("unit", "m", "unit to print the distance in: m, km, mi or nmi")
+	method := fs.String("method", "haversine", "how to measure the distance: haversine or vincenty")
+	args, err := parseFlags(fs, args, 0, 2)
+	if err != nil {
+		return err
+	}
+	if len(args) == 1 {
+		fmt.Fprintln(stderr, "geo distance: expected two points, or none to read a path from standard input")
+		return errUsage
+	}
+
+	u, err := parseUnit(*unit)
+	if err != nil {
+		return err
+	}
+	var m geo.DistanceMethod
+	switch strings.ToLower(*method) {
+	case "haversine":
+		m = geo.Haversine
+	case "vincenty":
+		m = geo.Vincenty
+	default:
+		return fmt.Errorf("unknown method %q, expected haversine or vincenty", *method)
+	}
+
+	var points []geo.Point
+	parse := func(s string) error {
+		p, err := geo.ParsePoint(s)
+		if err != nil {
+			return err
+		}
+		points = append(points, p)
+		return nil
+	}
+	if len(args) == 2 {
+		for _, s := range args {
+			if err := parse(s); err != nil {
+				return err
+			}
+		}
+	} else if err := eachLine(stdin, parse); err != nil {
+		return err
+	}
+
+	d := geo.NewLineString(points).LengthWith(m)
+	_, err = fmt.Fprintln(stdout, strconv.FormatFloat(float64(d/u), 'f', -1, 64))
+	return err
+}
+
+func runConvert(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
+	fs := newFlagSet("convert", stderr)
+	from := fs.String("from", "", "format to read: geojson, kml or gpx; detected when empty")
+	to := fs.String("to", "", "format to write: geojson, kml or gpx")
+	files, err := parseFlags(fs, args, 0, 1)
+	if err != nil {
+		return err
+	}
+	if *to == "" {
+		fmt.Fprintln(stderr, "geo convert: --to is required")
+		return errUsage
+	}
+
+	geometries, err := readGeometries(fileArg(files), *from, stdin)
+	if err != nil {
+		return err
+	}
+	return encode(stdout, strings.ToLower(*to), geometries)
+}
+
+func runSimplify(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
+	fs := newFlagSet("simplify", stderr)
+	tolerance := fs.Float64("tolerance", 0, "distance within which points are dropped")
+	unit := fs.String("unit", "m", "unit of the tolerance: m, km, mi or nmi")
+	from := fs.String("from", "", "format to read: geojson, kml or gpx; detected when empty")
+	to := fs.String("to", "", "format to write: geojson, kml or gpx; the format read when empty")
+	files, err := parseFlags(fs, args, 0, 1)
+	if err != nil {
+		return err
+	}
+	u, err := parseUnit(*unit)
+	if err != nil {
+		return err
+	}
+
+	data, format, err := readInput(fileArg(files), *from, stdin)
+	if err != nil {
+		return err
+	}
+	geometries, err := decode(format, data)
+	if err != nil {
+		return err
+	}
+
+	t := geo.Distance(*tolerance) * u
+	for i, g := range geometries {
+		switch g := g.(type) {
+		case geo.LineString:
+			geometries[i] = g.Simplify(t)
+		case geo.Track:
+			geometries[i] = g.LineString().Simplify(t)
+		case geo.Polygon:
+			geometries[i] = g.Simplify(t)
+		case geo.MultiPolygon:
+			polygons := make([]geo.Polygon, len(g.Polygons()))
+			for j, p := range g.Polygons() {
+				polygons[j] = p.Simplify(t)
+			}
+			geometries[i] = geo.NewMultiPolygon(polygons...)
+		}
+	}
+
+	if *to != "" {
+		format = strings.ToLower(*to)
+	}
+	return encode(stdout, format, geometries)
+}
+
+// newFlagSet returns a FlagSet for the named command that reports errors to stderr.
+func newFlagSet(name string, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet("geo "+name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	return fs
+}
+
+// parseFlags parses args with flags allowed before, between and after the positional arguments, which
+// it returns.  Everything after "--" is positional.  Unless there are between least and most positional
+// arguments, the usage of fs is reported and errUsage is returned.
+func parseFlags(fs *flag.FlagSet, args []string, least int, most int) ([]string, error) {
+	var tail []string
+	for i, arg := range args {
+		if arg == "--" {
+			args, tail = args[:i], args[i+1:]
+			break
+		}
+	}
+
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, errUsage
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	positional = append(positional, tail...)
+
+	if len(positional) < least || len(positional) > most {
+		fmt.Fprintf(fs.Output(), "%s: expected between %d and %d arguments, but got %d\n", fs.Name(), least, most, len(positional))
+		fs.Usage()
+		return nil, errUsage
+	}
+	return positional, nil
+}
+
+// fileArg returns the only file named in files, or "-" for standard input if there is none.
+func fileArg(files []string) string {
+	if len(files) == 0 {
+		return "-"
+	}
+	return files[0]
+}
+
+// readInput reads the named file, or stdin for "-", and returns its contents and format.
+// The format is detected unless one is passed in.
+func readInput(path string, format string, stdin io.Reader) ([]byte, string, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	if format == "" {
+		if format = detectFormat(path, data); format == "" {
+			return nil, "", fmt.Errorf("unable to detect the format of %s, pass --from", path)
+		}
+	}
+	return data, strings.ToLower(format), nil
+}
+
+// readGeometries reads every geometry from the named file, or stdin for "-".
+func readGeometries(path string, format string, stdin io.Reader) ([]geo.Geometry, error) {
+	data, format, err := readInput(path, format, stdin)
+	if err != nil {
+		return nil, err
+	}
+	return decode(format, data)
+}
+
+// eachLine calls fn with every line of r that is not blank, stopping at the first error.
+func eachLine(r io.Reader, fn func(string) error) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if err := fn(line); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// parseUnit returns the Distance named by a unit abbreviation.
+func parseUnit(s string) (geo.Distance, error) {
+	switch strings.ToLower(s) {
+	case "m":
+		return geo.Meter, nil
+	case "km":
+		return geo.Kilometer, nil
+	case "mi":
+		return geo.Mile, nil
+	case "nmi":
+		return geo.NauticalMile, nil
+	}
+	return 0, fmt.Errorf("unknown unit %q, expected m, km, mi or nmi", s)
+}
+
+// stringsFlag is a flag.Value collecting every occurrence of a repeated flag.
+type stringsFlag []string
+
+func (s *stringsFlag) String() string {
+	return strings.Join(*s, " ")
+}
+
+func (s *stringsFlag) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
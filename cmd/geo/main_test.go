@@ -0,0 +1,153 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	geo "github.com/smarteaston/golang-geo"
+)
+
+const fence = `{"type":"Feature","properties":{},"geometry":{"type":"Polygon",` +
+	`"coordinates":[[[114.2,4.5],[115.4,4.5],[115.4,5.1],[114.2,5.1],[114.2,4.5]]]}}`
+
+// runWith runs the command line with the passed in standard input and returns its standard output.
+func runWith(t *testing.T, stdin string, args ...string) (string, error) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	err := run(args, strings.NewReader(stdin), &stdout, &stderr)
+	return stdout.String(), err
+}
+
+// writeFile writes a temporary file with the passed in name and contents and returns its path.
+func writeFile(t *testing.T, name string, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// Ensures that points passed as flags or on standard input are tested against the fence.
+func TestContains(t *testing.T) {
+	path := writeFile(t, "fence.geojson", fence)
+
+	out, err := runWith(t, "", "contains", path, "--point", "4.94,114.95", "--point", "1,1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "true\nfalse\n" {
+		t.Errorf("Expected true then false, but got %q", out)
+	}
+
+	out, err = runWith(t, "0,0\n\n4.94 114.95\n", "contains", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "false\ntrue\n" {
+		t.Errorf("Expected false then true, but got %q", out)
+	}
+
+	out, err = runWith(t, fence, "contains", "-", "--point", "4.94,114.95")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "true\n" {
+		t.Errorf("Expected true for a fence read from standard input, but got %q", out)
+	}
+	if _, err := runWith(t, fence, "contains", "-"); !errors.Is(err, errUsage) {
+		t.Errorf("Expected reading both the fence and points from standard input to be a usage error, but got %v", err)
+	}
+}
+
+// Ensures that fences with holes are rejected rather than treated as solid.
+func TestContainsHoles(t *testing.T) {
+	hole := writeFile(t, "hole.geojson", `{"type":"Polygon","coordinates":[[[0,0],[10,0],[10,10],[0,10],[0,0]],[[4,4],[6,4],[6,6],[4,6],[4,4]]]}`)
+	if _, err := runWith(t, "", "contains", hole, "--point", "5,5"); !errors.Is(err, geo.ErrUnsupportedGeometry) {
+		t.Errorf("Expected a GeoJSON fence with a hole to be rejected, but got %v", err)
+	}
+
+	kml := writeFile(t, "hole.kml", `<kml><Placemark><Polygon>`+
+		`<outerBoundaryIs><LinearRing><coordinates>0,0 10,0 10,10 0,10 0,0</coordinates></LinearRing></outerBoundaryIs>`+
+		`<innerBoundaryIs><LinearRing><coordinates>4,4 6,4 6,6 4,6 4,4</coordinates></LinearRing></innerBoundaryIs>`+
+		`</Polygon></Placemark></kml>`)
+	if _, err := runWith(t, "", "contains", kml, "--point", "5,5"); !errors.Is(err, geo.ErrUnsupportedGeometry) {
+		t.Errorf("Expected a KML fence with a hole to be rejected, but got %v", err)
+	}
+}
+
+// Ensures that distances are measured between two points or along a path read from standard input.
+func TestDistance(t *testing.T) {
+	out, err := runWith(t, "", "distance", "--unit", "km", "0,0", "--", "-1,0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(out, "111.19") {
+		t.Errorf("Expected about 111.19km, but got %q", out)
+	}
+
+	out, err = runWith(t, "0,0\n1,0\n1,1\n", "distance", "--method", "vincenty", "--unit", "km")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(out, "221.") {
+		t.Errorf("Expected about 221km along the path, but got %q", out)
+	}
+
+	if _, err := runWith(t, "", "distance", "0,0"); !errors.Is(err, errUsage) {
+		t.Errorf("Expected a single point to be a usage error, but got %v", err)
+	}
+}
+
+// Ensures that geometries survive conversion to KML and back through standard input.
+func TestConvert(t *testing.T) {
+	path := writeFile(t, "fence.geojson", fence)
+
+	kml, err := runWith(t, "", "convert", path, "--to", "kml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(kml, "114.2,4.5 115.4,4.5 115.4,5.1 114.2,5.1 114.2,4.5") {
+		t.Errorf("Expected the closed ring in the KML, but got %s", kml)
+	}
+
+	geojson, err := runWith(t, kml, "convert", "--to", "geojson")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(geojson, `"coordinates":[[[114.2,4.5],[115.4,4.5],[115.4,5.1],[114.2,5.1],[114.2,4.5]]]`) {
+		t.Errorf("Expected the ring in the GeoJSON, but got %s", geojson)
+	}
+
+	if _, err := runWith(t, "", "convert", path, "--to", "gpx"); err == nil {
+		t.Errorf("Expected an error writing a polygon as GPX")
+	}
+	if _, err := runWith(t, fence, "convert"); !errors.Is(err, errUsage) {
+		t.Errorf("Expected a missing --to to be a usage error, but got %v", err)
+	}
+}
+
+// Ensures that lines are simplified and written in the format they were read in.
+func TestSimplify(t *testing.T) {
+	line := `{"type":"LineString","coordinates":[[0,0],[0.5,0.00001],[1,0],[1,1]]}`
+	out, err := runWith(t, line, "simplify", "--tolerance", "10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, `"coordinates":[[0,0],[1,0],[1,1]]`) {
+		t.Errorf("Expected the middle point dropped, but got %s", out)
+	}
+
+	gpx := `<gpx version="1.1"><trk><trkseg><trkpt lat="0" lon="0"></trkpt><trkpt lat="0" lon="1"></trkpt></trkseg></trk></gpx>`
+	out, err = runWith(t, gpx, "simplify", "--tolerance", "1", "--unit", "km")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "<gpx") || strings.Count(out, "<trkpt") != 2 {
+		t.Errorf("Expected a GPX track of 2 points, but got %s", out)
+	}
+}
@@ -0,0 +1,109 @@
+package geo
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// ctxCheckInterval is how many items the context-aware functions process between checks for cancellation.
+const ctxCheckInterval = 256
+
+// ContainsMany reports, for each of the passed in points, whether the Polygon contains it.
+// The points are split across runtime.GOMAXPROCS(0) goroutines.
+func (p Polygon) ContainsMany(points []Point) []bool {
+	return p.ContainsManyWith(points, runtime.GOMAXPROCS(0))
+}
+
+// ContainsManyWith is like ContainsMany, but splits the points across the passed in number of goroutines.
+// The polygon is prepared once, as by NewPreparedPolygon, and its banded edges are shared by every goroutine.
+func (p Polygon) ContainsManyWith(points []Point, workers int) []bool {
+	results, _ := p.containsManyCtx(context.Background(), points, workers)
+	return results
+}
+
+// ContainsManyCtx is like ContainsMany, but stops early and returns ctx.Err() once ctx is done.
+func (p Polygon) ContainsManyCtx(ctx context.Context, points []Point) ([]bool, error) {
+	return p.containsManyCtx(ctx, points, runtime.GOMAXPROCS(0))
+}
+
+func (p Polygon) containsManyCtx(ctx context.Context, points []Point, workers int) ([]bool, error) {
+	pp := NewPreparedPolygon(p)
+	return containsMany(ctx, points, workers, func(point Point) bool {
+		addMetric(MetricContainsCalls, "polygon", 1)
+		return pp.contains(point)
+	})
+}
+
+// containsMany evaluates contains for every point in parallel.
+func containsMany(ctx context.Context, points []Point, workers int, contains func(Point) bool) ([]bool, error) {
+	results := make([]bool, len(points))
+	err := parallelForCtx(ctx, len(points), workers, nil, func(i int) {
+		results[i] = contains(points[i])
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// parallelFor calls f with every index in [0, n), splitting
+// the indices into one contiguous chunk per worker.
+func parallelFor(n int, workers int, f func(i int)) {
+	_ = parallelForCtx(context.Background(), n, workers, nil, f)
+}
+
+// parallelForCtx is like parallelFor, but every worker checks ctx every ctxCheckInterval indices,
+// reporting its progress to f and stopping once ctx is done.  It returns ctx.Err() if any index was skipped.
+func parallelForCtx(ctx context.Context, n int, workers int, progress ProgressFunc, f func(i int)) error {
+	prog := newProgress(progress, n)
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > n {
+		workers = n
+	}
+	if workers <= 1 {
+		return forCtx(ctx, prog, 0, n, f)
+	}
+
+	chunk := (n + workers - 1) / workers
+	var wg sync.WaitGroup
+	var stopped atomic.Bool
+	for start := 0; start < n; start += chunk {
+		end := min(start+chunk, n)
+
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			if forCtx(ctx, prog, start, end, f) != nil {
+				stopped.Store(true)
+			}
+		}(start, end)
+	}
+	wg.Wait()
+
+	if stopped.Load() {
+		return ctx.Err()
+	}
+	return nil
+}
+
+// forCtx calls f with every index in [start, end), adding to prog and checking ctx every ctxCheckInterval
+// indices.  It returns ctx.Err() as soon as a check finds ctx done.
+func forCtx(ctx context.Context, prog *progress, start int, end int, f func(i int)) error {
+	last := start
+	for i := start; i < end; i++ {
+		if (i-start)%ctxCheckInterval == 0 {
+			prog.add(i - last)
+			last = i
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+		f(i)
+	}
+	prog.add(end - last)
+	return nil
+}
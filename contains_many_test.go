@@ -0,0 +1,95 @@
+package geo
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync/atomic"
+	"testing"
+)
+
+// Ensures that bulk containment agrees with Contains for any number of workers.
+func TestContainsMany(t *testing.T) {
+	nsw, err := polygonFromFile("test/data/nsw.json")
+	if err != nil {
+		t.Fatal("nsw json file failed to parse: ", err)
+	}
+
+	r := rand.New(rand.NewSource(1))
+	points := make([]Point, 1000)
+	for i := range points {
+		points[i] = NewPoint(-38+r.Float64()*10, 140+r.Float64()*14)
+	}
+
+	for _, workers := range []int{0, 1, 3, 2000} {
+		results := nsw.ContainsManyWith(points, workers)
+		for i, point := range points {
+			if results[i] != nsw.Contains(point) {
+				t.Errorf("Expected ContainsMany with %d workers to agree with Contains for %v", workers, point)
+			}
+		}
+	}
+
+	if results := nsw.ContainsMany(nil); len(results) != 0 {
+		t.Errorf("Expected no results for no points, but got %v", results)
+	}
+
+	// Points on vertex longitudes, where the prepared edges must be nudged off as Contains does.
+	p, points := vertexLongitudePolygon()
+	multi := NewMultiPolygon(p)
+	for _, workers := range []int{1, 3} {
+		results, multiResults := p.ContainsManyWith(points, workers), multi.ContainsManyWith(points, workers)
+		for i, point := range points {
+			if results[i] != p.Contains(point) || multiResults[i] != multi.Contains(point) {
+				t.Errorf("Expected ContainsMany with %d workers to agree with Contains on the vertex longitude %v", workers, point)
+			}
+		}
+	}
+}
+
+// Ensures that the context-aware bulk containment stops once its context is cancelled.
+func TestContainsManyCtx(t *testing.T) {
+	square := NewPolygon([]Point{NewPoint(0, 0), NewPoint(0, 2), NewPoint(2, 2), NewPoint(2, 0)})
+	points := []Point{NewPoint(1, 1), NewPoint(3, 3)}
+
+	results, err := square.ContainsManyCtx(context.Background(), points)
+	if err != nil || len(results) != 2 || !results[0] || results[1] {
+		t.Errorf("Expected [true false], but got %v, %v", results, err)
+	}
+
+	multi := NewMultiPolygon(square)
+	if results, err := multi.ContainsManyCtx(context.Background(), points); err != nil || !results[0] || results[1] {
+		t.Errorf("Expected [true false], but got %v, %v", results, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := square.ContainsManyCtx(ctx, points); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, but got %v", err)
+	}
+
+	if _, err := multi.ContainsManyCtx(ctx, points); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, but got %v", err)
+	}
+}
+
+// Ensures that parallelForCtx skips the remaining indices once its context is cancelled part way through.
+func TestParallelForCtxCancel(t *testing.T) {
+	for _, workers := range []int{1, 4} {
+		ctx, cancel := context.WithCancel(context.Background())
+		var calls atomic.Int64
+		err := parallelForCtx(ctx, 100*ctxCheckInterval, workers, nil, func(i int) {
+			if calls.Add(1) == 10 {
+				cancel()
+			}
+		})
+
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled with %d workers, but got %v", workers, err)
+		}
+		if n := calls.Load(); n >= 100*ctxCheckInterval {
+			t.Errorf("Expected cancellation to skip some of the work with %d workers, but made all %d calls", workers, n)
+		}
+	}
+}
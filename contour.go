@@ -0,0 +1,259 @@
+package geo
+
+import "math"
+
+// contourEdge identifies the edge between two neighboring cell centers of a Grid, one of
+// which is inside a contour and the other outside: the edge from the center at row, col to
+// the center to its east, or to its south when vertical is set.  Rows and columns run from -1
+// to the size of the grid, the centers outside it counting as outside every contour.
+type contourEdge struct {
+	row, col int
+	vertical bool
+}
+
+// Contours returns the regions of the Grid whose values are at least threshold, traced with the
+// marching squares algorithm between the centers of its cells.  Each Polygon of the result holds
+// the outline of one region followed by the outlines of its holes, joined by bridging edges that
+// cancel out, so that containment holds for the region without its holes.  NaN cells count as
+// below every threshold.
+func (g *Grid) Contours(threshold float64) MultiPolygon {
+	return g.nestRings(g.contourRings(threshold, 0.5))
+}
+
+// IsoBands returns the regions of the Grid whose values are at least lower and below upper, as
+// polygons with holes like Contours.
+func (g *Grid) IsoBands(lower float64, upper float64) MultiPolygon {
+	if !(lower < upper) {
+		return NewMultiPolygon()
+	}
+	// The band is the region above lower less the region above upper.  Next to NaN cells and the
+	// edge of the Grid, where the outlines of both would otherwise meet, that of the region above
+	// upper keeps closer to the cells inside, so that the outlines never touch.
+	return g.nestRings(append(g.contourRings(lower, 0.5), g.contourRings(upper, 0.25)...))
+}
+
+// IsoLines returns the lines along which the values of the Grid equal level, traced with the
+// marching squares algorithm between the centers of its cells.  Lines that close on themselves
+// end at their first Point, and the others end at the edge of the Grid or at NaN cells.
+func (g *Grid) IsoLines(level float64) []LineString {
+	next := g.contourLinks(level, false)
+	toPoints := func(line []contourEdge) []Point {
+		points := make([]Point, len(line))
+		for i, e := range line {
+			v := g.contourCrossing(e, level, 0.5)
+			points[i] = g.gridPoint(v[0], v[1])
+		}
+		return points
+	}
+
+	// Open lines start at a crossing no other leads to, and what remains are closed lines.
+	targets := make(map[contourEdge]bool, len(next))
+	for _, e := range next {
+		targets[e] = true
+	}
+	var lines []LineString
+	for e := range next {
+		if targets[e] {
+			continue
+		}
+		line := []contourEdge{e}
+		for n, ok := next[e]; ok; n, ok = next[n] {
+			delete(next, e)
+			line = append(line, n)
+			e = n
+		}
+		lines = append(lines, NewLineString(toPoints(line)))
+	}
+	for _, line := range g.traceRings(next) {
+		lines = append(lines, NewLineString(append(toPoints(line), toPoints(line[:1])...)))
+	}
+	return lines
+}
+
+// contourRings returns the outlines of the regions of the Grid whose values are at least threshold,
+// in rows and columns, as rings around the regions padded with cells outside them.  The outlines pass
+// between a cell inside and a NaN cell or the edge of the Grid at pad cells from the cell inside.
+func (g *Grid) contourRings(threshold float64, pad float64) [][][2]float64 {
+	var rings [][][2]float64
+	for _, ring := range g.traceRings(g.contourLinks(threshold, true)) {
+		points := make([][2]float64, len(ring))
+		for i, e := range ring {
+			points[i] = g.contourCrossing(e, threshold, pad)
+		}
+		rings = append(rings, points)
+	}
+	return rings
+}
+
+// contourLinks links each crossing of a contour at threshold to the next, keeping the region at
+// or above threshold on the same side.  If padded is set, the Grid is surrounded by cells below
+// every threshold so that every contour closes, and otherwise squares with a NaN corner are skipped.
+func (g *Grid) contourLinks(threshold float64, padded bool) map[contourEdge]contourEdge {
+	inside := func(row int, col int) bool {
+		return g.valueOr(row, col) >= threshold
+	}
+	first, last := -1, 0
+	if !padded {
+		first, last = 0, -1
+	}
+
+	// Link the crossings of each square between four cell centers, going clockwise round the
+	// square from its north-west corner, so that every region is kept on the same side.
+	next := make(map[contourEdge]contourEdge)
+	for row := first; row < g.rows+last; row++ {
+		for col := first; col < g.cols+last; col++ {
+			if !padded && (math.IsNaN(g.values[row*g.cols+col]) || math.IsNaN(g.values[row*g.cols+col+1]) ||
+				math.IsNaN(g.values[(row+1)*g.cols+col]) || math.IsNaN(g.values[(row+1)*g.cols+col+1])) {
+				continue
+			}
+			corners := [4]bool{inside(row, col), inside(row, col+1), inside(row+1, col+1), inside(row+1, col)}
+			edges := [4]contourEdge{{row, col, false}, {row, col + 1, true}, {row + 1, col, false}, {row, col, true}}
+
+			// Crossings alternate between leaving and entering the region.
+			var crossings []contourEdge
+			var exits []bool
+			for i := range 4 {
+				if corners[i] != corners[(i+1)%4] {
+					crossings = append(crossings, edges[i])
+					exits = append(exits, corners[i])
+				}
+			}
+			if len(crossings) == 0 {
+				continue
+			}
+
+			// In a saddle, the diagonal corners inside are joined if the center of the square is
+			// inside, so that each exit leads to the following entry rather than the preceding one.
+			joined := len(crossings) == 4 && (g.valueOr(row, col)+g.valueOr(row, col+1)+g.valueOr(row+1, col+1)+g.valueOr(row+1, col))/4 >= threshold
+			n := len(crossings)
+			for k, exit := range exits {
+				if !exit {
+					continue
+				}
+				if joined {
+					next[crossings[k]] = crossings[(k+1)%n]
+				} else {
+					next[crossings[k]] = crossings[(k+n-1)%n]
+				}
+			}
+		}
+	}
+	return next
+}
+
+// traceRings follows the links from crossing to crossing round each ring, consuming them.
+func (g *Grid) traceRings(next map[contourEdge]contourEdge) [][]contourEdge {
+	var rings [][]contourEdge
+	for len(next) > 0 {
+		var start contourEdge
+		for e := range next {
+			start = e
+			break
+		}
+		var ring []contourEdge
+		for e := start; ; {
+			ring = append(ring, e)
+			n := next[e]
+			delete(next, e)
+			if n == start {
+				break
+			}
+			e = n
+		}
+		rings = append(rings, ring)
+	}
+	return rings
+}
+
+// contourCrossing returns the row and column at which the values along edge e cross threshold,
+// interpolating linearly, or pad cells from the corner inside where the other is NaN or outside the Grid.
+func (g *Grid) contourCrossing(e contourEdge, threshold float64, pad float64) [2]float64 {
+	r2, c2 := e.row, e.col+1
+	if e.vertical {
+		r2, c2 = e.row+1, e.col
+	}
+	a, b := g.valueOr(e.row, e.col), g.valueOr(r2, c2)
+	var f float64
+	switch {
+	case math.IsInf(a, -1):
+		f = 1 - pad
+	case math.IsInf(b, -1):
+		f = pad
+	case a != b:
+		f = (threshold - a) / (b - a)
+	}
+	if e.vertical {
+		return [2]float64{float64(e.row) + f, float64(e.col)}
+	}
+	return [2]float64{float64(e.row), float64(e.col) + f}
+}
+
+// valueOr returns the value of the cell at row and col, or -Inf outside the Grid or for NaN.
+func (g *Grid) valueOr(row int, col int) float64 {
+	if row < 0 || row >= g.rows || col < 0 || col >= g.cols || math.IsNaN(g.values[row*g.cols+col]) {
+		return math.Inf(-1)
+	}
+	return g.values[row*g.cols+col]
+}
+
+// nestRings returns a Polygon for each ring of rows and columns that lies inside an even number of
+// the others, followed by the rings directly inside it as holes.
+func (g *Grid) nestRings(rings [][][2]float64) MultiPolygon {
+	// parent holds the index of the innermost ring around each ring, or -1.
+	depth := make([]int, len(rings))
+	parent := make([]int, len(rings))
+	for i, ring := range rings {
+		parent[i] = -1
+		for j, other := range rings {
+			if i != j && ringContains(other, ring[0]) {
+				depth[i]++
+				if parent[i] < 0 || ringContains(rings[parent[i]], other[0]) {
+					parent[i] = j
+				}
+			}
+		}
+	}
+
+	toPoints := func(ring [][2]float64) []Point {
+		points := make([]Point, len(ring))
+		for i, v := range ring {
+			points[i] = g.gridPoint(v[0], v[1])
+		}
+		return points
+	}
+
+	var polygons []Polygon
+	for i, ring := range rings {
+		if depth[i]%2 != 0 {
+			continue
+		}
+		points := toPoints(ring)
+		start := points[0]
+		for j, hole := range rings {
+			if parent[j] == i && depth[j] == depth[i]+1 {
+				points = append(points, start)
+				points = append(points, toPoints(hole)...)
+				points = append(points, g.gridPoint(hole[0][0], hole[0][1]))
+			}
+		}
+		if len(points) > len(ring) {
+			points = append(points, start)
+		}
+		polygons = append(polygons, NewPolygon(points))
+	}
+	return NewMultiPolygon(polygons...)
+}
+
+// ringContains reports whether the point at row and column v lies inside ring, by the even-odd rule.
+// Points on the ring are not distinguished, which nestRings does not need as rings never touch.
+func ringContains(ring [][2]float64, v [2]float64) bool {
+	inside := false
+	prev := ring[len(ring)-1]
+	for _, q := range ring {
+		if (q[0] > v[0]) != (prev[0] > v[0]) && v[1] < prev[1]+(v[0]-prev[0])*(q[1]-prev[1])/(q[0]-prev[0]) {
+			inside = !inside
+		}
+		prev = q
+	}
+	return inside
+}
@@ -0,0 +1,162 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+// Ensures that contours outline the regions above a threshold, with holes, as separate polygons.
+func TestGridContours(t *testing.T) {
+	// A ring of high values around a low center, and a separate peak.
+	rows := [][]float64{
+		{0, 0, 0, 0, 0, 0, 0},
+		{0, 5, 5, 5, 0, 0, 0},
+		{0, 5, 1, 5, 0, 9, 0},
+		{0, 5, 5, 5, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0},
+	}
+	g := NewGrid(NewBoundingBox(NewPoint(0, 0), NewPoint(5, 7)), 5, 7)
+	for r, row := range rows {
+		for c, v := range row {
+			g.Set(r, c, v)
+		}
+	}
+
+	contours := g.Contours(3)
+	if len(contours.Polygons()) != 2 {
+		t.Fatalf("Expected 2 regions, but got %v", contours)
+	}
+	tests := []struct {
+		row, col int
+		expected bool
+	}{
+		{1, 1, true},
+		{3, 2, true},
+		{2, 2, false},
+		{0, 0, false},
+		{2, 5, true},
+		{2, 4, false},
+	}
+	for _, tt := range tests {
+		if c := g.CellCenter(tt.row, tt.col); contours.Contains(c) != tt.expected {
+			t.Errorf("Expected cell %d,%d contained to be %v", tt.row, tt.col, tt.expected)
+		}
+	}
+	// The edge of a region lies where the values cross the threshold.
+	if p := g.gridPoint(2, 4+1.0/3); !contours.Contains(NewPoint(p.lat, p.lng+0.01)) || contours.Contains(NewPoint(p.lat, p.lng-0.01)) {
+		t.Errorf("Expected the edge of the peak at %v", p)
+	}
+
+	if contours := g.Contours(10); len(contours.Polygons()) != 0 {
+		t.Errorf("Expected no regions above the peak, but got %v", contours)
+	}
+	if contours := g.Contours(-1); len(contours.Polygons()) != 1 || !contours.Contains(g.CellCenter(2, 2)) {
+		t.Errorf("Expected a single region over the whole grid, but got %v", contours)
+	}
+}
+
+// Ensures that saddles are joined when the center of the square is above the threshold.
+func TestGridContoursSaddle(t *testing.T) {
+	g := NewGrid(NewBoundingBox(NewPoint(0, 0), NewPoint(2, 2)), 2, 2)
+	g.Set(0, 0, 10)
+	g.Set(1, 1, 10)
+	g.Set(0, 1, 4)
+	g.Set(1, 0, 4)
+
+	if contours := g.Contours(5); len(contours.Polygons()) != 1 || !contours.Contains(NewPoint(1, 1)) {
+		t.Errorf("Expected the diagonal joined, but got %v", contours)
+	}
+	if contours := g.Contours(8); len(contours.Polygons()) != 2 || contours.Contains(NewPoint(1, 1)) {
+		t.Errorf("Expected the diagonal split, but got %v", contours)
+	}
+}
+
+// Ensures that iso-bands hold the cells between their levels, without touching outlines.
+func TestGridIsoBands(t *testing.T) {
+	rows := [][]float64{
+		{0, 0, 0, 0, 0},
+		{0, 4, 4, 4, 0},
+		{0, 4, 8, 4, 0},
+		{0, 4, 4, 4, 8},
+	}
+	g := NewGrid(NewBoundingBox(NewPoint(0, 0), NewPoint(4, 5)), 4, 5)
+	for r, row := range rows {
+		for c, v := range row {
+			g.Set(r, c, v)
+		}
+	}
+	g.Set(0, 4, math.NaN())
+
+	bands := g.IsoBands(2, 6)
+	tests := []struct {
+		row, col int
+		expected bool
+	}{
+		{1, 1, true},
+		{3, 3, true},
+		{2, 2, false},
+		{3, 4, false},
+		{0, 0, false},
+		{0, 4, false},
+	}
+	for _, tt := range tests {
+		if c := g.CellCenter(tt.row, tt.col); bands.Contains(c) != tt.expected {
+			t.Errorf("Expected cell %d,%d contained to be %v", tt.row, tt.col, tt.expected)
+		}
+	}
+
+	if bands := g.IsoBands(-1, math.Inf(1)); !bands.Contains(g.CellCenter(2, 2)) || bands.Contains(g.CellCenter(0, 4)) {
+		t.Errorf("Expected a band over every cell but the NaN one, but got %v", bands)
+	}
+	if bands := g.IsoBands(6, 2); len(bands.Polygons()) != 0 {
+		t.Errorf("Expected no band between inverted levels, but got %v", bands)
+	}
+}
+
+// Ensures that iso-lines are closed round peaks and open where they leave the grid.
+func TestGridIsoLines(t *testing.T) {
+	g := NewGrid(NewBoundingBox(NewPoint(0, 0), NewPoint(4, 4)), 4, 4)
+	g.Set(1, 1, 10)
+	g.Set(3, 3, 10)
+
+	lines := g.IsoLines(5)
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines, but got %v", lines)
+	}
+	var closed, open int
+	for _, line := range lines {
+		points := line.Points()
+		if points[0] == points[len(points)-1] {
+			closed++
+			if len(points) != 5 {
+				t.Errorf("Expected a diamond round the peak, but got %v", line)
+			}
+		} else {
+			open++
+		}
+		for _, p := range points {
+			if _, _, ok := g.Cell(p); !ok {
+				t.Errorf("Expected %v on the grid", p)
+			}
+		}
+	}
+	if closed != 1 || open != 1 {
+		t.Errorf("Expected a closed and an open line, but got %v", lines)
+	}
+
+	// The line round the peak passes halfway between it and its neighbors.
+	diamond := map[Point]bool{g.gridPoint(0.5, 1): true, g.gridPoint(1, 1.5): true, g.gridPoint(1.5, 1): true, g.gridPoint(1, 0.5): true}
+	for _, line := range lines {
+		if points := line.Points(); points[0] == points[len(points)-1] {
+			for _, p := range points {
+				if !diamond[p] {
+					t.Errorf("Expected %v halfway between the peak and a neighbor", p)
+				}
+			}
+		}
+	}
+
+	if lines := g.IsoLines(20); len(lines) != 0 {
+		t.Errorf("Expected no lines above the peaks, but got %v", lines)
+	}
+}
@@ -0,0 +1,164 @@
+package geo
+
+import "math"
+
+// An Ellipsoid is a reference ellipsoid described by its semi-major axis in meters and its flattening.
+type Ellipsoid struct {
+	SemiMajorAxis float64
+	Flattening    float64
+}
+
+// Common reference ellipsoids.
+var (
+	WGS84Ellipsoid             = Ellipsoid{SemiMajorAxis: wgs84SemiMajorAxis, Flattening: wgs84Flattening}
+	GRS80Ellipsoid             = Ellipsoid{SemiMajorAxis: 6378137, Flattening: 1 / 298.257222101}
+	Airy1830Ellipsoid          = Ellipsoid{SemiMajorAxis: 6377563.396, Flattening: (6377563.396 - 6356256.909) / 6377563.396}
+	Clarke1866Ellipsoid        = Ellipsoid{SemiMajorAxis: 6378206.4, Flattening: (6378206.4 - 6356583.8) / 6378206.4}
+	International1924Ellipsoid = Ellipsoid{SemiMajorAxis: 6378388, Flattening: 1 / 297.0}
+)
+
+// eccentricitySquared returns the square of the first eccentricity of the ellipsoid.
+func (e Ellipsoid) eccentricitySquared() float64 {
+	return e.Flattening * (2 - e.Flattening)
+}
+
+// ToCartesian returns the earth-centered, earth-fixed coordinates in meters
+// of the passed in latitude, longitude and ellipsoidal height in meters.
+func (e Ellipsoid) ToCartesian(lat float64, lng float64, h float64) (x float64, y float64, z float64) {
+	phi := lat * math.Pi / 180
+	lambda := lng * math.Pi / 180
+	e2 := e.eccentricitySquared()
+
+	sinPhi, cosPhi := math.Sin(phi), math.Cos(phi)
+	nu := e.SemiMajorAxis / math.Sqrt(1-e2*sinPhi*sinPhi)
+
+	x = (nu + h) * cosPhi * math.Cos(lambda)
+	y = (nu + h) * cosPhi * math.Sin(lambda)
+	z = (nu*(1-e2) + h) * sinPhi
+	return x, y, z
+}
+
+// FromCartesian is the inverse of ToCartesian, using Bowring's method.
+func (e Ellipsoid) FromCartesian(x float64, y float64, z float64) (lat float64, lng float64, h float64) {
+	a := e.SemiMajorAxis
+	e2 := e.eccentricitySquared()
+	b := a * (1 - e.Flattening)
+	ep2 := e2 / (1 - e2)
+
+	p := math.Hypot(x, y)
+	r := math.Hypot(p, z)
+
+	beta := math.Atan2(b*z*(1+ep2*b/r), a*p)
+	sinBeta, cosBeta := math.Sin(beta), math.Cos(beta)
+
+	phi := math.Atan2(z+ep2*b*sinBeta*sinBeta*sinBeta, p-e2*a*cosBeta*cosBeta*cosBeta)
+	lambda := math.Atan2(y, x)
+
+	sinPhi, cosPhi := math.Sin(phi), math.Cos(phi)
+	nu := a / math.Sqrt(1-e2*sinPhi*sinPhi)
+	h = p*cosPhi + z*sinPhi - a*a/nu
+
+	return phi * 180 / math.Pi, lambda * 180 / math.Pi, h
+}
+
+// Helmert holds the parameters of a seven parameter Helmert transformation between
+// cartesian coordinate frames, using the position vector convention: translations
+// in meters, scale in parts per million and rotations in arc seconds.
+type Helmert struct {
+	Tx, Ty, Tz float64
+	Scale      float64
+	Rx, Ry, Rz float64
+}
+
+// Apply transforms the passed in cartesian coordinates, using the small angle approximation.
+func (t Helmert) Apply(x float64, y float64, z float64) (float64, float64, float64) {
+	s := 1 + t.Scale*1e-6
+	rx := t.Rx / 3600 * math.Pi / 180
+	ry := t.Ry / 3600 * math.Pi / 180
+	rz := t.Rz / 3600 * math.Pi / 180
+
+	return t.Tx + s*(x-rz*y+ry*z),
+		t.Ty + s*(rz*x+y-rx*z),
+		t.Tz + s*(-ry*x+rx*y+z)
+}
+
+// Inverse returns the approximate inverse of the transformation,
+// which is accurate to well under a millimeter for datum shift sized parameters.
+func (t Helmert) Inverse() Helmert {
+	return Helmert{Tx: -t.Tx, Ty: -t.Ty, Tz: -t.Tz, Scale: -t.Scale, Rx: -t.Rx, Ry: -t.Ry, Rz: -t.Rz}
+}
+
+// A Datum is a geodetic datum: a reference ellipsoid together with
+// the Helmert transformation that takes its cartesian coordinates to WGS84.
+type Datum struct {
+	Name      string
+	Ellipsoid Ellipsoid
+	ToWGS84   Helmert
+}
+
+// Common datums.  The NAD27 and ED50 shifts are continental averages
+// accurate to a few meters; grid based shifts are needed for survey work.
+var (
+	WGS84Datum  = Datum{Name: "WGS84", Ellipsoid: WGS84Ellipsoid}
+	NAD83Datum  = Datum{Name: "NAD83", Ellipsoid: GRS80Ellipsoid}
+	NAD27Datum  = Datum{Name: "NAD27", Ellipsoid: Clarke1866Ellipsoid, ToWGS84: Helmert{Tx: -8, Ty: 160, Tz: 176}}
+	ED50Datum   = Datum{Name: "ED50", Ellipsoid: International1924Ellipsoid, ToWGS84: Helmert{Tx: -87, Ty: -98, Tz: -121}}
+	OSGB36Datum = Datum{
+		Name:      "OSGB36",
+		Ellipsoid: Airy1830Ellipsoid,
+		ToWGS84: Helmert{
+			Tx: 446.448, Ty: -125.157, Tz: 542.060,
+			Scale: -20.4894,
+			Rx:    0.1502, Ry: 0.2470, Rz: 0.8421,
+		},
+	}
+)
+
+// Geographic reference systems of the common datums.
+const (
+	NAD83 CRS = 4269
+	NAD27 CRS = 4267
+	ED50  CRS = 4230
+)
+
+func init() {
+	RegisterDatum(NAD83, NAD83Datum)
+	RegisterDatum(NAD27, NAD27Datum)
+	RegisterDatum(ED50, ED50Datum)
+	RegisterDatum(OSGB36, OSGB36Datum)
+}
+
+// RegisterDatum registers a geographic reference system on the passed in Datum,
+// so that Points can be converted to and from it with TransformPoint.
+func RegisterDatum(code CRS, d Datum) {
+	RegisterTransformer(code, d)
+}
+
+// FromWGS84 converts a WGS84 Point onto the Datum.
+func (d Datum) FromWGS84(p Point) Point {
+	return shiftDatum(p, WGS84Ellipsoid, d.Ellipsoid, d.ToWGS84.Inverse())
+}
+
+// ToWGS84Point converts a Point on the Datum onto WGS84.
+func (d Datum) ToWGS84Point(p Point) Point {
+	return shiftDatum(p, d.Ellipsoid, WGS84Ellipsoid, d.ToWGS84)
+}
+
+// Forward implements the Transformer interface, returning the longitude and latitude of p on the Datum.
+func (d Datum) Forward(p Point) (float64, float64) {
+	q := d.FromWGS84(p)
+	return q.lng, q.lat
+}
+
+// Inverse implements the Transformer interface, converting a longitude and latitude on the Datum to WGS84.
+func (d Datum) Inverse(x float64, y float64) Point {
+	return d.ToWGS84Point(NewPoint(y, x))
+}
+
+// shiftDatum converts Point p from the from ellipsoid to the to ellipsoid via the passed in transformation.
+func shiftDatum(p Point, from Ellipsoid, to Ellipsoid, t Helmert) Point {
+	x, y, z := from.ToCartesian(p.lat, p.lng, 0)
+	x, y, z = t.Apply(x, y, z)
+	lat, lng, _ := to.FromCartesian(x, y, z)
+	return NewPoint(lat, lng)
+}
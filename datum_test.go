@@ -0,0 +1,96 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+// Ensures that geographic coordinates round trip through earth-centered cartesian coordinates.
+func TestEllipsoidCartesian(t *testing.T) {
+	tests := []struct {
+		lat, lng, h float64
+	}{
+		{0, 0, 0},
+		{51.4778, -0.0015, 45},
+		{-33.8568, 151.2153, 0},
+		{90, 0, 0},
+		{-89.999, 120, 1000},
+	}
+
+	for _, tt := range tests {
+		x, y, z := WGS84Ellipsoid.ToCartesian(tt.lat, tt.lng, tt.h)
+		lat, lng, h := WGS84Ellipsoid.FromCartesian(x, y, z)
+		if math.Abs(lat-tt.lat) > 1e-9 || math.Abs(h-tt.h) > 1e-3 || (math.Abs(tt.lat) != 90 && math.Abs(lng-tt.lng) > 1e-9) {
+			t.Errorf("Expected %v,%v,%v to round trip, but got %v,%v,%v", tt.lat, tt.lng, tt.h, lat, lng, h)
+		}
+	}
+
+	x, _, _ := WGS84Ellipsoid.ToCartesian(0, 0, 0)
+	if x != WGS84Ellipsoid.SemiMajorAxis {
+		t.Errorf("Expected the origin to lie on the semi-major axis, but got x=%v", x)
+	}
+}
+
+// Ensures that a Helmert transformation followed by its inverse is close to the identity.
+func TestHelmertInverse(t *testing.T) {
+	x, y, z := WGS84Ellipsoid.ToCartesian(52, -1, 0)
+	x2, y2, z2 := OSGB36Datum.ToWGS84.Apply(x, y, z)
+	x3, y3, z3 := OSGB36Datum.ToWGS84.Inverse().Apply(x2, y2, z2)
+
+	if math.Abs(x3-x) > 0.01 || math.Abs(y3-y) > 0.01 || math.Abs(z3-z) > 0.01 {
+		t.Errorf("Expected the inverse transformation to restore %v,%v,%v but got %v,%v,%v", x, y, z, x3, y3, z3)
+	}
+}
+
+// Ensures that the registered datum shifts move points by plausible amounts and round trip.
+func TestDatumShifts(t *testing.T) {
+	tests := []struct {
+		crs      CRS
+		p        Point
+		min, max Distance
+	}{
+		// NAD27 coordinates in the continental US differ from WGS84 by tens of meters.
+		{NAD27, NewPoint(39.0, -98.0), 10 * Meter, 100 * Meter},
+		// NAD83 and WGS84 are treated as coincident.
+		{NAD83, NewPoint(39.0, -98.0), 0, 0.01 * Meter},
+		// ED50 coordinates in Europe differ from WGS84 by around a hundred meters.
+		{ED50, NewPoint(48.8582, 2.2945), 50 * Meter, 250 * Meter},
+		{OSGB36, NewPoint(51.5, -0.12), 50 * Meter, 200 * Meter},
+	}
+
+	for _, tt := range tests {
+		shifted, err := TransformPoint(tt.p, WGS84, tt.crs)
+		if err != nil {
+			t.Fatalf("Should not encounter an error when transforming into %v, but got %v", tt.crs, err)
+		}
+
+		if d := tt.p.GreatCircleDistance(shifted); d < tt.min || d > tt.max {
+			t.Errorf("Expected the %v shift to be between %v and %v, but got %v", tt.crs, tt.min, tt.max, d)
+		}
+
+		back, _ := TransformPoint(shifted, tt.crs, WGS84)
+		if d := tt.p.GreatCircleDistance(back); d > 0.01*Meter {
+			t.Errorf("Expected %v to round trip through %v, but got %v (%v away)", tt.p, tt.crs, back, d)
+		}
+	}
+}
+
+// Ensures that a custom datum can be registered and used through the Transformer pipeline.
+func TestRegisterDatum(t *testing.T) {
+	d := Datum{Name: "Shifted", Ellipsoid: WGS84Ellipsoid, ToWGS84: Helmert{Tz: 100}}
+	RegisterDatum(990002, d)
+
+	p := NewPoint(90, 0)
+	shifted, err := TransformPoint(NewPoint(0, 0), WGS84, 990002)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if shifted.Lat() >= 0 {
+		t.Errorf("Expected moving the origin north to move points south on the datum, but got %v", shifted)
+	}
+
+	if d.FromWGS84(p).Lat() != 90 {
+		t.Errorf("Expected the pole to stay on the axis, but got %v", d.FromWGS84(p))
+	}
+}
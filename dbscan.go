@@ -0,0 +1,79 @@
+package geo
+
+// DBSCANNoise is the label DBSCAN gives points that belong to no cluster.
+const DBSCANNoise = -1
+
+// DBSCANResult holds the clusters found by DBSCAN.
+type DBSCANResult struct {
+	// Labels holds the cluster of each point, numbered from 0 in the order clusters were found,
+	// or DBSCANNoise.
+	Labels []int
+	// Clusters holds the indices of the points of each cluster, in ascending order.
+	Clusters [][]int
+	// Noise holds the indices of the points that belong to no cluster, in ascending order.
+	Noise []int
+}
+
+// DBSCAN clusters points by density with the algorithm of Ester et al., "A density-based algorithm
+// for discovering clusters in large spatial databases with noise" (1996): points with at least
+// minPts points, themselves included, within eps of them are the core of a cluster, which also takes
+// in every point within eps of its core.  Distances are great circle distances, and neighbors are
+// found with a KDTree, so clustering takes O(n log n) time for evenly spread points.
+func DBSCAN(points []Point, eps Distance, minPts int) DBSCANResult {
+	const unvisited = -2
+
+	tree := NewKDTree(points)
+	neighbors := func(i int) []int {
+		var found []int
+		tree.visitWithin(points[i], eps, func(j int) {
+			found = append(found, j)
+		})
+		return found
+	}
+
+	result := DBSCANResult{Labels: make([]int, len(points))}
+	for i := range result.Labels {
+		result.Labels[i] = unvisited
+	}
+	for i := range points {
+		if result.Labels[i] != unvisited {
+			continue
+		}
+		seeds := neighbors(i)
+		if len(seeds) < minPts {
+			result.Labels[i] = DBSCANNoise
+			continue
+		}
+
+		// Grow a new cluster from the core point i.
+		cluster := len(result.Clusters)
+		result.Clusters = append(result.Clusters, nil)
+		result.Labels[i] = cluster
+		for len(seeds) > 0 {
+			j := seeds[len(seeds)-1]
+			seeds = seeds[:len(seeds)-1]
+			switch result.Labels[j] {
+			case DBSCANNoise:
+				// A border point of the cluster, which cannot extend it.
+				result.Labels[j] = cluster
+				continue
+			case unvisited:
+				result.Labels[j] = cluster
+			default:
+				continue
+			}
+			if more := neighbors(j); len(more) >= minPts {
+				seeds = append(seeds, more...)
+			}
+		}
+	}
+
+	for i, label := range result.Labels {
+		if label == DBSCANNoise {
+			result.Noise = append(result.Noise, i)
+		} else {
+			result.Clusters[label] = append(result.Clusters[label], i)
+		}
+	}
+	return result
+}
@@ -0,0 +1,62 @@
+package geo
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// Ensures that dense groups of points become clusters and scattered points noise.
+func TestDBSCAN(t *testing.T) {
+	r := rand.New(rand.NewSource(11))
+	var points []Point
+	// Two hotspots of 30 points within about 50 meters, one straddling the antimeridian, and 10 strays.
+	for range 30 {
+		points = append(points, NewPoint(51.5+r.Float64()*0.0004, -0.1+r.Float64()*0.0004))
+	}
+	for range 30 {
+		points = append(points, NewPoint(-17+r.Float64()*0.0004, NormalizeLng(179.9998+r.Float64()*0.0004)))
+	}
+	for i := range 10 {
+		points = append(points, NewPoint(float64(i), float64(i)))
+	}
+
+	result := DBSCAN(points, 100*Meter, 5)
+	if len(result.Clusters) != 2 || len(result.Clusters[0]) != 30 || len(result.Clusters[1]) != 30 {
+		t.Fatalf("Expected 2 clusters of 30 points, but got %v", result.Clusters)
+	}
+	for i, label := range result.Labels {
+		expected := DBSCANNoise
+		if i < 60 {
+			expected = i / 30
+		}
+		if label != expected {
+			t.Errorf("Expected point %d labeled %d, but got %d", i, expected, label)
+		}
+	}
+	if !reflect.DeepEqual(result.Noise, []int{60, 61, 62, 63, 64, 65, 66, 67, 68, 69}) {
+		t.Errorf("Expected the strays as noise, but got %v", result.Noise)
+	}
+}
+
+// Ensures that border points join a cluster without extending it.
+func TestDBSCANBorder(t *testing.T) {
+	// A core of 3 points 10 meters apart along the equator, then points 90 meters further on.
+	step := 10 * Meter
+	points := []Point{
+		destinationPoint(NewPoint(0, 0), 90, 0),
+		destinationPoint(NewPoint(0, 0), 90, step),
+		destinationPoint(NewPoint(0, 0), 90, 2*step),
+		destinationPoint(NewPoint(0, 0), 90, 11*step),
+		destinationPoint(NewPoint(0, 0), 90, 21*step),
+	}
+
+	result := DBSCAN(points, 95*Meter, 3)
+	if !reflect.DeepEqual(result.Labels, []int{0, 0, 0, 0, DBSCANNoise}) {
+		t.Errorf("Expected the border point in the cluster and the last point noise, but got %v", result.Labels)
+	}
+
+	if result := DBSCAN(nil, 95*Meter, 3); len(result.Labels) != 0 || result.Clusters != nil {
+		t.Errorf("Expected no clusters, but got %+v", result)
+	}
+}
@@ -0,0 +1,98 @@
+package geo
+
+import (
+	"math"
+	"time"
+)
+
+// Propagate returns where a subject at Point p ends up after moving for dt at speed meters per second
+// along the great circle leaving p at bearing degrees clockwise from true north.
+func Propagate(p Point, speed float64, bearing float64, dt time.Duration) Point {
+	return destinationPoint(p, bearing, Distance(speed*dt.Seconds()))
+}
+
+// destinationPoint returns the Point d along the great circle leaving p at bearing degrees clockwise from true north.
+func destinationPoint(p Point, bearing float64, d Distance) Point {
+	if d == 0 {
+		return p
+	}
+	lat, lng := p.lat*math.Pi/180, p.lng*math.Pi/180
+	theta := bearing * math.Pi / 180
+	delta := d.Kilometers() / EARTH_RADIUS
+
+	sinLat := math.Sin(lat)*math.Cos(delta) + math.Cos(lat)*math.Sin(delta)*math.Cos(theta)
+	lat2 := math.Asin(sinLat)
+	lng2 := lng + math.Atan2(math.Sin(theta)*math.Sin(delta)*math.Cos(lat), math.Cos(delta)-math.Sin(lat)*sinLat)
+	return NewPoint(lat2*180/math.Pi, NormalizeLng(lng2*180/math.Pi))
+}
+
+// initialBearing returns the bearing in degrees clockwise from true north of the great circle
+// leaving p1 towards p2.
+func initialBearing(p1 Point, p2 Point) float64 {
+	lat1, lat2 := p1.lat*math.Pi/180, p2.lat*math.Pi/180
+	dLng := (p2.lng - p1.lng) * math.Pi / 180
+	y := math.Sin(dLng) * math.Cos(lat2)
+	x := math.Cos(lat1)*math.Sin(lat2) - math.Sin(lat1)*math.Cos(lat2)*math.Cos(dLng)
+	return math.Mod(math.Atan2(y, x)*180/math.Pi+360, 360)
+}
+
+// A DeadReckoner extrapolates the position of a subject between GPS fixes, assuming it keeps the
+// speed and bearing it last had.  The velocity comes from the receiver when it reports one, and is
+// otherwise estimated from the last two fixes.  The zero value is ready to use.
+// A DeadReckoner is not safe for concurrent use.
+type DeadReckoner struct {
+	last    TrackPoint
+	fixes   int
+	speed   float64
+	bearing float64
+}
+
+// Update records that the subject was at Point p at time t.  Fixes older than the last one are ignored.
+func (r *DeadReckoner) Update(p Point, t time.Time) {
+	if r.fixes > 0 {
+		if !t.After(r.last.Time) {
+			return
+		}
+		r.speed = r.last.Point.GreatCircleDistance(p).Meters() / t.Sub(r.last.Time).Seconds()
+		if r.speed > 0 {
+			r.bearing = initialBearing(r.last.Point, p)
+		}
+	}
+	r.last = TrackPoint{Point: p, Time: t}
+	r.fixes++
+}
+
+// UpdateWithVelocity is like Update for a fix reporting the speed in meters per second and bearing
+// in degrees clockwise from true north the subject was moving at.
+func (r *DeadReckoner) UpdateWithVelocity(p Point, t time.Time, speed float64, bearing float64) {
+	if r.fixes > 0 && t.Before(r.last.Time) {
+		return
+	}
+	r.last = TrackPoint{Point: p, Time: t}
+	r.fixes++
+	r.speed, r.bearing = speed, bearing
+}
+
+// Velocity returns the speed in meters per second and bearing in degrees clockwise from true north
+// the subject is assumed to move at.  Before two fixes or one with a velocity, the speed is zero.
+func (r *DeadReckoner) Velocity() (speed float64, bearing float64) {
+	return r.speed, r.bearing
+}
+
+// Last returns the position and time of the last fix, and false if there has been none.
+func (r *DeadReckoner) Last() (Point, time.Time, bool) {
+	return r.last.Point, r.last.Time, r.fixes > 0
+}
+
+// Predict returns the position of the subject at time t, propagated from the last fix, and false
+// if there has been no fix.  Times before the last fix extrapolate backwards.
+func (r *DeadReckoner) Predict(t time.Time) (Point, bool) {
+	if r.fixes == 0 {
+		return Point{}, false
+	}
+	dt := t.Sub(r.last.Time)
+	if dt < 0 {
+		return Propagate(r.last.Point, r.speed, math.Mod(r.bearing+180, 360), -dt), true
+	}
+	return Propagate(r.last.Point, r.speed, r.bearing, dt), true
+}
@@ -0,0 +1,70 @@
+package geo
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// Ensures that points are propagated along great circles at the passed in speed and bearing.
+func TestPropagate(t *testing.T) {
+	p := NewPoint(-33.8568, 151.2153)
+	tests := []struct {
+		bearing float64
+	}{{0}, {45}, {90}, {200}, {359}}
+	for _, tt := range tests {
+		q := Propagate(p, 20, tt.bearing, 5*time.Minute)
+		if d := p.GreatCircleDistance(q); math.Abs(d.Meters()-6000) > 0.001 {
+			t.Errorf("Expected 6000m travelled, but got %v", d)
+		}
+		if b := initialBearing(p, q); math.Abs(b-tt.bearing) > 1e-6 {
+			t.Errorf("Expected bearing %v, but got %v", tt.bearing, b)
+		}
+	}
+
+	// A quarter of the way around the earth due east along the equator.
+	quarter := Distance(EARTH_RADIUS * math.Pi / 2 * float64(Kilometer))
+	if q := Propagate(NewPoint(0, 135), quarter.Meters(), 90, time.Second); math.Abs(q.lat) > 1e-9 || math.Abs(q.lng+135) > 1e-9 {
+		t.Errorf("Expected to cross the antimeridian to 0,-135, but got %v", q)
+	}
+	if q := Propagate(p, 20, 90, 0); q != p {
+		t.Errorf("Expected no movement, but got %v", q)
+	}
+}
+
+// Ensures that the dead reckoner estimates the velocity from fixes and extrapolates from the last one.
+func TestDeadReckoner(t *testing.T) {
+	var r DeadReckoner
+	start := time.Date(2024, time.May, 1, 9, 0, 0, 0, time.UTC)
+	if _, ok := r.Predict(start); ok {
+		t.Errorf("Expected no prediction without fixes")
+	}
+
+	r.Update(NewPoint(0, 0), start)
+	if p, ok := r.Predict(start.Add(time.Minute)); !ok || p != NewPoint(0, 0) {
+		t.Errorf("Expected to stay at the only fix, but got %v", p)
+	}
+
+	// Ten meters a second due north.
+	north := Propagate(NewPoint(0, 0), 10, 0, 10*time.Second)
+	r.Update(north, start.Add(10*time.Second))
+	r.Update(NewPoint(5, 5), start)
+	if speed, bearing := r.Velocity(); math.Abs(speed-10) > 1e-9 || math.Abs(bearing) > 1e-9 {
+		t.Errorf("Expected 10 m/s due north, but got %v m/s at %v", speed, bearing)
+	}
+	if p, _ := r.Predict(start.Add(20 * time.Second)); math.Abs(p.GreatCircleDistance(NewPoint(0, 0)).Meters()-200) > 1e-6 || math.Abs(p.lng) > 1e-12 {
+		t.Errorf("Expected 200m north of the origin, but got %v", p)
+	}
+	if p, _ := r.Predict(start); p.GreatCircleDistance(NewPoint(0, 0)) > Meter/1000 {
+		t.Errorf("Expected to extrapolate back to the origin, but got %v", p)
+	}
+
+	// A reported velocity replaces the estimated one.
+	r.UpdateWithVelocity(north, start.Add(20*time.Second), 5, 90)
+	if p, _ := r.Predict(start.Add(30 * time.Second)); math.Abs(p.GreatCircleDistance(north).Meters()-50) > 1e-6 || initialBearing(north, p) < 89.9 {
+		t.Errorf("Expected 50m east of the last fix, but got %v", p)
+	}
+	if p, tm, ok := r.Last(); !ok || p != north || !tm.Equal(start.Add(20*time.Second)) {
+		t.Errorf("Unexpected last fix %v at %v", p, tm)
+	}
+}
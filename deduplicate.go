@@ -0,0 +1,33 @@
+package geo
+
+// DeduplicatePoints returns points with those within tolerance of one another merged into their
+// MeanCenter.  Going through points in order, each point not yet merged gathers every other point
+// not yet merged within tolerance of it, so the result keeps the order in which groups first appear.
+// Merged points may end up a little closer than tolerance to each other, as a group is centered
+// away from the point that gathered it.  A tolerance of zero only merges identical points.
+func DeduplicatePoints(points []Point, tolerance Distance) []Point {
+	if len(points) == 0 {
+		return nil
+	}
+	tree := NewKDTree(points)
+	merged := make([]bool, len(points))
+	var result []Point
+	for i, p := range points {
+		if merged[i] {
+			continue
+		}
+		var group []Point
+		tree.visitWithin(p, max(tolerance, 0), func(j int) {
+			if !merged[j] {
+				merged[j] = true
+				group = append(group, points[j])
+			}
+		})
+		if len(group) == 1 {
+			result = append(result, p)
+			continue
+		}
+		result = append(result, MeanCenter(group))
+	}
+	return result
+}
@@ -0,0 +1,43 @@
+package geo
+
+import (
+	"testing"
+)
+
+// Ensures that points within the tolerance are merged into their center, keeping the order.
+func TestDeduplicatePoints(t *testing.T) {
+	points := []Point{
+		NewPoint(10, 10),
+		NewPoint(0, 0),
+		NewPoint(0, 0.00005),
+		NewPoint(10, 10),
+		NewPoint(0, 0.0001),
+		NewPoint(20, 20),
+	}
+
+	tests := []struct {
+		tolerance Distance
+		expected  []Point
+	}{
+		{0, []Point{NewPoint(10, 10), NewPoint(0, 0), NewPoint(0, 0.00005), NewPoint(0, 0.0001), NewPoint(20, 20)}},
+		{12 * Meter, []Point{NewPoint(10, 10), NewPoint(0, 0.00005), NewPoint(20, 20)}},
+		{6 * Meter, []Point{NewPoint(10, 10), NewPoint(0, 0.000025), NewPoint(0, 0.0001), NewPoint(20, 20)}},
+	}
+	for _, tt := range tests {
+		result := DeduplicatePoints(points, tt.tolerance)
+		if len(result) != len(tt.expected) {
+			t.Errorf("Expected %v within %v, but got %v", tt.expected, tt.tolerance, result)
+			continue
+		}
+		for i, p := range result {
+			if p.GreatCircleDistance(tt.expected[i]) > 0.01*Meter {
+				t.Errorf("Expected %v within %v, but got %v", tt.expected, tt.tolerance, result)
+				break
+			}
+		}
+	}
+
+	if result := DeduplicatePoints(nil, Meter); result != nil {
+		t.Errorf("Expected no points, but got %v", result)
+	}
+}
@@ -0,0 +1,89 @@
+package geo
+
+import "math"
+
+// delaunayTriangle holds the indices of the corners of a triangle, counterclockwise.
+type delaunayTriangle [3]int
+
+// delaunay returns the Delaunay triangulation of planar points with the Bowyer-Watson algorithm,
+// which takes O(n²) time.  Repeated points are left out of the triangles.
+func delaunay(xs []float64, ys []float64) []delaunayTriangle {
+	n := len(xs)
+	if n < 3 {
+		return nil
+	}
+
+	// Enclose every point in a super triangle, whose corners are added as points n to n+2.
+	minX, minY, maxX, maxY := math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)
+	for i := range xs {
+		minX, maxX = math.Min(minX, xs[i]), math.Max(maxX, xs[i])
+		minY, maxY = math.Min(minY, ys[i]), math.Max(maxY, ys[i])
+	}
+	span := math.Max(math.Max(maxX-minX, maxY-minY), 1)
+	midX, midY := (minX+maxX)/2, (minY+maxY)/2
+	xs = append(append([]float64(nil), xs...), midX-20*span, midX, midX+20*span)
+	ys = append(append([]float64(nil), ys...), midY-span, midY+20*span, midY-span)
+
+	triangles := []delaunayTriangle{{n, n + 2, n + 1}}
+	seen := make(map[[2]float64]bool, n)
+	for i := range n {
+		if seen[[2]float64{xs[i], ys[i]}] {
+			continue
+		}
+		seen[[2]float64{xs[i], ys[i]}] = true
+
+		// Remove the triangles whose circumcircles hold the point, and count the edges of the hole.
+		edges := make(map[[2]int]int)
+		kept := triangles[:0]
+		for _, t := range triangles {
+			if !inCircumcircle(xs, ys, t, xs[i], ys[i]) {
+				kept = append(kept, t)
+				continue
+			}
+			for k := range 3 {
+				a, b := t[k], t[(k+1)%3]
+				if a > b {
+					a, b = b, a
+				}
+				edges[[2]int{a, b}]++
+			}
+		}
+		triangles = kept
+
+		// Join the point to every edge on the boundary of the hole.
+		for edge, count := range edges {
+			if count != 1 {
+				continue
+			}
+			t := delaunayTriangle{edge[0], edge[1], i}
+			if cross(xs, ys, t) < 0 {
+				t[0], t[1] = t[1], t[0]
+			}
+			triangles = append(triangles, t)
+		}
+	}
+
+	// Drop the triangles touching the super triangle.
+	result := triangles[:0]
+	for _, t := range triangles {
+		if t[0] < n && t[1] < n && t[2] < n {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// cross returns twice the signed area of triangle t, positive when its corners run counterclockwise.
+func cross(xs []float64, ys []float64, t delaunayTriangle) float64 {
+	a, b, c := t[0], t[1], t[2]
+	return (xs[b]-xs[a])*(ys[c]-ys[a]) - (ys[b]-ys[a])*(xs[c]-xs[a])
+}
+
+// inCircumcircle reports whether the point x, y lies inside the circumcircle of the counterclockwise triangle t.
+func inCircumcircle(xs []float64, ys []float64, t delaunayTriangle, x float64, y float64) bool {
+	ax, ay := xs[t[0]]-x, ys[t[0]]-y
+	bx, by := xs[t[1]]-x, ys[t[1]]-y
+	cx, cy := xs[t[2]]-x, ys[t[2]]-y
+	det := (ax*ax+ay*ay)*(bx*cy-cx*by) - (bx*bx+by*by)*(ax*cy-cx*ay) + (cx*cx+cy*cy)*(ax*by-bx*ay)
+	return det > 0
+}
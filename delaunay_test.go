@@ -0,0 +1,47 @@
+package geo
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// Ensures that the triangulation covers the convex hull and leaves no point inside a circumcircle.
+func TestDelaunay(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	xs, ys := make([]float64, 200), make([]float64, 200)
+	for i := range xs {
+		xs[i], ys[i] = r.Float64()*1000, r.Float64()*1000
+	}
+	xs[1], ys[1] = xs[0], ys[0]
+
+	triangles := delaunay(xs, ys)
+	if len(triangles) == 0 {
+		t.Fatal("Expected triangles")
+	}
+	for _, tri := range triangles {
+		if cross(xs, ys, tri) <= 0 {
+			t.Errorf("Expected triangle %v to run counterclockwise", tri)
+		}
+		for i := range xs {
+			if i != tri[0] && i != tri[1] && i != tri[2] && inCircumcircle(xs, ys, tri, xs[i], ys[i]) {
+				t.Errorf("Expected point %d outside the circumcircle of %v", i, tri)
+			}
+		}
+	}
+
+	// A square is split into two triangles of half its area each.
+	square := delaunay([]float64{0, 1, 1, 0}, []float64{0, 0, 1, 1})
+	if len(square) != 2 {
+		t.Fatalf("Expected 2 triangles, but got %v", square)
+	}
+	for _, tri := range square {
+		if area := cross([]float64{0, 1, 1, 0}, []float64{0, 0, 1, 1}, tri); math.Abs(area-1) > 1e-12 {
+			t.Errorf("Expected triangle %v of twice the area 1, but got %v", tri, area)
+		}
+	}
+
+	if triangles := delaunay([]float64{0, 1}, []float64{0, 1}); triangles != nil {
+		t.Errorf("Expected no triangles for 2 points, but got %v", triangles)
+	}
+}
@@ -0,0 +1,55 @@
+package geo
+
+import "math"
+
+// MeanCenter returns the center of points on the sphere, the WeightedCentroid of them all weighing
+// the same.  It returns the zero Point for no points.
+func MeanCenter(points []Point) Point {
+	p, _ := WeightedCentroid(points, nil)
+	return p
+}
+
+// StandardDistance returns the root mean square of the distances from points to their MeanCenter,
+// a single measure of how tightly they gather, like the standard deviation of a set of numbers.
+// It returns zero for no points.
+func StandardDistance(points []Point) Distance {
+	if len(points) == 0 {
+		return 0
+	}
+	center := MeanCenter(points)
+	var sum float64
+	for _, p := range points {
+		d := center.GreatCircleDistance(p).Meters()
+		sum += d * d
+	}
+	return Distance(math.Sqrt(sum / float64(len(points))))
+}
+
+// StandardDeviationalEllipse returns the Ellipse around the MeanCenter of points summarizing their
+// spread and its direction, such as the trend of burglaries along a road.  Its axes point along the
+// directions of greatest and least spread, measured on the local tangent plane at the MeanCenter,
+// and are deviations standard deviations long: one covers about 39% of normally spread points, two
+// about 86%, and three about 99%.
+func StandardDeviationalEllipse(points []Point, deviations float64) Ellipse {
+	center := MeanCenter(points)
+	if len(points) == 0 {
+		return NewEllipse(center, 0, 0, 0)
+	}
+
+	frame := NewLocalFrame(center, 0)
+	var see, snn, sen float64
+	for _, p := range points {
+		east, north, _ := frame.ToENU(p, 0)
+		see += east * east
+		snn += north * north
+		sen += east * north
+	}
+	n := float64(len(points))
+	see, snn, sen = see/n, snn/n, sen/n
+
+	// The axes are the eigenvectors of the covariance matrix, and their variances its eigenvalues.
+	mean, spread := (see+snn)/2, math.Hypot((see-snn)/2, sen)
+	major, minor := mean+spread, math.Max(mean-spread, 0)
+	azimuth := math.Atan2(2*sen, see-snn) / 2
+	return NewEllipse(center, Distance(deviations*math.Sqrt(major)), Distance(deviations*math.Sqrt(minor)), 90-azimuth*180/math.Pi)
+}
@@ -0,0 +1,60 @@
+package geo
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// Ensures that the standard distance is the root mean square distance to the mean center.
+func TestStandardDistance(t *testing.T) {
+	center := NewPoint(51.5, -0.12)
+	var points []Point
+	for _, bearing := range []float64{0, 90, 180, 270} {
+		points = append(points, destinationPoint(center, bearing, 300*Meter))
+	}
+	if c := MeanCenter(points); c.GreatCircleDistance(center) > Meter/100 {
+		t.Errorf("Expected the mean center at %v, but got %v", center, c)
+	}
+	if d := StandardDistance(points); math.Abs(d.Meters()-300) > 0.01 {
+		t.Errorf("Expected a standard distance of 300m, but got %v", d)
+	}
+	if d := StandardDistance(nil); d != 0 {
+		t.Errorf("Expected no standard distance without points, but got %v", d)
+	}
+}
+
+// Ensures that the standard deviational ellipse follows the direction and spread of points.
+func TestStandardDeviationalEllipse(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	center := NewPoint(40.7, -74)
+	var points []Point
+	// Spread 1km along a north-east road and 200m across it.
+	frame := NewLocalFrame(center, 0)
+	for range 5000 {
+		along, across := r.NormFloat64()*1000, r.NormFloat64()*200
+		p, _ := frame.FromENU((along+across)/math.Sqrt2, (along-across)/math.Sqrt2, 0)
+		points = append(points, p)
+	}
+
+	e := StandardDeviationalEllipse(points, 1)
+	if math.Abs(e.Azimuth()-45) > 2 || math.Abs(e.SemiMajor().Meters()-1000) > 50 || math.Abs(e.SemiMinor().Meters()-200) > 10 {
+		t.Errorf("Expected a 1000m by 200m ellipse at 45°, but got %v", e)
+	}
+	if e.Center().GreatCircleDistance(center) > 50*Meter {
+		t.Errorf("Expected the ellipse centered at %v, but got %v", center, e.Center())
+	}
+
+	inside := 0
+	for _, p := range points {
+		if e.Contains(p) {
+			inside++
+		}
+	}
+	if share := float64(inside) / float64(len(points)); math.Abs(share-0.393) > 0.03 {
+		t.Errorf("Expected about 39%% of points inside one standard deviation, but got %v", share)
+	}
+	if twice := StandardDeviationalEllipse(points, 2); math.Abs(float64(twice.SemiMajor()/e.SemiMajor())-2) > 1e-9 {
+		t.Errorf("Expected two deviations to double the axes, but got %v", twice)
+	}
+}
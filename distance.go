@@ -0,0 +1,40 @@
+package geo
+
+import "strconv"
+
+// Distance represents a length along the surface of the Earth in meters.
+type Distance float64
+
+// Common units of Distance.
+const (
+	Meter        Distance = 1
+	Kilometer    Distance = 1000 * Meter
+	Foot         Distance = 0.3048 * Meter
+	Mile         Distance = 1609.344 * Meter
+	NauticalMile Distance = 1852 * Meter
+)
+
+// Meters returns the Distance d in meters.
+func (d Distance) Meters() float64 {
+	return float64(d)
+}
+
+// Kilometers returns the Distance d in kilometers.
+func (d Distance) Kilometers() float64 {
+	return float64(d / Kilometer)
+}
+
+// Miles returns the Distance d in statute miles.
+func (d Distance) Miles() float64 {
+	return float64(d / Mile)
+}
+
+// NauticalMiles returns the Distance d in nautical miles.
+func (d Distance) NauticalMiles() float64 {
+	return float64(d / NauticalMile)
+}
+
+// String renders the Distance d in meters, e.g. "1500m".
+func (d Distance) String() string {
+	return strconv.FormatFloat(float64(d), 'f', -1, 64) + "m"
+}
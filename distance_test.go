@@ -0,0 +1,34 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+// Ensures that a Distance can be converted between the supported units.
+func TestDistanceUnits(t *testing.T) {
+	d := 2 * Kilometer
+
+	if d.Meters() != 2000 {
+		t.Errorf("Expected 2km to be 2000 meters, but got %v instead", d.Meters())
+	}
+
+	if d.Kilometers() != 2 {
+		t.Errorf("Expected 2km to be 2 kilometers, but got %v instead", d.Kilometers())
+	}
+
+	if math.Abs(Mile.Kilometers()-1.609344) > 1e-12 {
+		t.Errorf("Expected a mile to be 1.609344 kilometers, but got %v instead", Mile.Kilometers())
+	}
+
+	if NauticalMile.NauticalMiles() != 1 {
+		t.Errorf("Expected a nautical mile to be 1 nautical mile, but got %v instead", NauticalMile.NauticalMiles())
+	}
+}
+
+// Ensures that a Distance renders in meters.
+func TestDistanceString(t *testing.T) {
+	if s := (1500 * Meter).String(); s != "1500m" {
+		t.Errorf("Expected 1500 meters to render as 1500m, but got %s instead", s)
+	}
+}
@@ -0,0 +1,224 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// DMSFormat describes how coordinates are rendered in degrees, minutes and seconds.
+type DMSFormat struct {
+	// Symbols written after the degrees, minutes and seconds.
+	Degree, Minute, Second string
+	// Separator is written between the latitude and the longitude.
+	Separator string
+	// Precision is the number of decimal places of the seconds.
+	Precision int
+}
+
+// DefaultDMSFormat renders coordinates like 40°44'55"N 73°59'11"W.
+var DefaultDMSFormat = DMSFormat{Degree: "°", Minute: "'", Second: `"`, Separator: " "}
+
+// FormatDMS renders Point p in degrees, minutes and seconds using DefaultDMSFormat.
+func FormatDMS(p Point) string {
+	return DefaultDMSFormat.Format(p)
+}
+
+// Format renders Point p in degrees, minutes and seconds, latitude first.
+func (f DMSFormat) Format(p Point) string {
+	return f.FormatLat(p.lat) + f.Separator + f.FormatLng(p.lng)
+}
+
+// FormatLat renders a latitude in degrees, minutes and seconds with an N or S suffix.
+func (f DMSFormat) FormatLat(lat float64) string {
+	if lat < 0 {
+		return f.format(-lat, 'S')
+	}
+	return f.format(lat, 'N')
+}
+
+// FormatLng renders a longitude in degrees, minutes and seconds with an E or W suffix.
+func (f DMSFormat) FormatLng(lng float64) string {
+	if lng < 0 {
+		return f.format(-lng, 'W')
+	}
+	return f.format(lng, 'E')
+}
+
+func (f DMSFormat) format(v float64, hemisphere byte) string {
+	precision := f.Precision
+	if precision < 0 {
+		precision = 0
+	}
+
+	// Round once in units of the smallest rendered second so that
+	// 59.9999" carries into the minutes instead of printing as 60".
+	scale := math.Pow10(precision)
+	units := math.Round(v * 3600 * scale)
+	deg := math.Floor(units / (3600 * scale))
+	units -= deg * 3600 * scale
+	min := math.Floor(units / (60 * scale))
+	sec := (units - min*60*scale) / scale
+
+	return fmt.Sprintf("%.0f%s%.0f%s%.*f%s%c", deg, f.Degree, min, f.Minute, precision, sec, f.Second, hemisphere)
+}
+
+// ParseDMS parses a latitude and longitude pair written in degrees, minutes and seconds (DMS),
+// degrees and decimal minutes (DDM) or signed decimal degrees, for example
+// 40°44'55"N 73°59'11"W, 40 44.917N, 73 59.183W or 40.7486, -73.9864.
+// The pair is split on a comma, semicolon or trailing hemisphere letter.
+// Coordinates marked E or W are taken as the longitude regardless of their position.
+func ParseDMS(s string) (Point, error) {
+	first, second, ok := splitCoordinatePair(s)
+	if !ok {
+		return Point{}, fmt.Errorf("%w: unable to split %q into a latitude and longitude", ErrInvalidFormat, s)
+	}
+
+	a, axisA, err := ParseDMSCoordinate(first)
+	if err != nil {
+		return Point{}, err
+	}
+
+	b, axisB, err := ParseDMSCoordinate(second)
+	if err != nil {
+		return Point{}, err
+	}
+
+	if axisA == axisB && axisA != 0 {
+		return Point{}, fmt.Errorf("%w: both coordinates of %q are on the same axis", ErrInvalidFormat, s)
+	}
+
+	if axisA == 'E' || axisB == 'N' {
+		a, b = b, a
+	}
+
+	if a < -90 || a > 90 {
+		return Point{}, fmt.Errorf("%w: %v in %q", ErrInvalidLatitude, a, s)
+	}
+	if b < -180 || b > 180 {
+		return Point{}, fmt.Errorf("%w: %v in %q", ErrInvalidLongitude, b, s)
+	}
+
+	return NewPoint(a, b), nil
+}
+
+// ParseDMSCoordinate parses a single coordinate written in DMS, DDM or signed decimal degrees,
+// returning its signed value in degrees.  The axis is 'N' for a coordinate marked N or S,
+// 'E' for one marked E or W, and zero when no hemisphere was given.
+func ParseDMSCoordinate(s string) (degrees float64, axis byte, err error) {
+	t := strings.TrimSpace(s)
+	sign := 1.0
+
+	if hemisphere, rest, ok := cutHemisphere(t); ok {
+		t = rest
+		switch hemisphere {
+		case 'S':
+			sign, axis = -1, 'N'
+		case 'N':
+			axis = 'N'
+		case 'W':
+			sign, axis = -1, 'E'
+		case 'E':
+			axis = 'E'
+		}
+	}
+
+	t = strings.TrimSpace(t)
+	if strings.HasPrefix(t, "-") || strings.HasPrefix(t, "+") {
+		if t[0] == '-' {
+			if sign < 0 {
+				return 0, 0, fmt.Errorf("%w: coordinate %q has both a negative sign and a southern or western hemisphere", ErrInvalidFormat, s)
+			}
+			sign = -1
+		}
+		t = t[1:]
+	}
+
+	fields := strings.FieldsFunc(t, func(r rune) bool {
+		return !unicode.IsDigit(r) && r != '.'
+	})
+	if len(fields) == 0 || len(fields) > 3 || strings.IndexFunc(t, isDMSJunk) >= 0 {
+		return 0, 0, fmt.Errorf("%w: invalid coordinate %q", ErrInvalidFormat, s)
+	}
+
+	var parts [3]float64
+	for i, field := range fields {
+		v, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("%w: invalid coordinate %q: %v", ErrInvalidFormat, s, err)
+		}
+		if i < len(fields)-1 && v != math.Trunc(v) {
+			return 0, 0, fmt.Errorf("%w: only the last component of %q may have a fraction", ErrInvalidFormat, s)
+		}
+		if i > 0 && v >= 60 {
+			return 0, 0, fmt.Errorf("%w: minutes or seconds of %q must be less than 60", ErrInvalidFormat, s)
+		}
+		parts[i] = v
+	}
+
+	return sign * (parts[0] + parts[1]/60 + parts[2]/3600), axis, nil
+}
+
+// cutHemisphere removes a leading or trailing hemisphere letter from s.
+// Hemispheres must be upper case, since a lower case s marks seconds.
+func cutHemisphere(s string) (hemisphere byte, rest string, ok bool) {
+	if s == "" {
+		return 0, s, false
+	}
+
+	if h := s[len(s)-1]; isHemisphere(h) {
+		return h, s[:len(s)-1], true
+	}
+
+	if h := s[0]; isHemisphere(h) {
+		return h, s[1:], true
+	}
+
+	return 0, s, false
+}
+
+func isHemisphere(c byte) bool {
+	return c == 'N' || c == 'S' || c == 'E' || c == 'W'
+}
+
+// isDMSJunk reports whether r cannot appear within a DMS, DDM or decimal coordinate.
+func isDMSJunk(r rune) bool {
+	if unicode.IsDigit(r) || unicode.IsSpace(r) {
+		return false
+	}
+
+	return !strings.ContainsRune(".°º˚'′’\"″”:dms", r)
+}
+
+// splitCoordinatePair splits s into its two coordinates.
+func splitCoordinatePair(s string) (string, string, bool) {
+	s = strings.TrimSpace(s)
+
+	for _, sep := range []string{";", ","} {
+		if i := strings.Index(s, sep); i >= 0 && !strings.Contains(s[i+1:], sep) {
+			return s[:i], s[i+1:], true
+		}
+	}
+
+	// Split at the first hemisphere letter inside the string: after it when it
+	// ends the first coordinate, before it when it starts the second one.
+	for i := 1; i < len(s)-1; i++ {
+		if !isHemisphere(s[i]) || unicode.IsLetter(rune(s[i+1])) {
+			continue
+		}
+		if unicode.IsSpace(rune(s[i-1])) && !unicode.IsSpace(rune(s[i+1])) {
+			return s[:i], s[i:], true
+		}
+		return s[:i+1], s[i+1:], true
+	}
+
+	fields := strings.Fields(s)
+	if len(fields) > 0 && len(fields)%2 == 0 {
+		half := len(fields) / 2
+		return strings.Join(fields[:half], " "), strings.Join(fields[half:], " "), true
+	}
+
+	return "", "", false
+}
@@ -0,0 +1,79 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+// Ensures that points are rendered in degrees, minutes and seconds.
+func TestFormatDMS(t *testing.T) {
+	if s := FormatDMS(NewPoint(40.7486, -73.9864)); s != `40°44'55"N 73°59'11"W` {
+		t.Errorf(`Expected 40°44'55"N 73°59'11"W, but got %s instead`, s)
+	}
+
+	if s := FormatDMS(NewPoint(-33.8568, 151.2153)); s != `33°51'24"S 151°12'55"E` {
+		t.Errorf(`Expected 33°51'24"S 151°12'55"E, but got %s instead`, s)
+	}
+
+	// Seconds that round up to 60 must carry into the minutes and degrees.
+	if s := FormatDMS(NewPoint(10.99999, 0)); s != `11°0'0"N 0°0'0"E` {
+		t.Errorf(`Expected 11°0'0"N 0°0'0"E, but got %s instead`, s)
+	}
+
+	f := DMSFormat{Degree: "d", Minute: "m", Second: "s", Separator: ", ", Precision: 2}
+	if s := f.Format(NewPoint(40.7486, -73.9864)); s != "40d44m54.96sN, 73d59m11.04sW" {
+		t.Errorf("Expected 40d44m54.96sN, 73d59m11.04sW, but got %s instead", s)
+	}
+}
+
+// Ensures that DMS, DDM and decimal pairs are parsed.
+func TestParseDMS(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected Point
+	}{
+		{`40°44'55"N 73°59'11"W`, NewPoint(40.748611, -73.986389)},
+		{`40°44'55"N73°59'11"W`, NewPoint(40.748611, -73.986389)},
+		{`40 44 55 N 73 59 11 W`, NewPoint(40.748611, -73.986389)},
+		{"40d44m55sN 73d59m11sW", NewPoint(40.748611, -73.986389)},
+		{"N40 44.917 W73 59.183", NewPoint(40.748617, -73.986383)},
+		{"40 44.917N, 73 59.183W", NewPoint(40.748617, -73.986383)},
+		{"40.7486, -73.9864", NewPoint(40.7486, -73.9864)},
+		{"40.7486 -73.9864", NewPoint(40.7486, -73.9864)},
+		{"73.9864W 40.7486N", NewPoint(40.7486, -73.9864)},
+		{`33°51′24″S; 151°12′55″E`, NewPoint(-33.856667, 151.215278)},
+	}
+
+	for _, tt := range tests {
+		p, err := ParseDMS(tt.s)
+		if err != nil {
+			t.Errorf("Should not encounter an error when parsing %q, but got %v", tt.s, err)
+			continue
+		}
+		if math.Abs(p.lat-tt.expected.lat) > 1e-6 || math.Abs(p.lng-tt.expected.lng) > 1e-6 {
+			t.Errorf("Expected %q to parse as %v, but got %v", tt.s, tt.expected, p)
+		}
+	}
+
+	for _, invalid := range []string{"", "40.7486", "40N 73N", "91, 0", "40°61'N 73°W", "40.5 30 N 73 W", "-40S, 73W", "forty, two"} {
+		if _, err := ParseDMS(invalid); err == nil {
+			t.Errorf("Expected an error when parsing %q", invalid)
+		}
+	}
+}
+
+// Ensures that formatted coordinates parse back to the same point.
+func TestDMSRoundTrip(t *testing.T) {
+	p := NewPoint(-12.345678, 98.765432)
+	f := DefaultDMSFormat
+	f.Precision = 3
+
+	back, err := ParseDMS(f.Format(p))
+	if err != nil {
+		t.Fatalf("Should not encounter an error when parsing %s, but got %v", f.Format(p), err)
+	}
+
+	if math.Abs(back.lat-p.lat) > 1e-6 || math.Abs(back.lng-p.lng) > 1e-6 {
+		t.Errorf("Expected %v to round trip, but got %v", p, back)
+	}
+}
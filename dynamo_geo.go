@@ -0,0 +1,154 @@
+package geo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DefaultDynamoHashKeyLength is the geohash prefix length used as the partition key
+// when none is configured.  Cells of this size are roughly 5km across.
+const DefaultDynamoHashKeyLength = 5
+
+// DynamoGeoRecord is a single item stored in a geohash indexed DynamoDB table.
+// HashKey is the partition key and RangeKey the sort key of the table.
+type DynamoGeoRecord struct {
+	HashKey  string
+	RangeKey string
+	ID       string
+	Point    Point
+}
+
+// DynamoGeoBackend performs the raw DynamoDB operations needed by a DynamoGeoTable.
+// Implementations usually wrap the PutItem, DeleteItem and Query calls of the AWS SDK,
+// mapping HashKey and RangeKey onto the key schema of the table.
+type DynamoGeoBackend interface {
+	PutItem(ctx context.Context, record DynamoGeoRecord) error
+	DeleteItem(ctx context.Context, hashKey string, rangeKey string) error
+	QueryHashKey(ctx context.Context, hashKey string) ([]DynamoGeoRecord, error)
+}
+
+// DynamoGeoResult is a single item returned from a DynamoGeoTable query.
+// Distance is only populated by radius queries.
+type DynamoGeoResult struct {
+	ID       string
+	Point    Point
+	Distance Distance
+}
+
+// DynamoGeoTable stores Points in DynamoDB, partitioned by geohash prefix,
+// in the style of the AWS Geo Library.  Queries fan out over every partition
+// covering the search area and post-filter the candidates exactly.
+type DynamoGeoTable struct {
+	backend       DynamoGeoBackend
+	hashKeyLength int
+}
+
+// NewDynamoGeoTable returns a new DynamoGeoTable using the passed in backend.
+// hashKeyLength is the number of geohash characters used as the partition key;
+// a value less than one selects DefaultDynamoHashKeyLength.
+func NewDynamoGeoTable(backend DynamoGeoBackend, hashKeyLength int) *DynamoGeoTable {
+	if hashKeyLength < 1 {
+		hashKeyLength = DefaultDynamoHashKeyLength
+	}
+	if hashKeyLength > MaxGeohashPrecision {
+		hashKeyLength = MaxGeohashPrecision
+	}
+
+	return &DynamoGeoTable{backend: backend, hashKeyLength: hashKeyLength}
+}
+
+// Record returns the DynamoGeoRecord that stores Point p under id.
+// The sort key is the full precision geohash of p followed by the id,
+// so items in a partition are ordered spatially.
+func (t *DynamoGeoTable) Record(id string, p Point) DynamoGeoRecord {
+	hash := EncodeGeohash(p, MaxGeohashPrecision)
+	return DynamoGeoRecord{
+		HashKey:  hash[:t.hashKeyLength],
+		RangeKey: hash + "#" + id,
+		ID:       id,
+		Point:    p,
+	}
+}
+
+// Put stores Point p under id.
+func (t *DynamoGeoTable) Put(ctx context.Context, id string, p Point) error {
+	if err := t.backend.PutItem(ctx, t.Record(id, p)); err != nil {
+		return fmt.Errorf("dynamodb put %s: %w", id, err)
+	}
+
+	return nil
+}
+
+// Delete removes the item stored under id at Point p.
+func (t *DynamoGeoTable) Delete(ctx context.Context, id string, p Point) error {
+	r := t.Record(id, p)
+	if err := t.backend.DeleteItem(ctx, r.HashKey, r.RangeKey); err != nil {
+		return fmt.Errorf("dynamodb delete %s: %w", id, err)
+	}
+
+	return nil
+}
+
+// QueryBoundingBox returns every item that lies within the BoundingBox b.
+func (t *DynamoGeoTable) QueryBoundingBox(ctx context.Context, b BoundingBox) ([]DynamoGeoResult, error) {
+	records, err := t.query(ctx, b)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []DynamoGeoResult
+	for _, r := range records {
+		if b.Contains(r.Point) {
+			results = append(results, DynamoGeoResult{ID: r.ID, Point: r.Point})
+		}
+	}
+
+	return results, nil
+}
+
+// QueryRadius returns every item within radius of center, nearest first.
+// Candidates are filtered by their exact Haversine distance from center.
+func (t *DynamoGeoTable) QueryRadius(ctx context.Context, center Point, radius Distance) ([]DynamoGeoResult, error) {
+	records, err := t.query(ctx, BoundsAround(center, radius))
+	if err != nil {
+		return nil, err
+	}
+
+	var results []DynamoGeoResult
+	for _, r := range records {
+		if d := center.GreatCircleDistance(r.Point); d <= radius {
+			results = append(results, DynamoGeoResult{ID: r.ID, Point: r.Point, Distance: d})
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Distance < results[j].Distance
+	})
+
+	return results, nil
+}
+
+// query collects the candidate records of every partition covering b.
+func (t *DynamoGeoTable) query(ctx context.Context, b BoundingBox) ([]DynamoGeoRecord, error) {
+	hashKeys := GeohashesCovering(b, t.hashKeyLength)
+	debugf("geo: querying %d dynamodb partitions covering %v", len(hashKeys), b)
+
+	var records []DynamoGeoRecord
+	for _, hashKey := range hashKeys {
+		found, err := t.backend.QueryHashKey(ctx, hashKey)
+		if err != nil {
+			return nil, fmt.Errorf("dynamodb query %s: %w", hashKey, err)
+		}
+
+		for _, r := range found {
+			if r.ID == "" {
+				r.ID = r.RangeKey[strings.IndexByte(r.RangeKey, '#')+1:]
+			}
+			records = append(records, r)
+		}
+	}
+
+	return records, nil
+}
@@ -0,0 +1,102 @@
+package geo
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// An in-memory DynamoGeoBackend keyed by partition and sort key.
+type memoryDynamo struct {
+	partitions map[string]map[string]DynamoGeoRecord
+	queries    int
+}
+
+func newMemoryDynamo() *memoryDynamo {
+	return &memoryDynamo{partitions: make(map[string]map[string]DynamoGeoRecord)}
+}
+
+func (m *memoryDynamo) PutItem(ctx context.Context, r DynamoGeoRecord) error {
+	if m.partitions[r.HashKey] == nil {
+		m.partitions[r.HashKey] = make(map[string]DynamoGeoRecord)
+	}
+	m.partitions[r.HashKey][r.RangeKey] = r
+	return nil
+}
+
+func (m *memoryDynamo) DeleteItem(ctx context.Context, hashKey string, rangeKey string) error {
+	delete(m.partitions[hashKey], rangeKey)
+	return nil
+}
+
+func (m *memoryDynamo) QueryHashKey(ctx context.Context, hashKey string) ([]DynamoGeoRecord, error) {
+	m.queries++
+	var records []DynamoGeoRecord
+	for _, r := range m.partitions[hashKey] {
+		records = append(records, r)
+	}
+	return records, nil
+}
+
+// Ensures that records are keyed by geohash prefix and sorted by full geohash.
+func TestDynamoGeoTableRecord(t *testing.T) {
+	table := NewDynamoGeoTable(newMemoryDynamo(), 0)
+	r := table.Record("stop-1", NewPoint(57.64911, 10.40744))
+
+	if r.HashKey != "u4pru" {
+		t.Errorf("Expected the hash key to be u4pru, but got %s instead", r.HashKey)
+	}
+
+	if !strings.HasPrefix(r.RangeKey, "u4pruydqqvj") || !strings.HasSuffix(r.RangeKey, "#stop-1") {
+		t.Errorf("Expected the range key to be the geohash followed by the id, but got %s instead", r.RangeKey)
+	}
+}
+
+// Ensures that radius queries post-filter and order results by exact distance.
+func TestDynamoGeoTableQueryRadius(t *testing.T) {
+	backend := newMemoryDynamo()
+	table := NewDynamoGeoTable(backend, 5)
+	ctx := context.Background()
+
+	center := NewPoint(47.6062, -122.3321)
+	table.Put(ctx, "far", NewPoint(47.6062, -122.3021))
+	table.Put(ctx, "near", NewPoint(47.6072, -122.3321))
+	table.Put(ctx, "mid", NewPoint(47.6062, -122.3221))
+
+	results, err := table.QueryRadius(ctx, center, Kilometer)
+	if err != nil {
+		t.Fatalf("Should not encounter an error when querying, but got %v", err)
+	}
+
+	if len(results) != 2 || results[0].ID != "near" || results[1].ID != "mid" {
+		t.Fatalf("Expected near and mid in order, but got %+v instead", results)
+	}
+
+	if results[0].Distance > 120*Meter {
+		t.Errorf("Expected near to be about 111m away, but got %v instead", results[0].Distance)
+	}
+
+	if backend.queries == 0 {
+		t.Error("Expected at least one partition to be queried")
+	}
+}
+
+// Ensures that bounding box queries exclude candidates outside of the box and honour deletes.
+func TestDynamoGeoTableQueryBoundingBox(t *testing.T) {
+	table := NewDynamoGeoTable(newMemoryDynamo(), 4)
+	ctx := context.Background()
+
+	table.Put(ctx, "in", NewPoint(1.5, 1.5))
+	table.Put(ctx, "out", NewPoint(2.5, 1.5))
+	table.Put(ctx, "gone", NewPoint(1.2, 1.2))
+	table.Delete(ctx, "gone", NewPoint(1.2, 1.2))
+
+	results, err := table.QueryBoundingBox(ctx, NewBoundingBox(NewPoint(1, 1), NewPoint(2, 2)))
+	if err != nil {
+		t.Fatalf("Should not encounter an error when querying, but got %v", err)
+	}
+
+	if len(results) != 1 || results[0].ID != "in" {
+		t.Errorf("Expected only the point inside the box, but got %+v instead", results)
+	}
+}
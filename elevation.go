@@ -0,0 +1,101 @@
+package geo
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+// An Elevator looks up the height of the terrain above sea level.
+// Implementations wrap ErrOutOfBounds for locations they hold no data for
+// and ErrNoResults for voids in the data they do hold.
+type Elevator interface {
+	// ElevationAt returns the elevation of the terrain at Point p.
+	ElevationAt(ctx context.Context, p Point) (Distance, error)
+	// ElevationsAt returns the elevation of the terrain at each of the passed in points, in order.
+	ElevationsAt(ctx context.Context, points []Point) ([]Distance, error)
+}
+
+// elevationGrid is a regular raster of elevation samples in meters, such as a DEM tile.
+// Row 0 is the northernmost row and column 0 the westernmost column; the centers of
+// samples lie at north - row*latStep and west + col*lngStep.  The samples are held in
+// row major order in either heights, which halves the memory of integer rasters, or samples.
+type elevationGrid struct {
+	rows, cols       int
+	north, west      float64
+	latStep, lngStep float64
+	heights          []int16
+	samples          []float32
+	noData           float32
+	hasNoData        bool
+}
+
+// sample returns the i'th sample in row major order.
+func (g *elevationGrid) sample(i int) float32 {
+	if g.heights != nil {
+		return float32(g.heights[i])
+	}
+	return g.samples[i]
+}
+
+// covers reports whether Point p lies within the samples of the grid.
+func (g *elevationGrid) covers(p Point) bool {
+	row := (g.north - p.lat) / g.latStep
+	col := (p.lng - g.west) / g.lngStep
+	return row >= 0 && col >= 0 && row <= float64(g.rows-1) && col <= float64(g.cols-1)
+}
+
+// elevationAt bilinearly interpolates the four samples surrounding Point p.
+// It returns an error wrapping ErrOutOfBounds if the grid does not cover p,
+// or ErrNoResults if any of the four samples is a void.
+func (g *elevationGrid) elevationAt(p Point) (Distance, error) {
+	if !g.covers(p) {
+		return 0, fmt.Errorf("%w: %v is outside of the elevation data", ErrOutOfBounds, p)
+	}
+
+	row := (g.north - p.lat) / g.latStep
+	col := (p.lng - g.west) / g.lngStep
+	r0, c0 := min(int(row), g.rows-2), min(int(col), g.cols-2)
+	r0, c0 = max(r0, 0), max(c0, 0)
+	r1, c1 := min(r0+1, g.rows-1), min(c0+1, g.cols-1)
+	fr, fc := row-float64(r0), col-float64(c0)
+
+	var sum float64
+	for _, s := range [4]struct {
+		r, c   int
+		weight float64
+	}{
+		{r0, c0, (1 - fr) * (1 - fc)},
+		{r0, c1, (1 - fr) * fc},
+		{r1, c0, fr * (1 - fc)},
+		{r1, c1, fr * fc},
+	} {
+		v := g.sample(s.r*g.cols + s.c)
+		if (g.hasNoData && v == g.noData) || math.IsNaN(float64(v)) {
+			if s.weight == 0 {
+				continue
+			}
+			return 0, fmt.Errorf("%w: no elevation data at %v", ErrNoResults, p)
+		}
+		sum += float64(v) * s.weight
+	}
+
+	return Distance(sum), nil
+}
+
+// elevationsAt calls elevationAt for each of the passed in points, checking ctx between them.
+func elevationsAt(ctx context.Context, points []Point, elevationAt func(ctx context.Context, p Point) (Distance, error)) ([]Distance, error) {
+	elevations := make([]Distance, len(points))
+	for i, p := range points {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		e, err := elevationAt(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		elevations[i] = e
+	}
+	return elevations, nil
+}
@@ -0,0 +1,91 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+)
+
+// An Ellipse is an elliptical region on the local tangent plane at its center, such as the
+// spread of incidents summarized by StandardDeviationalEllipse.  It is meant for regions up to a
+// few hundred kilometers across, over which the tangent plane follows the earth closely.
+type Ellipse struct {
+	center               Point
+	semiMajor, semiMinor Distance
+	azimuth              float64
+}
+
+// NewEllipse returns a new Ellipse around center with the passed in semi-axes, whose first axis
+// points azimuth degrees clockwise from true north.  The axes are swapped if the first is the shorter.
+func NewEllipse(center Point, semiMajor Distance, semiMinor Distance, azimuth float64) Ellipse {
+	if semiMajor < semiMinor {
+		semiMajor, semiMinor, azimuth = semiMinor, semiMajor, azimuth+90
+	}
+	azimuth = math.Mod(azimuth, 180)
+	if azimuth < 0 {
+		azimuth += 180
+	}
+	return Ellipse{center: center, semiMajor: semiMajor, semiMinor: semiMinor, azimuth: azimuth}
+}
+
+// Center returns the center of the Ellipse.
+func (e Ellipse) Center() Point {
+	return e.center
+}
+
+// SemiMajor returns half the length of the long axis of the Ellipse.
+func (e Ellipse) SemiMajor() Distance {
+	return e.semiMajor
+}
+
+// SemiMinor returns half the length of the short axis of the Ellipse.
+func (e Ellipse) SemiMinor() Distance {
+	return e.semiMinor
+}
+
+// Azimuth returns the direction of the long axis of the Ellipse in degrees clockwise from true north,
+// from 0 up to 180.
+func (e Ellipse) Azimuth() float64 {
+	return e.azimuth
+}
+
+// Bounds returns a BoundingBox enclosing the Ellipse: that of the Circle through the ends of its long axis.
+func (e Ellipse) Bounds() BoundingBox {
+	return BoundsAround(e.center, e.semiMajor)
+}
+
+// Contains reports whether Point p lies inside the Ellipse.
+func (e Ellipse) Contains(p Point) bool {
+	if e.semiMinor <= 0 {
+		return false
+	}
+	east, north, _ := NewLocalFrame(e.center, 0).ToENU(p, 0)
+	major, minor := e.axes(east, north)
+	return major*major+minor*minor <= 1
+}
+
+// axes returns the coordinates of the point east and north meters from the center along the axes
+// of the Ellipse, scaled by their lengths.
+func (e Ellipse) axes(east float64, north float64) (major float64, minor float64) {
+	sin, cos := math.Sincos(e.azimuth * math.Pi / 180)
+	return (east*sin + north*cos) / e.semiMajor.Meters(), (east*cos - north*sin) / e.semiMinor.Meters()
+}
+
+// Polygon returns a Polygon of n points on the outline of the Ellipse, for drawing it on a map.
+// n is raised to at least 3.
+func (e Ellipse) Polygon(n int) Polygon {
+	n = max(n, 3)
+	frame := NewLocalFrame(e.center, 0)
+	sin, cos := math.Sincos(e.azimuth * math.Pi / 180)
+	points := make([]Point, n)
+	for i := range points {
+		t := 2 * math.Pi * float64(i) / float64(n)
+		major, minor := e.semiMajor.Meters()*math.Cos(t), e.semiMinor.Meters()*math.Sin(t)
+		points[i], _ = frame.FromENU(major*sin+minor*cos, major*cos-minor*sin, 0)
+	}
+	return NewPolygon(points)
+}
+
+// String renders the Ellipse as its center, axes and azimuth, for example "Ellipse(-33.8688,151.2093, 500m x 200m, 45°)".
+func (e Ellipse) String() string {
+	return fmt.Sprintf("Ellipse(%v, %v x %v, %v°)", e.center, e.semiMajor, e.semiMinor, e.azimuth)
+}
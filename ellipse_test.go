@@ -0,0 +1,59 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+// Ensures that ellipses contain the points within their rotated axes.
+func TestEllipse(t *testing.T) {
+	center := NewPoint(-33.8688, 151.2093)
+	e := NewEllipse(center, 200*Meter, 500*Meter, 0)
+	if e.SemiMajor() != 500*Meter || e.SemiMinor() != 200*Meter || e.Azimuth() != 90 || e.Center() != center {
+		t.Fatalf("Expected the axes swapped, but got %v", e)
+	}
+	if e.String() != "Ellipse(-33.8688,151.2093, 500m x 200m, 90°)" {
+		t.Errorf("Unexpected string %q", e.String())
+	}
+
+	e = NewEllipse(center, 500*Meter, 200*Meter, 225)
+	if e.Azimuth() != 45 {
+		t.Errorf("Expected the azimuth reduced to 45, but got %v", e.Azimuth())
+	}
+	tests := []struct {
+		bearing  float64
+		distance Distance
+		expected bool
+	}{
+		{45, 490 * Meter, true},
+		{225, 490 * Meter, true},
+		{45, 510 * Meter, false},
+		{135, 190 * Meter, true},
+		{135, 210 * Meter, false},
+		{0, 250 * Meter, true},
+		{0, 275 * Meter, false},
+		{90, 400 * Meter, false},
+	}
+	for _, tt := range tests {
+		if p := destinationPoint(center, tt.bearing, tt.distance); e.Contains(p) != tt.expected {
+			t.Errorf("Expected the point %v at %v° to be contained: %v", tt.distance, tt.bearing, tt.expected)
+		}
+	}
+
+	polygon := e.Polygon(64)
+	if len(polygon.Points()) != 64 {
+		t.Fatalf("Expected 64 points, but got %d", len(polygon.Points()))
+	}
+	if d := center.GreatCircleDistance(polygon.Points()[0]); math.Abs(d.Meters()-500) > 1 {
+		t.Errorf("Expected the first point at the end of the long axis, but got %v", d)
+	}
+	b := e.Bounds()
+	for _, p := range polygon.Points() {
+		if !b.Contains(p) {
+			t.Errorf("Expected %v inside the bounds %v", p, b)
+		}
+	}
+	if !polygon.Contains(destinationPoint(center, 45, 450*Meter)) || polygon.Contains(destinationPoint(center, 135, 250*Meter)) {
+		t.Errorf("Expected the polygon to follow the ellipse")
+	}
+}
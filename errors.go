@@ -0,0 +1,46 @@
+package geo
+
+import "errors"
+
+// Errors returned, usually wrapped with further detail, by the functions in this package.
+// Use errors.Is to test for them.
+var (
+	// ErrInvalidLatitude is returned for latitudes outside of [-90, 90].
+	ErrInvalidLatitude = errors.New("latitude out of range")
+	// ErrInvalidLongitude is returned for longitudes outside of [-180, 180].
+	ErrInvalidLongitude = errors.New("longitude out of range")
+	// ErrNonFiniteCoordinate is returned for NaN or infinite coordinates.
+	ErrNonFiniteCoordinate = errors.New("coordinate is not a finite number")
+	// ErrUnclosedPolygon is returned when a polygon has too few points to enclose an area.
+	ErrUnclosedPolygon = errors.New("polygon is not closed")
+	// ErrDegeneratePolygon is returned when a polygon's points are repeated or collinear.
+	ErrDegeneratePolygon = errors.New("polygon is degenerate")
+	// ErrUnsupportedGeometry is returned when a geometry type cannot be handled.
+	ErrUnsupportedGeometry = errors.New("unsupported geometry")
+	// ErrUnknownCRS is returned when no Transformer is registered for a reference system.
+	ErrUnknownCRS = errors.New("unknown coordinate reference system")
+	// ErrInvalidFormat is returned when a coordinate, grid reference or cell identifier cannot be parsed.
+	ErrInvalidFormat = errors.New("invalid format")
+	// ErrInvalidPrecision is returned for unsupported precisions, resolutions or zoom levels.
+	ErrInvalidPrecision = errors.New("invalid precision")
+	// ErrInvalidDistance is returned for distances that are negative or zero where a length is required.
+	ErrInvalidDistance = errors.New("invalid distance")
+	// ErrInvalidSpeed is returned for speeds that are not positive and finite.
+	ErrInvalidSpeed = errors.New("invalid speed")
+	// ErrOutOfBounds is returned when a location lies outside of the area a grid system covers.
+	ErrOutOfBounds = errors.New("outside of the supported area")
+	// ErrInvalidDate is returned for dates outside of the period a model is valid for.
+	ErrInvalidDate = errors.New("date outside of the valid period")
+	// ErrUnexpectedReply is returned when a storage backend replies with data of the wrong shape.
+	ErrUnexpectedReply = errors.New("unexpected reply")
+	// ErrUnknownGeocoder is returned when no Geocoder is registered under a provider name.
+	ErrUnknownGeocoder = errors.New("unknown geocoder")
+	// ErrNoResults is returned when a Geocoder or other lookup finds no match for an address or location.
+	ErrNoResults = errors.New("no results")
+	// ErrNoIndex is returned by lookups that rely on an index which has not been installed.
+	ErrNoIndex = errors.New("no index has been set")
+	// ErrRateLimited is returned when a remote service rejects a request for exceeding its quota.
+	ErrRateLimited = errors.New("rate limited")
+	// ErrServiceUnavailable is returned when a remote service fails with a temporary server error.
+	ErrServiceUnavailable = errors.New("service unavailable")
+)
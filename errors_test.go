@@ -0,0 +1,40 @@
+package geo
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// Ensures that errors returned across the package can be matched with errors.Is.
+func TestSentinelErrors(t *testing.T) {
+	_, crsErr := TransformPoint(NewPoint(0, 0), WGS84, 999999)
+	_, hashErr := DecodeGeohash("u4pr!")
+	_, mgrsErr := EncodeMGRS(NewPoint(0, 0), 9)
+	_, _, gridErr := ParseOSGridReference("ZZ 1 1")
+	_, mongoErr := MongoPoint{Type: "LineString"}.Point()
+
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{func() error { _, err := NewPointValidated(91, 0); return err }(), ErrInvalidLatitude},
+		{func() error { _, err := NewPointValidated(0, -181); return err }(), ErrInvalidLongitude},
+		{func() error { _, err := NewPointValidated(math.NaN(), 0); return err }(), ErrNonFiniteCoordinate},
+		{func() error { _, err := NewPolygonValidated([]Point{{0, 0}, {1, 1}, {2, 2}}); return err }(), ErrDegeneratePolygon},
+		{func() error { _, err := NewPolygonValidated([]Point{{0, 0}, {95, 1}, {2, 2}}); return err }(), ErrInvalidLatitude},
+		{func() error { _, err := ParsePoint("12, 200"); return err }(), ErrInvalidLongitude},
+		{func() error { _, err := ParsePoint("north, south"); return err }(), ErrInvalidFormat},
+		{crsErr, ErrUnknownCRS},
+		{hashErr, ErrInvalidFormat},
+		{mgrsErr, ErrInvalidPrecision},
+		{gridErr, ErrOutOfBounds},
+		{mongoErr, ErrUnsupportedGeometry},
+	}
+
+	for _, tt := range tests {
+		if !errors.Is(tt.err, tt.expected) {
+			t.Errorf("Expected %v to wrap %v", tt.err, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package geo
+
+import (
+	"math"
+	"sort"
+)
+
+// Extent returns the smallest BoundingBox containing every Point, crossing the antimeridian when that
+// is narrower, as for points on either side of the Pacific.  It returns the zero BoundingBox for no points.
+func Extent(points []Point) BoundingBox {
+	boxes := make([]BoundingBox, len(points))
+	for i, p := range points {
+		boxes[i] = NewBoundingBox(p, p)
+	}
+	return boxesExtent(boxes)
+}
+
+// GeometryExtent returns the smallest BoundingBox containing the bounds of every Geometry, crossing the
+// antimeridian when that is narrower.  Nil geometries and those without points are left out, and the
+// zero BoundingBox is returned when none remain.
+func GeometryExtent(geometries []Geometry) BoundingBox {
+	return boxesExtent(geometryBounds(geometries))
+}
+
+// geometryBounds returns the bounds of every Geometry that has points.
+func geometryBounds(geometries []Geometry) []BoundingBox {
+	var boxes []BoundingBox
+	for _, g := range geometries {
+		switch g := g.(type) {
+		case nil:
+			continue
+		case interface{ Points() []Point }:
+			if len(g.Points()) == 0 {
+				continue
+			}
+		case MultiPolygon:
+			empty := true
+			for _, p := range g.polygons {
+				empty = empty && len(p.points) == 0
+			}
+			if empty {
+				continue
+			}
+		}
+		boxes = append(boxes, g.Bounds())
+	}
+	return boxes
+}
+
+// Envelope returns the GeometryExtent of the geometries as a Polygon, so that it can be passed where a
+// Polygon is expected.  It returns a Polygon without points when none of the geometries has any.
+func Envelope(geometries ...Geometry) Polygon {
+	boxes := geometryBounds(geometries)
+	if len(boxes) == 0 {
+		return Polygon{}
+	}
+	return boxesExtent(boxes).ToPolygon()
+}
+
+// boxesExtent returns the smallest BoundingBox containing every box.  Its longitudes span the circle but
+// for the widest gap between the longitudes the boxes cover.
+func boxesExtent(boxes []BoundingBox) BoundingBox {
+	if len(boxes) == 0 {
+		return BoundingBox{}
+	}
+
+	south, north := math.Inf(1), math.Inf(-1)
+	var intervals [][2]float64
+	for _, b := range boxes {
+		south, north = math.Min(south, b.sw.lat), math.Max(north, b.ne.lat)
+		intervals = append(intervals, b.lngIntervals()...)
+	}
+	sort.Slice(intervals, func(i, j int) bool { return intervals[i][0] < intervals[j][0] })
+
+	// Merge the overlapping intervals, then find the widest gap between them, counting the one
+	// from the last interval round to the first.  On a tie the box avoids the antimeridian.
+	merged := intervals[:1]
+	for _, iv := range intervals[1:] {
+		if last := &merged[len(merged)-1]; iv[0] <= last[1] {
+			last[1] = math.Max(last[1], iv[1])
+		} else {
+			merged = append(merged, iv)
+		}
+	}
+	west, east := merged[0][0], merged[len(merged)-1][1]
+	widest := west + 360 - east
+	for i := 1; i < len(merged); i++ {
+		if gap := merged[i][0] - merged[i-1][1]; gap > widest {
+			widest, west, east = gap, merged[i][0], merged[i-1][1]
+		}
+	}
+	return NewBoundingBox(NewPoint(south, west), NewPoint(north, east))
+}
@@ -0,0 +1,59 @@
+package geo
+
+import (
+	"testing"
+)
+
+// Ensures that the extent is the narrowest box around the points, across the antimeridian if need be.
+func TestExtent(t *testing.T) {
+	tests := []struct {
+		points   []Point
+		expected BoundingBox
+	}{
+		{nil, BoundingBox{}},
+		{[]Point{NewPoint(1, 2)}, NewBoundingBox(NewPoint(1, 2), NewPoint(1, 2))},
+		{[]Point{NewPoint(1, 2), NewPoint(-3, 10), NewPoint(5, -4)}, NewBoundingBox(NewPoint(-3, -4), NewPoint(5, 10))},
+		{[]Point{NewPoint(-10, 170), NewPoint(10, -170), NewPoint(0, 179)}, NewBoundingBox(NewPoint(-10, 170), NewPoint(10, -170))},
+		{[]Point{NewPoint(0, -90), NewPoint(0, 90)}, NewBoundingBox(NewPoint(0, -90), NewPoint(0, 90))},
+		{[]Point{NewPoint(0, -120), NewPoint(0, 0), NewPoint(0, 120)}, NewBoundingBox(NewPoint(0, -120), NewPoint(0, 120))},
+	}
+	for _, tt := range tests {
+		if e := Extent(tt.points); e != tt.expected {
+			t.Errorf("Expected the extent of %v to be %v, but got %v", tt.points, tt.expected, e)
+		}
+	}
+}
+
+// Ensures that the extent of geometries covers their bounds and leaves out empty ones.
+func TestGeometryExtent(t *testing.T) {
+	square := NewPolygon([]Point{NewPoint(0, 175), NewPoint(0, 178), NewPoint(3, 178), NewPoint(3, 175), NewPoint(0, 175)})
+	tests := []struct {
+		geometries []Geometry
+		expected   BoundingBox
+	}{
+		{nil, BoundingBox{}},
+		{[]Geometry{nil, NewPolygon(nil), NewMultiPolygon(NewPolygon(nil))}, BoundingBox{}},
+		{[]Geometry{square, NewPoint(-2, 176), NewPolygon(nil)}, NewBoundingBox(NewPoint(-2, 175), NewPoint(3, 178))},
+		{[]Geometry{square, NewBoundingBox(NewPoint(5, 179), NewPoint(6, -179)), NewLineString([]Point{NewPoint(1, -170), NewPoint(2, -172)})}, NewBoundingBox(NewPoint(0, 175), NewPoint(6, -170))},
+		{[]Geometry{NewBoundingBox(NewPoint(0, -180), NewPoint(1, 180))}, NewBoundingBox(NewPoint(0, -180), NewPoint(1, 180))},
+	}
+	for _, tt := range tests {
+		if e := GeometryExtent(tt.geometries); e != tt.expected {
+			t.Errorf("Expected the extent of %v to be %v, but got %v", tt.geometries, tt.expected, e)
+		}
+	}
+}
+
+// Ensures that the envelope is the extent as a Polygon, and empty without points.
+func TestEnvelope(t *testing.T) {
+	e := Envelope(NewPoint(0, 0), NewLineString([]Point{NewPoint(2, 1), NewPoint(-1, 3)}))
+	if e.Bounds() != NewBoundingBox(NewPoint(-1, 0), NewPoint(2, 3)) || !e.Contains(NewPoint(1, 2)) {
+		t.Errorf("Expected the envelope of the geometries, but got %v", e)
+	}
+	if e := Envelope(NewPoint(0, 0)); len(e.Points()) != 5 {
+		t.Errorf("Expected the envelope of a point at the origin, but got %v", e)
+	}
+	if e := Envelope(NewPolygon(nil)); len(e.Points()) != 0 {
+		t.Errorf("Expected an empty envelope, but got %v", e)
+	}
+}
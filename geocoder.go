@@ -0,0 +1,112 @@
+package geo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+// A Geocoder converts between free text addresses and Points using a geocoding service.
+// Implementations wrap ErrNoResults when nothing matches, ErrRateLimited when the service
+// rejects a request for exceeding its quota and ErrServiceUnavailable for temporary failures.
+type Geocoder interface {
+	// Geocode returns the Point of the best match for address.
+	Geocode(ctx context.Context, address string) (Point, error)
+	// ReverseGeocode returns the Address of the best match for Point p.
+	ReverseGeocode(ctx context.Context, p Point) (Address, error)
+}
+
+// An Address is a location returned by reverse geocoding.
+// Services fill in as many of the components as they know; Formatted is always set.
+type Address struct {
+	Formatted   string
+	HouseNumber string
+	Street      string
+	Locality    string
+	Region      string
+	PostalCode  string
+	Country     string
+	CountryCode string
+}
+
+// String returns the formatted address.
+func (a Address) String() string {
+	return a.Formatted
+}
+
+// GeocoderConfig is the provider independent configuration of a Geocoder created with NewGeocoder.
+// Provider specific settings, such as Google's component filters, are only available
+// through each provider's own constructor.
+type GeocoderConfig struct {
+	// APIKey authenticates with the provider.  Pelias servers may not need one.
+	APIKey string
+	// BaseURL replaces the provider's default endpoint, for example to point at a proxy
+	// or a self-hosted server.
+	BaseURL string
+	// Language is the language results are returned in, such as "en".  Defaults to the provider's choice.
+	Language string
+	// Client sends the requests.  Defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+// A GeocoderFactory creates a Geocoder from its configuration.
+type GeocoderFactory func(config GeocoderConfig) (Geocoder, error)
+
+var (
+	geocodersMu sync.RWMutex
+	geocoders   = map[string]GeocoderFactory{
+		"google": func(c GeocoderConfig) (Geocoder, error) {
+			return NewGoogleGeocoder(c.APIKey, GoogleGeocoderOptions{BaseURL: c.BaseURL, Language: c.Language, Client: c.Client}), nil
+		},
+		"mapbox": func(c GeocoderConfig) (Geocoder, error) {
+			return NewMapboxGeocoder(c.APIKey, MapboxGeocoderOptions{BaseURL: c.BaseURL, Language: c.Language, Client: c.Client}), nil
+		},
+		"here": func(c GeocoderConfig) (Geocoder, error) {
+			return NewHEREGeocoder(c.APIKey, HEREGeocoderOptions{BaseURL: c.BaseURL, Language: c.Language, Client: c.Client}), nil
+		},
+		"opencage": func(c GeocoderConfig) (Geocoder, error) {
+			return NewOpenCageGeocoder(c.APIKey, OpenCageGeocoderOptions{BaseURL: c.BaseURL, Language: c.Language, Client: c.Client}), nil
+		},
+		"pelias": func(c GeocoderConfig) (Geocoder, error) {
+			return NewPeliasGeocoder(c.APIKey, PeliasGeocoderOptions{BaseURL: c.BaseURL, Language: c.Language, Client: c.Client}), nil
+		},
+	}
+)
+
+// RegisterGeocoder makes a GeocoderFactory available to NewGeocoder under the passed in provider name,
+// replacing any previously registered factory for that name.  The "google", "mapbox", "here",
+// "opencage" and "pelias" providers are registered by default.
+func RegisterGeocoder(provider string, f GeocoderFactory) {
+	geocodersMu.Lock()
+	defer geocodersMu.Unlock()
+
+	geocoders[provider] = f
+}
+
+// GeocoderProviders returns the names of every registered provider in ascending order.
+func GeocoderProviders() []string {
+	geocodersMu.RLock()
+	defer geocodersMu.RUnlock()
+
+	providers := make([]string, 0, len(geocoders))
+	for provider := range geocoders {
+		providers = append(providers, provider)
+	}
+	sort.Strings(providers)
+	return providers
+}
+
+// NewGeocoder returns a new Geocoder from the factory registered under the passed in provider name,
+// so that the provider can be chosen by configuration.
+func NewGeocoder(provider string, config GeocoderConfig) (Geocoder, error) {
+	geocodersMu.RLock()
+	f, ok := geocoders[provider]
+	geocodersMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownGeocoder, provider)
+	}
+
+	return f(config)
+}
@@ -0,0 +1,238 @@
+package geo
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultGeocoderCachePrecision is the number of decimal places coordinates are rounded to
+// when WithGeocoderCache keys reverse geocoding lookups.  Five places are roughly 1m apart.
+const DefaultGeocoderCachePrecision = 5
+
+// A GeocoderCache stores encoded geocoding results by key for WithGeocoderCache.
+// Get reports false for keys that are missing or have expired.
+type GeocoderCache interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// GeocoderCacheOptions configures WithGeocoderCache.
+type GeocoderCacheOptions struct {
+	// TTL is how long results are kept.  Zero keeps them until the cache evicts them.
+	TTL time.Duration
+	// Precision is the number of decimal places coordinates are rounded to, so that nearby
+	// reverse geocoding lookups share a result.  Defaults to DefaultGeocoderCachePrecision.
+	Precision int
+
+	// Provider, Language and Types describe the wrapped Geocoder, such as "google", "en" and the
+	// result types it is restricted to.  They are part of every key, so that Geocoders returning
+	// different results can share a cache without mixing them up.
+	Provider string
+	Language string
+	Types    []string
+}
+
+// WithGeocoderCache returns a GeocoderMiddleware that answers repeated lookups from cache instead
+// of the wrapped Geocoder.  Forward lookups are keyed by the address with case and whitespace
+// normalized, and reverse lookups by the Point rounded to opts.Precision decimal places, both
+// together with opts.Provider, opts.Language and opts.Types.
+// Only successful results are cached.  Cache failures are logged and fall through to the Geocoder.
+func WithGeocoderCache(cache GeocoderCache, opts GeocoderCacheOptions) GeocoderMiddleware {
+	if opts.Precision <= 0 {
+		opts.Precision = DefaultGeocoderCachePrecision
+	}
+	types := append([]string(nil), opts.Types...)
+	slices.Sort(types)
+	scope := opts.Provider + ":" + opts.Language + ":" + strings.Join(types, ",") + ":"
+
+	return func(g Geocoder) Geocoder {
+		return geocoderFuncs{
+			geocode: func(ctx context.Context, address string) (Point, error) {
+				var p Point
+				err := cached(ctx, cache, opts.TTL, "geocode:"+scope+normalizeAddress(address), &p, func() (err error) {
+					p, err = g.Geocode(ctx, address)
+					return err
+				})
+				return p, err
+			},
+			reverseGeocode: func(ctx context.Context, p Point) (Address, error) {
+				key := "reverse:" + scope + roundCoordinate(p.lat, opts.Precision) + "," + roundCoordinate(p.lng, opts.Precision)
+				var a Address
+				err := cached(ctx, cache, opts.TTL, key, &a, func() (err error) {
+					a, err = g.ReverseGeocode(ctx, p)
+					return err
+				})
+				return a, err
+			},
+		}
+	}
+}
+
+// cached decodes the JSON value stored under key into v, or calls lookup to fill v and stores it.
+func cached(ctx context.Context, cache GeocoderCache, ttl time.Duration, key string, v interface{}, lookup func() error) error {
+	data, ok, err := cache.Get(ctx, key)
+	if err != nil {
+		debugf("geo: unable to read geocoder cache %q: %v", key, err)
+	}
+	if ok {
+		err := json.Unmarshal(data, v)
+		if err == nil {
+			return nil
+		}
+		debugf("geo: unable to decode geocoder cache %q: %v", key, err)
+	}
+
+	if err := lookup(); err != nil {
+		return err
+	}
+
+	if data, err = json.Marshal(v); err == nil {
+		err = cache.Set(ctx, key, data, ttl)
+	}
+	if err != nil {
+		debugf("geo: unable to write geocoder cache %q: %v", key, err)
+	}
+	return nil
+}
+
+// normalizeAddress lowercases address and collapses its runs of whitespace to single spaces.
+func normalizeAddress(address string) string {
+	return strings.Join(strings.Fields(strings.ToLower(address)), " ")
+}
+
+// roundCoordinate formats v rounded to precision decimal places.
+func roundCoordinate(v float64, precision int) string {
+	scale := math.Pow(10, float64(precision))
+	return strconv.FormatFloat(math.Round(v*scale)/scale, 'f', precision, 64)
+}
+
+// An LRUGeocoderCache is an in-memory GeocoderCache holding up to a fixed number of entries,
+// evicting the least recently used first.  It is safe for concurrent use.
+type LRUGeocoderCache struct {
+	capacity int
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type lruGeocoderEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+var _ GeocoderCache = (*LRUGeocoderCache)(nil)
+
+// NewLRUGeocoderCache returns a new LRUGeocoderCache holding up to capacity entries.
+// A capacity less than one is treated as one.
+func NewLRUGeocoderCache(capacity int) *LRUGeocoderCache {
+	return &LRUGeocoderCache{capacity: max(capacity, 1), order: list.New(), entries: make(map[string]*list.Element)}
+}
+
+// Len returns the number of entries in the cache, including expired entries not yet evicted.
+func (c *LRUGeocoderCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// Get returns the value stored under key, marking it as recently used.
+func (c *LRUGeocoderCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	entry := e.Value.(*lruGeocoderEntry)
+	if !entry.expires.IsZero() && time.Now().After(entry.expires) {
+		c.order.Remove(e)
+		delete(c.entries, key)
+		return nil, false, nil
+	}
+
+	c.order.MoveToFront(e)
+	return entry.value, true, nil
+}
+
+// Set stores value under key for ttl, or until evicted if ttl is zero.
+func (c *LRUGeocoderCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &lruGeocoderEntry{key: key, value: value}
+	if ttl > 0 {
+		entry.expires = time.Now().Add(ttl)
+	}
+
+	if e, ok := c.entries[key]; ok {
+		e.Value = entry
+		c.order.MoveToFront(e)
+		return nil
+	}
+
+	c.entries[key] = c.order.PushFront(entry)
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruGeocoderEntry).key)
+	}
+	return nil
+}
+
+// A RedisGeocoderCache is a GeocoderCache storing entries as Redis strings, so that
+// several processes can share results.  Expiry is left to Redis.  Missing keys must reach it as
+// a nil reply, so RedisClients built on drivers that return an error instead, such as go-redis's
+// redis.Nil, should translate that error.
+type RedisGeocoderCache struct {
+	client RedisClient
+	prefix string
+}
+
+var _ GeocoderCache = (*RedisGeocoderCache)(nil)
+
+// NewRedisGeocoderCache returns a new RedisGeocoderCache that stores each entry under prefix followed by its key.
+func NewRedisGeocoderCache(client RedisClient, prefix string) *RedisGeocoderCache {
+	return &RedisGeocoderCache{client: client, prefix: prefix}
+}
+
+// Get returns the value stored under key with GET.
+func (c *RedisGeocoderCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	reply, err := c.client.Do(ctx, "GET", c.prefix+key)
+	if err != nil {
+		return nil, false, fmt.Errorf("redis GET %s: %w", c.prefix+key, err)
+	}
+	if reply == nil {
+		return nil, false, nil
+	}
+
+	s, err := redisString(reply)
+	if err != nil {
+		return nil, false, err
+	}
+	return []byte(s), true, nil
+}
+
+// Set stores value under key with SET, expiring it after ttl unless ttl is zero.
+func (c *RedisGeocoderCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	args := []interface{}{"SET", c.prefix + key, value}
+	if ttl > 0 {
+		args = append(args, "PX", max(ttl.Milliseconds(), 1))
+	}
+
+	if _, err := c.client.Do(ctx, args...); err != nil {
+		return fmt.Errorf("redis SET %s: %w", c.prefix+key, err)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package geo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// Ensures that repeated lookups with equivalent addresses or nearby points are served from the cache.
+func TestWithGeocoderCache(t *testing.T) {
+	inner := &failingGeocoder{}
+	cache := NewLRUGeocoderCache(10)
+	g := WrapGeocoder(inner, WithGeocoderCache(cache, GeocoderCacheOptions{TTL: time.Hour}))
+	ctx := context.Background()
+
+	for _, address := range []string{"1 Macquarie St", "  1 macquarie   ST "} {
+		if p, err := g.Geocode(ctx, address); err != nil || p != NewPoint(0, 0) {
+			t.Errorf("Expected 0,0 for %q, but got %v, %v", address, p, err)
+		}
+	}
+	if inner.calls != 1 {
+		t.Errorf("Expected equivalent addresses to share 1 call, but made %d", inner.calls)
+	}
+
+	for _, p := range []Point{NewPoint(-33.868801, 151.209301), NewPoint(-33.868799, 151.209299)} {
+		if a, err := g.ReverseGeocode(ctx, p); err != nil || a.Formatted != "Null Island" {
+			t.Errorf("Expected Null Island for %v, but got %v, %v", p, a, err)
+		}
+	}
+	if inner.calls != 2 {
+		t.Errorf("Expected nearby points to share 1 call, but made %d in total", inner.calls)
+	}
+
+	for _, opts := range []GeocoderCacheOptions{{Provider: "mapbox"}, {Language: "fr"}, {Types: []string{"address"}}} {
+		g = WrapGeocoder(inner, WithGeocoderCache(cache, opts))
+		if _, err := g.Geocode(ctx, "1 Macquarie St"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if inner.calls != 5 {
+		t.Errorf("Expected each provider, language and types to make their own call, but made %d in total", inner.calls)
+	}
+
+	failing := &failingGeocoder{failures: 1, err: ErrServiceUnavailable}
+	g = WrapGeocoder(failing, WithGeocoderCache(NewLRUGeocoderCache(10), GeocoderCacheOptions{}))
+	if _, err := g.Geocode(ctx, "anywhere"); !errors.Is(err, ErrServiceUnavailable) {
+		t.Errorf("Expected ErrServiceUnavailable, but got %v", err)
+	}
+	if _, err := g.Geocode(ctx, "anywhere"); err != nil || failing.calls != 2 {
+		t.Errorf("Expected a failure not to be cached, but got %v after %d calls", err, failing.calls)
+	}
+}
+
+// Ensures that the LRU cache evicts its least recently used entry and expires entries after their TTL.
+func TestLRUGeocoderCache(t *testing.T) {
+	ctx := context.Background()
+	cache := NewLRUGeocoderCache(2)
+	cache.Set(ctx, "a", []byte("1"), 0)
+	cache.Set(ctx, "b", []byte("2"), 0)
+	cache.Get(ctx, "a")
+	cache.Set(ctx, "c", []byte("3"), 0)
+
+	if _, ok, _ := cache.Get(ctx, "b"); ok {
+		t.Error("Expected b to be evicted as the least recently used entry")
+	}
+	if v, ok, _ := cache.Get(ctx, "a"); !ok || string(v) != "1" {
+		t.Errorf("Expected a to be kept, but got %q, %v", v, ok)
+	}
+
+	cache.Set(ctx, "c", []byte("4"), time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	if _, ok, _ := cache.Get(ctx, "c"); ok || cache.Len() != 1 {
+		t.Errorf("Expected c to expire, leaving 1 entry, but found %v with %d entries", ok, cache.Len())
+	}
+}
+
+// Ensures that the Redis cache issues GET and SET commands with a millisecond expiry.
+func TestRedisGeocoderCache(t *testing.T) {
+	ctx := context.Background()
+	client := &fakeRedis{reply: "OK"}
+	cache := NewRedisGeocoderCache(client, "geocode:")
+
+	if err := cache.Set(ctx, "key", []byte("value"), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	cmd := client.commands[0]
+	if got := fmt.Sprintln(cmd[0], cmd[1], string(cmd[2].([]byte)), cmd[3], cmd[4]); got != "SET geocode:key value PX 60000\n" {
+		t.Errorf("Expected command SET geocode:key value PX 60000, but got %s", got)
+	}
+
+	client.reply = []byte("value")
+	if v, ok, err := cache.Get(ctx, "key"); err != nil || !ok || string(v) != "value" {
+		t.Errorf("Expected value, but got %q, %v, %v", v, ok, err)
+	}
+
+	client.reply = nil
+	if _, ok, err := cache.Get(ctx, "missing"); err != nil || ok {
+		t.Errorf("Expected a nil reply to be a miss, but got %v, %v", ok, err)
+	}
+
+	client.err = errors.New("connection refused")
+	if _, _, err := cache.Get(ctx, "key"); err == nil {
+		t.Error("Expected a client error to be returned")
+	}
+}
@@ -0,0 +1,160 @@
+package geo
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// A GeocoderMiddleware wraps a Geocoder with extra behaviour, such as rate limiting or retries.
+type GeocoderMiddleware func(Geocoder) Geocoder
+
+// WrapGeocoder returns g wrapped in every passed in middleware.  The first middleware is the
+// outermost, so WrapGeocoder(g, WithGeocoderRetries(3, time.Second), WithGeocoderRateLimit(10, 1))
+// waits for the rate limit before every attempt of a retried request.
+// The HTTP client of each provider is set through its options or GeocoderConfig.
+func WrapGeocoder(g Geocoder, middleware ...GeocoderMiddleware) Geocoder {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		g = middleware[i](g)
+	}
+	return g
+}
+
+// geocoderFuncs adapts a pair of functions to the Geocoder interface.
+type geocoderFuncs struct {
+	geocode        func(ctx context.Context, address string) (Point, error)
+	reverseGeocode func(ctx context.Context, p Point) (Address, error)
+}
+
+func (g geocoderFuncs) Geocode(ctx context.Context, address string) (Point, error) {
+	return g.geocode(ctx, address)
+}
+
+func (g geocoderFuncs) ReverseGeocode(ctx context.Context, p Point) (Address, error) {
+	return g.reverseGeocode(ctx, p)
+}
+
+// around returns a Geocoder that runs both of g's methods through call.
+func around(g Geocoder, call func(ctx context.Context, f func(ctx context.Context) error) error) Geocoder {
+	return geocoderFuncs{
+		geocode: func(ctx context.Context, address string) (p Point, err error) {
+			err = call(ctx, func(ctx context.Context) error {
+				p, err = g.Geocode(ctx, address)
+				return err
+			})
+			return p, err
+		},
+		reverseGeocode: func(ctx context.Context, p Point) (a Address, err error) {
+			err = call(ctx, func(ctx context.Context) error {
+				a, err = g.ReverseGeocode(ctx, p)
+				return err
+			})
+			return a, err
+		},
+	}
+}
+
+// WithGeocoderRateLimit returns a GeocoderMiddleware that allows rate requests per second on average,
+// with bursts of up to burst requests, using a token bucket shared by every call to the wrapped Geocoder.
+// Calls wait for a token until their context is done.  A burst less than one is treated as one,
+// and a rate that is not positive leaves the Geocoder unlimited.
+func WithGeocoderRateLimit(rate float64, burst int) GeocoderMiddleware {
+	return func(g Geocoder) Geocoder {
+		if rate <= 0 {
+			return g
+		}
+
+		bucket := newTokenBucket(rate, burst)
+		return around(g, func(ctx context.Context, f func(ctx context.Context) error) error {
+			if err := bucket.wait(ctx); err != nil {
+				return err
+			}
+			return f(ctx)
+		})
+	}
+}
+
+// WithGeocoderRetries returns a GeocoderMiddleware that retries calls failing with ErrRateLimited or
+// ErrServiceUnavailable, which providers return for HTTP 429 and 5xx replies, up to attempts times in total.
+// It waits backoff before the first retry and doubles the wait before each further retry.
+func WithGeocoderRetries(attempts int, backoff time.Duration) GeocoderMiddleware {
+	return func(g Geocoder) Geocoder {
+		return around(g, func(ctx context.Context, f func(ctx context.Context) error) error {
+			delay := backoff
+			for attempt := 1; ; attempt++ {
+				err := f(ctx)
+				if attempt >= attempts || !(errors.Is(err, ErrRateLimited) || errors.Is(err, ErrServiceUnavailable)) {
+					return err
+				}
+
+				timer := time.NewTimer(delay)
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return ctx.Err()
+				case <-timer.C:
+				}
+				delay *= 2
+			}
+		})
+	}
+}
+
+// WithGeocoderTimeout returns a GeocoderMiddleware that cancels each call to the wrapped Geocoder
+// that has not finished within timeout.
+func WithGeocoderTimeout(timeout time.Duration) GeocoderMiddleware {
+	return func(g Geocoder) Geocoder {
+		return around(g, func(ctx context.Context, f func(ctx context.Context) error) error {
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			return f(ctx)
+		})
+	}
+}
+
+// tokenBucket hands out up to burst tokens at once, refilled at rate tokens per second.
+type tokenBucket struct {
+	rate  float64
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	b := float64(max(burst, 1))
+	return &tokenBucket{rate: rate, burst: b, tokens: b, last: time.Now()}
+}
+
+// wait takes a token, first waiting for one to be refilled if the bucket is empty.
+// It returns ctx.Err() without taking a token if ctx is done before then.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	b.mu.Lock()
+	now := time.Now()
+	b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	b.tokens--
+	var delay time.Duration
+	if b.tokens < 0 {
+		delay = time.Duration(-b.tokens / b.rate * float64(time.Second))
+	}
+	b.mu.Unlock()
+
+	if delay == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		b.mu.Lock()
+		b.tokens++
+		b.mu.Unlock()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,110 @@
+package geo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// failingGeocoder fails its first failures calls with err and then geocodes everything to the origin.
+type failingGeocoder struct {
+	failures int
+	err      error
+	calls    int
+}
+
+func (g *failingGeocoder) Geocode(ctx context.Context, address string) (Point, error) {
+	g.calls++
+	if g.calls <= g.failures {
+		return Point{}, g.err
+	}
+	return NewPoint(0, 0), ctx.Err()
+}
+
+func (g *failingGeocoder) ReverseGeocode(ctx context.Context, p Point) (Address, error) {
+	g.calls++
+	if g.calls <= g.failures {
+		return Address{}, g.err
+	}
+	return Address{Formatted: "Null Island"}, ctx.Err()
+}
+
+// Ensures that retries only repeat temporary failures, up to the number of attempts.
+func TestWithGeocoderRetries(t *testing.T) {
+	ctx := context.Background()
+
+	inner := &failingGeocoder{failures: 2, err: ErrServiceUnavailable}
+	g := WrapGeocoder(inner, WithGeocoderRetries(3, time.Millisecond))
+	if _, err := g.Geocode(ctx, "anywhere"); err != nil || inner.calls != 3 {
+		t.Errorf("Expected success on the third attempt, but got %v after %d calls", err, inner.calls)
+	}
+
+	inner = &failingGeocoder{failures: 5, err: ErrRateLimited}
+	g = WrapGeocoder(inner, WithGeocoderRetries(3, time.Millisecond))
+	if _, err := g.ReverseGeocode(ctx, NewPoint(0, 0)); !errors.Is(err, ErrRateLimited) || inner.calls != 3 {
+		t.Errorf("Expected ErrRateLimited after 3 attempts, but got %v after %d calls", err, inner.calls)
+	}
+
+	inner = &failingGeocoder{failures: 5, err: ErrNoResults}
+	g = WrapGeocoder(inner, WithGeocoderRetries(3, time.Millisecond))
+	if _, err := g.Geocode(ctx, "nowhere"); !errors.Is(err, ErrNoResults) || inner.calls != 1 {
+		t.Errorf("Expected ErrNoResults without retrying, but got %v after %d calls", err, inner.calls)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	inner = &failingGeocoder{failures: 5, err: ErrServiceUnavailable}
+	g = WrapGeocoder(inner, WithGeocoderRetries(3, time.Hour))
+	if _, err := g.Geocode(cancelled, "anywhere"); !errors.Is(err, context.Canceled) || inner.calls != 1 {
+		t.Errorf("Expected context.Canceled while backing off, but got %v after %d calls", err, inner.calls)
+	}
+}
+
+// Ensures that the rate limit allows a burst and then waits for tokens to be refilled.
+func TestWithGeocoderRateLimit(t *testing.T) {
+	inner := &failingGeocoder{}
+	g := WrapGeocoder(inner, WithGeocoderRateLimit(100, 2))
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if _, err := g.Geocode(context.Background(), "anywhere"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 15*time.Millisecond {
+		t.Errorf("Expected the last 2 of 4 calls to wait about 10ms each, but took %v", elapsed)
+	}
+
+	g = WrapGeocoder(inner, WithGeocoderRateLimit(0.001, 1))
+	if _, err := g.Geocode(context.Background(), "anywhere"); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	if _, err := g.Geocode(ctx, "anywhere"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded waiting for a token, but got %v", err)
+	}
+
+	if WrapGeocoder(inner, WithGeocoderRateLimit(0, 1)) != Geocoder(inner) {
+		t.Error("Expected a rate of zero to leave the Geocoder unlimited")
+	}
+}
+
+// Ensures that the timeout is applied to the context of each call.
+func TestWithGeocoderTimeout(t *testing.T) {
+	var deadline time.Time
+	g := WrapGeocoder(geocoderFuncs{
+		geocode: func(ctx context.Context, address string) (Point, error) {
+			deadline, _ = ctx.Deadline()
+			return Point{}, nil
+		},
+	}, WithGeocoderTimeout(time.Minute))
+
+	if _, err := g.Geocode(context.Background(), "anywhere"); err != nil {
+		t.Fatal(err)
+	}
+	if until := time.Until(deadline); until <= 0 || until > time.Minute {
+		t.Errorf("Expected a deadline within a minute, but got %v", until)
+	}
+}
@@ -0,0 +1,46 @@
+package geo
+
+import (
+	"errors"
+	"testing"
+)
+
+// Ensures that an Address prints as its formatted form.
+func TestAddressString(t *testing.T) {
+	a := Address{Formatted: "1 Macquarie St, Sydney NSW 2000, Australia", Locality: "Sydney"}
+	if a.String() != a.Formatted {
+		t.Errorf("Expected %q, but got %q", a.Formatted, a.String())
+	}
+}
+
+// Ensures that geocoders are created by provider name and that new providers can be registered.
+func TestNewGeocoder(t *testing.T) {
+	for _, provider := range []string{"google", "mapbox", "here", "opencage", "pelias"} {
+		g, err := NewGeocoder(provider, GeocoderConfig{APIKey: "secret"})
+		if err != nil || g == nil {
+			t.Errorf("Expected a %s geocoder, but got %v, %v", provider, g, err)
+		}
+	}
+
+	if _, err := NewGeocoder("nominatim", GeocoderConfig{}); !errors.Is(err, ErrUnknownGeocoder) {
+		t.Errorf("Expected ErrUnknownGeocoder, but got %v", err)
+	}
+
+	RegisterGeocoder("test", func(c GeocoderConfig) (Geocoder, error) {
+		return NewPeliasGeocoder(c.APIKey, PeliasGeocoderOptions{BaseURL: c.BaseURL}), nil
+	})
+	defer func() {
+		geocodersMu.Lock()
+		delete(geocoders, "test")
+		geocodersMu.Unlock()
+	}()
+
+	if g, err := NewGeocoder("test", GeocoderConfig{BaseURL: "http://localhost:4000/v1"}); err != nil || g.(*PeliasGeocoder).opts.BaseURL != "http://localhost:4000/v1" {
+		t.Errorf("Expected the registered factory to receive the config, but got %v, %v", g, err)
+	}
+
+	providers := GeocoderProviders()
+	if len(providers) != 6 || providers[0] != "google" || providers[5] != "test" {
+		t.Errorf("Expected the 6 providers in ascending order, but got %v", providers)
+	}
+}
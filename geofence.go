@@ -0,0 +1,423 @@
+package geo
+
+import (
+	"hash/fnv"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// A Geofence is a region that a GeofenceManager tests points against,
+// such as a Circle, Ellipse, Polygon, PreparedPolygon, MultiPolygon or BoundingBox.
+type Geofence interface {
+	Geometry
+	// Contains reports whether Point p lies inside the Geofence.
+	Contains(p Point) bool
+}
+
+var (
+	_ Geofence = Circle{}
+	_ Geofence = Polygon{}
+	_ Geofence = (*PreparedPolygon)(nil)
+	_ Geofence = MultiPolygon{}
+	_ Geofence = BoundingBox{}
+	_ Geofence = Ellipse{}
+	_ Geofence = Cap{}
+)
+
+// GeofenceOptions configures how a GeofenceManager turns the positions of a subject
+// into Enter and Exit events for a fence, to keep GPS jitter at the edge of the fence
+// from firing a flurry of events.
+type GeofenceOptions struct {
+	// Dwell is how long a subject must stay inside the fence before Enter fires.
+	// Leaving the fence before then restarts the wait.
+	Dwell time.Duration
+	// ExitBuffer is how far beyond the edge of the fence a subject that has entered it must be
+	// before Exit fires.  It applies to Circle, Polygon, PreparedPolygon and MultiPolygon fences.
+	ExitBuffer Distance
+}
+
+// GeofenceEventType is the kind of a GeofenceEvent.
+type GeofenceEventType int
+
+// Kinds of GeofenceEvent.
+const (
+	GeofenceEnter GeofenceEventType = iota + 1
+	GeofenceExit
+)
+
+// String returns "enter" or "exit".
+func (t GeofenceEventType) String() string {
+	switch t {
+	case GeofenceEnter:
+		return "enter"
+	case GeofenceExit:
+		return "exit"
+	default:
+		return "unknown"
+	}
+}
+
+// A GeofenceEvent records a subject entering or leaving a fence.
+type GeofenceEvent struct {
+	Type    GeofenceEventType
+	Subject string
+	Fence   string
+	// Point and Time are the position update that fired the event.
+	Point Point
+	Time  time.Time
+}
+
+// geofenceSubjectShards is the number of independently locked shards the state of subjects is split into.
+const geofenceSubjectShards = 64
+
+// A GeofenceManager answers which of a changing set of named geofences contain a point,
+// and tracks subjects moving between them.
+// Fences are indexed by their bounds, so only the few whose bounds hold a point are tested.
+// It is safe for concurrent use: updates for different subjects proceed in parallel,
+// and only wait for changes to the fences.
+type GeofenceManager struct {
+	mu     sync.RWMutex
+	fences map[string]geofenceRecord
+	tree   *RTree[geofenceEntry]
+
+	// shards hold the state of subjects by the hash of their ID.  Their locks are
+	// only taken while holding mu, which keeps Remove from racing with Update.
+	shards [geofenceSubjectShards]geofenceShard
+}
+
+// geofenceShard holds the fences entered by some of the subjects of a GeofenceManager.
+type geofenceShard struct {
+	mu       sync.Mutex
+	subjects map[string]map[string]*geofenceState
+}
+
+// geofenceRecord is a fence and its options.
+type geofenceRecord struct {
+	fence Geofence
+	opts  GeofenceOptions
+}
+
+// geofenceState is where a subject stands with a fence it has been seen inside.
+type geofenceState struct {
+	since   time.Time
+	entered bool
+}
+
+// geofenceEntry is the RTree item standing for the fence named id.
+type geofenceEntry struct {
+	id     string
+	bounds BoundingBox
+}
+
+// Bounds implements the Geometry interface.
+func (e geofenceEntry) Bounds() BoundingBox {
+	return e.bounds
+}
+
+// NewGeofenceManager returns a new GeofenceManager without any fences.
+func NewGeofenceManager() *GeofenceManager {
+	m := &GeofenceManager{fences: make(map[string]geofenceRecord), tree: NewRTree[geofenceEntry](DefaultRTreeNodeCapacity)}
+	for i := range m.shards {
+		m.shards[i].subjects = make(map[string]map[string]*geofenceState)
+	}
+	return m
+}
+
+// shard returns the shard holding the state of subject.
+func (m *GeofenceManager) shard(subject string) *geofenceShard {
+	return &m.shards[geofenceShardOf(subject, geofenceSubjectShards)]
+}
+
+// geofenceShardOf returns which of n shards subject belongs to.
+func geofenceShardOf(subject string, n int) int {
+	h := fnv.New32a()
+	h.Write([]byte(subject))
+	return int(h.Sum32() % uint32(n))
+}
+
+// Len returns the number of fences in the manager.
+func (m *GeofenceManager) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.fences)
+}
+
+// Fence returns the fence named id.
+func (m *GeofenceManager) Fence(id string) (Geofence, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	r, ok := m.fences[id]
+	return r.fence, ok
+}
+
+// Set adds fence under id, replacing any fence already named id and forgetting the subjects inside it.
+// Subjects enter it as soon as they are inside and exit it as soon as they are outside.
+func (m *GeofenceManager) Set(id string, fence Geofence) {
+	m.SetWithOptions(id, fence, GeofenceOptions{})
+}
+
+// SetWithOptions adds fence under id like Set, with the passed in dwell and exit hysteresis.
+func (m *GeofenceManager) SetWithOptions(id string, fence Geofence, opts GeofenceOptions) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.remove(id)
+	m.fences[id] = geofenceRecord{fence: fence, opts: opts}
+	m.tree.Insert(geofenceEntry{id: id, bounds: fence.Bounds()})
+}
+
+// Remove removes the fence named id, returning whether there was one.
+// Subjects inside it are forgotten without an Exit event.
+func (m *GeofenceManager) Remove(id string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.remove(id)
+}
+
+func (m *GeofenceManager) remove(id string) bool {
+	r, ok := m.fences[id]
+	if !ok {
+		return false
+	}
+	delete(m.fences, id)
+	m.tree.Delete(r.fence.Bounds(), func(e geofenceEntry) bool { return e.id == id })
+	for i := range m.shards {
+		shard := &m.shards[i]
+		shard.mu.Lock()
+		for _, states := range shard.subjects {
+			delete(states, id)
+		}
+		shard.mu.Unlock()
+	}
+	return true
+}
+
+// FindContaining returns the IDs of every fence containing Point p, in ascending order.
+func (m *GeofenceManager) FindContaining(p Point) []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.findContaining(p)
+}
+
+func (m *GeofenceManager) findContaining(p Point) []string {
+	defer timeIndexQuery("geofence")()
+
+	found := []string{}
+	m.tree.SearchFunc(p.Bounds(), func(e geofenceEntry) bool {
+		if m.fences[e.id].fence.Contains(p) {
+			found = append(found, e.id)
+		}
+		return true
+	})
+	sort.Strings(found)
+	return found
+}
+
+// Update records that subject was at Point p at time t and returns the events this fires:
+// Exit events for the fences it has left, then Enter events for the fences it has dwelt in
+// long enough, each in ascending order of fence ID.  Dwell is only measured at updates,
+// so Enter fires at the first update at least the fence's Dwell after the subject came inside.
+// Updates for a subject are expected in chronological order.
+func (m *GeofenceManager) Update(subject string, p Point, t time.Time) []GeofenceEvent {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	shard := m.shard(subject)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	states := shard.subjects[subject]
+	if states == nil {
+		states = make(map[string]*geofenceState)
+		shard.subjects[subject] = states
+	}
+
+	inside := make(map[string]bool)
+	for _, id := range m.findContaining(p) {
+		inside[id] = true
+	}
+
+	var exits, enters []GeofenceEvent
+	for id, state := range states {
+		if inside[id] {
+			continue
+		}
+		r := m.fences[id]
+		if state.entered && r.opts.ExitBuffer > 0 && geofenceDistance(r.fence, p) <= r.opts.ExitBuffer {
+			continue
+		}
+		if state.entered {
+			exits = append(exits, GeofenceEvent{Type: GeofenceExit, Subject: subject, Fence: id, Point: p, Time: t})
+		}
+		delete(states, id)
+	}
+
+	for id := range inside {
+		state := states[id]
+		if state == nil {
+			state = &geofenceState{since: t}
+			states[id] = state
+		}
+		if !state.entered && t.Sub(state.since) >= m.fences[id].opts.Dwell {
+			state.entered = true
+			enters = append(enters, GeofenceEvent{Type: GeofenceEnter, Subject: subject, Fence: id, Point: p, Time: t})
+		}
+	}
+
+	if len(states) == 0 {
+		delete(shard.subjects, subject)
+	}
+
+	sort.Slice(exits, func(i, j int) bool { return exits[i].Fence < exits[j].Fence })
+	sort.Slice(enters, func(i, j int) bool { return enters[i].Fence < enters[j].Fence })
+	if len(exits) > 0 {
+		addMetric(MetricGeofenceEvents, GeofenceExit.String(), float64(len(exits)))
+	}
+	if len(enters) > 0 {
+		addMetric(MetricGeofenceEvents, GeofenceEnter.String(), float64(len(enters)))
+	}
+	return append(exits, enters...)
+}
+
+// Inside returns the IDs of the fences subject has entered and not yet exited, in ascending order.
+func (m *GeofenceManager) Inside(subject string) []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	shard := m.shard(subject)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+
+	ids := []string{}
+	for id, state := range shard.subjects[subject] {
+		if state.entered {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// A GeofenceWarning predicts that a subject will enter a fence.
+type GeofenceWarning struct {
+	Subject string
+	Fence   string
+	// Point is where the subject is predicted to come inside the fence.
+	Point Point
+	// In is how long from the prediction the subject is expected to come inside the fence.
+	In time.Duration
+}
+
+// geofenceWarningStep is the spacing of the positions Imminent tests along the predicted path.
+const geofenceWarningStep = 10 * Meter
+
+// Imminent predicts which fences subject will come inside within the passed in time if it keeps
+// moving from Point p at speed meters per second along the great circle leaving p at bearing
+// degrees clockwise from true north, as a DeadReckoner assumes.  Fences the subject is already
+// inside of, or dwelling in, are left out.  Warnings are returned in the order they are expected,
+// and the path is tested every 10 meters, or at 1000 positions along longer paths.
+func (m *GeofenceManager) Imminent(subject string, p Point, speed float64, bearing float64, within time.Duration) []GeofenceWarning {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	shard := m.shard(subject)
+	shard.mu.Lock()
+	states := shard.subjects[subject]
+	skip := make(map[string]bool, len(states))
+	for id := range states {
+		skip[id] = true
+	}
+	shard.mu.Unlock()
+
+	total := Distance(speed * within.Seconds())
+	if total <= 0 {
+		return []GeofenceWarning{}
+	}
+	n := min(int(math.Ceil(float64(total/geofenceWarningStep))), 1000)
+	path := make([]Point, n+1)
+	for i := range path {
+		path[i] = destinationPoint(p, bearing, total*Distance(i)/Distance(n))
+	}
+
+	// Test the path against the fences whose bounds it crosses, skipping those containing p.
+	var candidates []string
+	m.tree.SearchFunc(pointsBounds(path), func(e geofenceEntry) bool {
+		if !skip[e.id] && !m.fences[e.id].fence.Contains(p) {
+			candidates = append(candidates, e.id)
+		}
+		return true
+	})
+	sort.Strings(candidates)
+
+	warnings := []GeofenceWarning{}
+	for i, q := range path[1:] {
+		remaining := candidates[:0]
+		for _, id := range candidates {
+			if !m.fences[id].fence.Contains(q) {
+				remaining = append(remaining, id)
+				continue
+			}
+			warnings = append(warnings, GeofenceWarning{
+				Subject: subject,
+				Fence:   id,
+				Point:   q,
+				In:      time.Duration(float64(within) * float64(i+1) / float64(n)),
+			})
+		}
+		candidates = remaining
+	}
+	return warnings
+}
+
+// Forget drops everything the manager knows about subject, without firing events.
+func (m *GeofenceManager) Forget(subject string) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	shard := m.shard(subject)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+	delete(shard.subjects, subject)
+}
+
+// geofenceDistance returns how far Point p lies outside fence, or +Inf for kinds of
+// fence it cannot measure.  Polygon edges are measured on the local tangent plane at p,
+// which is accurate for the short distances of exit buffers.
+func geofenceDistance(fence Geofence, p Point) Distance {
+	switch f := fence.(type) {
+	case Circle:
+		return max(f.center.GreatCircleDistance(p)-f.radius, 0)
+	case Polygon:
+		return ringDistance(f.points, p)
+	case *PreparedPolygon:
+		return ringDistance(f.Polygon().points, p)
+	case MultiPolygon:
+		d := Distance(math.Inf(1))
+		for _, polygon := range f.polygons {
+			d = min(d, ringDistance(polygon.points, p))
+		}
+		return d
+	default:
+		return Distance(math.Inf(1))
+	}
+}
+
+// ringDistance returns the distance from Point p to the closest edge of a ring of points.
+func ringDistance(ring []Point, p Point) Distance {
+	if len(ring) == 0 {
+		return Distance(math.Inf(1))
+	}
+
+	frame := NewLocalFrame(p, 0)
+	closest := math.Inf(1)
+	x1, y1, _ := frame.ToENU(ring[len(ring)-1], 0)
+	for _, vertex := range ring {
+		x2, y2, _ := frame.ToENU(vertex, 0)
+		// Project p, the origin of the frame, onto the edge.
+		dx, dy := x2-x1, y2-y1
+		f := 0.0
+		if l := dx*dx + dy*dy; l > 0 {
+			f = math.Max(0, math.Min(1, -(x1*dx+y1*dy)/l))
+		}
+		closest = math.Min(closest, math.Hypot(x1+f*dx, y1+f*dy))
+		x1, y1 = x2, y2
+	}
+	return Distance(closest)
+}
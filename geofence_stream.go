@@ -0,0 +1,118 @@
+package geo
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// geofenceStreamBuffer is how many updates each worker of GeofenceManager.Stream queues.
+const geofenceStreamBuffer = 64
+
+// A GeofenceUpdate is the position of a subject at a point in time, as consumed by GeofenceManager.Stream.
+type GeofenceUpdate struct {
+	Subject string
+	Point   Point
+	Time    time.Time
+}
+
+// Stream applies the updates received from updates with Update on up to workers goroutines, and sends
+// the events they fire on the returned channel.  Updates are sharded across the workers by subject,
+// so the updates of each subject are applied in the order they are received, and events are sent in
+// the order of the updates that fired them, exactly as if every update had been passed to Update in turn.
+//
+// The returned channel must be drained, or the workers stop taking updates.  It is closed once updates
+// is closed and every event has been sent, or once ctx is done, when updates already taken may have
+// been applied without their events being sent.
+func (m *GeofenceManager) Stream(ctx context.Context, updates <-chan GeofenceUpdate, workers int) <-chan GeofenceEvent {
+	type job struct {
+		seq    uint64
+		update GeofenceUpdate
+	}
+	type result struct {
+		seq    uint64
+		events []GeofenceEvent
+	}
+
+	workers = max(workers, 1)
+	shards := make([]chan job, workers)
+	results := make(chan result, workers)
+	events := make(chan GeofenceEvent)
+
+	var wg sync.WaitGroup
+	for i := range shards {
+		shards[i] = make(chan job, geofenceStreamBuffer)
+		wg.Add(1)
+		go func(jobs <-chan job) {
+			defer wg.Done()
+			for j := range jobs {
+				r := result{seq: j.seq, events: m.Update(j.update.Subject, j.update.Point, j.update.Time)}
+				select {
+				case results <- r:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(shards[i])
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	// Number the updates and hand each to the worker of its subject.
+	go func() {
+		defer func() {
+			for _, jobs := range shards {
+				close(jobs)
+			}
+		}()
+
+		var seq uint64
+		for {
+			select {
+			case u, ok := <-updates:
+				if !ok {
+					return
+				}
+				select {
+				case shards[geofenceShardOf(u.Subject, workers)] <- job{seq: seq, update: u}:
+					seq++
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	// Send the events of each update once those of every earlier update have been sent.
+	go func() {
+		defer close(events)
+
+		pending := make(map[uint64][]GeofenceEvent)
+		var next uint64
+		for r := range results {
+			pending[r.seq] = r.events
+			for {
+				fired, ok := pending[next]
+				if !ok {
+					break
+				}
+				delete(pending, next)
+				next++
+
+				for _, e := range fired {
+					select {
+					case events <- e:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}
+	}()
+
+	return events
+}
@@ -0,0 +1,82 @@
+package geo
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// Ensures that streaming updates across workers fires the same events, in the same order, as applying them in turn.
+func TestGeofenceStream(t *testing.T) {
+	fences := func() *GeofenceManager {
+		m := NewGeofenceManager()
+		m.SetWithOptions("depot", NewCircle(NewPoint(0, 0), 500*Meter), GeofenceOptions{Dwell: 2 * time.Second, ExitBuffer: 50 * Meter})
+		m.Set("yard", NewPolygon([]Point{NewPoint(0, 0), NewPoint(0, 0.01), NewPoint(0.01, 0.01), NewPoint(0.01, 0)}))
+		return m
+	}
+
+	// Vehicles wander back and forth across both fences.
+	rnd := rand.New(rand.NewSource(1))
+	start := time.Date(2024, time.May, 1, 9, 0, 0, 0, time.UTC)
+	var updates []GeofenceUpdate
+	for i := 0; i < 2000; i++ {
+		updates = append(updates, GeofenceUpdate{
+			Subject: fmt.Sprintf("vehicle-%d", rnd.Intn(20)),
+			Point:   NewPoint(rnd.Float64()*0.02-0.005, rnd.Float64()*0.02-0.005),
+			Time:    start.Add(time.Duration(i) * time.Second),
+		})
+	}
+
+	sequential := fences()
+	var expected []GeofenceEvent
+	for _, u := range updates {
+		expected = append(expected, sequential.Update(u.Subject, u.Point, u.Time)...)
+	}
+	if len(expected) == 0 {
+		t.Fatal("Expected the updates to fire events")
+	}
+
+	in := make(chan GeofenceUpdate)
+	go func() {
+		defer close(in)
+		for _, u := range updates {
+			in <- u
+		}
+	}()
+
+	var streamed []GeofenceEvent
+	for e := range fences().Stream(context.Background(), in, 4) {
+		streamed = append(streamed, e)
+	}
+	if !reflect.DeepEqual(streamed, expected) {
+		t.Errorf("Expected %d streamed events to match the %d sequential ones", len(streamed), len(expected))
+	}
+}
+
+// Ensures that cancelling the context closes the event stream even though updates stay open.
+func TestGeofenceStreamCancel(t *testing.T) {
+	m := NewGeofenceManager()
+	m.Set("depot", NewCircle(NewPoint(0, 0), 500*Meter))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan GeofenceUpdate, 1)
+	events := m.Stream(ctx, in, 2)
+
+	in <- GeofenceUpdate{Subject: "van", Point: NewPoint(0, 0), Time: time.Now()}
+	if e := <-events; e.Type != GeofenceEnter || e.Subject != "van" {
+		t.Errorf("Expected the van to enter the depot, but got %+v", e)
+	}
+
+	cancel()
+	select {
+	case _, ok := <-events:
+		if ok {
+			t.Error("Expected no more events once cancelled")
+		}
+	case <-time.After(time.Second):
+		t.Error("Expected the event stream to close once cancelled")
+	}
+}
@@ -0,0 +1,197 @@
+package geo
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// Ensures that the manager finds the circle and polygon fences containing a point as fences come and go.
+func TestGeofenceManager(t *testing.T) {
+	nsw, err := polygonFromFile("test/data/nsw.json")
+	if err != nil {
+		t.Fatal("nsw json file failed to parse: ", err)
+	}
+
+	opera := NewPoint(-33.8568, 151.2153)
+	m := NewGeofenceManager()
+	m.Set("nsw", NewPreparedPolygon(nsw))
+	m.Set("opera house", NewCircle(opera, 500*Meter))
+	m.Set("harbour bridge", NewCircle(NewPoint(-33.8523, 151.2108), 300*Meter))
+
+	tests := []struct {
+		p        Point
+		expected []string
+	}{
+		{opera, []string{"nsw", "opera house"}},
+		{NewPoint(-33.8540, 151.2120), []string{"harbour bridge", "nsw", "opera house"}},
+		{NewPoint(-33.8688, 151.2093), []string{"nsw"}},
+		{NewPoint(0, 0), []string{}},
+	}
+	for _, tt := range tests {
+		if found := m.FindContaining(tt.p); !reflect.DeepEqual(found, tt.expected) {
+			t.Errorf("Expected %v to be in %v, but got %v", tt.p, tt.expected, found)
+		}
+	}
+
+	// Replacing a fence moves it, and removing one drops it.
+	m.Set("opera house", NewCircle(opera, 10*Meter))
+	if found := m.FindContaining(NewPoint(-33.8540, 151.2120)); !reflect.DeepEqual(found, []string{"harbour bridge", "nsw"}) {
+		t.Errorf("Expected the shrunk fence to be left, but got %v", found)
+	}
+	if !m.Remove("nsw") || m.Remove("nsw") {
+		t.Error("Expected nsw to be removed exactly once")
+	}
+	if found := m.FindContaining(opera); !reflect.DeepEqual(found, []string{"opera house"}) {
+		t.Errorf("Expected only the opera house, but got %v", found)
+	}
+
+	if m.Len() != 2 {
+		t.Errorf("Expected 2 fences, but got %d", m.Len())
+	}
+	if fence, ok := m.Fence("opera house"); !ok || fence.(Circle).Radius() != 10 {
+		t.Errorf("Expected the 10m opera house fence, but got %v, %v", fence, ok)
+	}
+	if _, ok := m.Fence("nsw"); ok {
+		t.Error("Expected no nsw fence")
+	}
+}
+
+// Ensures that Enter only fires once a subject has dwelt inside a fence, and that leaving early restarts the wait.
+func TestGeofenceDwell(t *testing.T) {
+	site := NewPoint(-33.8568, 151.2153)
+	m := NewGeofenceManager()
+	m.SetWithOptions("site", NewCircle(site, 100*Meter), GeofenceOptions{Dwell: time.Minute})
+
+	start := time.Date(2024, time.May, 1, 9, 0, 0, 0, time.UTC)
+	outside := site.Offset(0.01, 0)
+	steps := []struct {
+		p        Point
+		after    time.Duration
+		expected []GeofenceEventType
+	}{
+		{site, 0, nil},
+		{outside, 30 * time.Second, nil},
+		{site, 40 * time.Second, nil},
+		{site, 90 * time.Second, nil},
+		{site, 100 * time.Second, []GeofenceEventType{GeofenceEnter}},
+		{site, 200 * time.Second, nil},
+		{outside, 210 * time.Second, []GeofenceEventType{GeofenceExit}},
+	}
+	for i, step := range steps {
+		events := m.Update("truck", step.p, start.Add(step.after))
+		var types []GeofenceEventType
+		for _, e := range events {
+			if e.Subject != "truck" || e.Fence != "site" || e.Point != step.p || !e.Time.Equal(start.Add(step.after)) {
+				t.Errorf("Step %d: unexpected event %+v", i, e)
+			}
+			types = append(types, e.Type)
+		}
+		if !reflect.DeepEqual(types, step.expected) {
+			t.Errorf("Step %d: expected %v, but got %v", i, step.expected, types)
+		}
+	}
+}
+
+// Ensures that a subject jittering around the edge of a fence only exits once it is beyond the exit buffer.
+func TestGeofenceExitBuffer(t *testing.T) {
+	square := NewPolygon([]Point{NewPoint(0, 0), NewPoint(0, 0.01), NewPoint(0.01, 0.01), NewPoint(0.01, 0)})
+	m := NewGeofenceManager()
+	m.SetWithOptions("square", square, GeofenceOptions{ExitBuffer: 50 * Meter})
+	m.SetWithOptions("circle", NewCircle(NewPoint(0.005, 0.0148), 500*Meter), GeofenceOptions{ExitBuffer: 100 * Meter})
+
+	now := time.Date(2024, time.May, 1, 9, 0, 0, 0, time.UTC)
+	update := func(p Point) []GeofenceEvent {
+		now = now.Add(time.Second)
+		return m.Update("phone", p, now)
+	}
+
+	// The east edge of the square lies about 35m west of the west edge of the circle.
+	if events := update(NewPoint(0.005, 0.0105)); len(events) != 1 || events[0].Fence != "circle" || events[0].Type != GeofenceEnter {
+		t.Errorf("Expected to enter the circle, but got %+v", events)
+	}
+	if events := update(NewPoint(0.005, 0.0099)); len(events) != 1 || events[0].Fence != "square" || events[0].Type != GeofenceEnter {
+		t.Errorf("Expected to enter the square, while staying within the circle's buffer, but got %+v", events)
+	}
+	// Jitter 30m either side of the square's edge fires nothing.
+	for _, lng := range []float64{0.01027, 0.0098, 0.01027} {
+		if events := update(NewPoint(0.005, lng)); len(events) != 0 {
+			t.Errorf("Expected no events at %v, but got %+v", lng, events)
+		}
+	}
+	if inside := m.Inside("phone"); !reflect.DeepEqual(inside, []string{"circle", "square"}) {
+		t.Errorf("Expected to be inside both fences, but got %v", inside)
+	}
+
+	// Deeper inside the square is beyond the buffer of the circle.
+	events := update(NewPoint(0.005, 0.00928))
+	if len(events) != 1 || events[0].Fence != "circle" || events[0].Type != GeofenceExit {
+		t.Errorf("Expected to exit the circle, but got %+v", events)
+	}
+	events = update(NewPoint(0.005, 0.01072))
+	if len(events) != 2 || events[0].Fence != "square" || events[0].Type != GeofenceExit || events[1].Fence != "circle" || events[1].Type != GeofenceEnter {
+		t.Errorf("Expected to exit the square and then enter the circle, but got %+v", events)
+	}
+
+	m.Forget("phone")
+	if inside := m.Inside("phone"); len(inside) != 0 {
+		t.Errorf("Expected a forgotten subject to be inside nothing, but got %v", inside)
+	}
+	if events := update(NewPoint(0.005, 0.005)); len(events) != 1 || events[0].Fence != "square" {
+		t.Errorf("Expected a forgotten subject to enter afresh, but got %+v", events)
+	}
+	m.Remove("square")
+	if events := update(NewPoint(1, 1)); len(events) != 0 {
+		t.Errorf("Expected no exit from a removed fence, but got %+v", events)
+	}
+}
+
+// Ensures that distances to fences are measured from their edges.
+func TestGeofenceDistance(t *testing.T) {
+	square := NewPolygon([]Point{NewPoint(0, 0), NewPoint(0, 0.01), NewPoint(0.01, 0.01), NewPoint(0.01, 0)})
+	p := NewPoint(0.005, 0.011)
+	expected := NewPoint(0.005, 0.01).GreatCircleDistance(p)
+
+	for _, fence := range []Geofence{square, NewPreparedPolygon(square), NewMultiPolygon(NewPolygon(nil), square)} {
+		if d := geofenceDistance(fence, p); math.Abs(float64(d-expected)) > 0.5 {
+			t.Errorf("Expected %v from %v, but got %v", expected, fence, d)
+		}
+	}
+	if d := geofenceDistance(NewCircle(NewPoint(0, 0), 100), NewPoint(0, 0.01)); math.Abs(float64(d-1011.9)) > 0.5 {
+		t.Errorf("Expected 1011.9m from the circle, but got %v", d)
+	}
+	if d := geofenceDistance(NewBoundingBox(NewPoint(0, 0), NewPoint(0.01, 0.01)), p); !math.IsInf(float64(d), 1) {
+		t.Errorf("Expected an unmeasurable fence to be infinitely far, but got %v", d)
+	}
+}
+
+// Ensures that fences on the path of a moving subject are predicted in order, skipping those it is in.
+func TestGeofenceManagerImminent(t *testing.T) {
+	m := NewGeofenceManager()
+	m.Set("near", NewCircle(Propagate(NewPoint(0, 0), 10, 90, 10*time.Second), 20*Meter))
+	m.Set("far", NewCircle(Propagate(NewPoint(0, 0), 10, 90, 50*time.Second), 20*Meter))
+	m.Set("beside", NewCircle(Propagate(NewPoint(0, 0), 10, 0, 10*time.Second), 20*Meter))
+	m.Set("here", NewCircle(NewPoint(0, 0), 20*Meter))
+
+	warnings := m.Imminent("truck", NewPoint(0, 0), 10, 90, time.Minute)
+	if len(warnings) != 2 || warnings[0].Fence != "near" || warnings[1].Fence != "far" {
+		t.Fatalf("Expected warnings for near and far, but got %+v", warnings)
+	}
+	if w := warnings[0]; w.Subject != "truck" || w.In != 8*time.Second || !m.fences["near"].fence.Contains(w.Point) {
+		t.Errorf("Expected to enter near in 8s, but got %+v", w)
+	}
+	if w := warnings[1]; w.In != 48*time.Second {
+		t.Errorf("Expected to enter far in 48s, but got %+v", w)
+	}
+
+	// The horizon cuts off later fences, and fences the subject dwells in are skipped.
+	m.SetWithOptions("near", m.fences["near"].fence, GeofenceOptions{Dwell: time.Hour})
+	m.Update("truck", Propagate(NewPoint(0, 0), 10, 90, 10*time.Second), time.Now())
+	if warnings := m.Imminent("truck", NewPoint(0, 0), 10, 90, 30*time.Second); len(warnings) != 0 {
+		t.Errorf("Expected no warnings, but got %+v", warnings)
+	}
+	if warnings := m.Imminent("truck", NewPoint(0, 0), 0, 90, time.Minute); len(warnings) != 0 {
+		t.Errorf("Expected no warnings when standing still, but got %+v", warnings)
+	}
+}
@@ -0,0 +1,142 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// The base32 alphabet used by geohashes.  It omits a, i, l and o.
+const geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// MaxGeohashPrecision is the longest geohash that can be represented by a 64 bit integer.
+const MaxGeohashPrecision = 12
+
+// EncodeGeohash returns the geohash of Point p with the passed in number of characters.
+// The precision is clamped to the range [1, MaxGeohashPrecision].
+func EncodeGeohash(p Point, precision int) string {
+	if precision < 1 {
+		precision = 1
+	}
+	if precision > MaxGeohashPrecision {
+		precision = MaxGeohashPrecision
+	}
+
+	minLat, maxLat := -90.0, 90.0
+	minLng, maxLng := -180.0, 180.0
+
+	var sb strings.Builder
+	sb.Grow(precision)
+
+	even := true
+	bit, ch := 0, 0
+	for sb.Len() < precision {
+		if even {
+			mid := (minLng + maxLng) / 2
+			if p.lng >= mid {
+				ch = ch<<1 | 1
+				minLng = mid
+			} else {
+				ch <<= 1
+				maxLng = mid
+			}
+		} else {
+			mid := (minLat + maxLat) / 2
+			if p.lat >= mid {
+				ch = ch<<1 | 1
+				minLat = mid
+			} else {
+				ch <<= 1
+				maxLat = mid
+			}
+		}
+		even = !even
+
+		bit++
+		if bit == 5 {
+			sb.WriteByte(geohashAlphabet[ch])
+			bit, ch = 0, 0
+		}
+	}
+
+	return sb.String()
+}
+
+// DecodeGeohash returns the cell described by the passed in geohash.
+func DecodeGeohash(hash string) (BoundingBox, error) {
+	if hash == "" {
+		return BoundingBox{}, fmt.Errorf("%w: empty geohash", ErrInvalidFormat)
+	}
+
+	minLat, maxLat := -90.0, 90.0
+	minLng, maxLng := -180.0, 180.0
+
+	even := true
+	for i := 0; i < len(hash); i++ {
+		idx := strings.IndexByte(geohashAlphabet, hash[i])
+		if idx < 0 {
+			return BoundingBox{}, fmt.Errorf("%w: invalid geohash character %q in %q", ErrInvalidFormat, hash[i], hash)
+		}
+
+		for mask := 16; mask > 0; mask >>= 1 {
+			if even {
+				mid := (minLng + maxLng) / 2
+				if idx&mask != 0 {
+					minLng = mid
+				} else {
+					maxLng = mid
+				}
+			} else {
+				mid := (minLat + maxLat) / 2
+				if idx&mask != 0 {
+					minLat = mid
+				} else {
+					maxLat = mid
+				}
+			}
+			even = !even
+		}
+	}
+
+	return NewBoundingBox(NewPoint(minLat, minLng), NewPoint(maxLat, maxLng)), nil
+}
+
+// GeohashCellSize returns the height and width in degrees of a geohash cell
+// with the passed in number of characters.
+func GeohashCellSize(precision int) (latDegrees float64, lngDegrees float64) {
+	bits := 5 * precision
+	lngBits := (bits + 1) / 2
+	latBits := bits / 2
+
+	return 180 / math.Exp2(float64(latBits)), 360 / math.Exp2(float64(lngBits))
+}
+
+// GeohashesCovering returns the geohashes of the passed in precision whose cells
+// intersect the BoundingBox b.  Cells that only touch the north or east edge of b
+// are omitted.  Each cell is returned once.
+func GeohashesCovering(b BoundingBox, precision int) []string {
+	height, width := GeohashCellSize(precision)
+
+	var hashes []string
+	seen := make(map[string]bool)
+	for _, lngs := range b.lngIntervals() {
+		latStart := cellStart(b.sw.lat, -90, height)
+		for lat := latStart; (lat < b.ne.lat || lat == latStart) && lat < 90; lat += height {
+			lngStart := cellStart(lngs[0], -180, width)
+			for lng := lngStart; (lng < lngs[1] || lng == lngStart) && lng < 180; lng += width {
+				hash := EncodeGeohash(NewPoint(lat+height/2, lng+width/2), precision)
+				if !seen[hash] {
+					seen[hash] = true
+					hashes = append(hashes, hash)
+				}
+			}
+		}
+	}
+
+	return hashes
+}
+
+// cellStart returns the lower edge of the cell of the passed in size containing v.
+func cellStart(v, origin, size float64) float64 {
+	return origin + math.Floor((v-origin)/size)*size
+}
@@ -0,0 +1,77 @@
+package geo
+
+import (
+	"sort"
+	"testing"
+)
+
+// Ensures that points encode to well known geohashes.
+func TestEncodeGeohash(t *testing.T) {
+	tests := []struct {
+		p         Point
+		precision int
+		expected  string
+	}{
+		{NewPoint(42.6, -5.6), 5, "ezs42"},
+		{NewPoint(57.64911, 10.40744), 11, "u4pruydqqvj"},
+		{NewPoint(57.64911, 10.40744), 0, "u"},
+	}
+
+	for _, tt := range tests {
+		if got := EncodeGeohash(tt.p, tt.precision); got != tt.expected {
+			t.Errorf("Expected %v at precision %d to encode to %s, but got %s instead", tt.p, tt.precision, tt.expected, got)
+		}
+	}
+}
+
+// Ensures that a decoded geohash cell contains the encoded point.
+func TestDecodeGeohash(t *testing.T) {
+	p := NewPoint(57.64911, 10.40744)
+	cell, err := DecodeGeohash(EncodeGeohash(p, 8))
+	if err != nil {
+		t.Fatalf("Should not encounter an error when decoding a geohash, but got %v", err)
+	}
+
+	if !cell.Contains(p) {
+		t.Errorf("Expected cell %v to contain %v", cell, p)
+	}
+
+	height, width := GeohashCellSize(8)
+	if h := cell.ne.lat - cell.sw.lat; h != height {
+		t.Errorf("Expected the cell to be %v degrees tall, but got %v instead", height, h)
+	}
+	if w := cell.ne.lng - cell.sw.lng; w != width {
+		t.Errorf("Expected the cell to be %v degrees wide, but got %v instead", width, w)
+	}
+
+	if _, err := DecodeGeohash("u4pa"); err == nil {
+		t.Error("Expected an error when decoding a geohash with an invalid character")
+	}
+}
+
+// Ensures that the cells covering a box are exactly those intersecting it.
+func TestGeohashesCovering(t *testing.T) {
+	cell, _ := DecodeGeohash("ezs42")
+	hashes := GeohashesCovering(cell, 5)
+	if len(hashes) != 1 || hashes[0] != "ezs42" {
+		t.Errorf("Expected a cell to be covered by itself, but got %v instead", hashes)
+	}
+
+	hashes = GeohashesCovering(NewBoundingBox(NewPoint(-1, -1), NewPoint(1, 1)), 1)
+	sort.Strings(hashes)
+	expected := []string{"7", "e", "k", "s"}
+	if len(hashes) != len(expected) {
+		t.Fatalf("Expected %v, but got %v instead", expected, hashes)
+	}
+	for i := range expected {
+		if hashes[i] != expected[i] {
+			t.Errorf("Expected %v, but got %v instead", expected, hashes)
+		}
+	}
+
+	hashes = GeohashesCovering(NewBoundingBox(NewPoint(1, 179), NewPoint(2, -179)), 1)
+	sort.Strings(hashes)
+	if len(hashes) != 2 || hashes[0] != "8" || hashes[1] != "x" {
+		t.Errorf("Expected a box crossing the antimeridian to be covered by 8 and x, but got %v instead", hashes)
+	}
+}
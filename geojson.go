@@ -0,0 +1,232 @@
+package geo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// geoJSONObject is any GeoJSON object, with the members of every type it may be.
+type geoJSONObject struct {
+	Type        string           `json:"type"`
+	Coordinates json.RawMessage  `json:"coordinates,omitempty"`
+	Geometry    *geoJSONObject   `json:"geometry,omitempty"`
+	Geometries  []*geoJSONObject `json:"geometries,omitempty"`
+	Features    []*geoJSONObject `json:"features,omitempty"`
+}
+
+// geoJSONGeometry is a GeoJSON geometry as written by WriteGeoJSON.
+type geoJSONGeometry struct {
+	Type        string `json:"type"`
+	Coordinates any    `json:"coordinates"`
+}
+
+// geoJSONFeature is a GeoJSON Feature as written by WriteGeoJSON.
+type geoJSONFeature struct {
+	Type       string          `json:"type"`
+	Geometry   geoJSONGeometry `json:"geometry"`
+	Properties struct{}        `json:"properties"`
+}
+
+// DecodeGeoJSON decodes every geometry of a GeoJSON document: a geometry, a Feature, a FeatureCollection
+// or a GeometryCollection.  Points, LineStrings, Polygons and MultiPolygons decode to the matching
+// types, while MultiPoints and MultiLineStrings are split into their parts.  Features without geometry
+// are skipped, and polygons with interior rings are rejected with ErrUnsupportedGeometry.
+func DecodeGeoJSON(data []byte) ([]Geometry, error) {
+	var obj geoJSONObject
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFormat, err)
+	}
+	return obj.geometries()
+}
+
+// geometries returns the geometries of the GeoJSON object, as described for DecodeGeoJSON.
+func (o *geoJSONObject) geometries() ([]Geometry, error) {
+	switch o.Type {
+	case "FeatureCollection", "GeometryCollection":
+		var geometries []Geometry
+		for _, child := range append(o.Features, o.Geometries...) {
+			if child == nil {
+				continue
+			}
+			g, err := child.geometries()
+			if err != nil {
+				return nil, err
+			}
+			geometries = append(geometries, g...)
+		}
+		return geometries, nil
+	case "Feature":
+		if o.Geometry == nil {
+			return nil, nil
+		}
+		return o.Geometry.geometries()
+	case "Point":
+		var c []float64
+		if err := json.Unmarshal(o.Coordinates, &c); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidFormat, err)
+		}
+		points, err := geoJSONPositions([][]float64{c})
+		if err != nil {
+			return nil, err
+		}
+		return []Geometry{points[0]}, nil
+	case "MultiPoint", "LineString":
+		var c [][]float64
+		if err := json.Unmarshal(o.Coordinates, &c); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidFormat, err)
+		}
+		points, err := geoJSONPositions(c)
+		if err != nil {
+			return nil, err
+		}
+		if o.Type == "LineString" {
+			return []Geometry{NewLineString(points)}, nil
+		}
+		geometries := make([]Geometry, len(points))
+		for i, p := range points {
+			geometries[i] = p
+		}
+		return geometries, nil
+	case "MultiLineString":
+		var c [][][]float64
+		if err := json.Unmarshal(o.Coordinates, &c); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidFormat, err)
+		}
+		geometries := make([]Geometry, len(c))
+		for i, line := range c {
+			points, err := geoJSONPositions(line)
+			if err != nil {
+				return nil, err
+			}
+			geometries[i] = NewLineString(points)
+		}
+		return geometries, nil
+	case "Polygon":
+		var c [][][]float64
+		if err := json.Unmarshal(o.Coordinates, &c); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidFormat, err)
+		}
+		p, err := geoJSONPolygon(c)
+		if err != nil {
+			return nil, err
+		}
+		return []Geometry{p}, nil
+	case "MultiPolygon":
+		var c [][][][]float64
+		if err := json.Unmarshal(o.Coordinates, &c); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidFormat, err)
+		}
+		polygons := make([]Polygon, len(c))
+		for i, rings := range c {
+			p, err := geoJSONPolygon(rings)
+			if err != nil {
+				return nil, err
+			}
+			polygons[i] = p
+		}
+		return []Geometry{NewMultiPolygon(polygons...)}, nil
+	}
+	return nil, fmt.Errorf("%w: unexpected GeoJSON type %q", ErrUnsupportedGeometry, o.Type)
+}
+
+// WriteGeoJSON writes the passed in geometries to w as a GeoJSON FeatureCollection, one Feature each
+// without properties.  Tracks are written as LineStrings, dropping the times of their fixes, and rings
+// are closed.  Other geometries are rejected with ErrUnsupportedGeometry.
+func WriteGeoJSON(w io.Writer, geometries ...Geometry) error {
+	collection := struct {
+		Type     string           `json:"type"`
+		Features []geoJSONFeature `json:"features"`
+	}{Type: "FeatureCollection", Features: make([]geoJSONFeature, 0, len(geometries))}
+
+	for _, g := range geometries {
+		var geometry geoJSONGeometry
+		switch g := g.(type) {
+		case Point:
+			geometry = geoJSONGeometry{Type: "Point", Coordinates: []float64{g.lng, g.lat}}
+		case LineString:
+			geometry = geoJSONGeometry{Type: "LineString", Coordinates: geoJSONCoordinates(g.points)}
+		case Track:
+			geometry = geoJSONGeometry{Type: "LineString", Coordinates: geoJSONCoordinates(g.Points())}
+		case Polygon:
+			geometry = geoJSONGeometry{Type: "Polygon", Coordinates: [][][]float64{geoJSONCoordinates(g.ring().points)}}
+		case MultiPolygon:
+			rings := make([][][][]float64, len(g.polygons))
+			for i, p := range g.polygons {
+				rings[i] = [][][]float64{geoJSONCoordinates(p.ring().points)}
+			}
+			geometry = geoJSONGeometry{Type: "MultiPolygon", Coordinates: rings}
+		default:
+			return fmt.Errorf("%w: GeoJSON cannot hold %T", ErrUnsupportedGeometry, g)
+		}
+		collection.Features = append(collection.Features, geoJSONFeature{Type: "Feature", Geometry: geometry})
+	}
+
+	return json.NewEncoder(w).Encode(collection)
+}
+
+// DecodeGeoJSONPolygon decodes a Polygon from a GeoJSON Polygon geometry or a Feature whose geometry
+// is a Polygon.  The closing point of the ring is dropped.  Polygons with interior rings are rejected
+// with ErrUnsupportedGeometry, as Polygon cannot represent holes.
+func DecodeGeoJSONPolygon(data []byte) (Polygon, error) {
+	var obj geoJSONObject
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return Polygon{}, fmt.Errorf("%w: %v", ErrInvalidFormat, err)
+	}
+
+	if obj.Type == "Feature" {
+		if obj.Geometry == nil {
+			return Polygon{}, fmt.Errorf("%w: GeoJSON Feature has no geometry", ErrUnsupportedGeometry)
+		}
+		obj = *obj.Geometry
+	}
+	if obj.Type != "Polygon" {
+		return Polygon{}, fmt.Errorf("%w: unexpected GeoJSON type %q, expected Polygon", ErrUnsupportedGeometry, obj.Type)
+	}
+
+	var rings [][][]float64
+	if err := json.Unmarshal(obj.Coordinates, &rings); err != nil {
+		return Polygon{}, fmt.Errorf("%w: %v", ErrInvalidFormat, err)
+	}
+	return geoJSONPolygon(rings)
+}
+
+// geoJSONPolygon returns the Polygon described by the rings of a GeoJSON Polygon.
+func geoJSONPolygon(rings [][][]float64) (Polygon, error) {
+	if len(rings) == 0 {
+		return Polygon{}, fmt.Errorf("%w: GeoJSON Polygon has no rings", ErrUnclosedPolygon)
+	}
+	if len(rings) > 1 {
+		return Polygon{}, fmt.Errorf("%w: GeoJSON Polygon has %d interior rings, holes are not supported", ErrUnsupportedGeometry, len(rings)-1)
+	}
+
+	points, err := geoJSONPositions(rings[0])
+	if err != nil {
+		return Polygon{}, err
+	}
+	if len(points) > 1 && points[0] == points[len(points)-1] {
+		points = points[:len(points)-1]
+	}
+	return NewPolygon(points), nil
+}
+
+// geoJSONPositions converts GeoJSON positions, longitude first, to points.
+func geoJSONPositions(positions [][]float64) ([]Point, error) {
+	points := make([]Point, len(positions))
+	for i, c := range positions {
+		if len(c) < 2 {
+			return nil, fmt.Errorf("%w: GeoJSON position %d has %d coordinates, expected 2", ErrInvalidFormat, i, len(c))
+		}
+		points[i] = NewPoint(c[1], c[0])
+	}
+	return points, nil
+}
+
+// geoJSONCoordinates converts points to GeoJSON positions, longitude first.
+func geoJSONCoordinates(points []Point) [][]float64 {
+	positions := make([][]float64, len(points))
+	for i, p := range points {
+		positions[i] = []float64{p.lng, p.lat}
+	}
+	return positions
+}
@@ -0,0 +1,103 @@
+package geo
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// Ensures that Polygon geometries and Polygon Features decode to the same Polygon.
+func TestDecodeGeoJSONPolygon(t *testing.T) {
+	expected := []Point{NewPoint(0, 0), NewPoint(0, 1), NewPoint(1, 1)}
+
+	for _, doc := range []string{
+		`{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,0]]]}`,
+		`{"type":"Feature","properties":{"name":"fence"},"geometry":{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,0]]]}}`,
+	} {
+		p, err := DecodeGeoJSONPolygon([]byte(doc))
+		if err != nil {
+			t.Fatalf("Should not encounter an error when decoding %s, but got %v", doc, err)
+		}
+		if !reflect.DeepEqual(p.Points(), expected) {
+			t.Errorf("Expected %s to decode to %v, but got %v", doc, expected, p.Points())
+		}
+	}
+}
+
+// Ensures that other geometries and malformed documents are rejected.
+func TestDecodeGeoJSONPolygonInvalid(t *testing.T) {
+	if _, err := DecodeGeoJSONPolygon([]byte(`{"type":"Feature","geometry":null}`)); !errors.Is(err, ErrUnsupportedGeometry) {
+		t.Errorf("Expected a Feature without geometry to be rejected, but got %v", err)
+	}
+
+	if _, err := DecodeGeoJSONPolygon([]byte(`{"type":"LineString","coordinates":[[[0,0],[1,1]]]}`)); err == nil {
+		t.Error("Expected a LineString to be rejected")
+	}
+
+	hole := `{"type":"Polygon","coordinates":[[[0,0],[10,0],[10,10],[0,10],[0,0]],[[4,4],[6,4],[6,6],[4,6],[4,4]]]}`
+	if _, err := DecodeGeoJSONPolygon([]byte(hole)); !errors.Is(err, ErrUnsupportedGeometry) {
+		t.Errorf("Expected a Polygon with a hole to be rejected, but got %v", err)
+	}
+
+	if _, err := DecodeGeoJSONPolygon([]byte(`{"type":`)); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("Expected malformed JSON to be rejected, but got %v", err)
+	}
+}
+
+// Ensures that every geometry of a FeatureCollection is decoded and that holes are rejected.
+func TestDecodeGeoJSON(t *testing.T) {
+	doc := `{"type":"FeatureCollection","features":[
+		{"type":"Feature","geometry":{"type":"Point","coordinates":[2,1]}},
+		{"type":"Feature","geometry":null},
+		{"type":"Feature","geometry":{"type":"MultiLineString","coordinates":[[[0,0],[1,1]],[[2,2],[3,3]]]}},
+		{"type":"Feature","geometry":{"type":"MultiPolygon","coordinates":[[[[0,0],[1,0],[1,1],[0,0]]]]}}
+	]}`
+	geometries, err := DecodeGeoJSON([]byte(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(geometries) != 4 {
+		t.Fatalf("Expected 4 geometries, but got %v", geometries)
+	}
+	if p, ok := geometries[0].(Point); !ok || p != NewPoint(1, 2) {
+		t.Errorf("Expected the Point 1,2, but got %v", geometries[0])
+	}
+	if l, ok := geometries[2].(LineString); !ok || !reflect.DeepEqual(l.Points(), []Point{NewPoint(2, 2), NewPoint(3, 3)}) {
+		t.Errorf("Expected the second LineString, but got %v", geometries[2])
+	}
+	if m, ok := geometries[3].(MultiPolygon); !ok || len(m.Polygons()) != 1 || len(m.Polygons()[0].Points()) != 3 {
+		t.Errorf("Expected a MultiPolygon of one triangle, but got %v", geometries[3])
+	}
+
+	hole := `{"type":"MultiPolygon","coordinates":[[[[0,0],[10,0],[10,10],[0,0]],[[4,4],[6,4],[6,6],[4,4]]]]}`
+	if _, err := DecodeGeoJSON([]byte(hole)); !errors.Is(err, ErrUnsupportedGeometry) {
+		t.Errorf("Expected a MultiPolygon with a hole to be rejected, but got %v", err)
+	}
+}
+
+// Ensures that written geometries decode back to the same geometries.
+func TestWriteGeoJSON(t *testing.T) {
+	geometries := []Geometry{
+		NewPoint(1, 2),
+		NewLineString([]Point{NewPoint(0, 0), NewPoint(1, 1)}),
+		NewPolygon([]Point{NewPoint(0, 0), NewPoint(0, 1), NewPoint(1, 1)}),
+		NewMultiPolygon(NewPolygon([]Point{NewPoint(0, 0), NewPoint(0, 1), NewPoint(1, 1)})),
+	}
+
+	var buf bytes.Buffer
+	if err := WriteGeoJSON(&buf, geometries...); err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := DecodeGeoJSON(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, geometries) {
+		t.Errorf("Expected %v to round trip, but got %v", geometries, decoded)
+	}
+
+	if err := WriteGeoJSON(&buf, NewCircle(NewPoint(0, 0), Meter)); !errors.Is(err, ErrUnsupportedGeometry) {
+		t.Errorf("Expected a Circle to be rejected, but got %v", err)
+	}
+}
@@ -0,0 +1,38 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// Jitter returns Point p displaced in a random direction by a random distance between minRadius and
+// maxRadius, drawn uniformly over the ring between them: the donut geomasking used to publish
+// approximate locations, such as of patients or sensitive sites, whose displacement is bounded both
+// ways.  The minimum keeps p from being published nearly as is.  The radii may be given either way round.
+func Jitter(p Point, minRadius Distance, maxRadius Distance, r *rand.Rand) Point {
+	if minRadius > maxRadius {
+		minRadius, maxRadius = maxRadius, minRadius
+	}
+	// Drawing the cosine of the angle from p uniformly spreads points evenly over the ring on the sphere.
+	cosMin := math.Cos(math.Max(minRadius.Kilometers(), 0) / EARTH_RADIUS)
+	cosMax := math.Cos(math.Min(math.Max(maxRadius.Kilometers(), 0)/EARTH_RADIUS, math.Pi))
+	angle := math.Acos(cosMin - r.Float64()*(cosMin-cosMax))
+	return destinationPoint(p, r.Float64()*360, Distance(angle*EARTH_RADIUS*float64(Kilometer)))
+}
+
+// JitterWithin is like Jitter, but only returns points inside area, such as the district a location
+// is published for, so that masking does not move it across a boundary or into the sea.  It returns
+// an error wrapping ErrUnclosedPolygon for an area of fewer than three points, or ErrNoResults if no
+// point of the ring around p inside area is found.
+func JitterWithin(p Point, minRadius Distance, maxRadius Distance, area Polygon, r *rand.Rand) (Point, error) {
+	if !area.IsClosed() {
+		return Point{}, fmt.Errorf("%w: %d points", ErrUnclosedPolygon, len(area.points))
+	}
+	for range randomPointTries {
+		if q := Jitter(p, minRadius, maxRadius, r); area.contains(q) {
+			return q, nil
+		}
+	}
+	return Point{}, fmt.Errorf("%w: no point from %v to %v of %v inside the area", ErrNoResults, minRadius, maxRadius, p)
+}
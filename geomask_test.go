@@ -0,0 +1,57 @@
+package geo
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// Ensures that jittered points stay within the ring and spread evenly over its area.
+func TestJitter(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	p := NewPoint(51.5, -0.1)
+	minRadius, maxRadius := 100*Meter, 300*Meter
+
+	inner := 0
+	n := 10000
+	for range n {
+		q := Jitter(p, maxRadius, minRadius, r)
+		d := p.GreatCircleDistance(q)
+		if d < minRadius-0.01 || d > maxRadius+0.01 {
+			t.Fatalf("Expected %v between %v and %v of %v, but got %v", q, minRadius, maxRadius, p, d)
+		}
+		if d < 200*Meter {
+			inner++
+		}
+	}
+	// The inner half of the ring by radius holds (200²-100²)/(300²-100²) of its area.
+	if f := float64(inner) / float64(n); math.Abs(f-0.375) > 0.02 {
+		t.Errorf("Expected 37.5%% of points within 200m, but got %v", f)
+	}
+}
+
+// Ensures that jittered points are kept inside the area.
+func TestJitterWithin(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	p := NewPoint(0, 0)
+	area := NewPolygon([]Point{NewPoint(-0.01, -0.01), NewPoint(-0.01, 0.0005), NewPoint(0.01, 0.0005), NewPoint(0.01, -0.01), NewPoint(-0.01, -0.01)})
+
+	for range 100 {
+		q, err := JitterWithin(p, 100*Meter, 500*Meter, area, r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !area.Contains(q) {
+			t.Errorf("Expected %v inside the area", q)
+		}
+	}
+
+	if _, err := JitterWithin(p, 100*Meter, 500*Meter, NewPolygon(nil), r); !errors.Is(err, ErrUnclosedPolygon) {
+		t.Errorf("Expected ErrUnclosedPolygon, but got %v", err)
+	}
+	far := NewPolygon([]Point{NewPoint(1, 1), NewPoint(1, 2), NewPoint(2, 2), NewPoint(1, 1)})
+	if _, err := JitterWithin(p, 100*Meter, 500*Meter, far, r); !errors.Is(err, ErrNoResults) {
+		t.Errorf("Expected ErrNoResults, but got %v", err)
+	}
+}
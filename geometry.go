@@ -0,0 +1,20 @@
+package geo
+
+// Geometry is implemented by every shape in the package.
+type Geometry interface {
+	// Bounds returns the smallest BoundingBox containing the Geometry.
+	Bounds() BoundingBox
+}
+
+var (
+	_ Geometry = Point{}
+	_ Geometry = Polygon{}
+	_ Geometry = LineString{}
+	_ Geometry = BoundingBox{}
+	_ Geometry = MultiPolygon{}
+	_ Geometry = (*PreparedPolygon)(nil)
+	_ Geometry = Circle{}
+	_ Geometry = Track{}
+	_ Geometry = Ellipse{}
+	_ Geometry = Cap{}
+)
@@ -0,0 +1,173 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// The letters used by the World Geographic Reference System.
+// Longitude zones use 24 letters, latitude bands 12 and degree cells 15; I and O are never used.
+const (
+	georefLetters = "ABCDEFGHJKLMNPQRSTUVWXYZ"
+	georefBands   = "ABCDEFGHJKLM"
+	georefDegrees = "ABCDEFGHJKLMNPQ"
+)
+
+// The letters used for the 30 minute latitude bands of the Global Area Reference System.
+const garsLetters = "ABCDEFGHJKLMNPQRSTUVWXYZ"
+
+// EncodeGEOREF returns the World Geographic Reference System reference of Point p,
+// e.g. "NGAA" for the 1° cell at the origin.  The precision is the number of
+// minute digits given for each of the longitude and latitude: 0 for 1° cells,
+// 2 for 1' cells, 3 for 0.1' cells and 4 for 0.01' cells.
+func EncodeGEOREF(p Point, precision int) (string, error) {
+	if precision < 0 || precision == 1 || precision > 4 {
+		return "", fmt.Errorf("%w: GEOREF precision %d must be 0, 2, 3 or 4", ErrInvalidPrecision, precision)
+	}
+
+	lng := math.Min(p.lng+180, math.Nextafter(360, 0))
+	lat := math.Min(p.lat+90, math.Nextafter(180, 0))
+	if lng < 0 || lat < 0 {
+		return "", fmt.Errorf("%w: cannot encode %v as GEOREF", ErrOutOfBounds, p)
+	}
+
+	lngDeg, latDeg := math.Floor(lng), math.Floor(lat)
+
+	var sb strings.Builder
+	sb.WriteByte(georefLetters[int(lngDeg)/15])
+	sb.WriteByte(georefBands[int(latDeg)/15])
+	sb.WriteByte(georefDegrees[int(lngDeg)%15])
+	sb.WriteByte(georefDegrees[int(latDeg)%15])
+
+	if precision > 0 {
+		scale := math.Pow10(precision - 2)
+		lngMin := int(math.Floor((lng - lngDeg) * 60 * scale))
+		latMin := int(math.Floor((lat - latDeg) * 60 * scale))
+		fmt.Fprintf(&sb, "%0*d%0*d", precision, lngMin, precision, latMin)
+	}
+
+	return sb.String(), nil
+}
+
+// DecodeGEOREF returns the cell described by the passed in GEOREF reference.
+func DecodeGEOREF(ref string) (BoundingBox, error) {
+	s := strings.ToUpper(ref)
+	if len(s) < 4 || len(s) == 6 || len(s)%2 != 0 || len(s) > 12 {
+		return BoundingBox{}, fmt.Errorf("%w: invalid GEOREF reference %q", ErrInvalidFormat, ref)
+	}
+
+	zone := strings.IndexByte(georefLetters, s[0])
+	band := strings.IndexByte(georefBands, s[1])
+	lngDeg := strings.IndexByte(georefDegrees, s[2])
+	latDeg := strings.IndexByte(georefDegrees, s[3])
+	if zone < 0 || band < 0 || lngDeg < 0 || latDeg < 0 {
+		return BoundingBox{}, fmt.Errorf("%w: invalid GEOREF reference %q", ErrInvalidFormat, ref)
+	}
+
+	lng := float64(zone*15+lngDeg) - 180
+	lat := float64(band*15+latDeg) - 90
+	size := 1.0
+
+	if digits := s[4:]; len(digits) > 0 {
+		precision := len(digits) / 2
+		lngMin, errLng := strconv.Atoi(digits[:precision])
+		latMin, errLat := strconv.Atoi(digits[precision:])
+		if errLng != nil || errLat != nil || strings.ContainsAny(digits, "+-") {
+			return BoundingBox{}, fmt.Errorf("%w: invalid GEOREF minutes %q in %q", ErrInvalidFormat, digits, ref)
+		}
+
+		scale := math.Pow10(precision - 2)
+		if float64(lngMin) >= 60*scale || float64(latMin) >= 60*scale {
+			return BoundingBox{}, fmt.Errorf("%w: invalid GEOREF minutes %q in %q", ErrInvalidFormat, digits, ref)
+		}
+
+		size = 1 / (60 * scale)
+		lng += float64(lngMin) * size
+		lat += float64(latMin) * size
+	}
+
+	return NewBoundingBox(NewPoint(lat, lng), NewPoint(lat+size, lng+size)), nil
+}
+
+// EncodeGARS returns the Global Area Reference System cell of Point p, e.g. "361HN37".
+// The precision selects the cell size: 1 for 30' cells, 2 for 15' quadrants
+// and 3 for 5' keypad cells.
+func EncodeGARS(p Point, precision int) (string, error) {
+	if precision < 1 || precision > 3 {
+		return "", fmt.Errorf("%w: GARS precision %d out of range [1, 3]", ErrInvalidPrecision, precision)
+	}
+
+	// Work in units of 5 minutes from the south-west corner of the world.
+	lng := math.Min((p.lng+180)*12, math.Nextafter(360*12, 0))
+	lat := math.Min((p.lat+90)*12, math.Nextafter(180*12, 0))
+	if lng < 0 || lat < 0 {
+		return "", fmt.Errorf("%w: cannot encode %v as GARS", ErrOutOfBounds, p)
+	}
+
+	x, y := int(lng), int(lat)
+	band := y / 6
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%03d%c%c", x/6+1, garsLetters[band/len(garsLetters)], garsLetters[band%len(garsLetters)])
+
+	if precision >= 2 {
+		// Quadrants are numbered 1 2 / 3 4 from the north-west.
+		qx, qy := x%6/3, y%6/3
+		sb.WriteByte(byte('1' + qx + 2*(1-qy)))
+
+		if precision == 3 {
+			// Keypad cells are numbered 1 2 3 / 4 5 6 / 7 8 9 from the north-west.
+			kx, ky := x%3, y%3
+			sb.WriteByte(byte('1' + kx + 3*(2-ky)))
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// DecodeGARS returns the cell described by the passed in GARS reference.
+func DecodeGARS(ref string) (BoundingBox, error) {
+	s := strings.ToUpper(ref)
+	if len(s) < 5 || len(s) > 7 {
+		return BoundingBox{}, fmt.Errorf("%w: invalid GARS reference %q", ErrInvalidFormat, ref)
+	}
+
+	col, err := strconv.Atoi(s[:3])
+	if err != nil || col < 1 || col > 720 || strings.ContainsAny(s[:3], "+-") {
+		return BoundingBox{}, fmt.Errorf("%w: invalid GARS longitude band %q in %q", ErrInvalidFormat, s[:3], ref)
+	}
+
+	hi := strings.IndexByte(garsLetters, s[3])
+	lo := strings.IndexByte(garsLetters, s[4])
+	band := hi*len(garsLetters) + lo
+	if hi < 0 || lo < 0 || band >= 360 {
+		return BoundingBox{}, fmt.Errorf("%w: invalid GARS latitude band %q in %q", ErrInvalidFormat, s[3:5], ref)
+	}
+
+	// Again in units of 5 minutes.
+	x, y, size := (col-1)*6, band*6, 6
+	if len(s) >= 6 {
+		q := int(s[5] - '1')
+		if q < 0 || q > 3 {
+			return BoundingBox{}, fmt.Errorf("%w: invalid GARS quadrant %q in %q", ErrInvalidFormat, s[5], ref)
+		}
+		size = 3
+		x += q % 2 * 3
+		y += (1 - q/2) * 3
+	}
+	if len(s) == 7 {
+		k := int(s[6] - '1')
+		if k < 0 || k > 8 {
+			return BoundingBox{}, fmt.Errorf("%w: invalid GARS keypad %q in %q", ErrInvalidFormat, s[6], ref)
+		}
+		size = 1
+		x += k % 3
+		y += 2 - k/3
+	}
+
+	sw := NewPoint(float64(y)/12-90, float64(x)/12-180)
+	ne := NewPoint(float64(y+size)/12-90, float64(x+size)/12-180)
+	return NewBoundingBox(sw, ne), nil
+}
@@ -0,0 +1,110 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+// Ensures that points encode to the expected GEOREF references.
+func TestEncodeGEOREF(t *testing.T) {
+	tests := []struct {
+		p         Point
+		precision int
+		expected  string
+	}{
+		{NewPoint(0, 0), 0, "NGAA"},
+		{NewPoint(0.5, 0.25), 2, "NGAA1530"},
+		{NewPoint(0.5, 0.25), 4, "NGAA15003000"},
+		{NewPoint(-90, -180), 0, "AAAA"},
+		{NewPoint(90, 180), 0, "ZMQQ"},
+	}
+
+	for _, tt := range tests {
+		got, err := EncodeGEOREF(tt.p, tt.precision)
+		if err != nil {
+			t.Fatalf("Should not encounter an error when encoding %v, but got %v", tt.p, err)
+		}
+		if got != tt.expected {
+			t.Errorf("Expected %v to encode to %s, but got %s instead", tt.p, tt.expected, got)
+		}
+	}
+
+	if _, err := EncodeGEOREF(NewPoint(0, 0), 1); err == nil {
+		t.Error("Expected an error when encoding with an unsupported precision")
+	}
+}
+
+// Ensures that GEOREF references decode to cells containing the encoded point.
+func TestDecodeGEOREF(t *testing.T) {
+	p := NewPoint(38.286108, -76.4291704)
+	for _, precision := range []int{0, 2, 3, 4} {
+		ref, _ := EncodeGEOREF(p, precision)
+		b, err := DecodeGEOREF(ref)
+		if err != nil {
+			t.Fatalf("Should not encounter an error when decoding %s, but got %v", ref, err)
+		}
+		if !b.Contains(p) {
+			t.Errorf("Expected %s (%v) to contain %v", ref, b, p)
+		}
+	}
+
+	b, _ := DecodeGEOREF("ngaa1530")
+	if math.Abs(b.sw.lat-0.5) > 1e-12 || math.Abs(b.sw.lng-0.25) > 1e-12 {
+		t.Errorf("Expected lower case references to decode, but got %v", b)
+	}
+
+	for _, invalid := range []string{"", "NGA", "NGAA1", "NGAA15", "NIAA", "NGAA6000", "NGAA-130"} {
+		if _, err := DecodeGEOREF(invalid); err == nil {
+			t.Errorf("Expected an error when decoding %q", invalid)
+		}
+	}
+}
+
+// Ensures that points encode to the expected GARS cells.
+func TestEncodeGARS(t *testing.T) {
+	tests := []struct {
+		p         Point
+		precision int
+		expected  string
+	}{
+		{NewPoint(0, 0), 3, "361HN37"},
+		{NewPoint(0.49, 0.49), 3, "361HN23"},
+		{NewPoint(-90, -180), 1, "001AA"},
+		{NewPoint(90, 180), 1, "720QZ"},
+	}
+
+	for _, tt := range tests {
+		got, err := EncodeGARS(tt.p, tt.precision)
+		if err != nil {
+			t.Fatalf("Should not encounter an error when encoding %v, but got %v", tt.p, err)
+		}
+		if got != tt.expected {
+			t.Errorf("Expected %v to encode to %s, but got %s instead", tt.p, tt.expected, got)
+		}
+	}
+
+	if _, err := EncodeGARS(NewPoint(0, 0), 4); err == nil {
+		t.Error("Expected an error when encoding with an unsupported precision")
+	}
+}
+
+// Ensures that GARS cells decode to areas containing the encoded point.
+func TestDecodeGARS(t *testing.T) {
+	p := NewPoint(38.286108, -76.4291704)
+	for precision := 1; precision <= 3; precision++ {
+		ref, _ := EncodeGARS(p, precision)
+		b, err := DecodeGARS(ref)
+		if err != nil {
+			t.Fatalf("Should not encounter an error when decoding %s, but got %v", ref, err)
+		}
+		if !b.Contains(p) {
+			t.Errorf("Expected %s (%v) to contain %v", ref, b, p)
+		}
+	}
+
+	for _, invalid := range []string{"", "361H", "000HN", "721HN", "361HN5", "361HN30", "361RA"} {
+		if _, err := DecodeGARS(invalid); err == nil {
+			t.Errorf("Expected an error when decoding %q", invalid)
+		}
+	}
+}
@@ -0,0 +1,429 @@
+package geo
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// TIFF tags read by the GeoTIFF decoder.
+const (
+	tiffImageWidth      = 256
+	tiffImageLength     = 257
+	tiffBitsPerSample   = 258
+	tiffCompression     = 259
+	tiffStripOffsets    = 273
+	tiffSamplesPerPixel = 277
+	tiffRowsPerStrip    = 278
+	tiffStripByteCounts = 279
+	tiffPredictor       = 317
+	tiffTileWidth       = 322
+	tiffTileLength      = 323
+	tiffTileOffsets     = 324
+	tiffTileByteCounts  = 325
+	tiffSampleFormat    = 339
+	tiffModelPixelScale = 33550
+	tiffModelTiepoint   = 33922
+	tiffGeoKeyDirectory = 34735
+	tiffGDALNoData      = 42113
+)
+
+// GeoTIFF keys read by the GeoTIFF decoder.
+const (
+	geoKeyModelType  = 1024
+	geoKeyRasterType = 1025
+
+	geoModelTypeGeographic = 2
+	geoRasterPixelIsPoint  = 2
+)
+
+// maxDeflateExpansion is the most deflate can expand its input, which bounds the samples a compressed
+// block can hold.
+const maxDeflateExpansion = 1032
+
+// A GeoTIFFElevator is an Elevator backed by single band GeoTIFF elevation rasters
+// in geographic coordinates, such as the GeoTIFF releases of SRTM or Copernicus DEM tiles.
+// Uncompressed and deflate compressed rasters of 16 or 32 bit integers or 32 or 64 bit
+// floats are supported, laid out in strips or tiles.  The rasters are read into memory
+// when the GeoTIFFElevator is opened.  It is safe for concurrent use.
+type GeoTIFFElevator struct {
+	grids []*elevationGrid
+}
+
+var _ Elevator = (*GeoTIFFElevator)(nil)
+
+// OpenGeoTIFFElevator reads the GeoTIFF rasters at the passed in paths and returns
+// a GeoTIFFElevator that looks up each Point in the first raster covering it.
+func OpenGeoTIFFElevator(paths ...string) (*GeoTIFFElevator, error) {
+	grids := make([]*elevationGrid, len(paths))
+	for i, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		grid, err := decodeGeoTIFF(data)
+		if err != nil {
+			return nil, fmt.Errorf("geotiff %s: %w", path, err)
+		}
+		grids[i] = grid
+	}
+	return &GeoTIFFElevator{grids: grids}, nil
+}
+
+// ElevationAt returns the elevation of the terrain at Point p, bilinearly interpolated
+// between the surrounding samples.  It returns an error wrapping ErrOutOfBounds
+// if none of the rasters covers p.
+func (e *GeoTIFFElevator) ElevationAt(ctx context.Context, p Point) (Distance, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	for _, grid := range e.grids {
+		if grid.covers(p) {
+			return grid.elevationAt(p)
+		}
+	}
+	return 0, fmt.Errorf("%w: %v is outside of the elevation data", ErrOutOfBounds, p)
+}
+
+// ElevationsAt returns the elevation of the terrain at each of the passed in points, in order.
+func (e *GeoTIFFElevator) ElevationsAt(ctx context.Context, points []Point) ([]Distance, error) {
+	return elevationsAt(ctx, points, e.ElevationAt)
+}
+
+// tiffReader reads the values of TIFF directory entries.
+type tiffReader struct {
+	data  []byte
+	order binary.ByteOrder
+}
+
+// tiffEntry is an entry of a TIFF image file directory.
+type tiffEntry struct {
+	typ   uint16
+	count uint32
+	value []byte
+}
+
+// tiffTypeSizes holds the size in bytes of each TIFF field type.
+var tiffTypeSizes = map[uint16]int{1: 1, 2: 1, 3: 2, 4: 4, 5: 8, 6: 1, 7: 1, 8: 2, 9: 4, 10: 8, 11: 4, 12: 8}
+
+// ints returns the integer values of an entry.
+func (r *tiffReader) ints(e tiffEntry) ([]int, error) {
+	values := make([]int, e.count)
+	for i := range values {
+		switch e.typ {
+		case 1:
+			values[i] = int(e.value[i])
+		case 3:
+			values[i] = int(r.order.Uint16(e.value[2*i:]))
+		case 4:
+			values[i] = int(r.order.Uint32(e.value[4*i:]))
+		default:
+			return nil, fmt.Errorf("%w: tiff field type %d is not an integer", ErrInvalidFormat, e.typ)
+		}
+	}
+	return values, nil
+}
+
+// floats returns the floating point values of an entry.
+func (r *tiffReader) floats(e tiffEntry) ([]float64, error) {
+	if e.typ != 12 {
+		return nil, fmt.Errorf("%w: tiff field type %d is not a double", ErrInvalidFormat, e.typ)
+	}
+	values := make([]float64, e.count)
+	for i := range values {
+		values[i] = math.Float64frombits(r.order.Uint64(e.value[8*i:]))
+	}
+	return values, nil
+}
+
+// decodeGeoTIFF decodes the first image of a GeoTIFF file into an elevationGrid.
+func decodeGeoTIFF(data []byte) (*elevationGrid, error) {
+	if len(data) < 8 {
+		return nil, fmt.Errorf("%w: truncated tiff header", ErrInvalidFormat)
+	}
+	r := &tiffReader{data: data}
+	switch string(data[:2]) {
+	case "II":
+		r.order = binary.LittleEndian
+	case "MM":
+		r.order = binary.BigEndian
+	default:
+		return nil, fmt.Errorf("%w: not a tiff file", ErrInvalidFormat)
+	}
+	if magic := r.order.Uint16(data[2:]); magic != 42 {
+		return nil, fmt.Errorf("%w: unsupported tiff version %d", ErrInvalidFormat, magic)
+	}
+
+	entries, err := r.directory(int(r.order.Uint32(data[4:])))
+	if err != nil {
+		return nil, err
+	}
+
+	field := func(tag uint16, def int) (int, error) {
+		e, ok := entries[tag]
+		if !ok {
+			return def, nil
+		}
+		values, err := r.ints(e)
+		if err != nil || len(values) == 0 {
+			return 0, fmt.Errorf("%w: invalid tiff tag %d", ErrInvalidFormat, tag)
+		}
+		return values[0], nil
+	}
+
+	var width, height, bits, compression, samplesPerPixel, predictor, format int
+	for _, f := range []struct {
+		v   *int
+		tag uint16
+		def int
+	}{
+		{&width, tiffImageWidth, 0},
+		{&height, tiffImageLength, 0},
+		{&bits, tiffBitsPerSample, 1},
+		{&compression, tiffCompression, 1},
+		{&samplesPerPixel, tiffSamplesPerPixel, 1},
+		{&predictor, tiffPredictor, 1},
+		{&format, tiffSampleFormat, 1},
+	} {
+		if *f.v, err = field(f.tag, f.def); err != nil {
+			return nil, err
+		}
+	}
+
+	if width < 2 || height < 2 {
+		return nil, fmt.Errorf("%w: tiff image of %dx%d samples", ErrInvalidFormat, width, height)
+	}
+	if samplesPerPixel != 1 {
+		return nil, fmt.Errorf("%w: unsupported tiff image with %d bands", ErrInvalidFormat, samplesPerPixel)
+	}
+	if compression != 1 && compression != 8 && compression != 32946 {
+		return nil, fmt.Errorf("%w: unsupported tiff compression %d", ErrInvalidFormat, compression)
+	}
+	if predictor != 1 && predictor != 2 {
+		return nil, fmt.Errorf("%w: unsupported tiff predictor %d", ErrInvalidFormat, predictor)
+	}
+	// Sample formats are 1 for unsigned and 2 for signed integers, and 3 for floats.
+	switch {
+	case (format == 1 || format == 2) && (bits == 16 || bits == 32):
+	case format == 3 && (bits == 32 || bits == 64) && predictor == 1:
+	default:
+		return nil, fmt.Errorf("%w: unsupported tiff samples of %d bits in format %d", ErrInvalidFormat, bits, format)
+	}
+
+	// Strips are decoded as tiles spanning the width of the image.
+	blockWidth, blockHeight := width, height
+	offsetsTag, countsTag := uint16(tiffStripOffsets), uint16(tiffStripByteCounts)
+	if _, tiled := entries[tiffTileOffsets]; tiled {
+		if blockWidth, err = field(tiffTileWidth, 0); err != nil {
+			return nil, err
+		}
+		if blockHeight, err = field(tiffTileLength, 0); err != nil {
+			return nil, err
+		}
+		offsetsTag, countsTag = tiffTileOffsets, tiffTileByteCounts
+	} else if blockHeight, err = field(tiffRowsPerStrip, height); err != nil {
+		return nil, err
+	}
+	if blockWidth <= 0 || blockHeight <= 0 {
+		return nil, fmt.Errorf("%w: tiff blocks of %dx%d samples", ErrInvalidFormat, blockWidth, blockHeight)
+	}
+	blockHeight = min(blockHeight, height)
+
+	offsets, err := r.ints(entries[offsetsTag])
+	if err != nil {
+		return nil, err
+	}
+	counts, err := r.ints(entries[countsTag])
+	if err != nil {
+		return nil, err
+	}
+	across := (width + blockWidth - 1) / blockWidth
+	down := (height + blockHeight - 1) / blockHeight
+	if len(offsets) != across*down || len(counts) != len(offsets) {
+		return nil, fmt.Errorf("%w: tiff image has %d blocks, expected %d", ErrInvalidFormat, len(offsets), across*down)
+	}
+
+	// Check every block against the data before allocating the grid, so that the dimensions in a
+	// corrupt header cannot ask for more samples than the file can hold.
+	size := bits / 8
+	rowBytes := blockWidth * size
+	for b, offset := range offsets {
+		if offset < 0 || counts[b] < 0 || offset+counts[b] > len(data) {
+			return nil, fmt.Errorf("%w: tiff block %d is out of range", ErrInvalidFormat, b)
+		}
+		capacity := counts[b]
+		if compression != 1 {
+			capacity *= maxDeflateExpansion
+		}
+		if rows := min(blockHeight, height-(b/across)*blockHeight); rowBytes > capacity || rows > capacity/rowBytes {
+			return nil, fmt.Errorf("%w: tiff block %d is too short for %dx%d samples", ErrInvalidFormat, b, blockWidth, rows)
+		}
+	}
+
+	grid := &elevationGrid{rows: height, cols: width}
+	if format == 2 && bits == 16 {
+		grid.heights = make([]int16, width*height)
+	} else {
+		grid.samples = make([]float32, width*height)
+	}
+
+	for b, offset := range offsets {
+		top, left := (b/across)*blockHeight, (b%across)*blockWidth
+		rows := min(blockHeight, height-top)
+
+		block := data[offset : offset+counts[b]]
+		if compression != 1 {
+			zr, err := zlib.NewReader(bytes.NewReader(block))
+			if err != nil {
+				return nil, fmt.Errorf("%w: tiff block %d: %v", ErrInvalidFormat, b, err)
+			}
+			if block, err = io.ReadAll(io.LimitReader(zr, int64(rows*rowBytes))); err != nil {
+				return nil, fmt.Errorf("%w: tiff block %d: %v", ErrInvalidFormat, b, err)
+			}
+		}
+		if len(block) < rows*rowBytes {
+			return nil, fmt.Errorf("%w: tiff block %d is truncated", ErrInvalidFormat, b)
+		}
+
+		for y := 0; y < rows; y++ {
+			row := block[y*rowBytes : (y+1)*rowBytes]
+			var prev uint64
+			for x := 0; x < blockWidth; x++ {
+				var raw uint64
+				switch size {
+				case 2:
+					raw = uint64(r.order.Uint16(row[2*x:]))
+				case 4:
+					raw = uint64(r.order.Uint32(row[4*x:]))
+				case 8:
+					raw = r.order.Uint64(row[8*x:])
+				}
+				// The horizontal predictor stores each sample as the difference from the previous one.
+				if predictor == 2 {
+					raw += prev
+					prev = raw
+				}
+				if left+x >= width {
+					continue
+				}
+
+				i := (top+y)*width + left + x
+				switch {
+				case grid.heights != nil:
+					grid.heights[i] = int16(raw)
+				case format == 1 && bits == 16:
+					grid.samples[i] = float32(uint16(raw))
+				case format == 1:
+					grid.samples[i] = float32(uint32(raw))
+				case format == 2:
+					grid.samples[i] = float32(int32(raw))
+				case bits == 32:
+					grid.samples[i] = math.Float32frombits(uint32(raw))
+				default:
+					grid.samples[i] = float32(math.Float64frombits(raw))
+				}
+			}
+		}
+	}
+
+	if err := r.georeference(grid, entries); err != nil {
+		return nil, err
+	}
+	return grid, nil
+}
+
+// directory reads the image file directory at offset.
+func (r *tiffReader) directory(offset int) (map[uint16]tiffEntry, error) {
+	if offset < 8 || offset+2 > len(r.data) {
+		return nil, fmt.Errorf("%w: tiff directory is out of range", ErrInvalidFormat)
+	}
+	n := int(r.order.Uint16(r.data[offset:]))
+	if offset+2+12*n > len(r.data) {
+		return nil, fmt.Errorf("%w: tiff directory is truncated", ErrInvalidFormat)
+	}
+
+	entries := make(map[uint16]tiffEntry, n)
+	for i := 0; i < n; i++ {
+		raw := r.data[offset+2+12*i:]
+		e := tiffEntry{typ: r.order.Uint16(raw[2:]), count: r.order.Uint32(raw[4:])}
+		size, ok := tiffTypeSizes[e.typ]
+		if !ok {
+			continue
+		}
+
+		length := size * int(e.count)
+		if length <= 4 {
+			e.value = raw[8 : 8+length]
+		} else {
+			at := int(r.order.Uint32(raw[8:]))
+			if at < 0 || at+length > len(r.data) {
+				return nil, fmt.Errorf("%w: tiff tag %d is out of range", ErrInvalidFormat, r.order.Uint16(raw))
+			}
+			e.value = r.data[at : at+length]
+		}
+		entries[r.order.Uint16(raw)] = e
+	}
+	return entries, nil
+}
+
+// georeference sets the location of the samples of grid from its GeoTIFF tags.
+func (r *tiffReader) georeference(grid *elevationGrid, entries map[uint16]tiffEntry) error {
+	scale, err := r.floats(entries[tiffModelPixelScale])
+	if err != nil || len(scale) < 2 || scale[0] <= 0 || scale[1] <= 0 {
+		return fmt.Errorf("%w: missing or invalid geotiff pixel scale", ErrInvalidFormat)
+	}
+	tiepoint, err := r.floats(entries[tiffModelTiepoint])
+	if err != nil || len(tiepoint) < 6 {
+		return fmt.Errorf("%w: missing or invalid geotiff tiepoint", ErrInvalidFormat)
+	}
+
+	// Rasters default to PixelIsArea, where the tiepoint marks the corner of a pixel
+	// rather than its center.
+	modelType, rasterType := geoModelTypeGeographic, 1
+	if e, ok := entries[tiffGeoKeyDirectory]; ok {
+		keys, err := r.ints(e)
+		if err != nil {
+			return err
+		}
+		for i := 4; i+3 < len(keys); i += 4 {
+			// Only keys whose value is held inline in the directory are read.
+			if keys[i+1] != 0 {
+				continue
+			}
+			switch keys[i] {
+			case geoKeyModelType:
+				modelType = keys[i+3]
+			case geoKeyRasterType:
+				rasterType = keys[i+3]
+			}
+		}
+	}
+	if modelType != geoModelTypeGeographic {
+		return fmt.Errorf("%w: geotiff model type %d is not geographic", ErrUnknownCRS, modelType)
+	}
+
+	grid.lngStep, grid.latStep = scale[0], scale[1]
+	grid.west = tiepoint[3] - tiepoint[0]*grid.lngStep
+	grid.north = tiepoint[4] + tiepoint[1]*grid.latStep
+	if rasterType != geoRasterPixelIsPoint {
+		grid.west += grid.lngStep / 2
+		grid.north -= grid.latStep / 2
+	}
+
+	if e, ok := entries[tiffGDALNoData]; ok && e.typ == 2 {
+		noData, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimRight(string(e.value), "\x00")), 64)
+		if err != nil {
+			return fmt.Errorf("%w: invalid gdal nodata %q", ErrInvalidFormat, e.value)
+		}
+		grid.noData, grid.hasNoData = float32(noData), true
+	}
+	return nil
+}
@@ -0,0 +1,314 @@
+package geo
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"encoding/binary"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+// testGeoTIFF describes a GeoTIFF raster for encodeTestGeoTIFF.
+type testGeoTIFF struct {
+	order         binary.ByteOrder
+	width, height int
+	bits, format  int
+	// tile is the size of square tiles, or zero for strips of two rows.
+	tile         int
+	deflate      bool
+	predictor    int
+	pixelIsPoint bool
+	noData       string
+	west, north  float64
+	step         float64
+	sample       func(row, col int) float64
+}
+
+// encodeTestGeoTIFF encodes a single band GeoTIFF file.
+func encodeTestGeoTIFF(g testGeoTIFF) []byte {
+	var data bytes.Buffer
+	data.Write(make([]byte, 8))
+
+	blockWidth, blockHeight := g.width, 2
+	if g.tile > 0 {
+		blockWidth, blockHeight = g.tile, g.tile
+	}
+	var offsets, counts []uint32
+	for top := 0; top < g.height; top += blockHeight {
+		for left := 0; left < g.width; left += blockWidth {
+			var block bytes.Buffer
+			for y := top; y < top+blockHeight && (g.tile > 0 || y < g.height); y++ {
+				var prev uint64
+				for x := left; x < left+blockWidth; x++ {
+					var v float64
+					if y < g.height && x < g.width {
+						v = g.sample(y, x)
+					}
+					var raw uint64
+					switch {
+					case g.format == 3 && g.bits == 32:
+						raw = uint64(math.Float32bits(float32(v)))
+					case g.format == 3:
+						raw = math.Float64bits(v)
+					default:
+						raw = uint64(int64(v))
+					}
+					if g.predictor == 2 {
+						raw, prev = raw-prev, raw
+					}
+					b := make([]byte, g.bits/8)
+					switch g.bits {
+					case 16:
+						g.order.PutUint16(b, uint16(raw))
+					case 32:
+						g.order.PutUint32(b, uint32(raw))
+					case 64:
+						g.order.PutUint64(b, raw)
+					}
+					block.Write(b)
+				}
+			}
+
+			raw := block.Bytes()
+			if g.deflate {
+				var compressed bytes.Buffer
+				w := zlib.NewWriter(&compressed)
+				w.Write(raw)
+				w.Close()
+				raw = compressed.Bytes()
+			}
+			offsets = append(offsets, uint32(data.Len()))
+			counts = append(counts, uint32(len(raw)))
+			data.Write(raw)
+		}
+	}
+
+	type entry struct {
+		tag, typ uint16
+		count    int
+		value    []byte
+	}
+	var entries []entry
+	longs := func(tag uint16, values ...uint32) {
+		b := make([]byte, 4*len(values))
+		for i, v := range values {
+			g.order.PutUint32(b[4*i:], v)
+		}
+		entries = append(entries, entry{tag, 4, len(values), b})
+	}
+	shorts := func(tag uint16, values ...uint16) {
+		b := make([]byte, 2*len(values))
+		for i, v := range values {
+			g.order.PutUint16(b[2*i:], v)
+		}
+		entries = append(entries, entry{tag, 3, len(values), b})
+	}
+	doubles := func(tag uint16, values ...float64) {
+		b := make([]byte, 8*len(values))
+		for i, v := range values {
+			g.order.PutUint64(b[8*i:], math.Float64bits(v))
+		}
+		entries = append(entries, entry{tag, 12, len(values), b})
+	}
+
+	compression := uint16(1)
+	if g.deflate {
+		compression = 8
+	}
+	longs(tiffImageWidth, uint32(g.width))
+	longs(tiffImageLength, uint32(g.height))
+	shorts(tiffBitsPerSample, uint16(g.bits))
+	shorts(tiffCompression, compression)
+	shorts(tiffSamplesPerPixel, 1)
+	shorts(tiffSampleFormat, uint16(g.format))
+	if g.predictor != 0 {
+		shorts(tiffPredictor, uint16(g.predictor))
+	}
+	if g.tile > 0 {
+		longs(tiffTileWidth, uint32(g.tile))
+		longs(tiffTileLength, uint32(g.tile))
+		longs(tiffTileOffsets, offsets...)
+		longs(tiffTileByteCounts, counts...)
+	} else {
+		longs(tiffRowsPerStrip, uint32(blockHeight))
+		longs(tiffStripOffsets, offsets...)
+		longs(tiffStripByteCounts, counts...)
+	}
+	doubles(tiffModelPixelScale, g.step, g.step, 0)
+	doubles(tiffModelTiepoint, 0, 0, 0, g.west, g.north, 0)
+	rasterType := uint16(1)
+	if g.pixelIsPoint {
+		rasterType = geoRasterPixelIsPoint
+	}
+	shorts(tiffGeoKeyDirectory, 1, 1, 0, 2, geoKeyModelType, 0, 1, geoModelTypeGeographic, geoKeyRasterType, 0, 1, rasterType)
+	if g.noData != "" {
+		entries = append(entries, entry{tiffGDALNoData, 2, len(g.noData) + 1, append([]byte(g.noData), 0)})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].tag < entries[j].tag })
+
+	ifd := data.Len()
+	extra := ifd + 2 + 12*len(entries) + 4
+	var directory, values bytes.Buffer
+	b := make([]byte, 12)
+	g.order.PutUint16(b, uint16(len(entries)))
+	directory.Write(b[:2])
+	for _, e := range entries {
+		g.order.PutUint16(b, e.tag)
+		g.order.PutUint16(b[2:], e.typ)
+		g.order.PutUint32(b[4:], uint32(e.count))
+		clear(b[8:])
+		if len(e.value) <= 4 {
+			copy(b[8:], e.value)
+		} else {
+			g.order.PutUint32(b[8:], uint32(extra+values.Len()))
+			values.Write(e.value)
+		}
+		directory.Write(b)
+	}
+	directory.Write(make([]byte, 4))
+	data.Write(directory.Bytes())
+	data.Write(values.Bytes())
+
+	out := data.Bytes()
+	if g.order == binary.LittleEndian {
+		copy(out, "II")
+	} else {
+		copy(out, "MM")
+	}
+	g.order.PutUint16(out[2:], 42)
+	g.order.PutUint32(out[4:], uint32(ifd))
+	return out
+}
+
+// Ensures that GeoTIFF rasters in each supported layout decode to the same elevations.
+func TestGeoTIFFElevator(t *testing.T) {
+	// The height of each sample rises by a meter per row southwards and ten per column eastwards.
+	sample := func(row, col int) float64 { return float64(row + 10*col) }
+	base := testGeoTIFF{width: 5, height: 7, west: 151, north: -33, step: 0.1, sample: sample}
+
+	rasters := map[string]testGeoTIFF{}
+	for name, mutate := range map[string]func(g *testGeoTIFF){
+		"int16":        func(g *testGeoTIFF) { g.order, g.bits, g.format = binary.LittleEndian, 16, 2 },
+		"uint16 tiled": func(g *testGeoTIFF) { g.order, g.bits, g.format, g.tile = binary.BigEndian, 16, 1, 4 },
+		"int32 deflate": func(g *testGeoTIFF) {
+			g.order, g.bits, g.format, g.deflate, g.predictor = binary.LittleEndian, 32, 2, true, 2
+		},
+		"float32 deflate": func(g *testGeoTIFF) { g.order, g.bits, g.format, g.deflate, g.tile = binary.BigEndian, 32, 3, true, 2 },
+		"float64":         func(g *testGeoTIFF) { g.order, g.bits, g.format = binary.LittleEndian, 64, 3 },
+	} {
+		g := base
+		mutate(&g)
+		rasters[name] = g
+	}
+
+	ctx := context.Background()
+	for name, g := range rasters {
+		path := filepath.Join(t.TempDir(), "dem.tif")
+		if err := os.WriteFile(path, encodeTestGeoTIFF(g), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		e, err := OpenGeoTIFFElevator(path)
+		if err != nil {
+			t.Errorf("Expected the %s raster to open, but got %v", name, err)
+			continue
+		}
+
+		// The tiepoint marks the corner of the top left pixel, whose center lies half a step inside it.
+		tests := []struct {
+			p        Point
+			expected Distance
+		}{
+			{NewPoint(-33.05, 151.05), 0},
+			{NewPoint(-33.15, 151.25), 21},
+			{NewPoint(-33.2, 151.1), 6.5},
+		}
+		for _, tt := range tests {
+			if elevation, err := e.ElevationAt(ctx, tt.p); err != nil || math.Abs(float64(elevation-tt.expected)) > 1e-4 {
+				t.Errorf("Expected %v at %v in the %s raster, but got %v, %v", tt.expected, tt.p, name, elevation, err)
+			}
+		}
+		if _, err := e.ElevationAt(ctx, NewPoint(-33.01, 151.01)); !errors.Is(err, ErrOutOfBounds) {
+			t.Errorf("Expected the edge of the %s raster to return ErrOutOfBounds, but got %v", name, err)
+		}
+	}
+}
+
+// Ensures that GeoTIFF rasters honor PixelIsPoint and GDAL nodata values.
+func TestGeoTIFFPixelIsPointAndNoData(t *testing.T) {
+	g := testGeoTIFF{
+		order: binary.LittleEndian, width: 3, height: 3, bits: 32, format: 3,
+		pixelIsPoint: true, noData: "-9999", west: 10, north: 50, step: 0.5,
+		sample: func(row, col int) float64 {
+			if row == 2 && col == 2 {
+				return -9999
+			}
+			return 100
+		},
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "dem.tif"), encodeTestGeoTIFF(g), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	e, err := OpenGeoTIFFElevator(filepath.Join(dir, "dem.tif"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	elevations, err := e.ElevationsAt(ctx, []Point{NewPoint(50, 10), NewPoint(49.25, 10.25)})
+	if err != nil || elevations[0] != 100 || elevations[1] != 100 {
+		t.Errorf("Expected [100 100], but got %v, %v", elevations, err)
+	}
+	if _, err := e.ElevationAt(ctx, NewPoint(49.25, 10.75)); !errors.Is(err, ErrNoResults) {
+		t.Errorf("Expected a nodata sample to return ErrNoResults, but got %v", err)
+	}
+}
+
+// Ensures that image dimensions larger than the data can hold are rejected before any samples are allocated.
+func TestGeoTIFFOversized(t *testing.T) {
+	for _, deflate := range []bool{false, true} {
+		data := encodeTestGeoTIFF(testGeoTIFF{
+			order: binary.LittleEndian, width: 2, height: 2, bits: 16, format: 2, deflate: deflate, step: 1,
+			sample: func(row, col int) float64 { return 0 },
+		})
+		// Claim a single strip of 2^30 rows.
+		setTestTIFFTag(data, tiffImageLength, 1<<30)
+		setTestTIFFTag(data, tiffRowsPerStrip, 1<<30)
+
+		if _, err := decodeGeoTIFF(data); !errors.Is(err, ErrInvalidFormat) {
+			t.Errorf("Expected ErrInvalidFormat for an oversized image with deflate %v, but got %v", deflate, err)
+		}
+	}
+}
+
+// setTestTIFFTag overwrites the inline value of a tag in the directory of a file encoded by encodeTestGeoTIFF.
+func setTestTIFFTag(data []byte, tag uint16, value uint32) {
+	order := binary.LittleEndian
+	ifd := int(order.Uint32(data[4:]))
+	for i := 0; i < int(order.Uint16(data[ifd:])); i++ {
+		e := data[ifd+2+12*i:]
+		if order.Uint16(e) == tag {
+			order.PutUint32(e[8:], value)
+		}
+	}
+}
+
+// Ensures that files which are not GeoTIFF rasters fail to open.
+func TestGeoTIFFInvalid(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "dem.tif")
+	if err := os.WriteFile(path, []byte("not a tiff"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OpenGeoTIFFElevator(path); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("Expected ErrInvalidFormat, but got %v", err)
+	}
+	if _, err := OpenGeoTIFFElevator(filepath.Join(dir, "missing.tif")); err == nil {
+		t.Error("Expected a missing file to fail to open")
+	}
+}
@@ -1,3 +1,3 @@
 module github.com/smarteaston/golang-geo
 
-go 1.19
+go 1.23
@@ -0,0 +1,151 @@
+package geo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// GoogleGeocodeURL is the endpoint of the Google Maps Geocoding API.
+const GoogleGeocodeURL = "https://maps.googleapis.com/maps/api/geocode/json"
+
+// GoogleGeocoderOptions configures a GoogleGeocoder.
+type GoogleGeocoderOptions struct {
+	// Components restricts forward geocoding to results matching every component filter,
+	// keyed by component type, such as {"country": "AU", "postal_code": "2000"}.
+	Components map[string]string
+	// Language is the language results are returned in, such as "en".  Defaults to the service's choice.
+	Language string
+	// BaseURL replaces GoogleGeocodeURL, for example to point at a proxy.
+	BaseURL string
+	// Client sends the requests.  Defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+// A GoogleGeocoder is a Geocoder backed by the Google Maps Geocoding API.
+// It is safe for concurrent use.
+type GoogleGeocoder struct {
+	apiKey string
+	opts   GoogleGeocoderOptions
+}
+
+var _ Geocoder = (*GoogleGeocoder)(nil)
+
+// NewGoogleGeocoder returns a new GoogleGeocoder that authenticates with the passed in API key.
+func NewGoogleGeocoder(apiKey string, opts GoogleGeocoderOptions) *GoogleGeocoder {
+	if opts.BaseURL == "" {
+		opts.BaseURL = GoogleGeocodeURL
+	}
+	return &GoogleGeocoder{apiKey: apiKey, opts: opts}
+}
+
+type googleGeocodeResponse struct {
+	Status       string `json:"status"`
+	ErrorMessage string `json:"error_message"`
+	Results      []struct {
+		FormattedAddress  string `json:"formatted_address"`
+		AddressComponents []struct {
+			LongName  string   `json:"long_name"`
+			ShortName string   `json:"short_name"`
+			Types     []string `json:"types"`
+		} `json:"address_components"`
+		Geometry struct {
+			Location struct {
+				Lat float64 `json:"lat"`
+				Lng float64 `json:"lng"`
+			} `json:"location"`
+		} `json:"geometry"`
+	} `json:"results"`
+}
+
+// Geocode returns the Point of Google's best match for address.
+func (g *GoogleGeocoder) Geocode(ctx context.Context, address string) (Point, error) {
+	query := url.Values{"address": {address}}
+	if len(g.opts.Components) > 0 {
+		filters := make([]string, 0, len(g.opts.Components))
+		for component, value := range g.opts.Components {
+			filters = append(filters, component+":"+value)
+		}
+		sort.Strings(filters)
+		query.Set("components", strings.Join(filters, "|"))
+	}
+
+	resp, err := g.request(ctx, query)
+	if err != nil {
+		return Point{}, err
+	}
+
+	location := resp.Results[0].Geometry.Location
+	return NewPoint(location.Lat, location.Lng), nil
+}
+
+// ReverseGeocode returns the Address of Google's best match for Point p.
+func (g *GoogleGeocoder) ReverseGeocode(ctx context.Context, p Point) (Address, error) {
+	latlng := strconv.FormatFloat(p.lat, 'f', -1, 64) + "," + strconv.FormatFloat(p.lng, 'f', -1, 64)
+	resp, err := g.request(ctx, url.Values{"latlng": {latlng}})
+	if err != nil {
+		return Address{}, err
+	}
+
+	result := resp.Results[0]
+	address := Address{Formatted: result.FormattedAddress}
+	for _, c := range result.AddressComponents {
+		for _, t := range c.Types {
+			switch t {
+			case "street_number":
+				address.HouseNumber = c.LongName
+			case "route":
+				address.Street = c.LongName
+			case "locality", "postal_town":
+				address.Locality = c.LongName
+			case "administrative_area_level_1":
+				address.Region = c.LongName
+			case "postal_code":
+				address.PostalCode = c.LongName
+			case "country":
+				address.Country, address.CountryCode = c.LongName, c.ShortName
+			}
+		}
+	}
+
+	return address, nil
+}
+
+// request queries the geocoding API and checks the status of its reply,
+// which is only returned without error if it holds at least one result.
+func (g *GoogleGeocoder) request(ctx context.Context, query url.Values) (*googleGeocodeResponse, error) {
+	u, err := url.Parse(g.opts.BaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("google geocoder: %w", err)
+	}
+	query.Set("key", g.apiKey)
+	if g.opts.Language != "" {
+		query.Set("language", g.opts.Language)
+	}
+	u.RawQuery = query.Encode()
+
+	var resp googleGeocodeResponse
+	if err := getJSON(ctx, g.opts.Client, "google geocoder", u, &resp); err != nil {
+		return nil, err
+	}
+
+	switch resp.Status {
+	case "OK":
+		if len(resp.Results) == 0 {
+			return nil, fmt.Errorf("%w: google geocoder", ErrNoResults)
+		}
+		return &resp, nil
+	case "ZERO_RESULTS":
+		return nil, fmt.Errorf("%w: google geocoder", ErrNoResults)
+	case "OVER_QUERY_LIMIT", "OVER_DAILY_LIMIT":
+		return nil, fmt.Errorf("%w: google geocoder: %s", ErrRateLimited, resp.ErrorMessage)
+	case "UNKNOWN_ERROR":
+		return nil, fmt.Errorf("%w: google geocoder: %s", ErrServiceUnavailable, resp.ErrorMessage)
+	default:
+		return nil, fmt.Errorf("google geocoder: %s: %s", resp.Status, resp.ErrorMessage)
+	}
+}
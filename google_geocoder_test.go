@@ -0,0 +1,84 @@
+package geo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Ensures that the Google geocoder sends the API key and component filters and decodes the first result.
+func TestGoogleGeocoder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("key") != "secret" {
+			t.Errorf("Expected the API key to be sent, but got %q", q.Get("key"))
+		}
+
+		switch {
+		case q.Get("address") == "nowhere":
+			w.Write([]byte(`{"status": "ZERO_RESULTS", "results": []}`))
+		case q.Get("address") == "busy":
+			w.Write([]byte(`{"status": "OVER_QUERY_LIMIT", "error_message": "slow down", "results": []}`))
+		case q.Get("address") != "":
+			if q.Get("components") != "country:AU|postal_code:2000" {
+				t.Errorf("Expected sorted component filters, but got %q", q.Get("components"))
+			}
+			w.Write([]byte(`{"status": "OK", "results": [{"geometry": {"location": {"lat": -33.8688, "lng": 151.2093}}}]}`))
+		case q.Get("latlng") == "-33.8688,151.2093":
+			w.Write([]byte(`{"status": "OK", "results": [{
+				"formatted_address": "1 Macquarie St, Sydney NSW 2000, Australia",
+				"address_components": [
+					{"long_name": "1", "short_name": "1", "types": ["street_number"]},
+					{"long_name": "Macquarie Street", "short_name": "Macquarie St", "types": ["route"]},
+					{"long_name": "Sydney", "short_name": "Sydney", "types": ["locality", "political"]},
+					{"long_name": "New South Wales", "short_name": "NSW", "types": ["administrative_area_level_1", "political"]},
+					{"long_name": "Australia", "short_name": "AU", "types": ["country", "political"]},
+					{"long_name": "2000", "short_name": "2000", "types": ["postal_code"]}
+				]}]}`))
+		default:
+			w.Write([]byte(`{"status": "REQUEST_DENIED", "error_message": "bad request", "results": []}`))
+		}
+	}))
+	defer server.Close()
+
+	g := NewGoogleGeocoder("secret", GoogleGeocoderOptions{
+		Components: map[string]string{"postal_code": "2000", "country": "AU"},
+		BaseURL:    server.URL,
+		Client:     server.Client(),
+	})
+	ctx := context.Background()
+
+	p, err := g.Geocode(ctx, "1 Macquarie St")
+	if err != nil || p != NewPoint(-33.8688, 151.2093) {
+		t.Errorf("Expected -33.8688,151.2093, but got %v, %v", p, err)
+	}
+
+	address, err := g.ReverseGeocode(ctx, NewPoint(-33.8688, 151.2093))
+	expected := Address{
+		Formatted:   "1 Macquarie St, Sydney NSW 2000, Australia",
+		HouseNumber: "1",
+		Street:      "Macquarie Street",
+		Locality:    "Sydney",
+		Region:      "New South Wales",
+		PostalCode:  "2000",
+		Country:     "Australia",
+		CountryCode: "AU",
+	}
+	if err != nil || address != expected {
+		t.Errorf("Expected %+v, but got %+v, %v", expected, address, err)
+	}
+
+	if _, err := g.Geocode(ctx, "nowhere"); !errors.Is(err, ErrNoResults) {
+		t.Errorf("Expected ErrNoResults, but got %v", err)
+	}
+
+	if _, err := g.Geocode(ctx, "busy"); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Expected ErrRateLimited, but got %v", err)
+	}
+
+	if _, err := g.ReverseGeocode(ctx, NewPoint(0, 0)); err == nil {
+		t.Error("Expected a denied request to fail")
+	}
+}
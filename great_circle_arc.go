@@ -0,0 +1,53 @@
+package geo
+
+import "math"
+
+// GreatCircleArc returns the great circle route from a to b as a LineString of segments no longer than
+// maxSegment, for drawing flight paths, which on a map curve towards the pole rather than following the
+// straight line between a and b.  A maxSegment of zero joins a and b directly.  Where the route crosses
+// the antimeridian it gains a Point on either side of it, at longitudes 180 and -180, so that
+// SplitAntimeridian can split it there.  The route between antipodal points is undefined.
+func GreatCircleArc(a Point, b Point, maxSegment Distance) LineString {
+	segments := 1
+	if maxSegment > 0 {
+		segments = max(int(math.Ceil(float64(a.GreatCircleDistance(b)/maxSegment))), 1)
+	}
+
+	points := []Point{a}
+	for i := 1; i <= segments; i++ {
+		p := b
+		if i < segments {
+			p = intermediatePoint(a, b, float64(i)/float64(segments))
+		}
+		if prev := points[len(points)-1]; math.Abs(p.lng-prev.lng) > 180 {
+			lat := antimeridianLatitude(prev, p)
+			points = append(points, NewPoint(lat, math.Copysign(180, prev.lng)), NewPoint(lat, math.Copysign(180, p.lng)))
+		}
+		points = append(points, p)
+	}
+	return NewLineString(points)
+}
+
+// antimeridianLatitude returns the latitude at which the great circle through p1 and p2 crosses the
+// antimeridian, which must lie between them.
+func antimeridianLatitude(p1 Point, p2 Point) float64 {
+	lat1, lng1 := p1.lat*math.Pi/180, p1.lng*math.Pi/180
+	lat2, lng2 := p2.lat*math.Pi/180, p2.lng*math.Pi/180
+	num := math.Sin(lat1)*math.Cos(lat2)*math.Sin(math.Pi-lng2) - math.Sin(lat2)*math.Cos(lat1)*math.Sin(math.Pi-lng1)
+	return math.Atan(num/(math.Cos(lat1)*math.Cos(lat2)*math.Sin(lng1-lng2))) * 180 / math.Pi
+}
+
+// SplitAntimeridian splits the LineString where consecutive points lie more than 180 degrees of longitude
+// apart, the edge between them taking the short way across the antimeridian, so that every part can be
+// drawn on a map without spanning it.  Parts keep the CRS of the LineString.
+func (l LineString) SplitAntimeridian() []LineString {
+	var parts []LineString
+	start := 0
+	for i := 1; i < len(l.points); i++ {
+		if math.Abs(l.points[i].lng-l.points[i-1].lng) > 180 {
+			parts = append(parts, LineString{points: l.points[start:i], crs: l.crs})
+			start = i
+		}
+	}
+	return append(parts, LineString{points: l.points[start:], crs: l.crs})
+}
@@ -0,0 +1,63 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+// Ensures that an arc follows the great circle in segments no longer than asked for.
+func TestGreatCircleArc(t *testing.T) {
+	london, newYork := NewPoint(51.47, -0.45), NewPoint(40.64, -73.78)
+	arc := GreatCircleArc(london, newYork, 100*Kilometer)
+	points := arc.Points()
+	if points[0] != london || points[len(points)-1] != newYork {
+		t.Fatalf("Expected the arc to run from %v to %v, but got %v", london, newYork, points)
+	}
+
+	var length Distance
+	north := 0.0
+	for i := 1; i < len(points); i++ {
+		d := points[i-1].GreatCircleDistance(points[i])
+		if d > 100*Kilometer {
+			t.Errorf("Expected segments of at most 100km, but got %v", d)
+		}
+		length += d
+		north = math.Max(north, points[i].lat)
+	}
+	if total := london.GreatCircleDistance(newYork); math.Abs(float64(length-total)) > 1 {
+		t.Errorf("Expected the arc to be %v long, but got %v", total, length)
+	}
+	if north < 52 {
+		t.Errorf("Expected the arc to curve north of London, but got %v", north)
+	}
+
+	if arc := GreatCircleArc(london, newYork, 0); len(arc.Points()) != 2 {
+		t.Errorf("Expected a direct arc, but got %v", arc.Points())
+	}
+}
+
+// Ensures that an arc across the antimeridian meets it on either side and splits there.
+func TestGreatCircleArcAntimeridian(t *testing.T) {
+	tokyo, sanFrancisco := NewPoint(35.55, 139.78), NewPoint(37.62, -122.38)
+	arc := GreatCircleArc(tokyo, sanFrancisco, 200*Kilometer)
+
+	parts := arc.SplitAntimeridian()
+	if len(parts) != 2 {
+		t.Fatalf("Expected the arc split in 2, but got %v", parts)
+	}
+	west, east := parts[0].Points(), parts[1].Points()
+	last, first := west[len(west)-1], east[0]
+	if last.lng != 180 || first.lng != -180 || last.lat != first.lat {
+		t.Errorf("Expected the parts to meet at the antimeridian, but got %v and %v", last, first)
+	}
+
+	// The crossing lies on the great circle, as far from both ends as the points either side of it.
+	d := tokyo.GreatCircleDistance(last) + last.GreatCircleDistance(sanFrancisco)
+	if total := tokyo.GreatCircleDistance(sanFrancisco); math.Abs(float64(d-total)) > 1 {
+		t.Errorf("Expected the crossing at %v on the great circle", last)
+	}
+
+	if parts := GreatCircleArc(tokyo, NewPoint(0, 100), 0).SplitAntimeridian(); len(parts) != 1 {
+		t.Errorf("Expected an arc clear of the antimeridian in 1 part, but got %v", parts)
+	}
+}
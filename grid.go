@@ -0,0 +1,110 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+)
+
+// A Grid is a raster of values over a BoundingBox, such as a heatmap or an interpolated surface.
+// Its cells span equal steps of latitude and longitude, in rows from north to south and columns
+// from west to east, and each value stands for the center of its cell.  Cells without a value
+// hold NaN.  A Grid is not safe for concurrent modification.
+type Grid struct {
+	bounds     BoundingBox
+	rows, cols int
+	values     []float64
+}
+
+// NewGrid returns a new Grid of rows by cols zero valued cells over bounds.
+// rows and cols are raised to at least 1.
+func NewGrid(bounds BoundingBox, rows int, cols int) *Grid {
+	rows, cols = max(rows, 1), max(cols, 1)
+	return &Grid{bounds: bounds, rows: rows, cols: cols, values: make([]float64, rows*cols)}
+}
+
+// Bounds returns the BoundingBox covered by the Grid.
+func (g *Grid) Bounds() BoundingBox {
+	return g.bounds
+}
+
+// Rows returns the number of rows of the Grid.
+func (g *Grid) Rows() int {
+	return g.rows
+}
+
+// Cols returns the number of columns of the Grid.
+func (g *Grid) Cols() int {
+	return g.cols
+}
+
+// At returns the value of the cell at row and col.  It panics if the cell is out of range.
+func (g *Grid) At(row int, col int) float64 {
+	return g.values[g.index(row, col)]
+}
+
+// Set sets the value of the cell at row and col.  It panics if the cell is out of range.
+func (g *Grid) Set(row int, col int, v float64) {
+	g.values[g.index(row, col)] = v
+}
+
+func (g *Grid) index(row int, col int) int {
+	if row < 0 || row >= g.rows || col < 0 || col >= g.cols {
+		panic(fmt.Sprintf("geo: cell %d,%d out of range of %dx%d grid", row, col, g.rows, g.cols))
+	}
+	return row*g.cols + col
+}
+
+// cellSize returns the span of a cell in degrees of latitude and longitude.
+func (g *Grid) cellSize() (dLat float64, dLng float64) {
+	width := g.bounds.ne.lng - g.bounds.sw.lng
+	if g.bounds.CrossesAntimeridian() {
+		width += 360
+	}
+	return (g.bounds.ne.lat - g.bounds.sw.lat) / float64(g.rows), width / float64(g.cols)
+}
+
+// CellCenter returns the center of the cell at row and col.
+func (g *Grid) CellCenter(row int, col int) Point {
+	return g.gridPoint(float64(row), float64(col))
+}
+
+// gridPoint returns the Point at the fractional row and column y and x, counted between cell centers.
+func (g *Grid) gridPoint(y float64, x float64) Point {
+	dLat, dLng := g.cellSize()
+	return NewPoint(g.bounds.ne.lat-(y+0.5)*dLat, NormalizeLng(g.bounds.sw.lng+(x+0.5)*dLng))
+}
+
+// Cell returns the row and column of the cell containing Point p, and false if p lies outside the Grid.
+func (g *Grid) Cell(p Point) (row int, col int, ok bool) {
+	if !g.bounds.Contains(p) {
+		return 0, 0, false
+	}
+	dLat, dLng := g.cellSize()
+	x := p.lng - g.bounds.sw.lng
+	if x < 0 {
+		x += 360
+	}
+	row = min(int((g.bounds.ne.lat-p.lat)/dLat), g.rows-1)
+	col = min(int(x/dLng), g.cols-1)
+	return row, col, true
+}
+
+// Matrix returns a copy of the values of the Grid, by row from north to south.
+func (g *Grid) Matrix() [][]float64 {
+	m := make([][]float64, g.rows)
+	for r := range m {
+		m[r] = append([]float64(nil), g.values[r*g.cols:(r+1)*g.cols]...)
+	}
+	return m
+}
+
+// Max returns the largest value of the Grid, ignoring NaN cells, or NaN if every cell is NaN.
+func (g *Grid) Max() float64 {
+	largest := math.NaN()
+	for _, v := range g.values {
+		if !math.IsNaN(v) && !(v <= largest) {
+			largest = v
+		}
+	}
+	return largest
+}
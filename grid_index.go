@@ -0,0 +1,155 @@
+package geo
+
+import "math"
+
+// A GridIndex is a spatial hash that files points into fixed size cells of latitude and longitude.
+// Inserts, moves and removals take constant time, which makes it faster than the trees for
+// uniformly spread points that update often.  Points are identified by keys of type K.
+// It is not safe for concurrent modification.
+type GridIndex[K comparable] struct {
+	cellSize float64
+	columns  int
+	cells    map[gridCell]map[K]Point
+	points   map[K]gridCell
+}
+
+type gridCell struct {
+	row, col int
+}
+
+// NewGridIndex returns an empty GridIndex whose cells span cellSize degrees in each direction.
+// Cells should be about the size of typical queries; cellSize is clamped to (0, 180].
+func NewGridIndex[K comparable](cellSize float64) *GridIndex[K] {
+	if !(cellSize > 0) {
+		cellSize = 1
+	}
+	cellSize = math.Min(cellSize, 180)
+
+	return &GridIndex[K]{
+		cellSize: cellSize,
+		columns:  int(math.Ceil(360 / cellSize)),
+		cells:    make(map[gridCell]map[K]Point),
+		points:   make(map[K]gridCell),
+	}
+}
+
+// Len returns the number of points in the index.
+func (g *GridIndex[K]) Len() int {
+	return len(g.points)
+}
+
+// cell returns the cell containing Point p.
+func (g *GridIndex[K]) cell(p Point) gridCell {
+	return gridCell{
+		row: int(math.Floor((ClampLat(p.lat) + 90) / g.cellSize)),
+		col: int(math.Floor((NormalizeLng(p.lng)+180)/g.cellSize)) % g.columns,
+	}
+}
+
+// Set files the point identified by key at Point p, moving it if it was already in the index.
+func (g *GridIndex[K]) Set(key K, p Point) {
+	c := g.cell(p)
+	if old, ok := g.points[key]; ok && old != c {
+		g.removeFromCell(key, old)
+	}
+
+	if g.cells[c] == nil {
+		g.cells[c] = make(map[K]Point)
+	}
+	g.cells[c][key] = p
+	g.points[key] = c
+}
+
+// Get returns the location of the point identified by key.
+func (g *GridIndex[K]) Get(key K) (Point, bool) {
+	c, ok := g.points[key]
+	if !ok {
+		return Point{}, false
+	}
+	return g.cells[c][key], true
+}
+
+// Remove deletes the point identified by key, reporting whether it was in the index.
+func (g *GridIndex[K]) Remove(key K) bool {
+	c, ok := g.points[key]
+	if !ok {
+		return false
+	}
+
+	g.removeFromCell(key, c)
+	delete(g.points, key)
+	return true
+}
+
+func (g *GridIndex[K]) removeFromCell(key K, c gridCell) {
+	delete(g.cells[c], key)
+	if len(g.cells[c]) == 0 {
+		delete(g.cells, c)
+	}
+}
+
+// Neighborhood returns the keys of the points in the cell containing Point p
+// and in the rings of cells around it, in no particular order.
+func (g *GridIndex[K]) Neighborhood(p Point, rings int) []K {
+	center := g.cell(p)
+	cols := min(2*rings+1, g.columns)
+
+	var keys []K
+	for row := center.row - rings; row <= center.row+rings; row++ {
+		for i := 0; i < cols; i++ {
+			col := ((center.col-rings+i)%g.columns + g.columns) % g.columns
+			for key := range g.cells[gridCell{row: row, col: col}] {
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys
+}
+
+// Search returns the keys of the points inside BoundingBox b, in no particular order.
+func (g *GridIndex[K]) Search(b BoundingBox) []K {
+	var keys []K
+	g.search(b, func(key K, p Point) {
+		keys = append(keys, key)
+	})
+	return keys
+}
+
+// Within returns the keys of the points within radius of Point p, in no particular order.
+func (g *GridIndex[K]) Within(p Point, radius Distance) []K {
+	var keys []K
+	g.search(BoundsAround(p, radius), func(key K, q Point) {
+		if p.GreatCircleDistance(q) <= radius {
+			keys = append(keys, key)
+		}
+	})
+	return keys
+}
+
+func (g *GridIndex[K]) search(b BoundingBox, visit func(key K, p Point)) {
+	minRow, maxRow := g.cell(b.sw).row, g.cell(b.ne).row
+
+	// Columns wrap as they do in cell, which files longitude 180 with -180, so the intervals of a box
+	// can reach the same column twice.
+	searched := make(map[int]bool)
+	for _, interval := range b.lngIntervals() {
+		minCol := int(math.Floor((interval[0] + 180) / g.cellSize))
+		maxCol := int(math.Floor((interval[1] + 180) / g.cellSize))
+
+		for c := minCol; c <= maxCol; c++ {
+			col := c % g.columns
+			if searched[col] {
+				continue
+			}
+			searched[col] = true
+
+			for row := minRow; row <= maxRow; row++ {
+				for key, p := range g.cells[gridCell{row: row, col: col}] {
+					if b.Contains(p) {
+						visit(key, p)
+					}
+				}
+			}
+		}
+	}
+}
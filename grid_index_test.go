@@ -0,0 +1,107 @@
+package geo
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// Ensures that grid queries match a brute force scan as points move and are removed.
+func TestGridIndex(t *testing.T) {
+	r := rand.New(rand.NewSource(6))
+	g := NewGridIndex[int](2.5)
+
+	points := make(map[int]Point)
+	for i := 0; i < 2000; i++ {
+		points[i] = NewPoint(r.Float64()*180-90, r.Float64()*360-180)
+		g.Set(i, points[i])
+	}
+
+	// Move and remove some of the points.
+	for i := 0; i < 2000; i += 7 {
+		points[i] = NewPoint(r.Float64()*20-10, r.Float64()*20+170).Normalized()
+		g.Set(i, points[i])
+	}
+	for i := 3; i < 2000; i += 11 {
+		if !g.Remove(i) {
+			t.Fatalf("Expected to remove point %d", i)
+		}
+		delete(points, i)
+	}
+
+	if g.Len() != len(points) || g.Remove(3) {
+		t.Errorf("Expected %d points, but got %d", len(points), g.Len())
+	}
+
+	if p, ok := g.Get(7); !ok || p != points[7] {
+		t.Errorf("Expected point 7 to be at %v, but got %v", points[7], p)
+	}
+
+	box := NewBoundingBox(NewPoint(-10, 175), NewPoint(10, -175))
+	center := NewPoint(0, 179)
+	radius := 800 * Kilometer
+
+	var inBox, inRadius []int
+	for key, p := range points {
+		if box.Contains(p) {
+			inBox = append(inBox, key)
+		}
+		if center.GreatCircleDistance(p) <= radius {
+			inRadius = append(inRadius, key)
+		}
+	}
+
+	if found := g.Search(box); !sameInts(found, inBox) {
+		t.Errorf("Expected %d points in %v, but got %d", len(inBox), box, len(found))
+	}
+
+	if found := g.Within(center, radius); !sameInts(found, inRadius) {
+		t.Errorf("Expected %d points within %v of %v, but got %d", len(inRadius), radius, center, len(found))
+	}
+
+	for _, key := range g.Neighborhood(center, 1) {
+		if p := points[key]; p.lat < -2.5 || p.lat >= 5 || (p.lng < 175 && p.lng >= -177.5) {
+			t.Errorf("Expected %v to be outside of the neighborhood of %v", p, center)
+		}
+	}
+}
+
+// Ensures that points on the antimeridian, which share their cells with longitude -180, are found.
+func TestGridIndexAntimeridian(t *testing.T) {
+	g := NewGridIndex[int](1)
+	g.Set(1, NewPoint(10, 180))
+	g.Set(2, NewPoint(10, -180))
+
+	tests := []struct {
+		b        BoundingBox
+		expected []int
+	}{
+		{NewBoundingBox(NewPoint(9, 179.5), NewPoint(11, 180)), []int{1}},
+		{NewBoundingBox(NewPoint(9, -180), NewPoint(11, -179.5)), []int{2}},
+		{NewBoundingBox(NewPoint(9, 179.5), NewPoint(11, -179.5)), []int{1, 2}},
+		{NewBoundingBox(NewPoint(9, -180), NewPoint(11, 180)), []int{1, 2}},
+	}
+	for _, tt := range tests {
+		if found := g.Search(tt.b); !sameInts(found, tt.expected) {
+			t.Errorf("Expected %v in %v, but got %v", tt.expected, tt.b, found)
+		}
+	}
+
+	if found := g.Within(NewPoint(10, 179.9), 50*Kilometer); !sameInts(found, []int{1, 2}) {
+		t.Errorf("Expected both points near the antimeridian, but got %v", found)
+	}
+}
+
+func sameInts(a, b []int) bool {
+	sort.Ints(a)
+	sort.Ints(b)
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
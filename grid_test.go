@@ -0,0 +1,73 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+// Ensures that points map to the cells whose centers lie closest to them, including across the antimeridian.
+func TestGridCells(t *testing.T) {
+	tests := []struct {
+		bounds BoundingBox
+	}{
+		{NewBoundingBox(NewPoint(10, 20), NewPoint(14, 28))},
+		{NewBoundingBox(NewPoint(10, 176), NewPoint(14, -176))},
+	}
+	for _, tt := range tests {
+		g := NewGrid(tt.bounds, 4, 8)
+		if g.Rows() != 4 || g.Cols() != 8 || g.Bounds() != tt.bounds {
+			t.Fatalf("Unexpected grid %dx%d over %v", g.Rows(), g.Cols(), g.Bounds())
+		}
+		for row := range 4 {
+			for col := range 8 {
+				c := g.CellCenter(row, col)
+				if math.Abs(c.lat-(13.5-float64(row))) > 1e-9 {
+					t.Errorf("Expected row %d centered on latitude %v, but got %v", row, 13.5-float64(row), c)
+				}
+				if r, cc, ok := g.Cell(c); !ok || r != row || cc != col {
+					t.Errorf("Expected %v in cell %d,%d, but got %d,%d", c, row, col, r, cc)
+				}
+			}
+		}
+		// The corners belong to the cells at the corners.
+		if r, c, ok := g.Cell(tt.bounds.SouthWest()); !ok || r != 3 || c != 0 {
+			t.Errorf("Expected the south-west corner in cell 3,0, but got %d,%d", r, c)
+		}
+		if r, c, ok := g.Cell(tt.bounds.NorthEast()); !ok || r != 0 || c != 7 {
+			t.Errorf("Expected the north-east corner in cell 0,7, but got %d,%d", r, c)
+		}
+		if _, _, ok := g.Cell(NewPoint(0, 0)); ok {
+			t.Errorf("Expected no cell outside the grid")
+		}
+	}
+}
+
+// Ensures that values are set by cell and exported by row, and that NaN cells are ignored by Max.
+func TestGridValues(t *testing.T) {
+	g := NewGrid(NewBoundingBox(NewPoint(0, 0), NewPoint(1, 1)), 2, 3)
+	if empty := NewGrid(g.Bounds(), 0, 0); empty.Rows() != 1 || empty.Cols() != 1 {
+		t.Errorf("Expected a grid of at least one cell")
+	}
+	g.Set(0, 1, 5)
+	g.Set(1, 2, math.NaN())
+	g.Set(1, 0, -2)
+
+	m := g.Matrix()
+	if len(m) != 2 || len(m[0]) != 3 || m[0][1] != 5 || m[1][0] != -2 || !math.IsNaN(m[1][2]) {
+		t.Errorf("Unexpected matrix %v", m)
+	}
+	m[0][1] = 7
+	if g.At(0, 1) != 5 {
+		t.Errorf("Expected the matrix to be a copy")
+	}
+	if g.Max() != 5 {
+		t.Errorf("Expected a maximum of 5, but got %v", g.Max())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected a panic for a cell out of range")
+		}
+	}()
+	g.At(2, 0)
+}
@@ -0,0 +1,123 @@
+package geo
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// MaxH3Resolution is the finest resolution supported by H3.
+const MaxH3Resolution = 15
+
+// H3Cell is a 64 bit H3 cell index.
+type H3Cell uint64
+
+// ParseH3Cell parses the hexadecimal string form of an H3 cell index.
+func ParseH3Cell(s string) (H3Cell, error) {
+	v, err := strconv.ParseUint(s, 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid H3 cell %q: %v", ErrInvalidFormat, s, err)
+	}
+
+	return H3Cell(v), nil
+}
+
+// String returns the hexadecimal form of the cell index, e.g. "8928308280fffff".
+func (c H3Cell) String() string {
+	return strconv.FormatUint(uint64(c), 16)
+}
+
+// Resolution returns the resolution encoded in the cell index.
+func (c H3Cell) Resolution() int {
+	return int(c>>52) & 0xf
+}
+
+// H3Indexer computes H3 cells.  This package does not embed the H3 library itself;
+// implementations usually wrap the official bindings, e.g. github.com/uber/h3-go.
+type H3Indexer interface {
+	// LatLngToCell returns the cell containing p at the passed in resolution.
+	LatLngToCell(p Point, resolution int) (H3Cell, error)
+	// CellToLatLng returns the center of the cell.
+	CellToLatLng(c H3Cell) (Point, error)
+	// CellToBoundary returns the vertices of the cell in counter-clockwise order.
+	CellToBoundary(c H3Cell) ([]Point, error)
+	// GridDisk returns every cell within k grid steps of c, including c itself.
+	GridDisk(c H3Cell, k int) ([]H3Cell, error)
+}
+
+// H3CellAt returns the cell containing Point p at the passed in resolution.
+func H3CellAt(h H3Indexer, p Point, resolution int) (H3Cell, error) {
+	if resolution < 0 || resolution > MaxH3Resolution {
+		return 0, fmt.Errorf("%w: H3 resolution %d out of range [0, %d]", ErrInvalidPrecision, resolution, MaxH3Resolution)
+	}
+
+	return h.LatLngToCell(p, resolution)
+}
+
+// H3CellPolygon returns the boundary of the cell as a Polygon.
+func H3CellPolygon(h H3Indexer, c H3Cell) (Polygon, error) {
+	boundary, err := h.CellToBoundary(c)
+	if err != nil {
+		return Polygon{}, err
+	}
+
+	return NewPolygon(boundary), nil
+}
+
+// H3Polyfill returns the cells of the passed in resolution whose centers lie within Polygon p.
+// The search floods outwards from the cells of the polygon's vertices and stops at cells
+// lying entirely outside of the polygon's bounding box.
+func H3Polyfill(h H3Indexer, p Polygon, resolution int) ([]H3Cell, error) {
+	if !p.IsClosed() {
+		return nil, fmt.Errorf("%w: cannot polyfill a polygon with %d points", ErrUnclosedPolygon, len(p.points))
+	}
+
+	bounds := p.Bounds()
+	visited := make(map[H3Cell]bool)
+	var queue []H3Cell
+	for _, point := range p.points {
+		c, err := H3CellAt(h, point, resolution)
+		if err != nil {
+			return nil, err
+		}
+		if !visited[c] {
+			visited[c] = true
+			queue = append(queue, c)
+		}
+	}
+
+	var cells []H3Cell
+	for len(queue) > 0 {
+		c := queue[0]
+		queue = queue[1:]
+
+		center, err := h.CellToLatLng(c)
+		if err != nil {
+			return nil, err
+		}
+		if p.Contains(center) {
+			cells = append(cells, c)
+		}
+
+		boundary, err := h.CellToBoundary(c)
+		if err != nil {
+			return nil, err
+		}
+		if !pointsBounds(boundary).Intersects(bounds) {
+			continue
+		}
+
+		neighbors, err := h.GridDisk(c, 1)
+		if err != nil {
+			return nil, err
+		}
+		for _, n := range neighbors {
+			if !visited[n] {
+				visited[n] = true
+				queue = append(queue, n)
+			}
+		}
+	}
+
+	debugf("geo: polyfilled %d points at resolution %d with %d cells after visiting %d", len(p.points), resolution, len(cells), len(visited))
+	return cells, nil
+}
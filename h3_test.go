@@ -0,0 +1,116 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+// A fake H3Indexer over a square grid whose cells are 1/2^resolution degrees wide.
+type squareIndexer struct{}
+
+const squareOffset = 1 << 24
+
+func (squareIndexer) size(res int) float64 {
+	return 1 / math.Exp2(float64(res))
+}
+
+func (s squareIndexer) LatLngToCell(p Point, res int) (H3Cell, error) {
+	row := int64(math.Floor(p.lat/s.size(res))) + squareOffset
+	col := int64(math.Floor(p.lng/s.size(res))) + squareOffset
+	return H3Cell(uint64(res)<<52 | uint64(row)<<26 | uint64(col)), nil
+}
+
+func (s squareIndexer) cell(c H3Cell) (res int, row int64, col int64) {
+	return c.Resolution(), int64(c>>26&(1<<26-1)) - squareOffset, int64(c&(1<<26-1)) - squareOffset
+}
+
+func (s squareIndexer) CellToLatLng(c H3Cell) (Point, error) {
+	res, row, col := s.cell(c)
+	size := s.size(res)
+	return NewPoint((float64(row)+0.5)*size, (float64(col)+0.5)*size), nil
+}
+
+func (s squareIndexer) CellToBoundary(c H3Cell) ([]Point, error) {
+	res, row, col := s.cell(c)
+	size := s.size(res)
+	lat, lng := float64(row)*size, float64(col)*size
+	return []Point{
+		NewPoint(lat, lng), NewPoint(lat, lng+size), NewPoint(lat+size, lng+size), NewPoint(lat+size, lng),
+	}, nil
+}
+
+func (s squareIndexer) GridDisk(c H3Cell, k int) ([]H3Cell, error) {
+	res, row, col := s.cell(c)
+	var cells []H3Cell
+	for dr := -k; dr <= k; dr++ {
+		for dc := -k; dc <= k; dc++ {
+			r, cl := row+int64(dr)+squareOffset, col+int64(dc)+squareOffset
+			cells = append(cells, H3Cell(uint64(res)<<52|uint64(r)<<26|uint64(cl)))
+		}
+	}
+	return cells, nil
+}
+
+// Ensures that H3 cell indexes round trip through their string form.
+func TestH3CellString(t *testing.T) {
+	c, err := ParseH3Cell("8928308280fffff")
+	if err != nil {
+		t.Fatalf("Should not encounter an error when parsing a cell, but got %v", err)
+	}
+
+	if c.String() != "8928308280fffff" {
+		t.Errorf("Expected the cell to round trip, but got %s instead", c)
+	}
+
+	if c.Resolution() != 9 {
+		t.Errorf("Expected the cell to be at resolution 9, but got %d instead", c.Resolution())
+	}
+
+	if _, err := ParseH3Cell("not-a-cell"); err == nil {
+		t.Error("Expected an error when parsing an invalid cell")
+	}
+}
+
+// Ensures that out of range resolutions are rejected before reaching the indexer.
+func TestH3CellAt(t *testing.T) {
+	if _, err := H3CellAt(squareIndexer{}, NewPoint(0, 0), 16); err == nil {
+		t.Error("Expected an error for resolution 16")
+	}
+
+	c, err := H3CellAt(squareIndexer{}, NewPoint(0.3, 0.6), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	poly, err := H3CellPolygon(squareIndexer{}, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !poly.Contains(NewPoint(0.3, 0.6)) {
+		t.Errorf("Expected the cell boundary %v to contain the indexed point", poly.Points())
+	}
+}
+
+// Ensures that a polyfill returns every cell whose center lies in the polygon.
+func TestH3Polyfill(t *testing.T) {
+	square := NewPolygon([]Point{NewPoint(0, 0), NewPoint(0, 1), NewPoint(1, 1), NewPoint(1, 0)})
+	cells, err := H3Polyfill(squareIndexer{}, square, 2)
+	if err != nil {
+		t.Fatalf("Should not encounter an error when filling a polygon, but got %v", err)
+	}
+
+	if len(cells) != 16 {
+		t.Errorf("Expected 16 cells to fill the unit square at resolution 2, but got %d instead", len(cells))
+	}
+
+	triangle := NewPolygon([]Point{NewPoint(0, 0), NewPoint(0, 0.9), NewPoint(0.9, 0)})
+	cells, _ = H3Polyfill(squareIndexer{}, triangle, 2)
+	if len(cells) != 6 {
+		t.Errorf("Expected 6 cells to fill the triangle at resolution 2, but got %d instead", len(cells))
+	}
+
+	if _, err := H3Polyfill(squareIndexer{}, NewPolygon(nil), 2); err == nil {
+		t.Error("Expected an error when filling an empty polygon")
+	}
+}
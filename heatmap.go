@@ -0,0 +1,87 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+)
+
+// HeatmapOptions configures Heatmap.
+type HeatmapOptions struct {
+	// Weights holds the weight of each point, such as the number of incidents at an address.
+	// Without weights, every point weighs 1.
+	Weights []float64
+	// Bandwidth is the standard deviation of the Gaussian kernel each point is spread over.
+	// Without one, each point only counts towards the cell it falls in.
+	Bandwidth Distance
+	// Mask limits the heatmap to the cells whose centers it contains, such as the Polygon of a
+	// city; the others are set to NaN.
+	Mask Geofence
+}
+
+// Heatmap returns a Grid of rows by cols cells over bounds holding the intensity of points.
+// Without a Bandwidth, the intensity of a cell is the total weight of the points inside it.
+// With one, it is the kernel density estimate at the center of the cell: the total weight of
+// the points per square kilometer, each spread over a Gaussian kernel, which gives a smooth
+// surface independent of the size of the cells.  Points further than three bandwidths from a
+// cell are ignored.  It returns an error wrapping ErrInvalidFormat unless there is one weight
+// for each point.
+func Heatmap(points []Point, bounds BoundingBox, rows int, cols int, opts HeatmapOptions) (*Grid, error) {
+	if opts.Weights != nil && len(opts.Weights) != len(points) {
+		return nil, fmt.Errorf("%w: %d weights for %d points", ErrInvalidFormat, len(opts.Weights), len(points))
+	}
+	weight := func(i int) float64 {
+		if opts.Weights == nil {
+			return 1
+		}
+		return opts.Weights[i]
+	}
+
+	g := NewGrid(bounds, rows, cols)
+	if opts.Bandwidth <= 0 {
+		for i, p := range points {
+			if row, col, ok := g.Cell(p); ok {
+				g.values[row*g.cols+col] += weight(i)
+			}
+		}
+	} else {
+		h := opts.Bandwidth.Kilometers()
+		norm := 1 / (2 * math.Pi * h * h)
+		centers := make([]Point, len(g.values))
+		for i := range centers {
+			centers[i] = g.CellCenter(i/g.cols, i%g.cols)
+		}
+
+		for i, p := range points {
+			// Visit the cells within reach of the kernel of p.
+			reach := BoundsAround(p, 3*opts.Bandwidth)
+			first, last := g.rowRange(reach)
+			for row := first; row <= last; row++ {
+				for col := range g.cols {
+					c := centers[row*g.cols+col]
+					if !reach.Contains(c) {
+						continue
+					}
+					d := p.GreatCircleDistance(c).Kilometers()
+					g.values[row*g.cols+col] += weight(i) * norm * math.Exp(-d*d/(2*h*h))
+				}
+			}
+		}
+	}
+
+	if opts.Mask != nil {
+		for i := range g.values {
+			if !opts.Mask.Contains(g.CellCenter(i/g.cols, i%g.cols)) {
+				g.values[i] = math.NaN()
+			}
+		}
+	}
+	return g, nil
+}
+
+// rowRange returns the first and last rows of the Grid whose centers may lie within the latitudes of b.
+func (g *Grid) rowRange(b BoundingBox) (first int, last int) {
+	dLat, _ := g.cellSize()
+	first = max(int(math.Floor((g.bounds.ne.lat-b.ne.lat)/dLat-0.5)), 0)
+	last = min(int(math.Ceil((g.bounds.ne.lat-b.sw.lat)/dLat-0.5)), g.rows-1)
+	return first, last
+}
@@ -0,0 +1,65 @@
+package geo
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// Ensures that without a bandwidth, points are binned into the cells they fall in by weight.
+func TestHeatmapBins(t *testing.T) {
+	bounds := NewBoundingBox(NewPoint(0, 0), NewPoint(2, 2))
+	points := []Point{NewPoint(0.5, 0.5), NewPoint(0.6, 0.4), NewPoint(1.5, 1.5), NewPoint(5, 5)}
+
+	g, err := Heatmap(points, bounds, 2, 2, HeatmapOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m := g.Matrix(); m[0][0] != 0 || m[0][1] != 1 || m[1][0] != 2 || m[1][1] != 0 {
+		t.Errorf("Unexpected counts %v", m)
+	}
+
+	g, _ = Heatmap(points, bounds, 2, 2, HeatmapOptions{Weights: []float64{1, 2, 3, 4}})
+	if g.At(1, 0) != 3 || g.At(0, 1) != 3 {
+		t.Errorf("Unexpected weights %v", g.Matrix())
+	}
+
+	if _, err := Heatmap(points, bounds, 2, 2, HeatmapOptions{Weights: []float64{1}}); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("Expected ErrInvalidFormat, but got %v", err)
+	}
+}
+
+// Ensures that kernel density estimates integrate to the total weight and respect the mask.
+func TestHeatmapDensity(t *testing.T) {
+	bounds := NewBoundingBox(NewPoint(-0.1, -0.1), NewPoint(0.1, 0.1))
+	points := []Point{NewPoint(0, 0), NewPoint(0.01, 0.01)}
+
+	g, err := Heatmap(points, bounds, 100, 100, HeatmapOptions{Bandwidth: 1 * Kilometer, Weights: []float64{1, 3}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Each cell is about 0.2224 km square.
+	cell := NewPoint(0, 0).GreatCircleDistance(NewPoint(0, 0.002)).Kilometers()
+	var total float64
+	for _, row := range g.Matrix() {
+		for _, v := range row {
+			total += v * cell * cell
+		}
+	}
+	if math.Abs(total-4) > 0.05 {
+		t.Errorf("Expected the density to integrate to 4, but got %v", total)
+	}
+	for row := range g.Rows() {
+		for col := range g.Cols() {
+			if g.At(row, col) == g.Max() && g.CellCenter(row, col).GreatCircleDistance(NewPoint(0.01, 0.01)) > 200*Meter {
+				t.Errorf("Expected the peak at the heavier point, but got %v", g.CellCenter(row, col))
+			}
+		}
+	}
+
+	mask := NewCircle(NewPoint(0, 0), 5*Kilometer)
+	g, _ = Heatmap(points, bounds, 100, 100, HeatmapOptions{Bandwidth: 1 * Kilometer, Mask: mask})
+	if !math.IsNaN(g.At(0, 0)) || math.IsNaN(g.At(50, 50)) {
+		t.Errorf("Expected cells outside the mask to be NaN")
+	}
+}
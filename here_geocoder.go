@@ -0,0 +1,124 @@
+package geo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Endpoints of the HERE Geocoding and Search API.
+const (
+	HEREGeocodeURL        = "https://geocode.search.hereapi.com/v1/geocode"
+	HEREReverseGeocodeURL = "https://revgeocode.search.hereapi.com/v1/revgeocode"
+)
+
+// HEREGeocoderOptions configures a HEREGeocoder.
+type HEREGeocoderOptions struct {
+	// Language is the language results are returned in, such as "en-US".  Defaults to the service's choice.
+	Language string
+	// BaseURL replaces both HERE endpoints, for example to point at a proxy.
+	// Requests are sent to BaseURL + "/geocode" and BaseURL + "/revgeocode".
+	BaseURL string
+	// Client sends the requests.  Defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+// A HEREGeocoder is a Geocoder backed by the HERE Geocoding and Search API.
+// HERE identifies countries by their three letter ISO 3166 codes, which it returns in Address.CountryCode.
+// It is safe for concurrent use.
+type HEREGeocoder struct {
+	apiKey string
+	opts   HEREGeocoderOptions
+}
+
+var _ Geocoder = (*HEREGeocoder)(nil)
+
+// NewHEREGeocoder returns a new HEREGeocoder that authenticates with the passed in API key.
+func NewHEREGeocoder(apiKey string, opts HEREGeocoderOptions) *HEREGeocoder {
+	return &HEREGeocoder{apiKey: apiKey, opts: opts}
+}
+
+type hereGeocodeResponse struct {
+	Items []struct {
+		Title    string `json:"title"`
+		Position struct {
+			Lat float64 `json:"lat"`
+			Lng float64 `json:"lng"`
+		} `json:"position"`
+		Address struct {
+			Label       string `json:"label"`
+			HouseNumber string `json:"houseNumber"`
+			Street      string `json:"street"`
+			City        string `json:"city"`
+			State       string `json:"state"`
+			PostalCode  string `json:"postalCode"`
+			CountryName string `json:"countryName"`
+			CountryCode string `json:"countryCode"`
+		} `json:"address"`
+	} `json:"items"`
+}
+
+// Geocode returns the Point of HERE's best match for address.
+func (g *HEREGeocoder) Geocode(ctx context.Context, address string) (Point, error) {
+	resp, err := g.request(ctx, HEREGeocodeURL, "/geocode", url.Values{"q": {address}})
+	if err != nil {
+		return Point{}, err
+	}
+
+	position := resp.Items[0].Position
+	return NewPoint(position.Lat, position.Lng), nil
+}
+
+// ReverseGeocode returns the Address of HERE's best match for Point p.
+func (g *HEREGeocoder) ReverseGeocode(ctx context.Context, p Point) (Address, error) {
+	at := strconv.FormatFloat(p.lat, 'f', -1, 64) + "," + strconv.FormatFloat(p.lng, 'f', -1, 64)
+	resp, err := g.request(ctx, HEREReverseGeocodeURL, "/revgeocode", url.Values{"at": {at}})
+	if err != nil {
+		return Address{}, err
+	}
+
+	a := resp.Items[0].Address
+	return Address{
+		Formatted:   a.Label,
+		HouseNumber: a.HouseNumber,
+		Street:      a.Street,
+		Locality:    a.City,
+		Region:      a.State,
+		PostalCode:  a.PostalCode,
+		Country:     a.CountryName,
+		CountryCode: a.CountryCode,
+	}, nil
+}
+
+// request queries endpoint, or path under the configured BaseURL, and only returns
+// its reply without error if it holds at least one item.
+func (g *HEREGeocoder) request(ctx context.Context, endpoint string, path string, query url.Values) (*hereGeocodeResponse, error) {
+	if g.opts.BaseURL != "" {
+		endpoint = strings.TrimSuffix(g.opts.BaseURL, "/") + path
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("here geocoder: %w", err)
+	}
+	query.Set("apiKey", g.apiKey)
+	query.Set("limit", "1")
+	if g.opts.Language != "" {
+		query.Set("lang", g.opts.Language)
+	}
+	u.RawQuery = query.Encode()
+
+	var resp hereGeocodeResponse
+	if err := getJSON(ctx, g.opts.Client, "here geocoder", u, &resp); err != nil {
+		return nil, err
+	}
+
+	if len(resp.Items) == 0 {
+		return nil, fmt.Errorf("%w: here geocoder", ErrNoResults)
+	}
+
+	return &resp, nil
+}
@@ -0,0 +1,65 @@
+package geo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Ensures that the HERE geocoder queries both endpoints and decodes the first item.
+func TestHEREGeocoder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("apiKey") != "secret" {
+			t.Errorf("Expected the API key to be sent, but got %q", q.Get("apiKey"))
+		}
+
+		switch {
+		case r.URL.Path == "/geocode" && q.Get("q") == "1 Macquarie St":
+			w.Write([]byte(`{"items": [{"position": {"lat": -33.8688, "lng": 151.2093}}]}`))
+		case r.URL.Path == "/revgeocode" && q.Get("at") == "-33.8688,151.2093":
+			w.Write([]byte(`{"items": [{"address": {
+				"label": "1 Macquarie St, Sydney NSW 2000, Australia",
+				"houseNumber": "1",
+				"street": "Macquarie St",
+				"city": "Sydney",
+				"state": "New South Wales",
+				"postalCode": "2000",
+				"countryName": "Australia",
+				"countryCode": "AUS"
+			}}]}`))
+		default:
+			w.Write([]byte(`{"items": []}`))
+		}
+	}))
+	defer server.Close()
+
+	g := NewHEREGeocoder("secret", HEREGeocoderOptions{BaseURL: server.URL, Client: server.Client()})
+	ctx := context.Background()
+
+	p, err := g.Geocode(ctx, "1 Macquarie St")
+	if err != nil || p != NewPoint(-33.8688, 151.2093) {
+		t.Errorf("Expected -33.8688,151.2093, but got %v, %v", p, err)
+	}
+
+	address, err := g.ReverseGeocode(ctx, NewPoint(-33.8688, 151.2093))
+	expected := Address{
+		Formatted:   "1 Macquarie St, Sydney NSW 2000, Australia",
+		HouseNumber: "1",
+		Street:      "Macquarie St",
+		Locality:    "Sydney",
+		Region:      "New South Wales",
+		PostalCode:  "2000",
+		Country:     "Australia",
+		CountryCode: "AUS",
+	}
+	if err != nil || address != expected {
+		t.Errorf("Expected %+v, but got %+v, %v", expected, address, err)
+	}
+
+	if _, err := g.Geocode(ctx, "nowhere"); !errors.Is(err, ErrNoResults) {
+		t.Errorf("Expected ErrNoResults, but got %v", err)
+	}
+}
@@ -0,0 +1,73 @@
+package geo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// getJSON sends a GET request for u with client and decodes the JSON reply into v.
+func getJSON(ctx context.Context, client *http.Client, service string, u *url.URL, v interface{}) error {
+	return fetchJSON(ctx, client, service, http.MethodGet, u, nil, v)
+}
+
+// postJSON sends body encoded as JSON in a POST request for u with client and decodes the JSON reply into v.
+func postJSON(ctx context.Context, client *http.Client, service string, u *url.URL, body interface{}, v interface{}) error {
+	return fetchJSON(ctx, client, service, http.MethodPost, u, body, v)
+}
+
+// fetchJSON sends a request for u with client, encoding body as JSON unless it is nil,
+// and decodes the JSON reply into v.  The service name, such as "google geocoder", prefixes
+// any error.  Replies with a 429 status wrap ErrRateLimited and replies with a 5xx status
+// wrap ErrServiceUnavailable.  A nil client selects http.DefaultClient.
+func fetchJSON(ctx context.Context, client *http.Client, service string, method string, u *url.URL, body interface{}, v interface{}) (err error) {
+	done := timeRemoteRequest(service)
+	defer func() { done(err) }()
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	var payload io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("%s: %w", service, err)
+		}
+		payload = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), payload)
+	if err != nil {
+		return fmt.Errorf("%s: %w", service, err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", service, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return fmt.Errorf("%w: %s: %s", ErrRateLimited, service, resp.Status)
+	case resp.StatusCode >= 500:
+		return fmt.Errorf("%w: %s: %s", ErrServiceUnavailable, service, resp.Status)
+	case resp.StatusCode != http.StatusOK:
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s: %s: %s", service, resp.Status, data)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrUnexpectedReply, service, err)
+	}
+
+	return nil
+}
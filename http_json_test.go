@@ -0,0 +1,67 @@
+package geo
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// Ensures that unsuccessful HTTP statuses map onto the package's geocoding errors.
+func TestGetJSONStatus(t *testing.T) {
+	tests := []struct {
+		status   int
+		body     string
+		expected error
+	}{
+		{http.StatusTooManyRequests, "", ErrRateLimited},
+		{http.StatusBadGateway, "", ErrServiceUnavailable},
+		{http.StatusOK, "not json", ErrUnexpectedReply},
+	}
+
+	for _, tt := range tests {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tt.status)
+			w.Write([]byte(tt.body))
+		}))
+
+		u, _ := url.Parse(server.URL)
+		var v struct{}
+		if err := getJSON(context.Background(), server.Client(), "test", u, &v); !errors.Is(err, tt.expected) {
+			t.Errorf("Expected status %d to return %v, but got %v", tt.status, tt.expected, err)
+		}
+		server.Close()
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "denied", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	var v struct{}
+	err := getJSON(context.Background(), server.Client(), "test", u, &v)
+	if err == nil || errors.Is(err, ErrRateLimited) || errors.Is(err, ErrServiceUnavailable) {
+		t.Errorf("Expected a plain error for a forbidden request, but got %v", err)
+	}
+}
+
+// Ensures that postJSON sends its body as JSON and decodes the reply.
+func TestPostJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Expected a JSON POST, but got %s %s", r.Method, r.Header.Get("Content-Type"))
+		}
+		io.Copy(w, r.Body)
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	var reply map[string]int
+	if err := postJSON(context.Background(), server.Client(), "test", u, map[string]int{"a": 1}, &reply); err != nil || reply["a"] != 1 {
+		t.Errorf("Expected the body to be echoed back, but got %v, %v", reply, err)
+	}
+}
@@ -0,0 +1,166 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+)
+
+// InterpolationMethod is how an Interpolator estimates values between samples.
+type InterpolationMethod int
+
+// Methods of interpolation.
+const (
+	// InterpolateIDW weights the samples by the inverse of a power of their distance.
+	InterpolateIDW InterpolationMethod = iota
+	// InterpolateNearest takes the value of the nearest sample.
+	InterpolateNearest
+	// InterpolateLinear interpolates linearly within the triangles of the Delaunay triangulation of
+	// the samples, and has no value outside their convex hull.
+	InterpolateLinear
+)
+
+// DefaultIDWPower is the InterpolatorOptions.Power used when none is configured.
+const DefaultIDWPower = 2
+
+// InterpolatorOptions configures an Interpolator.
+type InterpolatorOptions struct {
+	// Method is how values are estimated between samples.  Defaults to InterpolateIDW.
+	Method InterpolationMethod
+	// Power is the power of the distance samples are weighted by the inverse of with InterpolateIDW.
+	// Higher powers give more weight to the nearest samples.  Defaults to DefaultIDWPower.
+	Power float64
+	// Neighbors limits InterpolateIDW to the nearest samples.  Zero uses every sample.
+	Neighbors int
+	// Radius limits InterpolateIDW and InterpolateNearest to samples within it, leaving points
+	// further from every sample without a value.  Zero places no limit.
+	Radius Distance
+}
+
+// An Interpolator estimates a surface from values sampled at points, such as readings of a network
+// of air quality sensors.  Linear interpolation works on the local tangent plane at the center of the
+// samples, which suits networks up to a few hundred kilometers across.
+// It is immutable once built and safe for concurrent use.
+type Interpolator struct {
+	samples []Point
+	values  []float64
+	opts    InterpolatorOptions
+	tree    *KDTree
+
+	// frame, xs and ys place the samples on the plane the triangles lie in, and index holds the
+	// bounds of the triangles, for InterpolateLinear.
+	frame     LocalFrame
+	xs, ys    []float64
+	triangles []delaunayTriangle
+	index     *RTree[indexedBounds]
+}
+
+// NewInterpolator returns an Interpolator of the values sampled at the point of the same index.
+// It returns an error wrapping ErrInvalidFormat unless there is one value for each sample.
+func NewInterpolator(samples []Point, values []float64, opts InterpolatorOptions) (*Interpolator, error) {
+	if len(values) != len(samples) {
+		return nil, fmt.Errorf("%w: %d values for %d samples", ErrInvalidFormat, len(values), len(samples))
+	}
+	if opts.Power <= 0 {
+		opts.Power = DefaultIDWPower
+	}
+
+	ip := &Interpolator{samples: samples, values: values, opts: opts, tree: NewKDTree(samples)}
+	if opts.Method == InterpolateLinear {
+		ip.frame = NewLocalFrame(MeanCenter(samples), 0)
+		ip.xs, ip.ys = make([]float64, len(samples)), make([]float64, len(samples))
+		for i, p := range samples {
+			ip.xs[i], ip.ys[i], _ = ip.frame.ToENU(p, 0)
+		}
+		ip.triangles = delaunay(ip.xs, ip.ys)
+		bounds := make([]BoundingBox, len(ip.triangles))
+		for i, t := range ip.triangles {
+			bounds[i] = pointsBounds([]Point{samples[t[0]], samples[t[1]], samples[t[2]]})
+		}
+		ip.index = newIndexRTree(bounds)
+	}
+	return ip, nil
+}
+
+// At returns the estimated value at Point p, or NaN where the Interpolator has none.
+func (ip *Interpolator) At(p Point) float64 {
+	switch ip.opts.Method {
+	case InterpolateNearest:
+		nearest := ip.tree.Nearest(p, 1)
+		if len(nearest) == 0 || ip.opts.Radius > 0 && nearest[0].Distance > ip.opts.Radius {
+			return math.NaN()
+		}
+		return ip.values[nearest[0].Index]
+	case InterpolateLinear:
+		return ip.linear(p)
+	default:
+		return ip.idw(p)
+	}
+}
+
+// idw returns the inverse distance weighted mean of the samples around Point p.
+func (ip *Interpolator) idw(p Point) float64 {
+	var neighbors []KDTreeResult
+	switch {
+	case ip.opts.Neighbors > 0:
+		neighbors = ip.tree.Nearest(p, ip.opts.Neighbors)
+	case ip.opts.Radius > 0:
+		neighbors = ip.tree.Within(p, ip.opts.Radius)
+	default:
+		neighbors = make([]KDTreeResult, len(ip.samples))
+		for i, s := range ip.samples {
+			neighbors[i] = KDTreeResult{Index: i, Point: s, Distance: p.GreatCircleDistance(s)}
+		}
+	}
+
+	var sum, weights float64
+	for _, n := range neighbors {
+		if ip.opts.Radius > 0 && n.Distance > ip.opts.Radius {
+			continue
+		}
+		if n.Distance == 0 {
+			return ip.values[n.Index]
+		}
+		w := 1 / math.Pow(n.Distance.Meters(), ip.opts.Power)
+		sum += w * ip.values[n.Index]
+		weights += w
+	}
+	if weights == 0 {
+		return math.NaN()
+	}
+	return sum / weights
+}
+
+// linear returns the value at Point p interpolated within the triangle around it.
+func (ip *Interpolator) linear(p Point) float64 {
+	x, y, _ := ip.frame.ToENU(p, 0)
+	value := math.NaN()
+	ip.index.SearchFunc(p.Bounds(), func(item indexedBounds) bool {
+		t := ip.triangles[item.i]
+		area := cross(ip.xs, ip.ys, t)
+		if area == 0 {
+			return true
+		}
+
+		// The barycentric coordinates of p weigh the values at the corners.
+		var weights [3]float64
+		for k := range 3 {
+			a, b := t[(k+1)%3], t[(k+2)%3]
+			weights[k] = ((ip.xs[b]-ip.xs[a])*(y-ip.ys[a]) - (ip.ys[b]-ip.ys[a])*(x-ip.xs[a])) / area
+			if weights[k] < -1e-9 {
+				return true
+			}
+		}
+		value = weights[0]*ip.values[t[0]] + weights[1]*ip.values[t[1]] + weights[2]*ip.values[t[2]]
+		return false
+	})
+	return value
+}
+
+// Grid returns a Grid of rows by cols cells over bounds holding the values estimated at their centers.
+func (ip *Interpolator) Grid(bounds BoundingBox, rows int, cols int) *Grid {
+	g := NewGrid(bounds, rows, cols)
+	for i := range g.values {
+		g.values[i] = ip.At(g.CellCenter(i/g.cols, i%g.cols))
+	}
+	return g
+}
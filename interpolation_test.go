@@ -0,0 +1,75 @@
+package geo
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// Ensures that every method reproduces the samples and estimates values between them.
+func TestInterpolator(t *testing.T) {
+	samples := []Point{NewPoint(0, 0), NewPoint(0, 0.01), NewPoint(0.01, 0.01), NewPoint(0.01, 0)}
+	values := []float64{0, 10, 20, 10}
+	middle := NewPoint(0.005, 0.005)
+
+	tests := []struct {
+		opts     InterpolatorOptions
+		at       Point
+		expected float64
+	}{
+		{InterpolatorOptions{}, samples[2], 20},
+		{InterpolatorOptions{}, middle, 10},
+		{InterpolatorOptions{Method: InterpolateNearest}, NewPoint(0.009, 0.008), 20},
+		{InterpolatorOptions{Method: InterpolateLinear}, samples[1], 10},
+		{InterpolatorOptions{Method: InterpolateLinear}, middle, 10},
+		{InterpolatorOptions{Method: InterpolateLinear}, NewPoint(0.0025, 0.005), 7.5},
+		{InterpolatorOptions{Method: InterpolateLinear}, NewPoint(0.02, 0.005), math.NaN()},
+		{InterpolatorOptions{Radius: 100 * Meter}, middle, math.NaN()},
+		{InterpolatorOptions{Method: InterpolateNearest, Radius: 100 * Meter}, middle, math.NaN()},
+		{InterpolatorOptions{Neighbors: 1}, NewPoint(0.001, 0.001), 0},
+	}
+	for _, tt := range tests {
+		ip, err := NewInterpolator(samples, values, tt.opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		v := ip.At(tt.at)
+		if math.IsNaN(tt.expected) != math.IsNaN(v) || math.Abs(v-tt.expected) > 0.01 {
+			t.Errorf("Expected %v at %v with %+v, but got %v", tt.expected, tt.at, tt.opts, v)
+		}
+	}
+
+	// A nearer sample weighs more with a higher power.
+	near := NewPoint(0.001, 0.001)
+	low, _ := NewInterpolator(samples, values, InterpolatorOptions{Power: 1})
+	high, _ := NewInterpolator(samples, values, InterpolatorOptions{Power: 4})
+	if low.At(near) <= high.At(near) {
+		t.Errorf("Expected %v above %v", low.At(near), high.At(near))
+	}
+
+	if _, err := NewInterpolator(samples, values[:3], InterpolatorOptions{}); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("Expected ErrInvalidFormat, but got %v", err)
+	}
+}
+
+// Ensures that a grid holds the values estimated at the centers of its cells.
+func TestInterpolatorGrid(t *testing.T) {
+	samples := []Point{NewPoint(0, 0), NewPoint(0, 1), NewPoint(1, 1), NewPoint(1, 0)}
+	ip, err := NewInterpolator(samples, []float64{1, 2, 3, 4}, InterpolatorOptions{Method: InterpolateLinear})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g := ip.Grid(NewBoundingBox(NewPoint(0, 0), NewPoint(2, 1)), 4, 2)
+	for row := range g.Rows() {
+		for col := range g.Cols() {
+			expected := ip.At(g.CellCenter(row, col))
+			if v := g.At(row, col); v != expected && !(math.IsNaN(v) && math.IsNaN(expected)) {
+				t.Errorf("Expected %v at cell %d,%d, but got %v", expected, row, col, v)
+			}
+		}
+	}
+	if math.IsNaN(g.At(0, 0)) == math.IsNaN(g.At(3, 0)) {
+		t.Errorf("Expected values only inside the samples, but got %v", g.Matrix())
+	}
+}
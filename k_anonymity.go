@@ -0,0 +1,53 @@
+package geo
+
+import "sort"
+
+// A GeohashCount is the number of points falling in a geohash cell.
+type GeohashCount struct {
+	Geohash string
+	Bounds  BoundingBox
+	Count   int
+}
+
+// KAnonymize counts points by geohash cell such that every count is of at least k points, for publishing
+// heatmaps without singling anyone out.  Cells start at precision characters, and the points of those
+// holding fewer than k are merged with those of their sparse neighbors into the cell one character
+// shorter, repeatedly, so cells are only as coarse as the data around them needs.  Cells may therefore
+// nest: the count of a cell leaves out the points of the finer cells returned within it.  Points left
+// too few even in the cell for the whole world, with an empty geohash, are suppressed, and their number
+// returned.  The cells are sorted by geohash, and the precision is clamped as by EncodeGeohash.
+func KAnonymize(points []Point, k int, precision int) (cells []GeohashCount, suppressed int) {
+	precision = max(min(precision, MaxGeohashPrecision), 1)
+	counts := make(map[string]int)
+	for _, p := range points {
+		counts[EncodeGeohash(p, precision)]++
+	}
+
+	for length := precision; length > 0; length-- {
+		var sparse []string
+		for hash, n := range counts {
+			if len(hash) == length && n < k {
+				sparse = append(sparse, hash)
+			}
+		}
+		for _, hash := range sparse {
+			counts[hash[:length-1]] += counts[hash]
+			delete(counts, hash)
+		}
+	}
+	if n, ok := counts[""]; ok && n < k {
+		suppressed = n
+		delete(counts, "")
+	}
+
+	cells = make([]GeohashCount, 0, len(counts))
+	for hash, n := range counts {
+		b := NewBoundingBox(NewPoint(-90, -180), NewPoint(90, 180))
+		if hash != "" {
+			b, _ = DecodeGeohash(hash)
+		}
+		cells = append(cells, GeohashCount{Geohash: hash, Bounds: b, Count: n})
+	}
+	sort.Slice(cells, func(i, j int) bool { return cells[i].Geohash < cells[j].Geohash })
+	return cells, suppressed
+}
@@ -0,0 +1,78 @@
+package geo
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// Ensures that every count is of at least k points, and that no point is lost or counted twice.
+func TestKAnonymize(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	var points []Point
+	// A dense town and a scattering of farms around it.
+	for range 500 {
+		points = append(points, NewPoint(52.2+r.Float64()*0.02, 0.1+r.Float64()*0.02))
+	}
+	for range 40 {
+		points = append(points, NewPoint(51.5+r.Float64()*1.5, -0.5+r.Float64()*1.5))
+	}
+
+	cells, suppressed := KAnonymize(points, 10, 6)
+	counted := make(map[string]int)
+	total := suppressed
+	for _, c := range cells {
+		if c.Count < 10 {
+			t.Errorf("Expected at least 10 points in %v, but got %d", c.Geohash, c.Count)
+		}
+		total += c.Count
+	}
+	if total != len(points) {
+		t.Errorf("Expected %d points counted, but got %d", len(points), total)
+	}
+
+	// The town keeps fine cells while the farms are merged into coarse ones.
+	// Each point is counted in the finest cell returned that holds it.
+	for _, p := range points {
+		finest := ""
+		for _, c := range cells {
+			if hash := EncodeGeohash(p, 6); strings.HasPrefix(hash, c.Geohash) && len(c.Geohash) >= len(finest) {
+				finest = c.Geohash
+			}
+		}
+		counted[finest]++
+	}
+	for _, c := range cells {
+		if counted[c.Geohash] != c.Count {
+			t.Errorf("Expected %d points in %v, but got %d", counted[c.Geohash], c.Geohash, c.Count)
+		}
+	}
+
+	var fine, coarse bool
+	for _, c := range cells {
+		fine = fine || len(c.Geohash) >= 5
+		coarse = coarse || len(c.Geohash) <= 3
+	}
+	if !fine || !coarse {
+		t.Errorf("Expected both fine and coarse cells, but got %v", cells)
+	}
+}
+
+// Ensures that too few points are suppressed, and that a k of 1 keeps every cell.
+func TestKAnonymizeSuppressed(t *testing.T) {
+	points := []Point{NewPoint(1, 1), NewPoint(-40, 100), NewPoint(60, -120)}
+	if cells, suppressed := KAnonymize(points, 5, 4); len(cells) != 0 || suppressed != 3 {
+		t.Errorf("Expected every point suppressed, but got %v and %d", cells, suppressed)
+	}
+	if cells, suppressed := KAnonymize(points, 3, 4); len(cells) != 1 || cells[0].Geohash != "" || suppressed != 0 {
+		t.Errorf("Expected a single cell for the world, but got %v and %d", cells, suppressed)
+	}
+
+	cells, _ := KAnonymize(points, 1, 4)
+	if len(cells) != 3 || cells[2].Geohash != EncodeGeohash(points[0], 4) {
+		t.Errorf("Expected a cell for every point, but got %v", cells)
+	}
+	if b, _ := DecodeGeohash(cells[0].Geohash); cells[0].Bounds != b {
+		t.Errorf("Expected the bounds of %v, but got %v", cells[0].Geohash, cells[0].Bounds)
+	}
+}
@@ -0,0 +1,198 @@
+package geo
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+)
+
+// KDTreeResult is a single point returned from a KDTree search.
+type KDTreeResult struct {
+	// Index is the position of the point in the slice the tree was built from.
+	Index    int
+	Point    Point
+	Distance Distance
+}
+
+// A KDTree answers nearest neighbor and radius queries over a fixed set of Points.
+// Points are indexed as unit vectors on the sphere, so pruning is exact for
+// Haversine distances, including across the antimeridian and near the poles.
+// It is immutable once built and safe for concurrent use.
+type KDTree struct {
+	points []Point
+	nodes  []kdNode
+}
+
+type kdNode struct {
+	index int
+	v     [3]float64
+}
+
+// NewKDTree returns a new KDTree over the passed in points.
+func NewKDTree(points []Point) *KDTree {
+	t := &KDTree{points: points, nodes: make([]kdNode, len(points))}
+	for i, p := range points {
+		t.nodes[i] = kdNode{index: i, v: unitVector(p)}
+	}
+	t.build(t.nodes, 0)
+	return t
+}
+
+// build arranges nodes so that the median along the depth's axis is in the middle
+// of the slice, with smaller values before it and larger values after it.
+func (t *KDTree) build(nodes []kdNode, depth int) {
+	if len(nodes) <= 1 {
+		return
+	}
+
+	axis := depth % 3
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].v[axis] < nodes[j].v[axis] })
+
+	mid := len(nodes) / 2
+	t.build(nodes[:mid], depth+1)
+	t.build(nodes[mid+1:], depth+1)
+}
+
+// Len returns the number of points in the tree.
+func (t *KDTree) Len() int {
+	return len(t.points)
+}
+
+// Nearest returns up to k points nearest to Point p, nearest first.
+func (t *KDTree) Nearest(p Point, k int) []KDTreeResult {
+	neighbors := t.KNN(p, k, nil)
+	if neighbors == nil {
+		return nil
+	}
+
+	results := make([]KDTreeResult, len(neighbors))
+	for i, n := range neighbors {
+		results[i] = KDTreeResult{Index: n.Item, Point: t.points[n.Item], Distance: n.Distance}
+	}
+	return results
+}
+
+// nearest keeps the k nodes nearest to target accepted by filter in h.
+func (t *KDTree) nearest(nodes []kdNode, depth int, target [3]float64, k int, filter func(index int) bool, h *kdHeap) {
+	if len(nodes) == 0 {
+		return
+	}
+
+	mid := len(nodes) / 2
+	node := nodes[mid]
+	if filter == nil || filter(node.index) {
+		if d := chordSquared(node.v, target); h.Len() < k {
+			heap.Push(h, kdCandidate{index: node.index, chord: d})
+		} else if d < (*h)[0].chord {
+			(*h)[0] = kdCandidate{index: node.index, chord: d}
+			heap.Fix(h, 0)
+		}
+	}
+
+	axis := depth % 3
+	diff := target[axis] - node.v[axis]
+	near, far := nodes[:mid], nodes[mid+1:]
+	if diff > 0 {
+		near, far = far, near
+	}
+
+	t.nearest(near, depth+1, target, k, filter, h)
+	if h.Len() < k || diff*diff < (*h)[0].chord {
+		t.nearest(far, depth+1, target, k, filter, h)
+	}
+}
+
+// Within returns every point within radius of Point p, nearest first.
+func (t *KDTree) Within(p Point, radius Distance) []KDTreeResult {
+	defer timeIndexQuery("kdtree")()
+
+	if radius < 0 {
+		return nil
+	}
+
+	var results []KDTreeResult
+	t.visitWithin(p, radius, func(index int) {
+		results = append(results, t.result(index, p))
+	})
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Distance < results[j].Distance
+	})
+	return results
+}
+
+// visitWithin calls visit with the index of every point within radius of Point p, in no particular order.
+func (t *KDTree) visitWithin(p Point, radius Distance, visit func(index int)) {
+	angle := math.Min(radius.Kilometers()/EARTH_RADIUS, math.Pi)
+	chord := 2 * math.Sin(angle/2)
+	// Allow for rounding, then filter by the exact Haversine distance below.
+	limit := chord*chord*(1+1e-9) + 1e-18
+
+	t.within(t.nodes, 0, unitVector(p), limit, func(index int) {
+		if p.GreatCircleDistance(t.points[index]) <= radius {
+			visit(index)
+		}
+	})
+}
+
+func (t *KDTree) within(nodes []kdNode, depth int, target [3]float64, limit float64, visit func(index int)) {
+	if len(nodes) == 0 {
+		return
+	}
+
+	mid := len(nodes) / 2
+	node := nodes[mid]
+	if chordSquared(node.v, target) <= limit {
+		visit(node.index)
+	}
+
+	axis := depth % 3
+	diff := target[axis] - node.v[axis]
+	if diff <= 0 || diff*diff <= limit {
+		t.within(nodes[:mid], depth+1, target, limit, visit)
+	}
+	if diff >= 0 || diff*diff <= limit {
+		t.within(nodes[mid+1:], depth+1, target, limit, visit)
+	}
+}
+
+func (t *KDTree) result(index int, p Point) KDTreeResult {
+	return KDTreeResult{Index: index, Point: t.points[index], Distance: p.GreatCircleDistance(t.points[index])}
+}
+
+// unitVector returns the position of Point p on the unit sphere.
+func unitVector(p Point) [3]float64 {
+	lat, lng := p.lat*math.Pi/180, p.lng*math.Pi/180
+	return [3]float64{math.Cos(lat) * math.Cos(lng), math.Cos(lat) * math.Sin(lng), math.Sin(lat)}
+}
+
+// vectorPoint returns the Point in the direction of v, the inverse of unitVector.
+func vectorPoint(v [3]float64) Point {
+	return NewPoint(math.Atan2(v[2], math.Hypot(v[0], v[1]))*180/math.Pi, math.Atan2(v[1], v[0])*180/math.Pi)
+}
+
+// chordSquared returns the squared straight line distance between two unit vectors,
+// which increases with the great circle distance between them.
+func chordSquared(a [3]float64, b [3]float64) float64 {
+	dx, dy, dz := a[0]-b[0], a[1]-b[1], a[2]-b[2]
+	return dx*dx + dy*dy + dz*dz
+}
+
+type kdCandidate struct {
+	index int
+	chord float64
+}
+
+// kdHeap is a max-heap of candidates by chord distance, so the farthest is at the top.
+type kdHeap []kdCandidate
+
+func (h kdHeap) Len() int            { return len(h) }
+func (h kdHeap) Less(i, j int) bool  { return h[i].chord > h[j].chord }
+func (h kdHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *kdHeap) Push(x interface{}) { *h = append(*h, x.(kdCandidate)) }
+func (h *kdHeap) Pop() interface{} {
+	old := *h
+	c := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return c
+}
@@ -0,0 +1,64 @@
+package geo
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// Ensures that nearest neighbor and radius queries match a brute force Haversine scan.
+func TestKDTree(t *testing.T) {
+	r := rand.New(rand.NewSource(5))
+	points := make([]Point, 2000)
+	for i := range points {
+		points[i] = NewPoint(r.Float64()*180-90, r.Float64()*360-180)
+	}
+	tree := NewKDTree(points)
+
+	queries := []Point{NewPoint(0, 0), NewPoint(0, 179.9), NewPoint(89.5, 10), NewPoint(-33.87, 151.21)}
+	for _, q := range queries {
+		byDistance := make([]int, len(points))
+		for i := range byDistance {
+			byDistance[i] = i
+		}
+		sort.Slice(byDistance, func(i, j int) bool {
+			return q.GreatCircleDistance(points[byDistance[i]]) < q.GreatCircleDistance(points[byDistance[j]])
+		})
+
+		nearest := tree.Nearest(q, 5)
+		if len(nearest) != 5 {
+			t.Fatalf("Expected 5 neighbors, but got %d", len(nearest))
+		}
+		for i, n := range nearest {
+			if n.Index != byDistance[i] {
+				t.Errorf("Expected neighbor %d of %v to be %v, but got %v", i, q, points[byDistance[i]], n.Point)
+			}
+		}
+
+		radius := 1000 * Kilometer
+		var expected int
+		for _, p := range points {
+			if q.GreatCircleDistance(p) <= radius {
+				expected++
+			}
+		}
+
+		within := tree.Within(q, radius)
+		if len(within) != expected {
+			t.Errorf("Expected %d points within %v of %v, but got %d", expected, radius, q, len(within))
+		}
+		for i := 1; i < len(within); i++ {
+			if within[i].Distance < within[i-1].Distance {
+				t.Errorf("Expected points within %v of %v to be sorted by distance", radius, q)
+			}
+		}
+	}
+
+	if n := NewKDTree(nil).Nearest(NewPoint(0, 0), 3); len(n) != 0 {
+		t.Errorf("Expected no neighbors in an empty tree, but got %v", n)
+	}
+
+	if n := tree.Nearest(NewPoint(0, 0), 0); n != nil {
+		t.Errorf("Expected no neighbors for k=0, but got %v", n)
+	}
+}
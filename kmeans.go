@@ -0,0 +1,187 @@
+package geo
+
+import (
+	"math"
+	"math/rand"
+)
+
+// DefaultKMeansIterations is the KMeansOptions.MaxIterations used when none is configured.
+const DefaultKMeansIterations = 100
+
+// KMeansOptions configures KMeans.
+type KMeansOptions struct {
+	// Seed seeds the choice of the initial centers, so that runs can be repeated.
+	Seed int64
+	// MaxIterations bounds the rounds of assigning points to centers and moving the centers.
+	// Defaults to DefaultKMeansIterations.
+	MaxIterations int
+	// Medoids makes every center one of the points, the one with the least total distance to the
+	// others of its cluster, as in k-medoids.  Medoids are less swayed by outliers than means, and
+	// always lie at a real location, at the cost of time quadratic in the size of the clusters.
+	Medoids bool
+}
+
+// KMeansResult holds the clusters found by KMeans.
+type KMeansResult struct {
+	// Centers holds the center of each cluster.
+	Centers []Point
+	// Labels holds the cluster of each point.
+	Labels []int
+	// Clusters holds the indices of the points of each cluster, in ascending order.
+	Clusters [][]int
+	// Cost is the sum of the distances from the points to the centers of their clusters.
+	Cost Distance
+	// Iterations is the number of rounds run before the clusters settled or MaxIterations was reached.
+	Iterations int
+}
+
+// KMeans partitions points into k clusters around centers, each point belonging to the cluster of the
+// nearest center, such as for dividing customers into sales territories.  Distances are great circle
+// distances and means are taken on the sphere, so clusters may span the antimeridian and the poles.
+// Initial centers are chosen by k-means++ seeding.  k is lowered to the number of distinct points.
+func KMeans(points []Point, k int, opts KMeansOptions) KMeansResult {
+	if opts.MaxIterations <= 0 {
+		opts.MaxIterations = DefaultKMeansIterations
+	}
+	if k <= 0 || len(points) == 0 {
+		return KMeansResult{Labels: make([]int, len(points))}
+	}
+
+	r := rand.New(rand.NewSource(opts.Seed))
+	centers := kMeansPlusPlus(points, k, r)
+	k = len(centers)
+	vectors := make([][3]float64, len(points))
+	for i, p := range points {
+		vectors[i] = unitVector(p)
+	}
+
+	result := KMeansResult{Labels: make([]int, len(points))}
+	for i := range result.Labels {
+		result.Labels[i] = -1
+	}
+	for result.Iterations < opts.MaxIterations {
+		result.Iterations++
+
+		// Assign each point to its nearest center; chords order like great circle distances.
+		centerVectors := make([][3]float64, k)
+		for c, p := range centers {
+			centerVectors[c] = unitVector(p)
+		}
+		changed := false
+		for i, v := range vectors {
+			best := 0
+			for c := 1; c < k; c++ {
+				if chordSquared(v, centerVectors[c]) < chordSquared(v, centerVectors[best]) {
+					best = c
+				}
+			}
+			if result.Labels[i] != best {
+				result.Labels[i], changed = best, true
+			}
+		}
+		if !changed {
+			break
+		}
+
+		clusters := kMeansClusters(result.Labels, k)
+		for c, members := range clusters {
+			switch {
+			case len(members) == 0:
+				// Restart an emptied cluster at the point furthest from its center.
+				far := 0
+				for i, v := range vectors {
+					if chordSquared(v, centerVectors[result.Labels[i]]) > chordSquared(vectors[far], centerVectors[result.Labels[far]]) {
+						far = i
+					}
+				}
+				centers[c] = points[far]
+				result.Labels[far] = c
+				centerVectors[c] = vectors[far]
+			case opts.Medoids:
+				centers[c] = medoid(points, members)
+			default:
+				var sum [3]float64
+				for _, i := range members {
+					sum[0], sum[1], sum[2] = sum[0]+vectors[i][0], sum[1]+vectors[i][1], sum[2]+vectors[i][2]
+				}
+				if sum != [3]float64{} {
+					centers[c] = vectorPoint(sum)
+				}
+			}
+		}
+	}
+
+	result.Centers = centers
+	result.Clusters = kMeansClusters(result.Labels, k)
+	for i, p := range points {
+		result.Cost += p.GreatCircleDistance(centers[result.Labels[i]])
+	}
+	return result
+}
+
+// kMeansPlusPlus chooses up to k distinct points as initial centers, each after the first with a
+// probability proportional to the square of its distance to the nearest center chosen so far, as in
+// Arthur and Vassilvitskii, "k-means++: the advantages of careful seeding" (2007).
+func kMeansPlusPlus(points []Point, k int, r *rand.Rand) []Point {
+	centers := []Point{points[r.Intn(len(points))]}
+	nearest := make([]float64, len(points))
+	for i := range nearest {
+		nearest[i] = math.Inf(1)
+	}
+
+	for len(centers) < k {
+		var total float64
+		last := centers[len(centers)-1]
+		for i, p := range points {
+			d := p.GreatCircleDistance(last).Meters()
+			nearest[i] = math.Min(nearest[i], d*d)
+			total += nearest[i]
+		}
+		if total == 0 {
+			// Every point coincides with a center.
+			break
+		}
+
+		target := r.Float64() * total
+		chosen := len(points) - 1
+		for i, d := range nearest {
+			if target < d {
+				chosen = i
+				break
+			}
+			target -= d
+		}
+		for nearest[chosen] == 0 {
+			chosen--
+		}
+		centers = append(centers, points[chosen])
+	}
+	return centers
+}
+
+// kMeansClusters returns the indices of the points with each of k labels.
+func kMeansClusters(labels []int, k int) [][]int {
+	clusters := make([][]int, k)
+	for i, label := range labels {
+		clusters[label] = append(clusters[label], i)
+	}
+	return clusters
+}
+
+// medoid returns the member of points with the least total distance to the other members.
+func medoid(points []Point, members []int) Point {
+	best, bestSum := members[0], math.Inf(1)
+	for _, i := range members {
+		var sum float64
+		for _, j := range members {
+			sum += points[i].GreatCircleDistance(points[j]).Meters()
+			if sum >= bestSum {
+				break
+			}
+		}
+		if sum < bestSum {
+			best, bestSum = i, sum
+		}
+	}
+	return points[best]
+}
@@ -0,0 +1,63 @@
+package geo
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// Ensures that k-means and k-medoids recover well separated groups, including one on the antimeridian.
+func TestKMeans(t *testing.T) {
+	r := rand.New(rand.NewSource(5))
+	centers := []Point{NewPoint(-33.87, 151.21), NewPoint(-36.85, 174.76), NewPoint(-17.8, 180)}
+	var points []Point
+	for i := range 150 {
+		c := centers[i%3]
+		points = append(points, NewPoint(c.lat+r.NormFloat64()*0.05, NormalizeLng(c.lng+r.NormFloat64()*0.05)))
+	}
+
+	for _, medoids := range []bool{false, true} {
+		result := KMeans(points, 3, KMeansOptions{Seed: 1, Medoids: medoids})
+		if len(result.Centers) != 3 || len(result.Clusters) != 3 || result.Iterations == 0 {
+			t.Fatalf("Expected 3 clusters, but got %+v", result)
+		}
+		for c, members := range result.Clusters {
+			if len(members) != 50 {
+				t.Errorf("Expected 50 points in cluster %d, but got %d", c, len(members))
+			}
+			group := members[0] % 3
+			for _, i := range members {
+				if i%3 != group || result.Labels[i] != c {
+					t.Errorf("Expected point %d in the cluster of group %d", i, group)
+				}
+			}
+			if d := result.Centers[c].GreatCircleDistance(centers[group]); d > 3*Kilometer {
+				t.Errorf("Expected the center of cluster %d near %v, but got %v", c, centers[group], result.Centers[c])
+			}
+			if medoids {
+				found := false
+				for _, i := range members {
+					found = found || points[i] == result.Centers[c]
+				}
+				if !found {
+					t.Errorf("Expected the medoid %v to be a member of its cluster", result.Centers[c])
+				}
+			}
+		}
+		if result.Cost <= 0 || result.Cost > 150*10*Kilometer {
+			t.Errorf("Unexpected cost %v", result.Cost)
+		}
+	}
+}
+
+// Ensures that k is lowered to the number of distinct points.
+func TestKMeansFewPoints(t *testing.T) {
+	points := []Point{NewPoint(1, 1), NewPoint(1, 1), NewPoint(2, 2)}
+	result := KMeans(points, 5, KMeansOptions{})
+	if len(result.Centers) != 2 || result.Cost > Meter/1000 || result.Labels[0] != result.Labels[1] || result.Labels[0] == result.Labels[2] {
+		t.Errorf("Expected 2 clusters, but got %+v", result)
+	}
+
+	if result := KMeans(nil, 3, KMeansOptions{}); len(result.Centers) != 0 {
+		t.Errorf("Expected no clusters, but got %+v", result)
+	}
+}
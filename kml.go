@@ -0,0 +1,143 @@
+package geo
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// DecodeKML decodes the Point, LineString and Polygon geometries anywhere in a KML document, such as
+// inside Placemarks, Folders or MultiGeometries.  The closing point of polygon rings is dropped, and
+// polygons with inner boundaries are rejected with ErrUnsupportedGeometry, as Polygon cannot represent holes.
+func DecodeKML(data []byte) ([]Geometry, error) {
+	var geometries []Geometry
+	var stack []string
+	var text strings.Builder
+
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidFormat, err)
+		}
+
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			stack = append(stack, tok.Name.Local)
+			text.Reset()
+		case xml.CharData:
+			text.Write(tok)
+		case xml.EndElement:
+			if tok.Name.Local == "coordinates" && len(stack) >= 2 {
+				g, err := kmlGeometry(stack, text.String())
+				if err != nil {
+					return nil, err
+				}
+				if g != nil {
+					geometries = append(geometries, g)
+				}
+			}
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		}
+	}
+	return geometries, nil
+}
+
+// kmlGeometry returns the geometry holding the coordinates text at the top of the element stack, or
+// nil for coordinates of other elements.
+func kmlGeometry(stack []string, text string) (Geometry, error) {
+	var points []Point
+	for i, tuple := range strings.Fields(text) {
+		parts := strings.Split(tuple, ",")
+		if len(parts) < 2 {
+			return nil, fmt.Errorf("%w: KML coordinate %d %q has %d values, expected 2", ErrInvalidFormat, i, tuple, len(parts))
+		}
+		lng, err := strconv.ParseFloat(parts[0], 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: KML coordinate %d: %v", ErrInvalidFormat, i, err)
+		}
+		lat, err := strconv.ParseFloat(parts[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: KML coordinate %d: %v", ErrInvalidFormat, i, err)
+		}
+		points = append(points, NewPoint(lat, lng))
+	}
+
+	switch stack[len(stack)-2] {
+	case "Point":
+		if len(points) != 1 {
+			return nil, fmt.Errorf("%w: KML Point has %d coordinates, expected 1", ErrInvalidFormat, len(points))
+		}
+		return points[0], nil
+	case "LineString":
+		return NewLineString(points), nil
+	case "LinearRing":
+		for _, name := range stack {
+			if name == "innerBoundaryIs" {
+				return nil, fmt.Errorf("%w: KML Polygon has an inner boundary, holes are not supported", ErrUnsupportedGeometry)
+			}
+		}
+		if len(points) > 1 && points[0] == points[len(points)-1] {
+			points = points[:len(points)-1]
+		}
+		return NewPolygon(points), nil
+	}
+	return nil, nil
+}
+
+// WriteKML writes the passed in geometries to w as a KML 2.2 document, one Placemark each.  Tracks are
+// written as LineStrings, dropping the times of their fixes, and MultiPolygons as MultiGeometries.
+// Other geometries are rejected with ErrUnsupportedGeometry.
+func WriteKML(w io.Writer, geometries ...Geometry) error {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString("<kml xmlns=\"http://www.opengis.net/kml/2.2\">\n  <Document>\n")
+	for _, g := range geometries {
+		b.WriteString("    <Placemark>\n")
+		switch g := g.(type) {
+		case Point:
+			fmt.Fprintf(&b, "      <Point><coordinates>%s</coordinates></Point>\n", kmlCoordinates([]Point{g}))
+		case LineString:
+			fmt.Fprintf(&b, "      <LineString><coordinates>%s</coordinates></LineString>\n", kmlCoordinates(g.points))
+		case Track:
+			fmt.Fprintf(&b, "      <LineString><coordinates>%s</coordinates></LineString>\n", kmlCoordinates(g.Points()))
+		case Polygon:
+			b.WriteString("      " + kmlPolygon(g) + "\n")
+		case MultiPolygon:
+			b.WriteString("      <MultiGeometry>\n")
+			for _, p := range g.polygons {
+				b.WriteString("        " + kmlPolygon(p) + "\n")
+			}
+			b.WriteString("      </MultiGeometry>\n")
+		default:
+			return fmt.Errorf("%w: KML cannot hold %T", ErrUnsupportedGeometry, g)
+		}
+		b.WriteString("    </Placemark>\n")
+	}
+	b.WriteString("  </Document>\n</kml>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// kmlPolygon renders a Polygon as a KML Polygon element with a closed outer boundary.
+func kmlPolygon(p Polygon) string {
+	return fmt.Sprintf("<Polygon><outerBoundaryIs><LinearRing><coordinates>%s</coordinates></LinearRing></outerBoundaryIs></Polygon>", kmlCoordinates(p.ring().points))
+}
+
+// kmlCoordinates renders points as KML coordinate tuples, longitude first.
+func kmlCoordinates(points []Point) string {
+	tuples := make([]string, len(points))
+	for i, p := range points {
+		tuples[i] = strconv.FormatFloat(p.lng, 'f', -1, 64) + "," + strconv.FormatFloat(p.lat, 'f', -1, 64)
+	}
+	return strings.Join(tuples, " ")
+}
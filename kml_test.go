@@ -0,0 +1,71 @@
+package geo
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// Ensures that geometries are found anywhere in a KML document and that holes are rejected.
+func TestDecodeKML(t *testing.T) {
+	doc := `<?xml version="1.0" encoding="UTF-8"?>
+<kml xmlns="http://www.opengis.net/kml/2.2"><Document><Folder>
+  <Placemark><Point><coordinates>2,1,0</coordinates></Point></Placemark>
+  <Placemark><MultiGeometry>
+    <LineString><coordinates>0,0 1,1</coordinates></LineString>
+    <Polygon><outerBoundaryIs><LinearRing><coordinates>
+      0,0 1,0 1,1 0,0
+    </coordinates></LinearRing></outerBoundaryIs></Polygon>
+  </MultiGeometry></Placemark>
+</Folder></Document></kml>`
+	geometries, err := DecodeKML([]byte(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Geometry{
+		NewPoint(1, 2),
+		NewLineString([]Point{NewPoint(0, 0), NewPoint(1, 1)}),
+		NewPolygon([]Point{NewPoint(0, 0), NewPoint(0, 1), NewPoint(1, 1)}),
+	}
+	if !reflect.DeepEqual(geometries, expected) {
+		t.Errorf("Expected %v, but got %v", expected, geometries)
+	}
+
+	hole := `<kml><Placemark><Polygon>` +
+		`<outerBoundaryIs><LinearRing><coordinates>0,0 10,0 10,10 0,0</coordinates></LinearRing></outerBoundaryIs>` +
+		`<innerBoundaryIs><LinearRing><coordinates>4,4 6,4 6,6 4,4</coordinates></LinearRing></innerBoundaryIs>` +
+		`</Polygon></Placemark></kml>`
+	if _, err := DecodeKML([]byte(hole)); !errors.Is(err, ErrUnsupportedGeometry) {
+		t.Errorf("Expected a Polygon with a hole to be rejected, but got %v", err)
+	}
+
+	if _, err := DecodeKML([]byte(`<kml><Point><coordinates>1</coordinates></Point></kml>`)); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("Expected a coordinate without latitude to be rejected, but got %v", err)
+	}
+}
+
+// Ensures that written geometries decode back to the same geometries.
+func TestWriteKML(t *testing.T) {
+	geometries := []Geometry{
+		NewPoint(1, 2),
+		NewLineString([]Point{NewPoint(0, 0), NewPoint(1, 1)}),
+		NewPolygon([]Point{NewPoint(0, 0), NewPoint(0, 1), NewPoint(1, 1)}),
+	}
+
+	var buf bytes.Buffer
+	if err := WriteKML(&buf, geometries...); err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := DecodeKML(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, geometries) {
+		t.Errorf("Expected %v to round trip, but got %v", geometries, decoded)
+	}
+
+	if err := WriteKML(&buf, NewCircle(NewPoint(0, 0), Meter)); !errors.Is(err, ErrUnsupportedGeometry) {
+		t.Errorf("Expected a Circle to be rejected, but got %v", err)
+	}
+}
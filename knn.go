@@ -0,0 +1,203 @@
+package geo
+
+import (
+	"container/heap"
+	"math"
+)
+
+// A Neighbor is an item returned by a k-nearest-neighbor query together with its distance.
+type Neighbor[T any] struct {
+	Item     T
+	Distance Distance
+}
+
+// KNNSearcher is implemented by the indexes that answer k-nearest-neighbor queries,
+// so that callers can switch between them.  KNN returns up to k items nearest to Point p,
+// nearest first, skipping items for which filter returns false.  A nil filter accepts every item.
+type KNNSearcher[T any] interface {
+	KNN(p Point, k int, filter func(item T) bool) []Neighbor[T]
+}
+
+var (
+	_ KNNSearcher[Point]                = (*RTree[Point])(nil)
+	_ KNNSearcher[QuadtreeItem[string]] = (*Quadtree[string])(nil)
+	_ KNNSearcher[int]                  = (*KDTree)(nil)
+)
+
+// boundsDistance returns the shortest Haversine distance from Point p to BoundingBox b,
+// which is zero when b contains p.
+func boundsDistance(p Point, b BoundingBox) Distance {
+	if b.Contains(p) {
+		return 0
+	}
+
+	for _, interval := range b.lngIntervals() {
+		if p.lng >= interval[0] && p.lng <= interval[1] {
+			return p.GreatCircleDistance(NewPoint(math.Max(b.sw.lat, math.Min(b.ne.lat, p.lat)), p.lng))
+		}
+	}
+
+	return min(meridianDistance(p, b.sw.lng, b.sw.lat, b.ne.lat), meridianDistance(p, b.ne.lng, b.sw.lat, b.ne.lat))
+}
+
+// meridianDistance returns the shortest Haversine distance from Point p
+// to the meridian lng between the latitudes minLat and maxLat.
+func meridianDistance(p Point, lng float64, minLat float64, maxLat float64) Distance {
+	lat := p.lat * math.Pi / 180
+	dLng := (p.lng - lng) * math.Pi / 180
+
+	// The component of p along the meridian's plane, towards the meridian rather than its antimeridian.
+	h := math.Cos(lat) * math.Cos(dLng)
+
+	var nearest float64
+	switch {
+	case h > 0:
+		nearest = math.Atan2(math.Sin(lat), h) * 180 / math.Pi
+	case p.lat >= 0:
+		nearest = 90
+	default:
+		nearest = -90
+	}
+
+	return p.GreatCircleDistance(NewPoint(math.Max(minLat, math.Min(maxLat, nearest)), lng))
+}
+
+// distanceHeap is a min-heap of values ordered by their distance.
+type distanceHeap[V any] []distanceEntry[V]
+
+type distanceEntry[V any] struct {
+	distance Distance
+	value    V
+}
+
+func (h distanceHeap[V]) Len() int            { return len(h) }
+func (h distanceHeap[V]) Less(i, j int) bool  { return h[i].distance < h[j].distance }
+func (h distanceHeap[V]) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *distanceHeap[V]) Push(x interface{}) { *h = append(*h, x.(distanceEntry[V])) }
+func (h *distanceHeap[V]) Pop() interface{} {
+	old := *h
+	e := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return e
+}
+
+// bestFirst runs a best-first search from the passed in roots.  Expand returns the children
+// of a value, and whether the value is itself an item to be returned; items are returned
+// nearest first once no unexpanded value could be nearer.
+func bestFirst[V any](roots []distanceEntry[V], k int, expand func(v V) ([]distanceEntry[V], bool)) []distanceEntry[V] {
+	h := distanceHeap[V](roots)
+	heap.Init(&h)
+
+	var found []distanceEntry[V]
+	for h.Len() > 0 && len(found) < k {
+		e := heap.Pop(&h).(distanceEntry[V])
+		children, isItem := expand(e.value)
+		if isItem {
+			found = append(found, e)
+			continue
+		}
+		for _, c := range children {
+			heap.Push(&h, c)
+		}
+	}
+	return found
+}
+
+type rtreeVisit[T Geometry] struct {
+	node *rtreeNode[T]
+	item T
+}
+
+// KNN returns up to k items nearest to Point p, nearest first, skipping items for which filter returns false.
+// Distances are measured to the bounds of each item, so they are exact for Points and zero for
+// polygons whose bounds contain p.  The tree is searched best first, visiting only the nodes
+// that could hold a nearer item.
+func (t *RTree[T]) KNN(p Point, k int, filter func(item T) bool) []Neighbor[T] {
+	if k <= 0 {
+		return nil
+	}
+
+	roots := []distanceEntry[rtreeVisit[T]]{{value: rtreeVisit[T]{node: t.root}}}
+	found := bestFirst(roots, k, func(v rtreeVisit[T]) ([]distanceEntry[rtreeVisit[T]], bool) {
+		if v.node == nil {
+			return nil, true
+		}
+
+		var children []distanceEntry[rtreeVisit[T]]
+		for _, e := range v.node.entries {
+			if v.node.height == 0 && filter != nil && !filter(e.item) {
+				continue
+			}
+			children = append(children, distanceEntry[rtreeVisit[T]]{
+				distance: boundsDistance(p, e.bounds),
+				value:    rtreeVisit[T]{node: e.child, item: e.item},
+			})
+		}
+		return children, false
+	})
+
+	neighbors := make([]Neighbor[T], len(found))
+	for i, e := range found {
+		neighbors[i] = Neighbor[T]{Item: e.value.item, Distance: e.distance}
+	}
+	return neighbors
+}
+
+type quadtreeVisit[T any] struct {
+	node *quadtreeNode[T]
+	item QuadtreeItem[T]
+}
+
+// KNN returns up to k items nearest to Point p, nearest first, skipping items for which filter returns false.
+func (q *Quadtree[T]) KNN(p Point, k int, filter func(item QuadtreeItem[T]) bool) []Neighbor[QuadtreeItem[T]] {
+	if k <= 0 {
+		return nil
+	}
+
+	roots := []distanceEntry[quadtreeVisit[T]]{{value: quadtreeVisit[T]{node: &q.root}}}
+	found := bestFirst(roots, k, func(v quadtreeVisit[T]) ([]distanceEntry[quadtreeVisit[T]], bool) {
+		if v.node == nil {
+			return nil, true
+		}
+
+		var children []distanceEntry[quadtreeVisit[T]]
+		for _, it := range v.node.items {
+			if filter == nil || filter(it) {
+				children = append(children, distanceEntry[quadtreeVisit[T]]{distance: p.GreatCircleDistance(it.Point), value: quadtreeVisit[T]{item: it}})
+			}
+		}
+		if v.node.children != nil {
+			for i := range v.node.children {
+				child := &v.node.children[i]
+				children = append(children, distanceEntry[quadtreeVisit[T]]{distance: boundsDistance(p, child.bounds), value: quadtreeVisit[T]{node: child}})
+			}
+		}
+		return children, false
+	})
+
+	neighbors := make([]Neighbor[QuadtreeItem[T]], len(found))
+	for i, e := range found {
+		neighbors[i] = Neighbor[QuadtreeItem[T]]{Item: e.value.item, Distance: e.distance}
+	}
+	return neighbors
+}
+
+// KNN returns the indices of up to k points nearest to Point p, nearest first,
+// skipping the indices for which filter returns false.
+func (t *KDTree) KNN(p Point, k int, filter func(index int) bool) []Neighbor[int] {
+	defer timeIndexQuery("kdtree")()
+
+	if k <= 0 {
+		return nil
+	}
+
+	h := &kdHeap{}
+	t.nearest(t.nodes, 0, unitVector(p), k, filter, h)
+
+	neighbors := make([]Neighbor[int], h.Len())
+	for i := len(neighbors) - 1; i >= 0; i-- {
+		index := heap.Pop(h).(kdCandidate).index
+		neighbors[i] = Neighbor[int]{Item: index, Distance: p.GreatCircleDistance(t.points[index])}
+	}
+	return neighbors
+}
@@ -0,0 +1,84 @@
+package geo
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// Ensures that the R-tree, quadtree and k-d tree return the same filtered nearest neighbors
+// as a brute force scan.
+func TestKNN(t *testing.T) {
+	r := rand.New(rand.NewSource(8))
+	points := make([]Point, 1500)
+	for i := range points {
+		points[i] = NewPoint(r.Float64()*180-90, r.Float64()*360-180)
+	}
+
+	rtree := BulkLoadRTree(points, 8)
+	quadtree := NewQuadtree[int](8)
+	for i, p := range points {
+		quadtree.Insert(p, i)
+	}
+	kdtree := NewKDTree(points)
+
+	// Only accept points in the northern hemisphere.
+	north := func(p Point) bool { return p.lat >= 0 }
+
+	for _, q := range []Point{NewPoint(0, 0), NewPoint(1, 179.9), NewPoint(-60, -100), NewPoint(85, 40)} {
+		var expected []Distance
+		for _, p := range points {
+			if north(p) {
+				expected = append(expected, q.GreatCircleDistance(p))
+			}
+		}
+		sort.Slice(expected, func(i, j int) bool { return expected[i] < expected[j] })
+		expected = expected[:10]
+
+		var got [3][]Distance
+		for _, n := range rtree.KNN(q, 10, north) {
+			got[0] = append(got[0], n.Distance)
+		}
+		for _, n := range quadtree.KNN(q, 10, func(it QuadtreeItem[int]) bool { return north(it.Point) }) {
+			got[1] = append(got[1], n.Distance)
+		}
+		for _, n := range kdtree.KNN(q, 10, func(i int) bool { return north(points[i]) }) {
+			got[2] = append(got[2], n.Distance)
+		}
+
+		for i, distances := range got {
+			if len(distances) != len(expected) {
+				t.Fatalf("Expected index %d to return %d neighbors of %v, but got %d", i, len(expected), q, len(distances))
+			}
+			for j := range expected {
+				if d := distances[j] - expected[j]; d > 1e-6 || d < -1e-6 {
+					t.Errorf("Expected neighbor %d of %v from index %d to be %v away, but got %v", j, q, i, expected[j], distances[j])
+				}
+			}
+		}
+	}
+}
+
+// Ensures that the distance to a bounding box is exact, including near its meridian edges.
+func TestBoundsDistance(t *testing.T) {
+	b := NewBoundingBox(NewPoint(10, 10), NewPoint(20, 20))
+
+	if d := boundsDistance(NewPoint(15, 15), b); d != 0 {
+		t.Errorf("Expected a point inside the box to be 0 away, but got %v", d)
+	}
+
+	// Sample the box edges densely and compare to the closest sample.
+	for _, p := range []Point{NewPoint(60, 0), NewPoint(0, 15), NewPoint(15, 40), NewPoint(-30, -160)} {
+		best := Distance(1e12)
+		for i := 0; i <= 1000; i++ {
+			f := float64(i) / 1000
+			for _, e := range []Point{NewPoint(10+10*f, 10), NewPoint(10+10*f, 20), NewPoint(10, 10+10*f), NewPoint(20, 10+10*f)} {
+				best = min(best, p.GreatCircleDistance(e))
+			}
+		}
+
+		if d := boundsDistance(p, b); d > best || best-d > 1000 {
+			t.Errorf("Expected %v to be about %v from the box, but got %v", p, best, d)
+		}
+	}
+}
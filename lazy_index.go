@@ -0,0 +1,296 @@
+package geo
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// The lazy polygon index format is a header followed by a variable length record for every
+// polygon file, all little endian:
+//
+//	header: magic "GEOLAZY1", uint32 version, uint32 file count
+//	record: float64 south, west, north, east, uint16 name length, slash separated name
+const (
+	lazyIndexMagic      = "GEOLAZY1"
+	lazyIndexVersion    = 1
+	lazyIndexHeaderSize = 16
+)
+
+// BuildLazyPolygonIndex decodes every GeoJSON (.geojson, .json) and WKB (.wkb) polygon file under dir
+// and writes the name and bounding box of each to the index file at indexPath,
+// to be opened with OpenLazyPolygonIndex.  Files with other extensions are ignored.
+func BuildLazyPolygonIndex(dir, indexPath string) error {
+	return BuildLazyPolygonIndexCtx(context.Background(), dir, indexPath)
+}
+
+// BuildLazyPolygonIndexCtx is like BuildLazyPolygonIndex, but stops before decoding the next file
+// and returns ctx.Err() once ctx is done.
+func BuildLazyPolygonIndexCtx(ctx context.Context, dir, indexPath string) error {
+	return BuildLazyPolygonIndexWith(ctx, dir, indexPath, BatchOptions{})
+}
+
+// BuildLazyPolygonIndexWith is like BuildLazyPolygonIndexCtx, but controlled by the passed in options.
+// Its progress is counted in polygon files decoded.
+func BuildLazyPolygonIndexWith(ctx context.Context, dir, indexPath string, opts BatchOptions) error {
+	var paths []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !isPolygonFile(path) {
+			return err
+		}
+		paths = append(paths, path)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, len(paths))
+	bounds := make([]BoundingBox, len(paths))
+	prog := newProgress(opts.Progress, len(paths))
+	for i, path := range paths {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		p, err := readPolygonFile(path)
+		if err != nil {
+			return err
+		}
+
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		names[i], bounds[i] = filepath.ToSlash(name), p.Bounds()
+		prog.add(1)
+	}
+
+	order := make([]int, len(names))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool { return names[order[i]] < names[order[j]] })
+	sortedNames, sortedBounds := make([]string, len(names)), make([]BoundingBox, len(names))
+	for i, j := range order {
+		sortedNames[i], sortedBounds[i] = names[j], bounds[j]
+	}
+
+	f, err := os.Create(indexPath)
+	if err != nil {
+		return err
+	}
+
+	if err := writeLazyIndex(f, sortedNames, sortedBounds); err != nil {
+		f.Close()
+		return fmt.Errorf("%s: %w", indexPath, err)
+	}
+
+	return f.Close()
+}
+
+func writeLazyIndex(f *os.File, names []string, bounds []BoundingBox) error {
+	bw := bufio.NewWriter(f)
+
+	buf := make([]byte, 0, lazyIndexHeaderSize)
+	buf = append(buf, lazyIndexMagic...)
+	buf = binary.LittleEndian.AppendUint32(buf, lazyIndexVersion)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(names)))
+	if _, err := bw.Write(buf); err != nil {
+		return err
+	}
+
+	for i, name := range names {
+		if len(name) > math.MaxUint16 {
+			return fmt.Errorf("%w: file name %q is too long", ErrInvalidFormat, name)
+		}
+
+		b := bounds[i]
+		buf = buf[:0]
+		for _, v := range []float64{b.sw.lat, b.sw.lng, b.ne.lat, b.ne.lng} {
+			buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(v))
+		}
+		buf = binary.LittleEndian.AppendUint16(buf, uint16(len(name)))
+		buf = append(buf, name...)
+		if _, err := bw.Write(buf); err != nil {
+			return err
+		}
+	}
+
+	return bw.Flush()
+}
+
+// isPolygonFile reports whether the file at path has an extension readPolygonFile understands.
+func isPolygonFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".geojson", ".json", ".wkb":
+		return true
+	}
+	return false
+}
+
+// readPolygonFile decodes the polygon file at path according to its extension.
+func readPolygonFile(path string) (Polygon, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Polygon{}, err
+	}
+
+	var p Polygon
+	if strings.EqualFold(filepath.Ext(path), ".wkb") {
+		p, err = DecodeWKBPolygon(data)
+	} else {
+		p, err = DecodeGeoJSONPolygon(data)
+	}
+	if err != nil {
+		return Polygon{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return p, nil
+}
+
+// A LazyPolygonIndex answers queries over a directory of polygon files using only the
+// bounding boxes stored in an index file built by BuildLazyPolygonIndex.  Opening it reads
+// no polygon files; each is decoded the first time a query needs it and is then kept in memory.
+// It is safe for concurrent use.
+type LazyPolygonIndex struct {
+	dir    string
+	names  []string
+	ids    map[string]int
+	bounds []BoundingBox
+	tree   *RTree[indexedBounds]
+
+	mu     sync.Mutex
+	loaded map[int]Polygon
+}
+
+// OpenLazyPolygonIndex opens the index file at indexPath, which describes the polygon files under dir.
+func OpenLazyPolygonIndex(dir, indexPath string) (*LazyPolygonIndex, error) {
+	data, err := os.ReadFile(indexPath)
+	if err != nil {
+		return nil, err
+	}
+
+	x, err := newLazyPolygonIndex(dir, data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", indexPath, err)
+	}
+
+	return x, nil
+}
+
+func newLazyPolygonIndex(dir string, data []byte) (*LazyPolygonIndex, error) {
+	if len(data) < lazyIndexHeaderSize || string(data[:8]) != lazyIndexMagic {
+		return nil, fmt.Errorf("%w: not a lazy polygon index", ErrInvalidFormat)
+	}
+	if v := binary.LittleEndian.Uint32(data[8:]); v != lazyIndexVersion {
+		return nil, fmt.Errorf("%w: unsupported lazy polygon index version %d", ErrInvalidFormat, v)
+	}
+
+	n := int(binary.LittleEndian.Uint32(data[12:]))
+	x := &LazyPolygonIndex{dir: dir, ids: make(map[string]int, n), loaded: make(map[int]Polygon)}
+	data = data[lazyIndexHeaderSize:]
+	for i := 0; i < n; i++ {
+		if len(data) < 34 {
+			return nil, fmt.Errorf("%w: truncated lazy polygon index", ErrInvalidFormat)
+		}
+
+		f := func(j int) float64 { return math.Float64frombits(binary.LittleEndian.Uint64(data[8*j:])) }
+		bounds := NewBoundingBox(NewPoint(f(0), f(1)), NewPoint(f(2), f(3)))
+		length := int(binary.LittleEndian.Uint16(data[32:]))
+		if len(data) < 34+length {
+			return nil, fmt.Errorf("%w: truncated lazy polygon index", ErrInvalidFormat)
+		}
+
+		name := string(data[34 : 34+length])
+		x.ids[name] = len(x.names)
+		x.names = append(x.names, name)
+		x.bounds = append(x.bounds, bounds)
+		data = data[34+length:]
+	}
+	x.tree = newIndexRTree(x.bounds)
+
+	return x, nil
+}
+
+// Len returns the number of polygon files in the index.
+func (x *LazyPolygonIndex) Len() int {
+	return len(x.names)
+}
+
+// Names returns the slash separated names of the polygon files relative to the directory, in ascending order.
+func (x *LazyPolygonIndex) Names() []string {
+	return append([]string(nil), x.names...)
+}
+
+// Loaded returns the number of polygon files decoded so far.
+func (x *LazyPolygonIndex) Loaded() int {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return len(x.loaded)
+}
+
+// Polygon returns the polygon stored in the named file, loading it if necessary.
+// The boolean result is false if the name is not in the index.
+func (x *LazyPolygonIndex) Polygon(name string) (Polygon, bool, error) {
+	i, ok := x.ids[name]
+	if !ok {
+		return Polygon{}, false, nil
+	}
+
+	p, err := x.load(i)
+	return p, err == nil, err
+}
+
+func (x *LazyPolygonIndex) load(i int) (Polygon, error) {
+	x.mu.Lock()
+	p, ok := x.loaded[i]
+	x.mu.Unlock()
+	if ok {
+		return p, nil
+	}
+
+	p, err := readPolygonFile(filepath.Join(x.dir, filepath.FromSlash(x.names[i])))
+	if err != nil {
+		return Polygon{}, err
+	}
+
+	x.mu.Lock()
+	x.loaded[i] = p
+	x.mu.Unlock()
+	return p, nil
+}
+
+// FindContaining returns the names of the polygon files containing Point p, in ascending order.
+// Only files whose indexed bounding box contains p are loaded.
+func (x *LazyPolygonIndex) FindContaining(p Point) ([]string, error) {
+	defer timeIndexQuery("lazy_polygon_index")()
+
+	var candidates []int
+	x.tree.SearchFunc(p.Bounds(), func(item indexedBounds) bool {
+		candidates = append(candidates, item.i)
+		return true
+	})
+	sort.Ints(candidates)
+
+	var found []string
+	for _, i := range candidates {
+		polygon, err := x.load(i)
+		if err != nil {
+			return nil, err
+		}
+		if polygon.Contains(p) {
+			found = append(found, x.names[i])
+		}
+	}
+
+	return found, nil
+}
@@ -0,0 +1,123 @@
+package geo
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// Ensures that the lazy index only loads the polygon files a query needs.
+func TestLazyPolygonIndex(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "au"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, file := range map[string]string{"au/nsw.geojson": "test/data/nsw.json", "au/act.json": "test/data/act.json"} {
+		p, err := polygonFromFile(file)
+		if err != nil {
+			t.Fatal(file, " failed to parse: ", err)
+		}
+		data, err := json.Marshal(NewMongoPolygon(p))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	brunei, err := polygonFromFile("test/data/brunei.json")
+	if err != nil {
+		t.Fatal("brunei json file failed to parse: ", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "brunei.wkb"), encodeWKBPolygon(brunei, binary.LittleEndian), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("not a polygon"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	indexPath := filepath.Join(t.TempDir(), "fences.idx")
+	if err := BuildLazyPolygonIndex(dir, indexPath); err != nil {
+		t.Fatalf("Should not encounter an error when building the index, but got %v", err)
+	}
+
+	x, err := OpenLazyPolygonIndex(dir, indexPath)
+	if err != nil {
+		t.Fatalf("Should not encounter an error when opening the index, but got %v", err)
+	}
+
+	if names := x.Names(); !reflect.DeepEqual(names, []string{"au/act.json", "au/nsw.geojson", "brunei.wkb"}) {
+		t.Errorf("Expected three polygon files, but got %v", names)
+	}
+	if x.Loaded() != 0 {
+		t.Errorf("Expected no polygons to be loaded when opening the index, but got %d", x.Loaded())
+	}
+
+	found, err := x.FindContaining(NewPoint(4.9031, 114.9398))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(found, []string{"brunei.wkb"}) || x.Loaded() != 1 {
+		t.Errorf("Expected only Brunei to be loaded and found, but got %v with %d loaded", found, x.Loaded())
+	}
+
+	found, err = x.FindContaining(NewPoint(-35.2819998, 149.1286843))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(found, []string{"au/act.json", "au/nsw.geojson"}) {
+		t.Errorf("Expected Canberra to be in the ACT and NSW, but got %v", found)
+	}
+
+	if _, ok, err := x.Polygon("missing.json"); ok || err != nil {
+		t.Errorf("Expected a missing file to be reported as absent, but got %v, %v", ok, err)
+	}
+}
+
+// Ensures that a polygon file that fails to decode fails the build.
+func TestBuildLazyPolygonIndexInvalid(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "broken.wkb"), []byte{1, 3}, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := BuildLazyPolygonIndex(dir, filepath.Join(t.TempDir(), "fences.idx")); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("Expected a broken WKB file to fail the build, but got %v", err)
+	}
+
+	if _, err := OpenLazyPolygonIndex(dir, "test/data/nsw.json"); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("Expected a JSON file to be rejected as an index, but got %v", err)
+	}
+}
+
+// Ensures that building the index reports progress per file and stops once its context is cancelled.
+func TestBuildLazyPolygonIndexCtx(t *testing.T) {
+	dir := t.TempDir()
+	square := NewPolygon([]Point{NewPoint(0, 0), NewPoint(0, 2), NewPoint(2, 2), NewPoint(2, 0)})
+	for _, name := range []string{"a.wkb", "b.wkb", "c.wkb"} {
+		if err := os.WriteFile(filepath.Join(dir, name), encodeWKBPolygon(square, binary.LittleEndian), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	opts, calls := recordProgress(t, 3)
+	if err := BuildLazyPolygonIndexWith(context.Background(), dir, filepath.Join(t.TempDir(), "fences.idx"), opts); err != nil {
+		t.Fatalf("Should not encounter an error when building the index, but got %v", err)
+	}
+	if !reflect.DeepEqual(*calls, []int{1, 2, 3}) {
+		t.Errorf("Expected progress after each of 3 files, but got %v", *calls)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := BuildLazyPolygonIndexCtx(ctx, dir, filepath.Join(t.TempDir(), "fences.idx")); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, but got %v", err)
+	}
+}
@@ -0,0 +1,116 @@
+package geo
+
+import "math"
+
+// DefaultBufferSegments is the BufferOptions.Segments used when none is configured.
+const DefaultBufferSegments = 16
+
+// BufferOptions controls the outline of the corridor returned by LineString.BufferWith.
+type BufferOptions struct {
+	// FlatCaps ends the corridor square at the ends of the LineString rather than rounding it
+	// about them.
+	FlatCaps bool
+	// Segments is the number of edges approximating half a circle at a round cap, turns taking a
+	// share of them.  Defaults to DefaultBufferSegments.
+	Segments int
+}
+
+// Buffer returns a corridor Polygon holding the points within width of the LineString, such as a
+// geofence of everywhere within 200m of a route, with round caps and the default BufferOptions.
+func (l LineString) Buffer(width Distance) Polygon {
+	return l.BufferWith(width, BufferOptions{})
+}
+
+// BufferWith returns a corridor Polygon around the LineString according to the passed in options.
+// Its sides run width either side of the LineString, rounded on the outside of turns and mitered on
+// the inside.  A LineString turning back on itself within twice width makes an outline crossing itself,
+// which leaves the overlap out under the even-odd rule of Polygon.Contains, and like other polygons
+// corridors must not cross the antimeridian.  A LineString of a single point gives a circle, and one
+// without points an empty Polygon.
+func (l LineString) BufferWith(width Distance, opts BufferOptions) Polygon {
+	if opts.Segments <= 0 {
+		opts.Segments = DefaultBufferSegments
+	}
+	var points []Point
+	for _, p := range l.points {
+		if len(points) == 0 || p != points[len(points)-1] {
+			points = append(points, p)
+		}
+	}
+	if len(points) == 0 {
+		return Polygon{}
+	}
+	step := 180 / float64(opts.Segments)
+	if len(points) == 1 {
+		return NewPolygon(bufferArc(points[0], 0, 360-step, step, width))
+	}
+
+	// Walk the left side forwards and the right side backwards, turning round the ends.
+	var left, right []Point
+	for i, p := range points {
+		in, out := math.NaN(), math.NaN()
+		if i > 0 {
+			in = NormalizeBearing(initialBearing(p, points[i-1]) + 180)
+		}
+		if i < len(points)-1 {
+			out = initialBearing(p, points[i+1])
+		}
+		switch {
+		case i == 0:
+			left, right = append(left, destinationPoint(p, out-90, width)), append(right, destinationPoint(p, out+90, width))
+		case i == len(points)-1:
+			left, right = append(left, destinationPoint(p, in-90, width)), append(right, destinationPoint(p, in+90, width))
+		default:
+			turn := BearingDifference(in, out)
+			miter := bufferMiter(p, in, turn, width, min(points[i-1].GreatCircleDistance(p), p.GreatCircleDistance(points[i+1])))
+			if turn >= 0 {
+				left, right = append(left, bufferArc(p, in-90, in-90+turn, step, width)...), append(right, miter)
+			} else {
+				left, right = append(left, miter), append(right, bufferArc(p, in+90, in+90+turn, -step, width)...)
+			}
+		}
+	}
+
+	first, last := points[0], points[len(points)-1]
+	end := NormalizeBearing(initialBearing(last, points[len(points)-2]) + 180)
+	start := initialBearing(first, points[1])
+	outline := left
+	if !opts.FlatCaps {
+		outline = append(outline, bufferArc(last, end-90+step, end+90-step, step, width)...)
+	}
+	for i := len(right) - 1; i >= 0; i-- {
+		outline = append(outline, right[i])
+	}
+	if !opts.FlatCaps {
+		outline = append(outline, bufferArc(first, start+90+step, start+270-step, step, width)...)
+	}
+	return NewPolygon(append(outline, outline[0]))
+}
+
+// bufferArc returns the points width from p at bearings from one to another in steps of step degrees,
+// both included.
+func bufferArc(p Point, from float64, to float64, step float64, width Distance) []Point {
+	n := max(int(math.Ceil((to-from)/step-1e-9)), 0)
+	arc := make([]Point, 0, n+1)
+	for k := range n + 1 {
+		bearing := to
+		if k < n {
+			bearing = from + (to-from)*float64(k)/float64(n)
+		}
+		arc = append(arc, destinationPoint(p, NormalizeBearing(bearing), width))
+	}
+	return arc
+}
+
+// bufferMiter returns the point on the inside of a turn of turn degrees at p, arriving at bearing in,
+// where the sides width away from the edges either side meet.  It is kept within the shorter edge,
+// of length limit, so that a sharp turn does not reach past the ends of its edges.
+func bufferMiter(p Point, in float64, turn float64, width Distance, limit Distance) Point {
+	half := math.Abs(turn) / 2 * math.Pi / 180
+	along := math.Min(width.Meters()*math.Tan(half), limit.Meters())
+	d := Distance(math.Hypot(width.Meters(), along))
+	if turn >= 0 {
+		return destinationPoint(p, NormalizeBearing(in+turn/2+90), d)
+	}
+	return destinationPoint(p, NormalizeBearing(in+turn/2-90), d)
+}
@@ -0,0 +1,48 @@
+package geo
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// Ensures that a corridor holds the points within its width of the line and no others.
+func TestLineStringBuffer(t *testing.T) {
+	l := NewLineString([]Point{NewPoint(0, 0), NewPoint(0, 0.02), NewPoint(0.02, 0.03), NewPoint(0.01, 0.05)})
+	width := 200 * Meter
+
+	for _, opts := range []BufferOptions{{}, {FlatCaps: true}, {Segments: 64}} {
+		corridor := l.BufferWith(width, opts)
+		if !corridor.IsClosed() {
+			t.Fatalf("Expected a closed corridor, but got %v", corridor)
+		}
+
+		// Sample around the line, keeping clear of the outline, which only approximates arcs.
+		r := rand.New(rand.NewSource(1))
+		for range 2000 {
+			p := NewPoint(r.Float64()*0.03-0.005, r.Float64()*0.06-0.005)
+			snapped, along, offset := l.Project(p)
+			if offset > width*0.97 && offset < width*1.03 {
+				continue
+			}
+			inside := offset <= width
+			if opts.FlatCaps && (along == 0 || along == l.Length()) && p.GreatCircleDistance(snapped) > 0 {
+				// Beyond the ends, flat caps leave out what round ones hold.
+				inside = false
+			}
+			if corridor.Contains(p) != inside {
+				t.Errorf("Expected %v, %v off the line, contained to be %v with %+v", p, offset, inside, opts)
+			}
+		}
+	}
+}
+
+// Ensures that the corridor of a single point is a circle and that of no points is empty.
+func TestLineStringBufferDegenerate(t *testing.T) {
+	circle := NewLineString([]Point{NewPoint(1, 1), NewPoint(1, 1)}).Buffer(Kilometer)
+	if len(circle.Points()) != 32 || !circle.Contains(NewPoint(1.008, 1)) || circle.Contains(NewPoint(1.01, 1)) {
+		t.Errorf("Expected a circle of 1km, but got %v", circle)
+	}
+	if empty := NewLineString(nil).Buffer(Kilometer); len(empty.Points()) != 0 {
+		t.Errorf("Expected an empty polygon, but got %v", empty)
+	}
+}
@@ -0,0 +1,184 @@
+package geo
+
+import (
+	"math"
+	"sort"
+)
+
+// Like the edges of a Polygon, the edges of a LineString are taken to be straight in degrees of
+// latitude and longitude by the functions in this file, and must not cross the antimeridian.
+
+// Intersects reports whether the LineString touches or crosses the other LineString.
+func (l LineString) Intersects(other LineString) bool {
+	found := false
+	l.crossings(other, func(int, float64) bool {
+		found = true
+		return false
+	})
+	return found
+}
+
+// IntersectionPoints returns the points where the LineString touches or crosses the other LineString,
+// in order along the LineString.  Where edges of the two overlap, the ends of the overlap are returned.
+func (l LineString) IntersectionPoints(other LineString) []Point {
+	type crossing struct {
+		i int
+		t float64
+	}
+	var found []crossing
+	l.crossings(other, func(i int, t float64) bool {
+		found = append(found, crossing{i, t})
+		return true
+	})
+	sort.Slice(found, func(i, j int) bool {
+		if found[i].i != found[j].i {
+			return found[i].i < found[j].i
+		}
+		return found[i].t < found[j].t
+	})
+
+	var points []Point
+	for _, c := range found {
+		p := edgePoint(l.points[c.i], l.points[c.i+1], c.t)
+		if len(points) == 0 || p != points[len(points)-1] {
+			points = append(points, p)
+		}
+	}
+	return points
+}
+
+// IntersectsPolygon reports whether the LineString enters the Polygon or touches its outline, as for a
+// route passing through a restricted area.
+func (l LineString) IntersectsPolygon(p Polygon) bool {
+	if !p.IsClosed() || len(l.points) == 0 {
+		return false
+	}
+	return p.Contains(l.points[0]) || l.Intersects(p.ring())
+}
+
+// ClipTo returns the parts of the LineString inside the Polygon, in order along the LineString.
+func (l LineString) ClipTo(p Polygon) []LineString {
+	if !p.IsClosed() {
+		return nil
+	}
+
+	// Cut every edge where it crosses the outline, and keep the pieces whose middles are inside.
+	cuts := make([][]float64, len(l.points))
+	l.crossings(p.ring(), func(i int, t float64) bool {
+		cuts[i] = append(cuts[i], t)
+		return true
+	})
+	var parts []LineString
+	var part []Point
+	flush := func() {
+		if len(part) > 1 {
+			parts = append(parts, LineString{points: part, crs: l.crs})
+		}
+		part = nil
+	}
+	for i := 1; i < len(l.points); i++ {
+		a, b := l.points[i-1], l.points[i]
+		ts := append([]float64{0, 1}, cuts[i-1]...)
+		sort.Float64s(ts)
+		for k := 1; k < len(ts); k++ {
+			if ts[k] == ts[k-1] {
+				continue
+			}
+			if !p.Contains(edgePoint(a, b, (ts[k-1]+ts[k])/2)) {
+				flush()
+				continue
+			}
+			if len(part) == 0 {
+				part = append(part, edgePoint(a, b, ts[k-1]))
+			}
+			part = append(part, edgePoint(a, b, ts[k]))
+		}
+	}
+	flush()
+	return parts
+}
+
+// ring returns the outline of the Polygon as a LineString ending where it starts.
+func (p Polygon) ring() LineString {
+	points := p.points
+	if len(points) > 0 && points[0] != points[len(points)-1] {
+		points = append(append([]Point(nil), points...), points[0])
+	}
+	return LineString{points: points}
+}
+
+// crossings calls visit with the index of the edge of the LineString and the fraction along it of
+// every point it shares with an edge of the other LineString, until visit returns false.
+func (l LineString) crossings(other LineString, visit func(i int, t float64) bool) {
+	if len(l.points) < 2 || len(other.points) < 2 {
+		return
+	}
+	bounds := make([]BoundingBox, len(other.points)-1)
+	for j := range bounds {
+		bounds[j] = pointsBounds(other.points[j : j+2])
+	}
+	tree := newIndexRTree(bounds)
+
+	for i := 1; i < len(l.points); i++ {
+		a, b := l.points[i-1], l.points[i]
+		more := true
+		tree.SearchFunc(pointsBounds(l.points[i-1:i+1]), func(item indexedBounds) bool {
+			for _, t := range edgeIntersections(a, b, other.points[item.i], other.points[item.i+1]) {
+				if more = visit(i-1, t); !more {
+					return false
+				}
+			}
+			return true
+		})
+		if !more {
+			return
+		}
+	}
+}
+
+// edgeIntersections returns the fractions along the edge from p1 to p2 where it meets the edge from q1 to
+// q2: none, one where they cross or touch, or the two ends of the stretch where they overlap.
+func edgeIntersections(p1 Point, p2 Point, q1 Point, q2 Point) []float64 {
+	rx, ry := p2.lng-p1.lng, p2.lat-p1.lat
+	sx, sy := q2.lng-q1.lng, q2.lat-q1.lat
+	qx, qy := q1.lng-p1.lng, q1.lat-p1.lat
+	rr := rx*rx + ry*ry
+	if rr == 0 {
+		return nil
+	}
+
+	denom := rx*sy - ry*sx
+	if denom != 0 {
+		t, u := (qx*sy-qy*sx)/denom, (qx*ry-qy*rx)/denom
+		if t < 0 || t > 1 || u < 0 || u > 1 {
+			return nil
+		}
+		return []float64{t}
+	}
+	if qx*ry-qy*rx != 0 {
+		// Parallel but apart.
+		return nil
+	}
+
+	t0 := (qx*rx + qy*ry) / rr
+	t1 := t0 + (sx*rx+sy*ry)/rr
+	lo, hi := math.Max(math.Min(t0, t1), 0), math.Min(math.Max(t0, t1), 1)
+	switch {
+	case lo > hi:
+		return nil
+	case lo == hi:
+		return []float64{lo}
+	}
+	return []float64{lo, hi}
+}
+
+// edgePoint returns the Point the fraction t along the straight edge from p1 to p2.
+func edgePoint(p1 Point, p2 Point, t float64) Point {
+	switch t {
+	case 0:
+		return p1
+	case 1:
+		return p2
+	}
+	return NewPoint(p1.lat+t*(p2.lat-p1.lat), p1.lng+t*(p2.lng-p1.lng))
+}
@@ -0,0 +1,82 @@
+package geo
+
+import (
+	"testing"
+)
+
+// Ensures that crossing, touching and overlapping lines intersect where expected.
+func TestLineStringIntersectionPoints(t *testing.T) {
+	l := NewLineString([]Point{NewPoint(0, 0), NewPoint(0, 4), NewPoint(4, 4)})
+
+	tests := []struct {
+		other    LineString
+		expected []Point
+	}{
+		{NewLineString([]Point{NewPoint(-1, 1), NewPoint(1, 1), NewPoint(1, 5), NewPoint(3, 3)}), []Point{NewPoint(0, 1), NewPoint(1, 4), NewPoint(2, 4)}},
+		{NewLineString([]Point{NewPoint(-1, 2), NewPoint(0, 2)}), []Point{NewPoint(0, 2)}},
+		{NewLineString([]Point{NewPoint(0, 3), NewPoint(0, 6)}), []Point{NewPoint(0, 3), NewPoint(0, 4)}},
+		{NewLineString([]Point{NewPoint(1, 0), NewPoint(1, 3)}), nil},
+		{NewLineString([]Point{NewPoint(5, 5)}), nil},
+	}
+	for _, tt := range tests {
+		points := l.IntersectionPoints(tt.other)
+		if len(points) != len(tt.expected) {
+			t.Errorf("Expected %v to meet %v at %v, but got %v", l.Points(), tt.other.Points(), tt.expected, points)
+			continue
+		}
+		for i, p := range points {
+			if p.GreatCircleDistance(tt.expected[i]) > 1e-6 {
+				t.Errorf("Expected %v to meet %v at %v, but got %v", l.Points(), tt.other.Points(), tt.expected, points)
+				break
+			}
+		}
+		if l.Intersects(tt.other) != (len(tt.expected) > 0) {
+			t.Errorf("Expected %v intersecting %v to be %v", l.Points(), tt.other.Points(), len(tt.expected) > 0)
+		}
+	}
+}
+
+// Ensures that lines are found entering polygons and clipped to them.
+func TestLineStringClipTo(t *testing.T) {
+	zone := NewPolygon([]Point{NewPoint(1, 1), NewPoint(1, 3), NewPoint(3, 3), NewPoint(3, 1), NewPoint(1, 1)})
+
+	tests := []struct {
+		line     LineString
+		expected [][]Point
+	}{
+		{NewLineString([]Point{NewPoint(2, 0), NewPoint(2, 4)}), [][]Point{{NewPoint(2, 1), NewPoint(2, 3)}}},
+		{NewLineString([]Point{NewPoint(2, 0), NewPoint(2, 2), NewPoint(4, 2), NewPoint(4, 2.5), NewPoint(2, 2.5), NewPoint(2, 4)}),
+			[][]Point{{NewPoint(2, 1), NewPoint(2, 2), NewPoint(3, 2)}, {NewPoint(3, 2.5), NewPoint(2, 2.5), NewPoint(2, 3)}}},
+		{NewLineString([]Point{NewPoint(1.5, 1.5), NewPoint(2.5, 2.5)}), [][]Point{{NewPoint(1.5, 1.5), NewPoint(2.5, 2.5)}}},
+		{NewLineString([]Point{NewPoint(0, 0), NewPoint(0, 4)}), nil},
+	}
+	for _, tt := range tests {
+		parts := tt.line.ClipTo(zone)
+		if len(parts) != len(tt.expected) {
+			t.Errorf("Expected %v clipped to %v, but got %v", tt.line.Points(), tt.expected, parts)
+			continue
+		}
+		for i, part := range parts {
+			points := part.Points()
+			if len(points) != len(tt.expected[i]) {
+				t.Errorf("Expected part %v, but got %v", tt.expected[i], points)
+				continue
+			}
+			for k, p := range points {
+				if p.GreatCircleDistance(tt.expected[i][k]) > 1e-6 {
+					t.Errorf("Expected part %v, but got %v", tt.expected[i], points)
+					break
+				}
+			}
+		}
+		if tt.line.IntersectsPolygon(zone) != (len(tt.expected) > 0) {
+			t.Errorf("Expected %v intersecting the zone to be %v", tt.line.Points(), len(tt.expected) > 0)
+		}
+	}
+
+	// A line touching the outline intersects without being clipped.
+	touching := NewLineString([]Point{NewPoint(0, 2), NewPoint(1, 2), NewPoint(0, 3)})
+	if !touching.IntersectsPolygon(zone) || len(touching.ClipTo(zone)) != 0 {
+		t.Errorf("Expected a touching line to intersect but not to be clipped")
+	}
+}
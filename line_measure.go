@@ -0,0 +1,165 @@
+package geo
+
+// A DistanceMethod is a way of measuring the distance between two points.
+type DistanceMethod int
+
+// Methods of measuring distances.
+const (
+	// Haversine measures great circle distances on a sphere, as GreatCircleDistance does.
+	Haversine DistanceMethod = iota
+	// Vincenty measures geodesic distances on the WGS84 ellipsoid, which is up to about 0.5% more
+	// accurate and several times slower.
+	Vincenty
+)
+
+// Distance returns the distance between p1 and p2 measured by the DistanceMethod.
+func (m DistanceMethod) Distance(p1 Point, p2 Point) Distance {
+	if m == Vincenty {
+		return WGS84Ellipsoid.Distance(p1, p2)
+	}
+	return p1.GreatCircleDistance(p2)
+}
+
+// Length returns the length of the LineString, the sum of the great circle distances along its edges.
+func (l LineString) Length() Distance {
+	return l.LengthWith(Haversine)
+}
+
+// LengthWith returns the length of the LineString with its edges measured by method.
+func (l LineString) LengthWith(method DistanceMethod) Distance {
+	var d Distance
+	for i := 1; i < len(l.points); i++ {
+		d += method.Distance(l.points[i-1], l.points[i])
+	}
+	return d
+}
+
+// LengthBetween returns the great circle length of the LineString from its point at index i to that at
+// index j, in either order.  It panics if i or j is out of range.
+func (l LineString) LengthBetween(i int, j int) Distance {
+	if i > j {
+		i, j = j, i
+	}
+	return LineString{points: l.points[i : j+1]}.Length()
+}
+
+// Project locates Point p along the LineString, for reporting positions like "km 42.3, 15m off".  It
+// returns the closest Point on the LineString to p, following great circles between its points, the
+// distance along the LineString from its start to that Point, and the distance from p to it.
+// Where several are equally close, the one nearest the start wins.  A LineString without points
+// returns the zero Point, 0 and 0.
+func (l LineString) Project(p Point) (snapped Point, distanceAlong Distance, offset Distance) {
+	snapped, distanceAlong, offset, _ = l.project(p)
+	return snapped, distanceAlong, offset
+}
+
+// project returns what Project does, and the index of the point starting the edge snapped lies on.
+func (l LineString) project(p Point) (snapped Point, distanceAlong Distance, offset Distance, edge int) {
+	if len(l.points) == 0 {
+		return Point{}, 0, 0, 0
+	}
+	snapped, offset = l.points[0], p.GreatCircleDistance(l.points[0])
+	var along Distance
+	for i := 1; i < len(l.points); i++ {
+		a, b := l.points[i-1], l.points[i]
+		c := closestOnArc(p, a, b)
+		if d := p.GreatCircleDistance(c); d < offset {
+			snapped, offset, distanceAlong, edge = c, d, along+a.GreatCircleDistance(c), i-1
+		}
+		along += a.GreatCircleDistance(b)
+	}
+	return snapped, distanceAlong, offset, edge
+}
+
+// SplitAt cuts the LineString in two at the closest Point on it to p, as found by Project, for breaking
+// a route at an incident or a stop.  Both parts hold the cut Point, the first ending and the second
+// starting there.  If p lies further than tolerance from the LineString, it is returned whole with an
+// empty second part.  A tolerance of zero places no limit.
+func (l LineString) SplitAt(p Point, tolerance Distance) (LineString, LineString) {
+	snapped, _, offset, edge := l.project(p)
+	if len(l.points) == 0 || tolerance > 0 && offset > tolerance {
+		return l, LineString{crs: l.crs}
+	}
+
+	first := append([]Point(nil), l.points[:edge+1]...)
+	if first[len(first)-1] != snapped {
+		first = append(first, snapped)
+	}
+	second := []Point{snapped}
+	for _, q := range l.points[edge+1:] {
+		if q != second[len(second)-1] || len(second) > 1 {
+			second = append(second, q)
+		}
+	}
+	return LineString{points: first, crs: l.crs}, LineString{points: second, crs: l.crs}
+}
+
+// closestOnArc returns the Point on the shorter great circle arc from a to b closest to p.
+func closestOnArc(p Point, a Point, b Point) Point {
+	va, vb, vp := unitVector(a), unitVector(b), unitVector(p)
+	n := cross3(va, vb)
+	if nn := dot3(n, n); nn > 0 {
+		// Drop p onto the plane of the great circle, and keep it if it lies between a and b.
+		k := dot3(vp, n) / nn
+		c := [3]float64{vp[0] - k*n[0], vp[1] - k*n[1], vp[2] - k*n[2]}
+		if dot3(c, c) > 0 && dot3(cross3(va, c), n) >= 0 && dot3(cross3(c, vb), n) >= 0 {
+			return vectorPoint(c)
+		}
+	}
+	if chordSquared(vp, vb) < chordSquared(vp, va) {
+		return b
+	}
+	return a
+}
+
+// cross3 returns the cross product of vectors u and v.
+func cross3(u [3]float64, v [3]float64) [3]float64 {
+	return [3]float64{u[1]*v[2] - u[2]*v[1], u[2]*v[0] - u[0]*v[2], u[0]*v[1] - u[1]*v[0]}
+}
+
+// dot3 returns the dot product of vectors u and v.
+func dot3(u [3]float64, v [3]float64) float64 {
+	return u[0]*v[0] + u[1]*v[1] + u[2]*v[2]
+}
+
+// Substring returns the part of the LineString between the distances from and to along it, measured as
+// by Length, in the direction of the LineString whichever is the greater.  Its ends are interpolated along
+// the great circles between points, and distances beyond either end of the LineString are clamped to it.
+func (l LineString) Substring(from Distance, to Distance) LineString {
+	if len(l.points) == 0 {
+		return LineString{crs: l.crs}
+	}
+	if from > to {
+		from, to = to, from
+	}
+
+	start, i := l.pointAt(from)
+	points := []Point{start}
+	end, j := l.pointAt(to)
+	for k := i + 1; k <= j; k++ {
+		if l.points[k] != points[len(points)-1] {
+			points = append(points, l.points[k])
+		}
+	}
+	if end != points[len(points)-1] {
+		points = append(points, end)
+	}
+	return LineString{points: points, crs: l.crs}
+}
+
+// pointAt returns the Point the distance d along the LineString, clamped to its ends, and the index of
+// the point starting the edge it lies on.
+func (l LineString) pointAt(d Distance) (Point, int) {
+	var along Distance
+	for i := 1; i < len(l.points); i++ {
+		edge := l.points[i-1].GreatCircleDistance(l.points[i])
+		if d < along+edge {
+			if d <= along {
+				return l.points[i-1], i - 1
+			}
+			return intermediatePoint(l.points[i-1], l.points[i], float64((d-along)/edge)), i - 1
+		}
+		along += edge
+	}
+	return l.points[len(l.points)-1], len(l.points) - 1
+}
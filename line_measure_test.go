@@ -0,0 +1,144 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+// Ensures that the length of a LineString sums its edges by the chosen method.
+func TestLineStringLength(t *testing.T) {
+	points := []Point{NewPoint(0, 0), NewPoint(0, 1), NewPoint(1, 1), NewPoint(1, 2)}
+	l := NewLineString(points)
+
+	var expected Distance
+	for i := 1; i < len(points); i++ {
+		expected += points[i-1].GreatCircleDistance(points[i])
+	}
+	if d := l.Length(); math.Abs(float64(d-expected)) > 1e-6 {
+		t.Errorf("Expected a length of %v, but got %v", expected, d)
+	}
+	if d := l.LengthWith(Vincenty); math.Abs(float64(d-expected))/float64(expected) > 0.005 || d == expected {
+		t.Errorf("Expected a geodesic length near %v, but got %v", expected, d)
+	}
+	if d := NewLineString(points[:1]).Length(); d != 0 {
+		t.Errorf("Expected no length for a single point, but got %v", d)
+	}
+
+	if d := l.LengthBetween(1, 3); math.Abs(float64(d-(points[1].GreatCircleDistance(points[2])+points[2].GreatCircleDistance(points[3])))) > 1e-6 {
+		t.Errorf("Expected the length of the last two edges, but got %v", d)
+	}
+	if l.LengthBetween(3, 1) != l.LengthBetween(1, 3) || l.LengthBetween(2, 2) != 0 {
+		t.Errorf("Expected lengths between points in either order")
+	}
+}
+
+// Ensures that projecting a point finds the closest position along the line and how far off it lies.
+func TestLineStringProject(t *testing.T) {
+	l := NewLineString([]Point{NewPoint(0, 0), NewPoint(0, 1), NewPoint(1, 1)})
+	first := NewPoint(0, 0).GreatCircleDistance(NewPoint(0, 1))
+
+	tests := []struct {
+		p       Point
+		snapped Point
+		along   Distance
+	}{
+		{NewPoint(0.001, 0.5), NewPoint(0, 0.5), first / 2},
+		{NewPoint(-0.01, -0.2), NewPoint(0, 0), 0},
+		{NewPoint(0.5, 1.002), NewPoint(0.5, 1), first + NewPoint(0, 1).GreatCircleDistance(NewPoint(0.5, 1))},
+		{NewPoint(2, 1), NewPoint(1, 1), l.Length()},
+		{NewPoint(0, 1), NewPoint(0, 1), first},
+	}
+	for _, tt := range tests {
+		snapped, along, offset := l.Project(tt.p)
+		if snapped.GreatCircleDistance(tt.snapped) > 0.01*Meter || math.Abs(float64(along-tt.along)) > 0.01 {
+			t.Errorf("Expected %v snapped to %v at %v, but got %v at %v", tt.p, tt.snapped, tt.along, snapped, along)
+		}
+		if math.Abs(float64(offset-tt.p.GreatCircleDistance(snapped))) > 1e-9 {
+			t.Errorf("Expected the offset of %v to be its distance from %v, but got %v", tt.p, snapped, offset)
+		}
+	}
+
+	// Off a long edge, the closest point follows the great circle rather than the straight line in degrees.
+	long := NewLineString([]Point{NewPoint(50, -60), NewPoint(50, 60)})
+	snapped, _, _ := long.Project(NewPoint(70, 0))
+	if snapped.lat < 60 || math.Abs(snapped.lng) > 1e-9 {
+		t.Errorf("Expected to snap to the great circle north of the 50th parallel, but got %v", snapped)
+	}
+
+	if snapped, along, offset := NewLineString(nil).Project(NewPoint(1, 1)); snapped != (Point{}) || along != 0 || offset != 0 {
+		t.Errorf("Expected zero values for an empty line, but got %v, %v, %v", snapped, along, offset)
+	}
+}
+
+// Ensures that a substring runs between two distances along the line, interpolating its ends.
+func TestLineStringSubstring(t *testing.T) {
+	l := NewLineString([]Point{NewPoint(0, 0), NewPoint(0, 1), NewPoint(1, 1)})
+	first := NewPoint(0, 0).GreatCircleDistance(NewPoint(0, 1))
+	second := NewPoint(0, 1).GreatCircleDistance(NewPoint(1, 1))
+
+	tests := []struct {
+		from, to Distance
+		expected []Point
+	}{
+		{first / 2, first + second/2, []Point{NewPoint(0, 0.5), NewPoint(0, 1), NewPoint(0.5, 1)}},
+		{first + second/2, first / 2, []Point{NewPoint(0, 0.5), NewPoint(0, 1), NewPoint(0.5, 1)}},
+		{-Kilometer, first, []Point{NewPoint(0, 0), NewPoint(0, 1)}},
+		{first, 1000 * Kilometer, []Point{NewPoint(0, 1), NewPoint(1, 1)}},
+		{first / 4, first / 2, []Point{NewPoint(0, 0.25), NewPoint(0, 0.5)}},
+		{first / 2, first / 2, []Point{NewPoint(0, 0.5)}},
+	}
+	for _, tt := range tests {
+		points := l.Substring(tt.from, tt.to).Points()
+		if len(points) != len(tt.expected) {
+			t.Errorf("Expected %v from %v to %v, but got %v", tt.expected, tt.from, tt.to, points)
+			continue
+		}
+		for i, p := range points {
+			if p.GreatCircleDistance(tt.expected[i]) > 0.01*Meter {
+				t.Errorf("Expected %v from %v to %v, but got %v", tt.expected, tt.from, tt.to, points)
+				break
+			}
+		}
+	}
+
+	if s := l.Substring(0, l.Length()); len(s.Points()) != 3 || math.Abs(float64(s.Length()-l.Length())) > 1e-6 {
+		t.Errorf("Expected the whole line, but got %v", s.Points())
+	}
+	if s := NewLineString(nil).Substring(0, Kilometer); len(s.Points()) != 0 {
+		t.Errorf("Expected an empty line, but got %v", s.Points())
+	}
+}
+
+// Ensures that a line is split at the closest point to the one given, within the tolerance.
+func TestLineStringSplitAt(t *testing.T) {
+	l := NewLineString([]Point{NewPoint(0, 0), NewPoint(0, 1), NewPoint(1, 1)})
+
+	tests := []struct {
+		p             Point
+		first, second []Point
+	}{
+		{NewPoint(0.0001, 0.5), []Point{NewPoint(0, 0), NewPoint(0, 0.5)}, []Point{NewPoint(0, 0.5), NewPoint(0, 1), NewPoint(1, 1)}},
+		{NewPoint(-0.0001, 1.0001), []Point{NewPoint(0, 0), NewPoint(0, 1)}, []Point{NewPoint(0, 1), NewPoint(1, 1)}},
+		{NewPoint(0, -0.0001), []Point{NewPoint(0, 0)}, []Point{NewPoint(0, 0), NewPoint(0, 1), NewPoint(1, 1)}},
+		{NewPoint(0.5, 3), []Point{NewPoint(0, 0), NewPoint(0, 1), NewPoint(1, 1)}, nil},
+	}
+	for _, tt := range tests {
+		first, second := l.SplitAt(tt.p, 100*Meter)
+		for _, part := range []struct{ got, expected []Point }{{first.Points(), tt.first}, {second.Points(), tt.second}} {
+			if len(part.got) != len(part.expected) {
+				t.Errorf("Expected %v split into %v and %v, but got %v and %v", tt.p, tt.first, tt.second, first, second)
+				break
+			}
+			for i, p := range part.got {
+				if p.GreatCircleDistance(part.expected[i]) > 0.01*Meter {
+					t.Errorf("Expected %v in %v, but got %v", part.expected, tt.p, part.got)
+					break
+				}
+			}
+		}
+	}
+
+	if first, second := l.SplitAt(NewPoint(0.5, 3), 0); len(first.Points()) != 3 || len(second.Points()) != 2 {
+		t.Errorf("Expected a split anywhere without a tolerance, but got %v and %v", first.Points(), second.Points())
+	}
+}
@@ -0,0 +1,98 @@
+package geo
+
+import (
+	"context"
+	"math"
+)
+
+// DefaultLineOfSightStep is the spacing of terrain samples along the path checked by
+// LineOfSight when none is configured, matching the resolution of three arc second SRTM tiles.
+const DefaultLineOfSightStep = 90 * Meter
+
+// HorizonDistance returns the distance along the surface of the earth to the geometric horizon
+// seen from altitudeMeters above sea level.  Radio waves bend with the atmosphere and reach
+// about 15% further, which LineOfSight accounts for with LineOfSightOptions.RefractionFactor.
+func HorizonDistance(altitudeMeters float64) Distance {
+	return horizonDistance(altitudeMeters, 1)
+}
+
+// horizonDistance returns the distance to the horizon of an earth whose radius is scaled by k.
+func horizonDistance(altitudeMeters float64, k float64) Distance {
+	if altitudeMeters <= 0 {
+		return 0
+	}
+	radius := k * EARTH_RADIUS * float64(Kilometer)
+	return Distance(radius * math.Acos(radius/(radius+altitudeMeters)))
+}
+
+// LineOfSightOptions configures LineOfSight.
+type LineOfSightOptions struct {
+	// Elevator supplies the height of the terrain between the two points.
+	// Without one, the path runs over a smooth earth at sea level.
+	Elevator Elevator
+	// Step is the spacing of terrain samples along the path.  Defaults to DefaultLineOfSightStep.
+	Step Distance
+	// RefractionFactor scales the radius of the earth to account for the atmosphere bending the
+	// line of sight, usually 4/3 for radio planning.  Defaults to 1, the geometric line of sight.
+	RefractionFactor float64
+}
+
+// LineOfSight reports whether the straight line between fromAltitude meters above sea level
+// at Point from and toAltitude meters above sea level at Point to clears the curve of the earth
+// and, when opts.Elevator is set, the terrain in between.  Errors of the Elevator are returned.
+func LineOfSight(ctx context.Context, from Point, fromAltitude float64, to Point, toAltitude float64, opts LineOfSightOptions) (bool, error) {
+	if opts.Step <= 0 {
+		opts.Step = DefaultLineOfSightStep
+	}
+	if opts.RefractionFactor <= 0 {
+		opts.RefractionFactor = 1
+	}
+
+	d := from.GreatCircleDistance(to)
+	if opts.Elevator == nil {
+		return d <= horizonDistance(fromAltitude, opts.RefractionFactor)+horizonDistance(toAltitude, opts.RefractionFactor), nil
+	}
+
+	n := int(math.Ceil(float64(d / opts.Step)))
+	if n < 2 {
+		return true, nil
+	}
+	samples := make([]Point, n-1)
+	for i := range samples {
+		samples[i] = intermediatePoint(from, to, float64(i+1)/float64(n))
+	}
+	terrain, err := opts.Elevator.ElevationsAt(ctx, samples)
+	if err != nil {
+		return false, err
+	}
+
+	radius := opts.RefractionFactor * EARTH_RADIUS * float64(Kilometer)
+	for i, height := range terrain {
+		f := float64(i+1) / float64(n)
+		d1, d2 := f*float64(d), (1-f)*float64(d)
+		// The straight line sags towards the surface by the bulge of the earth between the ends.
+		line := fromAltitude + f*(toAltitude-fromAltitude) - d1*d2/(2*radius)
+		if float64(height) >= line {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// intermediatePoint returns the Point the fraction f of the way along the great circle from p1 to p2.
+func intermediatePoint(p1 Point, p2 Point, f float64) Point {
+	lat1, lng1 := p1.lat*math.Pi/180, p1.lng*math.Pi/180
+	lat2, lng2 := p2.lat*math.Pi/180, p2.lng*math.Pi/180
+
+	delta := p1.GreatCircleDistance(p2).Kilometers() / EARTH_RADIUS
+	if delta == 0 {
+		return p1
+	}
+	a := math.Sin((1-f)*delta) / math.Sin(delta)
+	b := math.Sin(f*delta) / math.Sin(delta)
+
+	x := a*math.Cos(lat1)*math.Cos(lng1) + b*math.Cos(lat2)*math.Cos(lng2)
+	y := a*math.Cos(lat1)*math.Sin(lng1) + b*math.Cos(lat2)*math.Sin(lng2)
+	z := a*math.Sin(lat1) + b*math.Sin(lat2)
+	return NewPoint(math.Atan2(z, math.Hypot(x, y))*180/math.Pi, math.Atan2(y, x)*180/math.Pi)
+}
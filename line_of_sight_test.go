@@ -0,0 +1,105 @@
+package geo
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+)
+
+// Ensures that the horizon is about 4.7km away at eye level and 35.7km away from 100m up.
+func TestHorizonDistance(t *testing.T) {
+	tests := []struct {
+		altitude float64
+		expected Distance
+	}{
+		{-5, 0},
+		{0, 0},
+		{1.7, 4654},
+		{100, 35694},
+	}
+	for _, tt := range tests {
+		if d := HorizonDistance(tt.altitude); math.Abs(float64(d-tt.expected)) > 5 {
+			t.Errorf("Expected the horizon %v away from %vm, but got %v", tt.expected, tt.altitude, d)
+		}
+	}
+}
+
+// hillElevator is an Elevator with a single hill of the passed in height within radius of its top.
+type hillElevator struct {
+	top    Point
+	radius Distance
+	height Distance
+}
+
+func (h hillElevator) ElevationAt(ctx context.Context, p Point) (Distance, error) {
+	if p.GreatCircleDistance(h.top) <= h.radius {
+		return h.height, nil
+	}
+	return 0, nil
+}
+
+func (h hillElevator) ElevationsAt(ctx context.Context, points []Point) ([]Distance, error) {
+	return elevationsAt(ctx, points, h.ElevationAt)
+}
+
+// Ensures that the line of sight is blocked by the curve of the earth and by terrain.
+func TestLineOfSight(t *testing.T) {
+	ctx := context.Background()
+	from := NewPoint(0, 0)
+
+	// Two 100m masts see each other over the sea up to twice the horizon distance apart.
+	near, far := NewPoint(0, 0.63), NewPoint(0, 0.66)
+	if ok, err := LineOfSight(ctx, from, 100, near, 100, LineOfSightOptions{}); !ok || err != nil {
+		t.Errorf("Expected masts %v apart to see each other, but got %v, %v", from.GreatCircleDistance(near), ok, err)
+	}
+	if ok, err := LineOfSight(ctx, from, 100, far, 100, LineOfSightOptions{}); ok || err != nil {
+		t.Errorf("Expected masts %v apart to be hidden by the earth, but got %v, %v", from.GreatCircleDistance(far), ok, err)
+	}
+	if ok, err := LineOfSight(ctx, from, 100, far, 100, LineOfSightOptions{RefractionFactor: 4.0 / 3}); !ok || err != nil {
+		t.Errorf("Expected refraction to carry the line of sight over the horizon, but got %v, %v", ok, err)
+	}
+
+	// Sampling a flat sea agrees with the smooth earth.
+	flat := hillElevator{}
+	if ok, err := LineOfSight(ctx, from, 100, near, 100, LineOfSightOptions{Elevator: flat, Step: Kilometer}); !ok || err != nil {
+		t.Errorf("Expected masts over a flat sea to see each other, but got %v, %v", ok, err)
+	}
+	if ok, err := LineOfSight(ctx, from, 100, far, 100, LineOfSightOptions{Elevator: flat, Step: Kilometer}); ok || err != nil {
+		t.Errorf("Expected masts over a flat sea to be hidden by the earth, but got %v, %v", ok, err)
+	}
+
+	// A hill halfway between 10km apart masts blocks them once it rises above the line between them.
+	to := NewPoint(0, 0.09)
+	for _, tt := range []struct {
+		height   Distance
+		expected bool
+	}{
+		{40, true},
+		{60, false},
+	} {
+		hill := hillElevator{top: NewPoint(0, 0.045), radius: 200, height: tt.height}
+		if ok, err := LineOfSight(ctx, from, 50, to, 50, LineOfSightOptions{Elevator: hill}); ok != tt.expected || err != nil {
+			t.Errorf("Expected a %v hill to leave the line of sight %v, but got %v, %v", tt.height, tt.expected, ok, err)
+		}
+	}
+
+	srtm := NewSRTMElevator(t.TempDir())
+	if _, err := LineOfSight(ctx, from, 50, to, 50, LineOfSightOptions{Elevator: srtm}); !errors.Is(err, ErrOutOfBounds) {
+		t.Errorf("Expected missing terrain to return ErrOutOfBounds, but got %v", err)
+	}
+}
+
+// Ensures that intermediate points lie on the great circle between the ends.
+func TestIntermediatePoint(t *testing.T) {
+	p1, p2 := NewPoint(0, 0), NewPoint(0, 90)
+	if p := intermediatePoint(p1, p2, 0.5); math.Abs(p.lat) > 1e-9 || math.Abs(p.lng-45) > 1e-9 {
+		t.Errorf("Expected 0,45, but got %v", p)
+	}
+	if p := intermediatePoint(NewPoint(45, 0), NewPoint(45, 180), 0.5); math.Abs(p.lat-90) > 1e-9 {
+		t.Errorf("Expected the north pole, but got %v", p)
+	}
+	if p := intermediatePoint(p1, p1, 0.5); p != p1 {
+		t.Errorf("Expected %v, but got %v", p1, p)
+	}
+}
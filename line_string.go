@@ -0,0 +1,56 @@
+package geo
+
+import "fmt"
+
+// A LineString is an ordered sequence of points joined by straight edges.
+// Unlike a Polygon, the last point is not joined back to the first.
+type LineString struct {
+	points []Point
+	crs    CRS
+}
+
+// NewLineString returns a new LineString composed of the passed in points.
+func NewLineString(points []Point) LineString {
+	return LineString{points: points}
+}
+
+// Points returns the points of the LineString.
+func (l LineString) Points() []Point {
+	return l.points
+}
+
+// Bounds returns the smallest BoundingBox containing every point of the LineString.
+func (l LineString) Bounds() BoundingBox {
+	return pointsBounds(l.points)
+}
+
+// String renders the LineString as its point count and bounds, like Polygon.String.
+func (l LineString) String() string {
+	return fmt.Sprintf("LineString(%d points, %v)", len(l.points), l.Bounds())
+}
+
+// CRS returns the coordinate reference system the points of the LineString are expressed in.
+// LineStrings are in WGS84 unless stated otherwise.
+func (l LineString) CRS() CRS {
+	if l.crs == 0 {
+		return WGS84
+	}
+	return l.crs
+}
+
+// WithCRS returns a copy of the LineString labelled with the passed in reference system.
+// The points themselves are not converted; use Transform for that.
+func (l LineString) WithCRS(crs CRS) LineString {
+	l.crs = crs
+	return l
+}
+
+// Transform returns a new LineString with every point converted into the passed in reference system.
+func (l LineString) Transform(to CRS) (LineString, error) {
+	points, err := transformPoints(l.points, l.CRS(), to)
+	if err != nil {
+		return LineString{}, err
+	}
+
+	return LineString{points: points, crs: to}, nil
+}
@@ -0,0 +1,17 @@
+package geo
+
+import "testing"
+
+// Ensures that a LineString keeps its points and reports their bounds.
+func TestLineString(t *testing.T) {
+	line := NewLineString([]Point{NewPoint(0, 0), NewPoint(2, -1), NewPoint(1, 3)})
+
+	if len(line.Points()) != 3 {
+		t.Errorf("Expected the line to have 3 points, but got %d instead", len(line.Points()))
+	}
+
+	b := line.Bounds()
+	if b.SouthWest() != NewPoint(0, -1) || b.NorthEast() != NewPoint(2, 3) {
+		t.Errorf("Expected bounds (0,-1) to (2,3), but got %v to %v", b.SouthWest(), b.NorthEast())
+	}
+}
@@ -0,0 +1,68 @@
+package geo
+
+import "math"
+
+// A LocalFrame is a local tangent plane anchored at a reference origin on the WGS84 ellipsoid.
+// Coordinates within the frame are in meters, either East-North-Up (ENU)
+// or North-East-Down (NED) relative to the origin.
+type LocalFrame struct {
+	origin   Point
+	altitude float64
+
+	x0, y0, z0     float64
+	sinLat, cosLat float64
+	sinLng, cosLng float64
+}
+
+// NewLocalFrame returns a new LocalFrame anchored at the passed in origin
+// and ellipsoidal altitude in meters.
+func NewLocalFrame(origin Point, altitude float64) LocalFrame {
+	x0, y0, z0 := WGS84Ellipsoid.ToCartesian(origin.lat, origin.lng, altitude)
+	lat := origin.lat * math.Pi / 180
+	lng := origin.lng * math.Pi / 180
+
+	return LocalFrame{
+		origin:   origin,
+		altitude: altitude,
+		x0:       x0, y0: y0, z0: z0,
+		sinLat: math.Sin(lat), cosLat: math.Cos(lat),
+		sinLng: math.Sin(lng), cosLng: math.Cos(lng),
+	}
+}
+
+// Origin returns the reference origin and altitude of the LocalFrame.
+func (f LocalFrame) Origin() (Point, float64) {
+	return f.origin, f.altitude
+}
+
+// ToENU returns the East-North-Up coordinates of Point p at the passed in altitude.
+func (f LocalFrame) ToENU(p Point, altitude float64) (east float64, north float64, up float64) {
+	x, y, z := WGS84Ellipsoid.ToCartesian(p.lat, p.lng, altitude)
+	dx, dy, dz := x-f.x0, y-f.y0, z-f.z0
+
+	east = -f.sinLng*dx + f.cosLng*dy
+	north = -f.sinLat*f.cosLng*dx - f.sinLat*f.sinLng*dy + f.cosLat*dz
+	up = f.cosLat*f.cosLng*dx + f.cosLat*f.sinLng*dy + f.sinLat*dz
+	return east, north, up
+}
+
+// FromENU returns the Point and altitude at the passed in East-North-Up coordinates.
+func (f LocalFrame) FromENU(east float64, north float64, up float64) (Point, float64) {
+	dx := -f.sinLng*east - f.sinLat*f.cosLng*north + f.cosLat*f.cosLng*up
+	dy := f.cosLng*east - f.sinLat*f.sinLng*north + f.cosLat*f.sinLng*up
+	dz := f.cosLat*north + f.sinLat*up
+
+	lat, lng, h := WGS84Ellipsoid.FromCartesian(f.x0+dx, f.y0+dy, f.z0+dz)
+	return NewPoint(lat, lng), h
+}
+
+// ToNED returns the North-East-Down coordinates of Point p at the passed in altitude.
+func (f LocalFrame) ToNED(p Point, altitude float64) (north float64, east float64, down float64) {
+	east, north, up := f.ToENU(p, altitude)
+	return north, east, -up
+}
+
+// FromNED returns the Point and altitude at the passed in North-East-Down coordinates.
+func (f LocalFrame) FromNED(north float64, east float64, down float64) (Point, float64) {
+	return f.FromENU(east, north, -down)
+}
@@ -0,0 +1,57 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+// Ensures that the axes of the local frame point east, north and up.
+func TestLocalFrameAxes(t *testing.T) {
+	f := NewLocalFrame(NewPoint(47.6062, -122.3321), 50)
+
+	if e, n, u := f.ToENU(NewPoint(47.6062, -122.3321), 50); math.Abs(e)+math.Abs(n)+math.Abs(u) > 1e-6 {
+		t.Errorf("Expected the origin to be at 0,0,0 but got %v,%v,%v", e, n, u)
+	}
+
+	e, n, u := f.ToENU(NewPoint(47.6072, -122.3321), 50)
+	if math.Abs(e) > 0.01 || math.Abs(n-111.2) > 0.5 || math.Abs(u) > 0.01 {
+		t.Errorf("Expected a point 0.001° north to be ~111m north, but got %v,%v,%v", e, n, u)
+	}
+
+	e, n, u = f.ToENU(NewPoint(47.6062, -122.3311), 50)
+	if math.Abs(e-75.2) > 0.5 || math.Abs(n) > 0.01 {
+		t.Errorf("Expected a point 0.001° east to be ~75m east, but got %v,%v,%v", e, n, u)
+	}
+
+	_, _, u = f.ToENU(NewPoint(47.6062, -122.3321), 150)
+	if math.Abs(u-100) > 1e-6 {
+		t.Errorf("Expected a point 100m higher to be 100m up, but got %v", u)
+	}
+
+	n, e, d := f.ToNED(NewPoint(47.6072, -122.3311), 40)
+	e2, n2, u2 := f.ToENU(NewPoint(47.6072, -122.3311), 40)
+	if n != n2 || e != e2 || d != -u2 {
+		t.Errorf("Expected NED to be a permutation of ENU, but got %v,%v,%v and %v,%v,%v", n, e, d, e2, n2, u2)
+	}
+}
+
+// Ensures that local coordinates convert back to the points they came from.
+func TestLocalFrameRoundTrip(t *testing.T) {
+	f := NewLocalFrame(NewPoint(-33.8568, 151.2153), 0)
+
+	p, h := f.FromENU(1200, -850, 35)
+	e, n, u := f.ToENU(p, h)
+	if math.Abs(e-1200) > 1e-4 || math.Abs(n+850) > 1e-4 || math.Abs(u-35) > 1e-4 {
+		t.Errorf("Expected 1200,-850,35 to round trip, but got %v,%v,%v", e, n, u)
+	}
+
+	p, h = f.FromNED(10, 20, 5)
+	n, e, d := f.ToNED(p, h)
+	if math.Abs(n-10) > 1e-4 || math.Abs(e-20) > 1e-4 || math.Abs(d-5) > 1e-4 {
+		t.Errorf("Expected 10,20,5 to round trip, but got %v,%v,%v", n, e, d)
+	}
+
+	if origin, alt := f.Origin(); origin != NewPoint(-33.8568, 151.2153) || alt != 0 {
+		t.Errorf("Expected the origin to be kept, but got %v at %v", origin, alt)
+	}
+}
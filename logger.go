@@ -0,0 +1,22 @@
+package geo
+
+import "sync/atomic"
+
+// A LoggerFunc receives debug traces of parsing and indexing operations.
+// Its arguments follow the conventions of fmt.Printf.
+type LoggerFunc func(format string, args ...interface{})
+
+var logger atomic.Value
+
+// SetLogger installs f as the package's debug logger.  The package is silent by default;
+// passing nil silences it again.  It is safe to call concurrently with other functions.
+func SetLogger(f LoggerFunc) {
+	logger.Store(f)
+}
+
+// debugf passes a trace message to the installed LoggerFunc, if any.
+func debugf(format string, args ...interface{}) {
+	if f, _ := logger.Load().(LoggerFunc); f != nil {
+		f(format, args...)
+	}
+}
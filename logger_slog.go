@@ -0,0 +1,24 @@
+//go:build go1.21
+
+package geo
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// SetSlogLogger routes the package's debug traces to l at slog.LevelDebug.
+// Passing nil silences the package again.
+func SetSlogLogger(l *slog.Logger) {
+	if l == nil {
+		SetLogger(nil)
+		return
+	}
+
+	SetLogger(func(format string, args ...interface{}) {
+		if l.Enabled(context.Background(), slog.LevelDebug) {
+			l.Debug(fmt.Sprintf(format, args...))
+		}
+	})
+}
@@ -0,0 +1,31 @@
+package geo
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// Ensures that debug traces reach an installed LoggerFunc and stop once it is removed.
+func TestSetLogger(t *testing.T) {
+	var messages []string
+	SetLogger(func(format string, args ...interface{}) {
+		messages = append(messages, fmt.Sprintf(format, args...))
+	})
+	defer SetLogger(nil)
+
+	var p Point
+	if err := p.UnmarshalJSON([]byte(`{"lat": "north"}`)); err == nil {
+		t.Fatal("Expected an error when unmarshalling an invalid point")
+	}
+
+	if len(messages) != 1 || !strings.Contains(messages[0], "unable to decode point") {
+		t.Errorf("Expected a single decode trace, but got %q", messages)
+	}
+
+	SetLogger(nil)
+	ParsePoint("north, south")
+	if len(messages) != 1 {
+		t.Errorf("Expected no traces after removing the logger, but got %q", messages[1:])
+	}
+}
@@ -0,0 +1,240 @@
+package geo
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// magneticModelLifespan is the number of years after its epoch a MagneticModel is valid for.
+const magneticModelLifespan = 5
+
+// A MagneticModel is a spherical harmonic model of the earth's main magnetic field, such as
+// the World Magnetic Model (https://www.ncei.noaa.gov/products/world-magnetic-model).
+// Each model is fitted at an epoch and extrapolated linearly for the five years that follow it.
+// It is immutable once loaded and safe for concurrent use.
+type MagneticModel struct {
+	name   string
+	epoch  float64
+	degree int
+	// g, h and their yearly secular variation are Schmidt semi-normalized Gauss coefficients
+	// in nanotesla, stored at index n*(n+1)/2 + m for degree n and order m.
+	g, h, gDot, hDot []float64
+}
+
+// LoadMagneticModel reads a MagneticModel from a coefficient file in the format of the WMM.COF
+// file NOAA publishes with each release of the World Magnetic Model.
+func LoadMagneticModel(r io.Reader) (*MagneticModel, error) {
+	scanner := bufio.NewScanner(r)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: empty magnetic model", ErrInvalidFormat)
+	}
+
+	header := strings.Fields(scanner.Text())
+	if len(header) < 2 {
+		return nil, fmt.Errorf("%w: invalid magnetic model header %q", ErrInvalidFormat, scanner.Text())
+	}
+	epoch, err := strconv.ParseFloat(header[0], 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid magnetic model epoch %q", ErrInvalidFormat, header[0])
+	}
+
+	m := &MagneticModel{name: header[1], epoch: epoch}
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		// The coefficients are followed by lines of nines.
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "9999") {
+			break
+		}
+		if len(fields) != 6 {
+			return nil, fmt.Errorf("%w: invalid magnetic model line %q", ErrInvalidFormat, scanner.Text())
+		}
+
+		var values [6]float64
+		for i, f := range fields {
+			if values[i], err = strconv.ParseFloat(f, 64); err != nil {
+				return nil, fmt.Errorf("%w: invalid magnetic model line %q", ErrInvalidFormat, scanner.Text())
+			}
+		}
+		n, order := int(values[0]), int(values[1])
+		if n < 1 || order < 0 || order > n || n > 100 {
+			return nil, fmt.Errorf("%w: invalid magnetic model degree %d and order %d", ErrInvalidFormat, n, order)
+		}
+
+		if n > m.degree {
+			size := (n + 1) * (n + 2) / 2
+			for _, c := range []*[]float64{&m.g, &m.h, &m.gDot, &m.hDot} {
+				*c = append(*c, make([]float64, size-len(*c))...)
+			}
+			m.degree = n
+		}
+		k := n*(n+1)/2 + order
+		m.g[k], m.h[k], m.gDot[k], m.hDot[k] = values[2], values[3], values[4], values[5]
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if m.degree == 0 {
+		return nil, fmt.Errorf("%w: magnetic model has no coefficients", ErrInvalidFormat)
+	}
+
+	return m, nil
+}
+
+// Name returns the name of the model, such as "WMM-2025".
+func (m *MagneticModel) Name() string {
+	return m.name
+}
+
+// Epoch returns the decimal year the model was fitted at.
+func (m *MagneticModel) Epoch() float64 {
+	return m.epoch
+}
+
+// ValidAt reports whether date lies in the five years from the epoch of the model that it is
+// intended for.  Outside of them Declination extrapolates and quickly loses accuracy.
+func (m *MagneticModel) ValidAt(date time.Time) bool {
+	year := decimalYear(date)
+	return year >= m.epoch && year < m.epoch+magneticModelLifespan
+}
+
+// DeclinationValidated returns the declination like Declination, but returns an error wrapping
+// ErrInvalidDate for dates the model is not valid at.
+func (m *MagneticModel) DeclinationValidated(p Point, date time.Time) (float64, error) {
+	if !m.ValidAt(date) {
+		return 0, fmt.Errorf("%w: %s is valid from %v to %v, not at %s", ErrInvalidDate, m.name, m.epoch, m.epoch+magneticModelLifespan, date.Format(time.DateOnly))
+	}
+	return m.Declination(p, date), nil
+}
+
+// Declination returns the angle in degrees from true north to magnetic north at Point p
+// on the ellipsoid at the passed in date, positive when magnetic north lies east of true north.
+// Adding it to a magnetic bearing, such as the heading of a device's compass, gives the true bearing.
+// Dates the model is not valid at are extrapolated silently; see DeclinationValidated.
+func (m *MagneticModel) Declination(p Point, date time.Time) float64 {
+	north, east := m.field(p, decimalYear(date))
+	return math.Atan2(east, north) * 180 / math.Pi
+}
+
+// field returns the northward and eastward components in nanotesla of the magnetic field
+// at Point p on the ellipsoid at the passed in decimal year.
+func (m *MagneticModel) field(p Point, year float64) (north float64, east float64) {
+	// The model is expressed in geocentric spherical coordinates, with the radius in kilometers.
+	const referenceRadius = 6371.2
+	x, y, z := WGS84Ellipsoid.ToCartesian(p.lat, p.lng, 0)
+	r := math.Sqrt(x*x+y*y+z*z) / 1000
+	phi := math.Asin(z / 1000 / r)
+	lambda := p.lng * math.Pi / 180
+
+	sinPhi, cosPhi := math.Sin(phi), math.Cos(phi)
+	// Keep the east component finite at the poles, where it is undefined.
+	cosPhi = max(cosPhi, 1e-10)
+
+	// Schmidt semi-normalized associated Legendre functions of sin(phi) and their derivatives
+	// by phi, indexed like the coefficients.
+	size := len(m.g)
+	pnm, dpnm := make([]float64, size), make([]float64, size)
+	pnm[0] = 1
+	for n := 1; n <= m.degree; n++ {
+		k := n * (n + 1) / 2
+		for order := 0; order < n; order++ {
+			prev := (n-1)*n/2 + order
+			a := float64(2*n-1) / math.Sqrt(float64(n*n-order*order))
+			pnm[k+order] = a * sinPhi * pnm[prev]
+			dpnm[k+order] = a * (sinPhi*dpnm[prev] + cosPhi*pnm[prev])
+			if n >= 2 && order <= n-2 {
+				b := math.Sqrt(float64((n-1)*(n-1)-order*order) / float64(n*n-order*order))
+				prev2 := (n-2)*(n-1)/2 + order
+				pnm[k+order] -= b * pnm[prev2]
+				dpnm[k+order] -= b * dpnm[prev2]
+			}
+		}
+
+		diag := (n-1)*n/2 + n - 1
+		c := 1.0
+		if n > 1 {
+			c = math.Sqrt(float64(2*n-1) / float64(2*n))
+		}
+		pnm[k+n] = c * cosPhi * pnm[diag]
+		dpnm[k+n] = c * (cosPhi*dpnm[diag] - sinPhi*pnm[diag])
+	}
+
+	dt := year - m.epoch
+	var bx, by, bz float64
+	ratio := referenceRadius / r
+	scale := ratio * ratio
+	for n := 1; n <= m.degree; n++ {
+		scale *= ratio
+		k := n * (n + 1) / 2
+		for order := 0; order <= n; order++ {
+			g := m.g[k+order] + dt*m.gDot[k+order]
+			h := m.h[k+order] + dt*m.hDot[k+order]
+			sin, cos := math.Sincos(float64(order) * lambda)
+
+			bx -= scale * (g*cos + h*sin) * dpnm[k+order]
+			by += scale * float64(order) * (g*sin - h*cos) * pnm[k+order]
+			bz -= scale * float64(n+1) * (g*cos + h*sin) * pnm[k+order]
+		}
+	}
+	by /= cosPhi
+
+	// Rotate the northward component from the geocentric onto the geodetic latitude.
+	psi := phi - p.lat*math.Pi/180
+	return bx*math.Cos(psi) - bz*math.Sin(psi), by
+}
+
+// decimalYear returns the year of t plus the elapsed fraction of that year.
+func decimalYear(t time.Time) float64 {
+	t = t.UTC()
+	start := time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(1, 0, 0)
+	return float64(t.Year()) + float64(t.Sub(start))/float64(end.Sub(start))
+}
+
+var (
+	magneticModel        atomic.Pointer[MagneticModel]
+	defaultMagneticModel = sync.OnceValue(func() *MagneticModel {
+		m, err := LoadMagneticModel(strings.NewReader(wmm2025COF))
+		if err != nil {
+			panic(err)
+		}
+		return m
+	})
+)
+
+// SetMagneticModel installs m as the model used by Declination.  It is safe to call concurrently with Declination.
+func SetMagneticModel(m *MagneticModel) {
+	magneticModel.Store(m)
+}
+
+// Declination returns the angle in degrees from true north to magnetic north at Point p
+// at the passed in date, according to the model installed with SetMagneticModel.
+// Until one is installed, the built in World Magnetic Model 2025 is used, which is intended
+// for dates from 2025 to 2030; later dates are better served by loading a newer WMM.COF.
+// Dates outside of the model's validity are extrapolated silently; see DeclinationValidated.
+func Declination(p Point, date time.Time) float64 {
+	return currentMagneticModel().Declination(p, date)
+}
+
+// DeclinationValidated returns the declination like Declination, but returns an error wrapping
+// ErrInvalidDate for dates the installed model is not valid at.
+func DeclinationValidated(p Point, date time.Time) (float64, error) {
+	return currentMagneticModel().DeclinationValidated(p, date)
+}
+
+// currentMagneticModel returns the model installed with SetMagneticModel, or the built in one.
+func currentMagneticModel() *MagneticModel {
+	if m := magneticModel.Load(); m != nil {
+		return m
+	}
+	return defaultMagneticModel()
+}
@@ -0,0 +1,135 @@
+package geo
+
+import (
+	"errors"
+	"math"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Ensures that the World Magnetic Model 2020 reproduces the test values published with it.
+func TestDeclination(t *testing.T) {
+	defer SetMagneticModel(nil)
+	wmm2020 := loadWMM2020(t)
+	SetMagneticModel(wmm2020)
+
+	epoch := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		p           Point
+		north, east float64
+		declination float64
+	}{
+		{NewPoint(80, 0), 6570.4, -146.3, -1.28},
+		{NewPoint(0, 120), 39624.3, 109.9, 0.16},
+		{NewPoint(-80, 240), 5940.6, 15772.1, 69.36},
+	}
+
+	for _, tt := range tests {
+		north, east := wmm2020.field(tt.p, 2020)
+		if math.Abs(north-tt.north) > 0.1 || math.Abs(east-tt.east) > 0.1 {
+			t.Errorf("Expected a field of %v, %v nT at %v, but got %v, %v", tt.north, tt.east, tt.p, north, east)
+		}
+		if d := Declination(tt.p, epoch); math.Abs(d-tt.declination) > 0.01 {
+			t.Errorf("Expected a declination of %v at %v, but got %v", tt.declination, tt.p, d)
+		}
+	}
+}
+
+// Ensures that the built in World Magnetic Model 2025 agrees with the 2020 model extrapolated
+// to 2025, within the few hundred nanotesla the field drifts from its forecast.
+func TestDefaultMagneticModel(t *testing.T) {
+	wmm2025, wmm2020 := defaultMagneticModel(), loadWMM2020(t)
+	if wmm2025.Name() != "WMM-2025" || wmm2025.Epoch() != 2025 {
+		t.Fatalf("Expected WMM-2025 at 2025, but got %s at %v", wmm2025.Name(), wmm2025.Epoch())
+	}
+
+	for lat := -80.0; lat <= 80; lat += 20 {
+		for lng := -180.0; lng < 180; lng += 30 {
+			p := NewPoint(lat, lng)
+			n1, e1 := wmm2025.field(p, 2025)
+			n2, e2 := wmm2020.field(p, 2025)
+			if d := math.Hypot(n1-n2, e1-e2); d > 300 {
+				t.Errorf("Expected the models to agree within 300 nT at %v, but they differ by %v", p, d)
+			}
+		}
+	}
+}
+
+// Ensures that dates outside of the five years from the epoch of a model are reported.
+func TestDeclinationValidated(t *testing.T) {
+	p := NewPoint(-33.8688, 151.2093)
+	date := time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC)
+	d, err := DeclinationValidated(p, date)
+	if err != nil || d != Declination(p, date) {
+		t.Errorf("Expected the declination in 2026, but got %v, %v", d, err)
+	}
+
+	for _, date := range []time.Time{
+		time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC),
+		time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC),
+	} {
+		if defaultMagneticModel().ValidAt(date) {
+			t.Errorf("Expected WMM-2025 not to be valid at %v", date)
+		}
+		if _, err := DeclinationValidated(p, date); !errors.Is(err, ErrInvalidDate) {
+			t.Errorf("Expected ErrInvalidDate at %v, but got %v", date, err)
+		}
+	}
+}
+
+// loadWMM2020 loads the coefficients of the World Magnetic Model 2020 from the test data.
+func loadWMM2020(t *testing.T) *MagneticModel {
+	t.Helper()
+	f, err := os.Open("test/data/wmm2020.cof")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	m, err := LoadMagneticModel(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+// Ensures that an installed model replaces the built in one.
+func TestSetMagneticModel(t *testing.T) {
+	defer SetMagneticModel(nil)
+
+	// An axial dipole points at true north everywhere, until its secular variation tilts it eastwards.
+	m, err := LoadMagneticModel(strings.NewReader("2025.0 DIPOLE 01/01/2025\n  1  0  -30000.0  0.0  0.0  0.0\n  1  1  0.0  0.0  0.0  -1000.0\n999999999999999999999999999999\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Name() != "DIPOLE" || m.Epoch() != 2025 {
+		t.Errorf("Expected DIPOLE at 2025, but got %s at %v", m.Name(), m.Epoch())
+	}
+	SetMagneticModel(m)
+
+	p := NewPoint(0, 0)
+	if d := Declination(p, time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)); math.Abs(d) > 1e-9 {
+		t.Errorf("Expected no declination at the epoch, but got %v", d)
+	}
+	if d := Declination(p, time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)); d <= 0 {
+		t.Errorf("Expected an eastward declination a year later, but got %v", d)
+	}
+}
+
+// Ensures that malformed coefficient files are rejected.
+func TestLoadMagneticModelInvalid(t *testing.T) {
+	for _, cof := range []string{
+		"",
+		"2025.0\n",
+		"epoch WMM\n  1  0  -30000.0  0.0  0.0  0.0\n",
+		"2025.0 WMM\n",
+		"2025.0 WMM\n  1  2  -30000.0  0.0  0.0  0.0\n",
+		"2025.0 WMM\n  1  0  -30000.0  0.0  0.0\n",
+	} {
+		if _, err := LoadMagneticModel(strings.NewReader(cof)); !errors.Is(err, ErrInvalidFormat) {
+			t.Errorf("Expected %q to return ErrInvalidFormat, but got %v", cof, err)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// MaxMaidenheadPrecision is the largest number of character pairs supported in a Maidenhead locator.
+const MaxMaidenheadPrecision = 5
+
+// The number of subdivisions of each Maidenhead pair: fields, squares, subsquares,
+// extended squares and extended subsquares alternate between letters and digits.
+var maidenheadDivisions = [MaxMaidenheadPrecision]int{18, 10, 24, 10, 24}
+
+// EncodeMaidenhead returns the Maidenhead (QTH) locator of Point p with the passed in
+// number of character pairs, e.g. "JN58td" for 3 pairs.
+// The precision is clamped to the range [1, MaxMaidenheadPrecision].
+func EncodeMaidenhead(p Point, pairs int) string {
+	if pairs < 1 {
+		pairs = 1
+	}
+	if pairs > MaxMaidenheadPrecision {
+		pairs = MaxMaidenheadPrecision
+	}
+
+	// Work in the unit square, nudging the north and east edges inwards
+	// so that 90°N and 180°E fall in the last field rather than past it.
+	lng := math.Min((p.lng+180)/360, math.Nextafter(1, 0))
+	lat := math.Min((p.lat+90)/180, math.Nextafter(1, 0))
+
+	var sb strings.Builder
+	sb.Grow(2 * pairs)
+	for i := 0; i < pairs; i++ {
+		n := float64(maidenheadDivisions[i])
+		lng *= n
+		lat *= n
+		x, y := math.Floor(lng), math.Floor(lat)
+		lng -= x
+		lat -= y
+
+		sb.WriteByte(maidenheadChar(i, int(x)))
+		sb.WriteByte(maidenheadChar(i, int(y)))
+	}
+
+	return sb.String()
+}
+
+// DecodeMaidenhead returns the area described by the passed in Maidenhead locator.
+// Letters are accepted in either case.
+func DecodeMaidenhead(locator string) (BoundingBox, error) {
+	if len(locator) == 0 || len(locator)%2 != 0 || len(locator) > 2*MaxMaidenheadPrecision {
+		return BoundingBox{}, fmt.Errorf("%w: invalid Maidenhead locator length %d in %q", ErrInvalidFormat, len(locator), locator)
+	}
+
+	lng, lat := -180.0, -90.0
+	width, height := 360.0, 180.0
+	for i := 0; i < len(locator)/2; i++ {
+		n := maidenheadDivisions[i]
+		x, okX := maidenheadIndex(i, locator[2*i])
+		y, okY := maidenheadIndex(i, locator[2*i+1])
+		if !okX || !okY || x >= n || y >= n {
+			return BoundingBox{}, fmt.Errorf("%w: invalid Maidenhead locator %q", ErrInvalidFormat, locator)
+		}
+
+		width /= float64(n)
+		height /= float64(n)
+		lng += float64(x) * width
+		lat += float64(y) * height
+	}
+
+	return NewBoundingBox(NewPoint(lat, lng), NewPoint(lat+height, lng+width)), nil
+}
+
+// maidenheadChar returns the character for index v of pair i.
+// Fields are upper case and subsquares lower case, as is conventional.
+func maidenheadChar(i int, v int) byte {
+	switch {
+	case i%2 == 1:
+		return byte('0' + v)
+	case i == 0:
+		return byte('A' + v)
+	}
+	return byte('a' + v)
+}
+
+// maidenheadIndex returns the index encoded by character c of pair i.
+func maidenheadIndex(i int, c byte) (int, bool) {
+	if i%2 == 1 {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+		return int(c - '0'), true
+	}
+
+	switch {
+	case c >= 'A' && c <= 'Z':
+		return int(c - 'A'), true
+	case c >= 'a' && c <= 'z':
+		return int(c - 'a'), true
+	}
+	return 0, false
+}
@@ -0,0 +1,53 @@
+package geo
+
+import "testing"
+
+// Ensures that points encode to well known Maidenhead locators.
+func TestEncodeMaidenhead(t *testing.T) {
+	tests := []struct {
+		p        Point
+		pairs    int
+		expected string
+	}{
+		{NewPoint(48.14666, 11.60833), 3, "JN58td"},
+		{NewPoint(41.714775, -72.727260), 3, "FN31pr"},
+		{NewPoint(41.714775, -72.727260), 1, "FN"},
+		{NewPoint(-90, -180), 2, "AA00"},
+		{NewPoint(90, 180), 2, "RR99"},
+	}
+
+	for _, tt := range tests {
+		if got := EncodeMaidenhead(tt.p, tt.pairs); got != tt.expected {
+			t.Errorf("Expected %v to encode to %s, but got %s instead", tt.p, tt.expected, got)
+		}
+	}
+
+	if got := EncodeMaidenhead(NewPoint(48.14666, 11.60833), 5); len(got) != 10 {
+		t.Errorf("Expected 5 pairs to produce 10 characters, but got %s", got)
+	}
+}
+
+// Ensures that decoded locators cover the points they were encoded from.
+func TestDecodeMaidenhead(t *testing.T) {
+	p := NewPoint(48.14666, 11.60833)
+	for pairs := 1; pairs <= MaxMaidenheadPrecision; pairs++ {
+		b, err := DecodeMaidenhead(EncodeMaidenhead(p, pairs))
+		if err != nil {
+			t.Fatalf("Should not encounter an error when decoding, but got %v", err)
+		}
+		if !b.Contains(p) {
+			t.Errorf("Expected the %d pair locator %v to contain %v", pairs, b, p)
+		}
+	}
+
+	b, err := DecodeMaidenhead("jn58TD")
+	if err != nil || !b.Contains(p) {
+		t.Errorf("Expected mixed case locators to decode, got %v (%v)", b, err)
+	}
+
+	for _, invalid := range []string{"", "J", "JN5", "SN58", "JNA8", "JN58td0"} {
+		if _, err := DecodeMaidenhead(invalid); err == nil {
+			t.Errorf("Expected an error when decoding %q", invalid)
+		}
+	}
+}
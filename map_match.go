@@ -0,0 +1,313 @@
+package geo
+
+import (
+	"math"
+	"sort"
+)
+
+const (
+	// DefaultMatchSearchRadius is the MapMatcherOptions.SearchRadius used when none is configured.
+	DefaultMatchSearchRadius = 50 * Meter
+	// DefaultMatchSigma is the MapMatcherOptions.Sigma used when none is configured, the GPS noise
+	// measured by Newson and Krumm.
+	DefaultMatchSigma = 4.07 * Meter
+	// DefaultMatchBeta is the MapMatcherOptions.Beta used when none is configured.
+	DefaultMatchBeta = 5 * Meter
+)
+
+// MapMatcherOptions configures a MapMatcher.
+type MapMatcherOptions struct {
+	// SearchRadius is how far from a fix lines are considered as its match.
+	// Fixes further than it from every line are left unmatched.  Defaults to DefaultMatchSearchRadius.
+	SearchRadius Distance
+	// Sigma is the standard deviation of the error of fixes with no Accuracy of their own.
+	// Defaults to DefaultMatchSigma.
+	Sigma Distance
+	// Beta is how much the distance travelled along the lines between two matches may differ
+	// from the distance between their fixes before the match becomes unlikely.  Larger values
+	// tolerate detours.  Defaults to DefaultMatchBeta.
+	Beta Distance
+	// LineChangePenalty is the distance added to the travel between matches on different lines,
+	// which keeps matches on one line where lines run close together.
+	LineChangePenalty Distance
+}
+
+// A MapMatcher snaps Tracks to a network of reference LineStrings, such as the roads of a route
+// or the lines of a transit network, by finding the most likely sequence of positions on the lines
+// with the hidden Markov model of Newson and Krumm, "Hidden Markov map matching through noise and
+// sparseness" (2009).  As the lines carry no topology, the distance travelled between two matches
+// is measured along the line when they share one and straight across otherwise.
+// It is safe for concurrent use.
+type MapMatcher struct {
+	lines []LineString
+	// offsets holds the distance along each line to each of its points.
+	offsets  [][]Distance
+	segments []matchSegment
+	tree     *RTree[indexedBounds]
+	opts     MapMatcherOptions
+}
+
+// matchSegment is the segment of a line from its i'th point to the next.
+type matchSegment struct {
+	line, i int
+}
+
+// A MatchedPoint is a fix of a Track snapped to a line of a MapMatcher.
+type MatchedPoint struct {
+	// Fix is the fix of the Track.
+	Fix TrackPoint
+	// Matched is false for fixes with no line within the search radius, which have no other fields.
+	Matched bool
+	// Point is the position of the fix on the line.
+	Point Point
+	// Line is the index of the line in the MapMatcher.
+	Line int
+	// Offset is the distance along the line to Point.
+	Offset Distance
+	// Distance is the distance from the fix to Point.
+	Distance Distance
+}
+
+// LineCoverage is how much of a line of a MapMatcher a Track travelled along.
+type LineCoverage struct {
+	// Line is the index of the line in the MapMatcher.
+	Line int
+	// Length is the length of the line.
+	Length Distance
+	// Covered is the length of the parts of the line between consecutive fixes matched to it.
+	Covered Distance
+}
+
+// Fraction returns the share of the line that was covered, from 0 to 1.
+func (c LineCoverage) Fraction() float64 {
+	if c.Length <= 0 {
+		return 0
+	}
+	return float64(c.Covered / c.Length)
+}
+
+// A MapMatch is the result of matching a Track.
+type MapMatch struct {
+	// Points holds a MatchedPoint for every fix of the Track.
+	Points []MatchedPoint
+	// Coverage holds the coverage of every line with a matched fix, in order of line.
+	Coverage []LineCoverage
+}
+
+// Matched returns the fraction of the fixes that were matched to a line, from 0 to 1.
+func (m MapMatch) Matched() float64 {
+	if len(m.Points) == 0 {
+		return 0
+	}
+	matched := 0
+	for _, p := range m.Points {
+		if p.Matched {
+			matched++
+		}
+	}
+	return float64(matched) / float64(len(m.Points))
+}
+
+// NewMapMatcher returns a MapMatcher snapping to the passed in lines.
+func NewMapMatcher(lines []LineString, opts MapMatcherOptions) *MapMatcher {
+	if opts.SearchRadius <= 0 {
+		opts.SearchRadius = DefaultMatchSearchRadius
+	}
+	if opts.Sigma <= 0 {
+		opts.Sigma = DefaultMatchSigma
+	}
+	if opts.Beta <= 0 {
+		opts.Beta = DefaultMatchBeta
+	}
+
+	m := &MapMatcher{lines: lines, offsets: make([][]Distance, len(lines)), opts: opts}
+	var bounds []BoundingBox
+	for li, l := range lines {
+		offsets := make([]Distance, len(l.points))
+		for i := 1; i < len(l.points); i++ {
+			offsets[i] = offsets[i-1] + l.points[i-1].GreatCircleDistance(l.points[i])
+			m.segments = append(m.segments, matchSegment{line: li, i: i - 1})
+			bounds = append(bounds, pointsBounds(l.points[i-1:i+1]))
+		}
+		m.offsets[li] = offsets
+	}
+	m.tree = newIndexRTree(bounds)
+	return m
+}
+
+// matchCandidate is a possible match of a fix.
+type matchCandidate struct {
+	point    Point
+	line     int
+	offset   Distance
+	distance Distance
+}
+
+// candidates returns the closest position on every line within the search radius of Point p.
+func (m *MapMatcher) candidates(p Point) []matchCandidate {
+	frame := NewLocalFrame(p, 0)
+	closest := make(map[int]matchCandidate)
+	m.tree.SearchFunc(BoundsAround(p, m.opts.SearchRadius), func(item indexedBounds) bool {
+		s := m.segments[item.i]
+		a, b := m.lines[s.line].points[s.i], m.lines[s.line].points[s.i+1]
+		x1, y1, _ := frame.ToENU(a, 0)
+		x2, y2, _ := frame.ToENU(b, 0)
+
+		// Project p, the origin of the frame, onto the segment.
+		dx, dy := x2-x1, y2-y1
+		f := 0.0
+		if l := dx*dx + dy*dy; l > 0 {
+			f = math.Max(0, math.Min(1, -(x1*dx+y1*dy)/l))
+		}
+		d := Distance(math.Hypot(x1+f*dx, y1+f*dy))
+		if d > m.opts.SearchRadius {
+			return true
+		}
+		if c, ok := closest[s.line]; ok && c.distance <= d {
+			return true
+		}
+		point, _ := frame.FromENU(x1+f*dx, y1+f*dy, 0)
+		offsets := m.offsets[s.line]
+		closest[s.line] = matchCandidate{
+			point:    point,
+			line:     s.line,
+			offset:   offsets[s.i] + Distance(f)*(offsets[s.i+1]-offsets[s.i]),
+			distance: d,
+		}
+		return true
+	})
+
+	candidates := make([]matchCandidate, 0, len(closest))
+	for _, c := range closest {
+		candidates = append(candidates, c)
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].line < candidates[j].line })
+	return candidates
+}
+
+// Match snaps the fixes of Track t to the lines of the MapMatcher.  Fixes with no line within the
+// search radius are left unmatched, and break the Track into parts that are matched independently.
+func (m *MapMatcher) Match(t Track) MapMatch {
+	match := MapMatch{Points: make([]MatchedPoint, len(t))}
+
+	// Run the Viterbi algorithm over each run of fixes with candidates, in log probabilities.
+	var (
+		candidates [][]matchCandidate
+		scores     [][]float64
+		parents    [][]int
+	)
+	for i, tp := range t {
+		match.Points[i].Fix = tp
+		sigma := m.opts.Sigma
+		if tp.Accuracy > 0 {
+			sigma = tp.Accuracy
+		}
+
+		cs := m.candidates(tp.Point)
+		score := make([]float64, len(cs))
+		parent := make([]int, len(cs))
+		for j, c := range cs {
+			emission := -0.5 * math.Pow(float64(c.distance/sigma), 2)
+			if len(candidates) == 0 {
+				score[j], parent[j] = emission, -1
+				continue
+			}
+
+			prev := candidates[len(candidates)-1]
+			straight := t[i-1].Point.GreatCircleDistance(tp.Point)
+			score[j] = math.Inf(-1)
+			for k, pc := range prev {
+				travel := pc.point.GreatCircleDistance(c.point) + m.opts.LineChangePenalty
+				if pc.line == c.line {
+					travel = c.offset - pc.offset
+					if travel < 0 {
+						travel = -travel
+					}
+				}
+				transition := -float64(math.Abs(float64(straight-travel)) / float64(m.opts.Beta))
+				if s := scores[len(scores)-1][k] + transition + emission; s > score[j] {
+					score[j], parent[j] = s, k
+				}
+			}
+		}
+
+		if len(cs) == 0 {
+			m.backtrack(match.Points[i-len(candidates):i], candidates, scores, parents)
+			candidates, scores, parents = nil, nil, nil
+			continue
+		}
+		candidates, scores, parents = append(candidates, cs), append(scores, score), append(parents, parent)
+	}
+	m.backtrack(match.Points[len(t)-len(candidates):], candidates, scores, parents)
+
+	match.Coverage = m.coverage(match.Points)
+	return match
+}
+
+// backtrack fills in points, a run of fixes, from the most likely sequence of their candidates.
+func (m *MapMatcher) backtrack(points []MatchedPoint, candidates [][]matchCandidate, scores [][]float64, parents [][]int) {
+	if len(candidates) == 0 {
+		return
+	}
+
+	best := 0
+	last := scores[len(scores)-1]
+	for j := range last {
+		if last[j] > last[best] {
+			best = j
+		}
+	}
+	for i := len(candidates) - 1; i >= 0; i-- {
+		c := candidates[i][best]
+		points[i].Matched = true
+		points[i].Point = c.point
+		points[i].Line = c.line
+		points[i].Offset = c.offset
+		points[i].Distance = c.distance
+		best = parents[i][best]
+	}
+}
+
+// coverage returns the coverage of every line with a matched point.
+func (m *MapMatcher) coverage(points []MatchedPoint) []LineCoverage {
+	type interval struct{ from, to Distance }
+	intervals := make(map[int][]interval)
+	for i, p := range points {
+		if !p.Matched {
+			continue
+		}
+		if _, ok := intervals[p.Line]; !ok {
+			intervals[p.Line] = nil
+		}
+		if i > 0 && points[i-1].Matched && points[i-1].Line == p.Line {
+			from, to := points[i-1].Offset, p.Offset
+			if from > to {
+				from, to = to, from
+			}
+			intervals[p.Line] = append(intervals[p.Line], interval{from, to})
+		}
+	}
+
+	coverage := make([]LineCoverage, 0, len(intervals))
+	for line, ivs := range intervals {
+		offsets := m.offsets[line]
+		c := LineCoverage{Line: line}
+		if len(offsets) > 0 {
+			c.Length = offsets[len(offsets)-1]
+		}
+
+		// Merge the overlapping intervals travelled.
+		sort.Slice(ivs, func(i, j int) bool { return ivs[i].from < ivs[j].from })
+		end := Distance(math.Inf(-1))
+		for _, iv := range ivs {
+			if iv.to <= end {
+				continue
+			}
+			c.Covered += iv.to - max(iv.from, end)
+			end = iv.to
+		}
+		coverage = append(coverage, c)
+	}
+	sort.Slice(coverage, func(i, j int) bool { return coverage[i].Line < coverage[j].Line })
+	return coverage
+}
@@ -0,0 +1,67 @@
+package geo
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// Ensures that fixes are matched to the most likely line, and that coverage is measured along it.
+func TestMapMatcher(t *testing.T) {
+	lines := []LineString{
+		NewLineString([]Point{NewPoint(0, 0), NewPoint(0, 0.005), NewPoint(0, 0.01)}),
+		NewLineString([]Point{NewPoint(0.00027, 0), NewPoint(0.00027, 0.01)}),
+		NewLineString([]Point{NewPoint(1, 1), NewPoint(1, 1.01)}),
+	}
+
+	// Drive along the first line, with one fix drawn towards the second and one lost.
+	start := time.Date(2024, time.May, 1, 9, 0, 0, 0, time.UTC)
+	var track Track
+	for i, lat := range []float64{0.00002, -0.00003, 0.00016, 0.00001, -0.00002, 0.01, 0.00003} {
+		track = append(track, TrackPoint{Point: NewPoint(lat, 0.001*float64(i+1)), Time: start.Add(time.Duration(i) * 10 * time.Second)})
+	}
+
+	match := NewMapMatcher(lines, MapMatcherOptions{LineChangePenalty: 50 * Meter}).Match(track)
+	if len(match.Points) != len(track) {
+		t.Fatalf("Expected %d points, but got %d", len(track), len(match.Points))
+	}
+	for i, p := range match.Points {
+		if i == 5 {
+			if p.Matched {
+				t.Errorf("Expected the lost fix unmatched, but got %+v", p)
+			}
+			continue
+		}
+		if !p.Matched || p.Line != 0 || math.Abs(p.Point.lat) > 1e-9 {
+			t.Errorf("Expected fix %d on the first line, but got %+v", i, p)
+		}
+		if expected := NewPoint(0, 0).GreatCircleDistance(NewPoint(0, 0.001*float64(i+1))); math.Abs(float64(p.Offset-expected)) > 0.01 {
+			t.Errorf("Expected fix %d at %v along the line, but got %v", i, expected, p.Offset)
+		}
+		// The distance is measured on the ellipsoid, which is about 0.5% flatter than the sphere here.
+		if d := p.Fix.Point.GreatCircleDistance(p.Point); math.Abs(float64(p.Distance-d)) > 0.01*float64(d) {
+			t.Errorf("Expected the distance to the fix, but got %v", p.Distance)
+		}
+	}
+	if f := match.Matched(); math.Abs(f-6.0/7) > 1e-9 {
+		t.Errorf("Expected 6 of 7 fixes matched, but got %v", f)
+	}
+
+	// Fixes 1 to 5 cover 0.004 of the 0.01 degrees of the line; the last fix follows a lost one.
+	if len(match.Coverage) != 1 || match.Coverage[0].Line != 0 || math.Abs(match.Coverage[0].Fraction()-0.4) > 1e-6 {
+		t.Errorf("Expected 40%% of the first line covered, but got %+v", match.Coverage)
+	}
+
+	// Without a penalty for changing lines, the stray fix jumps to the closer line.
+	match = NewMapMatcher(lines, MapMatcherOptions{}).Match(track)
+	if match.Points[2].Line != 1 || match.Points[3].Line != 0 {
+		t.Errorf("Expected the stray fix on the second line, but got %+v", match.Points[2:4])
+	}
+	if len(match.Coverage) != 2 || math.Abs(match.Coverage[0].Fraction()-0.2) > 1e-6 || match.Coverage[1].Covered != 0 {
+		t.Errorf("Expected 20%% of the first line covered, but got %+v", match.Coverage)
+	}
+
+	if match := NewMapMatcher(nil, MapMatcherOptions{}).Match(track); match.Matched() != 0 || len(match.Coverage) != 0 {
+		t.Errorf("Expected nothing matched without lines, but got %+v", match)
+	}
+}
@@ -0,0 +1,149 @@
+package geo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// MapboxGeocodeURL is the endpoint of the Mapbox Geocoding API, to which the query is appended.
+const MapboxGeocodeURL = "https://api.mapbox.com/geocoding/v5/mapbox.places/"
+
+// MapboxGeocoderOptions configures a MapboxGeocoder.
+type MapboxGeocoderOptions struct {
+	// Proximity biases forward geocoding towards results near the Point.
+	Proximity *Point
+	// BBox restricts forward geocoding to results within the BoundingBox.
+	// A box crossing the antimeridian is widened to every longitude, which Mapbox requires.
+	BBox *BoundingBox
+	// Language is the language results are returned in, such as "en".  Defaults to the service's choice.
+	Language string
+	// BaseURL replaces MapboxGeocodeURL, for example to point at a proxy.
+	BaseURL string
+	// Client sends the requests.  Defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+// A MapboxGeocoder is a Geocoder backed by the Mapbox Geocoding API.
+// It is safe for concurrent use.
+type MapboxGeocoder struct {
+	accessToken string
+	opts        MapboxGeocoderOptions
+}
+
+var _ Geocoder = (*MapboxGeocoder)(nil)
+
+// NewMapboxGeocoder returns a new MapboxGeocoder that authenticates with the passed in access token.
+func NewMapboxGeocoder(accessToken string, opts MapboxGeocoderOptions) *MapboxGeocoder {
+	if opts.BaseURL == "" {
+		opts.BaseURL = MapboxGeocodeURL
+	}
+	return &MapboxGeocoder{accessToken: accessToken, opts: opts}
+}
+
+type mapboxGeocodeResponse struct {
+	Features []struct {
+		PlaceName string     `json:"place_name"`
+		Text      string     `json:"text"`
+		Address   string     `json:"address"`
+		Center    [2]float64 `json:"center"`
+		ID        string     `json:"id"`
+		Context   []struct {
+			ID        string `json:"id"`
+			Text      string `json:"text"`
+			ShortCode string `json:"short_code"`
+		} `json:"context"`
+	} `json:"features"`
+}
+
+// Geocode returns the Point of Mapbox's best match for address.
+func (g *MapboxGeocoder) Geocode(ctx context.Context, address string) (Point, error) {
+	query := url.Values{"limit": {"1"}}
+	if p := g.opts.Proximity; p != nil {
+		query.Set("proximity", formatLngLat(*p))
+	}
+	if b := g.opts.BBox; b != nil {
+		west, east := b.sw.lng, b.ne.lng
+		if b.CrossesAntimeridian() {
+			west, east = -180, 180
+		}
+		query.Set("bbox", strings.Join([]string{
+			strconv.FormatFloat(west, 'f', -1, 64),
+			strconv.FormatFloat(b.sw.lat, 'f', -1, 64),
+			strconv.FormatFloat(east, 'f', -1, 64),
+			strconv.FormatFloat(b.ne.lat, 'f', -1, 64),
+		}, ","))
+	}
+
+	resp, err := g.request(ctx, address, query)
+	if err != nil {
+		return Point{}, err
+	}
+
+	center := resp.Features[0].Center
+	return NewPoint(center[1], center[0]), nil
+}
+
+// ReverseGeocode returns the Address of Mapbox's best match for Point p.
+func (g *MapboxGeocoder) ReverseGeocode(ctx context.Context, p Point) (Address, error) {
+	// Reverse queries for several types reject a limit, and return one feature per type instead,
+	// the most specific first.
+	resp, err := g.request(ctx, formatLngLat(p), url.Values{"types": {"address,place,region,country"}})
+	if err != nil {
+		return Address{}, err
+	}
+
+	feature := resp.Features[0]
+	address := Address{Formatted: feature.PlaceName}
+	if strings.HasPrefix(feature.ID, "address.") {
+		address.HouseNumber, address.Street = feature.Address, feature.Text
+	}
+	for _, c := range feature.Context {
+		kind, _, _ := strings.Cut(c.ID, ".")
+		switch kind {
+		case "postcode":
+			address.PostalCode = c.Text
+		case "place":
+			address.Locality = c.Text
+		case "region":
+			address.Region = c.Text
+		case "country":
+			address.Country, address.CountryCode = c.Text, strings.ToUpper(c.ShortCode)
+		}
+	}
+
+	return address, nil
+}
+
+// request queries the geocoding API for search, which is an address or a "lng,lat" pair,
+// and only returns its reply without error if it holds at least one feature.
+func (g *MapboxGeocoder) request(ctx context.Context, search string, query url.Values) (*mapboxGeocodeResponse, error) {
+	u, err := url.Parse(strings.TrimSuffix(g.opts.BaseURL, "/") + "/" + url.PathEscape(search) + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("mapbox geocoder: %w", err)
+	}
+	query.Set("access_token", g.accessToken)
+	if g.opts.Language != "" {
+		query.Set("language", g.opts.Language)
+	}
+	u.RawQuery = query.Encode()
+
+	var resp mapboxGeocodeResponse
+	if err := getJSON(ctx, g.opts.Client, "mapbox geocoder", u, &resp); err != nil {
+		return nil, err
+	}
+
+	if len(resp.Features) == 0 {
+		return nil, fmt.Errorf("%w: mapbox geocoder", ErrNoResults)
+	}
+
+	return &resp, nil
+}
+
+// formatLngLat formats Point p as a "lng,lat" pair.
+func formatLngLat(p Point) string {
+	return strconv.FormatFloat(p.lng, 'f', -1, 64) + "," + strconv.FormatFloat(p.lat, 'f', -1, 64)
+}
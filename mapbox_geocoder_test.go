@@ -0,0 +1,85 @@
+package geo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Ensures that the Mapbox geocoder sends proximity and bbox restrictions and decodes the first feature.
+func TestMapboxGeocoder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("access_token") != "token" {
+			t.Errorf("Expected the access token to be sent, but got %q", q.Get("access_token"))
+		}
+
+		switch r.URL.Path {
+		case "/1 Macquarie St.json":
+			if q.Get("proximity") != "151.2,-33.9" {
+				t.Errorf("Expected proximity 151.2,-33.9, but got %q", q.Get("proximity"))
+			}
+			if q.Get("bbox") != "150,-35,152,-33" {
+				t.Errorf("Expected bbox 150,-35,152,-33, but got %q", q.Get("bbox"))
+			}
+			if q.Get("limit") != "1" {
+				t.Errorf("Expected limit 1, but got %q", q.Get("limit"))
+			}
+			w.Write([]byte(`{"features": [{"center": [151.2093, -33.8688]}]}`))
+		case "/151.2093,-33.8688.json":
+			if q.Has("limit") {
+				t.Errorf("Expected no limit on a reverse query for several types, but got %q", q.Get("limit"))
+			}
+			w.Write([]byte(`{"features": [{
+				"id": "address.123",
+				"place_name": "1 Macquarie Street, Sydney New South Wales 2000, Australia",
+				"address": "1",
+				"text": "Macquarie Street",
+				"context": [
+					{"id": "postcode.1", "text": "2000"},
+					{"id": "place.2", "text": "Sydney"},
+					{"id": "region.3", "text": "New South Wales", "short_code": "AU-NSW"},
+					{"id": "country.4", "text": "Australia", "short_code": "au"}
+				]}]}`))
+		default:
+			w.Write([]byte(`{"features": []}`))
+		}
+	}))
+	defer server.Close()
+
+	proximity := NewPoint(-33.9, 151.2)
+	bbox := NewBoundingBox(NewPoint(-35, 150), NewPoint(-33, 152))
+	g := NewMapboxGeocoder("token", MapboxGeocoderOptions{
+		Proximity: &proximity,
+		BBox:      &bbox,
+		BaseURL:   server.URL,
+		Client:    server.Client(),
+	})
+	ctx := context.Background()
+
+	p, err := g.Geocode(ctx, "1 Macquarie St")
+	if err != nil || p != NewPoint(-33.8688, 151.2093) {
+		t.Errorf("Expected -33.8688,151.2093, but got %v, %v", p, err)
+	}
+
+	address, err := g.ReverseGeocode(ctx, NewPoint(-33.8688, 151.2093))
+	expected := Address{
+		Formatted:   "1 Macquarie Street, Sydney New South Wales 2000, Australia",
+		HouseNumber: "1",
+		Street:      "Macquarie Street",
+		Locality:    "Sydney",
+		Region:      "New South Wales",
+		PostalCode:  "2000",
+		Country:     "Australia",
+		CountryCode: "AU",
+	}
+	if err != nil || address != expected {
+		t.Errorf("Expected %+v, but got %+v, %v", expected, address, err)
+	}
+
+	if _, err := g.ReverseGeocode(ctx, NewPoint(0, 0)); !errors.Is(err, ErrNoResults) {
+		t.Errorf("Expected ErrNoResults, but got %v", err)
+	}
+}
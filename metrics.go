@@ -0,0 +1,108 @@
+package geo
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Metrics receives counters and timings of the package's spatial workloads, for exporting to
+// a monitoring system such as Prometheus with PrometheusMetrics.  Every measurement of a metric
+// carries one label value, whose meaning the documentation of each Metric name gives.
+// Implementations must be safe for concurrent use.
+type Metrics interface {
+	// Add adds delta to the counter name for the passed in label value.
+	Add(name string, label string, delta float64)
+	// Observe records value in the histogram name for the passed in label value.
+	Observe(name string, label string, value float64)
+}
+
+// Names of the metrics reported to the installed Metrics.
+const (
+	// MetricContainsCalls counts Contains calls, labeled by the geometry:
+	// "polygon", "prepared_polygon" or "multi_polygon".
+	MetricContainsCalls = "geo_contains_calls_total"
+	// MetricIndexQueries counts point queries of spatial indexes, labeled by the index:
+	// "polygon_index", "polygon_store", "lazy_polygon_index", "timezone", "geofence" or "kdtree".
+	MetricIndexQueries = "geo_index_queries_total"
+	// MetricIndexQueryDuration is a histogram of the seconds taken by the queries counted in MetricIndexQueries.
+	MetricIndexQueryDuration = "geo_index_query_duration_seconds"
+	// MetricRemoteRequests counts requests to remote services, such as geocoders and elevation APIs,
+	// labeled by the service, such as "google geocoder".
+	MetricRemoteRequests = "geo_remote_requests_total"
+	// MetricRemoteRequestErrors counts the requests of MetricRemoteRequests that failed.
+	MetricRemoteRequestErrors = "geo_remote_request_errors_total"
+	// MetricRemoteRequestDuration is a histogram of the seconds taken by the requests counted in MetricRemoteRequests.
+	MetricRemoteRequestDuration = "geo_remote_request_duration_seconds"
+	// MetricGeofenceEvents counts the events fired by GeofenceManagers, labeled by the event type: "enter" or "exit".
+	MetricGeofenceEvents = "geo_geofence_events_total"
+)
+
+// metricLabels holds the name of the label and a description of every metric, for exporters.
+var metricLabels = map[string]struct{ label, help string }{
+	MetricContainsCalls:         {"geometry", "Contains calls by geometry."},
+	MetricIndexQueries:          {"index", "Point queries of spatial indexes."},
+	MetricIndexQueryDuration:    {"index", "Seconds taken by point queries of spatial indexes."},
+	MetricRemoteRequests:        {"service", "Requests to remote services."},
+	MetricRemoteRequestErrors:   {"service", "Failed requests to remote services."},
+	MetricRemoteRequestDuration: {"service", "Seconds taken by requests to remote services."},
+	MetricGeofenceEvents:        {"type", "Geofence events by type."},
+}
+
+// metricsHolder lets atomic.Pointer hold any implementation of Metrics.
+type metricsHolder struct {
+	m Metrics
+}
+
+var metrics atomic.Pointer[metricsHolder]
+
+// SetMetrics installs m to receive the package's metrics.  The package reports none by default;
+// passing nil stops reporting again.  It is safe to call concurrently with other functions.
+func SetMetrics(m Metrics) {
+	if m == nil {
+		metrics.Store(nil)
+		return
+	}
+	metrics.Store(&metricsHolder{m: m})
+}
+
+// addMetric adds delta to the counter name, if Metrics are installed.
+func addMetric(name string, label string, delta float64) {
+	if h := metrics.Load(); h != nil {
+		h.m.Add(name, label, delta)
+	}
+}
+
+func noMetric() {}
+
+// timeIndexQuery counts a query of index and returns a function recording its duration when called,
+// which is meant to be deferred.  It costs a single atomic load when no Metrics are installed.
+func timeIndexQuery(index string) func() {
+	h := metrics.Load()
+	if h == nil {
+		return noMetric
+	}
+
+	start := time.Now()
+	return func() {
+		h.m.Add(MetricIndexQueries, index, 1)
+		h.m.Observe(MetricIndexQueryDuration, index, time.Since(start).Seconds())
+	}
+}
+
+// timeRemoteRequest is like timeIndexQuery for a request to service, which also counts the request
+// as failed when the error it is passed is not nil.
+func timeRemoteRequest(service string) func(err error) {
+	h := metrics.Load()
+	if h == nil {
+		return func(error) {}
+	}
+
+	start := time.Now()
+	return func(err error) {
+		h.m.Add(MetricRemoteRequests, service, 1)
+		if err != nil {
+			h.m.Add(MetricRemoteRequestErrors, service, 1)
+		}
+		h.m.Observe(MetricRemoteRequestDuration, service, time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,101 @@
+package geo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingMetrics is a Metrics that remembers counters and the number of observations.
+type recordingMetrics struct {
+	mu           sync.Mutex
+	counters     map[metricKey]float64
+	observations map[metricKey]int
+}
+
+func newRecordingMetrics() *recordingMetrics {
+	return &recordingMetrics{counters: make(map[metricKey]float64), observations: make(map[metricKey]int)}
+}
+
+func (r *recordingMetrics) Add(name string, label string, delta float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.counters[metricKey{name, label}] += delta
+}
+
+func (r *recordingMetrics) Observe(name string, label string, value float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.observations[metricKey{name, label}]++
+}
+
+// Ensures that the package reports Contains calls, index queries, remote requests and geofence events.
+func TestMetrics(t *testing.T) {
+	r := newRecordingMetrics()
+	SetMetrics(r)
+	defer SetMetrics(nil)
+
+	square := NewPolygon([]Point{NewPoint(0, 0), NewPoint(0, 1), NewPoint(1, 1), NewPoint(1, 0)})
+	square.Contains(NewPoint(0.5, 0.5))
+	NewPreparedPolygon(square).Contains(NewPoint(0.5, 0.5))
+	NewMultiPolygon(square, square).Contains(NewPoint(2, 2))
+
+	NewPolygonIndex(map[string]Polygon{"square": square}).FindContaining(NewPoint(0.5, 0.5))
+
+	m := NewGeofenceManager()
+	m.Set("square", square)
+	m.Update("van", NewPoint(0.5, 0.5), time.Now())
+	m.Update("van", NewPoint(2, 2), time.Now())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/down" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	for _, path := range []string{"/up", "/down"} {
+		u, _ := url.Parse(server.URL + path)
+		var v struct{}
+		getJSON(context.Background(), server.Client(), "test service", u, &v)
+	}
+
+	// The index tests its prepared square, and the manager tests the square once more.
+	counters := map[metricKey]float64{
+		{MetricContainsCalls, "polygon"}:            2,
+		{MetricContainsCalls, "prepared_polygon"}:   2,
+		{MetricContainsCalls, "multi_polygon"}:      1,
+		{MetricIndexQueries, "polygon_index"}:       1,
+		{MetricIndexQueries, "geofence"}:            2,
+		{MetricGeofenceEvents, "enter"}:             1,
+		{MetricGeofenceEvents, "exit"}:              1,
+		{MetricRemoteRequests, "test service"}:      2,
+		{MetricRemoteRequestErrors, "test service"}: 1,
+	}
+	for key, expected := range counters {
+		if r.counters[key] != expected {
+			t.Errorf("Expected %v to be %v, but got %v", key, expected, r.counters[key])
+		}
+	}
+	observations := map[metricKey]int{
+		{MetricIndexQueryDuration, "polygon_index"}:   1,
+		{MetricIndexQueryDuration, "geofence"}:        2,
+		{MetricRemoteRequestDuration, "test service"}: 2,
+	}
+	for key, expected := range observations {
+		if r.observations[key] != expected {
+			t.Errorf("Expected %d observations of %v, but got %d", expected, key, r.observations[key])
+		}
+	}
+
+	SetMetrics(nil)
+	square.Contains(NewPoint(0.5, 0.5))
+	if r.counters[metricKey{MetricContainsCalls, "polygon"}] != 2 {
+		t.Error("Expected no metrics once uninstalled")
+	}
+}
@@ -0,0 +1,112 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// The column letters of 100km MGRS squares, repeating every three zones.
+var mgrsColumns = [3]string{"STUVWXYZ", "ABCDEFGH", "JKLMNPQR"}
+
+// The row letters of 100km MGRS squares, repeating every 2000km.
+const mgrsRows = "ABCDEFGHJKLMNPQRSTUV"
+
+// EncodeMGRS returns the Military Grid Reference System reference of Point p,
+// e.g. "33UXP0500444996".  The precision is the number of digits used for
+// each of the easting and northing, from 1 (10km) to 5 (1m).
+// Points outside of the UTM latitude range of [-80, 84] cannot be encoded.
+func EncodeMGRS(p Point, precision int) (string, error) {
+	if precision < 1 || precision > 5 {
+		return "", fmt.Errorf("%w: MGRS precision %d out of range [1, 5]", ErrInvalidPrecision, precision)
+	}
+
+	if p.lat < utmMinLatitude || p.lat > utmMaxLatitude {
+		return "", fmt.Errorf("%w: latitude %v is outside of the MGRS range [%v, %v]", ErrOutOfBounds, p.lat, utmMinLatitude, utmMaxLatitude)
+	}
+
+	zone := UTMZone(p)
+	easting, northing := toUTM(p, zone)
+
+	col := int(math.Floor(easting/100000)) - 1
+	row := int(math.Floor(math.Mod(northing, 2000000) / 100000))
+	if zone%2 == 0 {
+		row += 5
+	}
+
+	columns := mgrsColumns[zone%3]
+	if col < 0 || col >= len(columns) {
+		return "", fmt.Errorf("%w: easting %v of %v lies outside of zone %d", ErrOutOfBounds, easting, p, zone)
+	}
+
+	scale := math.Pow10(5 - precision)
+	e := int(math.Floor(math.Mod(easting, 100000) / scale))
+	n := int(math.Floor(math.Mod(northing, 100000) / scale))
+
+	return fmt.Sprintf("%02d%c%c%c%0*d%0*d",
+		zone, UTMBand(p.lat), columns[col], mgrsRows[row%len(mgrsRows)],
+		precision, e, precision, n), nil
+}
+
+// DecodeMGRS returns the center of the grid square described by the passed in
+// MGRS reference.  Whitespace within the reference is ignored.
+func DecodeMGRS(ref string) (Point, error) {
+	s := strings.ToUpper(strings.Join(strings.Fields(ref), ""))
+
+	i := 0
+	for i < len(s) && i < 2 && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	if i == 0 || len(s) < i+3 {
+		return Point{}, fmt.Errorf("%w: invalid MGRS reference %q", ErrInvalidFormat, ref)
+	}
+
+	zone, _ := strconv.Atoi(s[:i])
+	band := strings.IndexByte(utmBands, s[i])
+	if zone < 1 || zone > 60 || band < 0 {
+		return Point{}, fmt.Errorf("%w: invalid MGRS grid zone %q in %q", ErrInvalidFormat, s[:i+1], ref)
+	}
+
+	col := strings.IndexByte(mgrsColumns[zone%3], s[i+1])
+	row := strings.IndexByte(mgrsRows, s[i+2])
+	if col < 0 || row < 0 {
+		return Point{}, fmt.Errorf("%w: invalid MGRS square %q in %q", ErrInvalidFormat, s[i+1:i+3], ref)
+	}
+
+	digits := s[i+3:]
+	if len(digits)%2 != 0 || len(digits) > 10 {
+		return Point{}, fmt.Errorf("%w: invalid MGRS numerical location %q in %q", ErrInvalidFormat, digits, ref)
+	}
+
+	precision := len(digits) / 2
+	scale := math.Pow10(5 - precision)
+	var e, n float64
+	if precision > 0 {
+		ei, errE := strconv.Atoi(digits[:precision])
+		ni, errN := strconv.Atoi(digits[precision:])
+		if errE != nil || errN != nil {
+			return Point{}, fmt.Errorf("%w: invalid MGRS numerical location %q in %q", ErrInvalidFormat, digits, ref)
+		}
+		e, n = float64(ei)*scale, float64(ni)*scale
+	}
+
+	if zone%2 == 0 {
+		row = (row - 5 + len(mgrsRows)) % len(mgrsRows)
+	}
+
+	easting := float64(col+1)*100000 + e + scale/2
+	northing := float64(row)*100000 + n + scale/2
+
+	// The row letters repeat every 2000km, so find the first repetition
+	// that lies north of the bottom of the latitude band.
+	bandLat := utmMinLatitude + float64(band)*8
+	north := bandLat >= 0
+	_, bandNorthing := toUTM(NewPoint(bandLat, utmCentralMeridian(zone)), zone)
+	bandNorthing = math.Floor(bandNorthing/100000) * 100000
+	for northing < bandNorthing {
+		northing += 2000000
+	}
+
+	return fromUTM(zone, north, easting, northing), nil
+}
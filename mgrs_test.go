@@ -0,0 +1,73 @@
+package geo
+
+import "testing"
+
+// Ensures that points encode to known MGRS references.
+func TestEncodeMGRS(t *testing.T) {
+	tests := []struct {
+		p         Point
+		precision int
+		expected  string
+	}{
+		{NewPoint(48.8582, 2.2945), 5, "31UDQ4825111932"},
+		{NewPoint(48.8582, 2.2945), 1, "31UDQ41"},
+	}
+
+	for _, tt := range tests {
+		got, err := EncodeMGRS(tt.p, tt.precision)
+		if err != nil {
+			t.Fatalf("Should not encounter an error when encoding %v, but got %v", tt.p, err)
+		}
+		if got != tt.expected {
+			t.Errorf("Expected %v to encode to %s, but got %s instead", tt.p, tt.expected, got)
+		}
+	}
+
+	if _, err := EncodeMGRS(NewPoint(85, 0), 5); err == nil {
+		t.Error("Expected an error when encoding a point outside of the UTM latitude range")
+	}
+
+	if _, err := EncodeMGRS(NewPoint(0, 0), 6); err == nil {
+		t.Error("Expected an error when encoding with an unsupported precision")
+	}
+}
+
+// Ensures that references decode to within their precision of the encoded point.
+func TestDecodeMGRS(t *testing.T) {
+	points := []Point{
+		NewPoint(48.8582, 2.2945),
+		NewPoint(-33.8568, 151.2153),
+		NewPoint(60.39, 5.32),
+		NewPoint(78.22, 15.65),
+		NewPoint(-79.5, -60),
+		NewPoint(0.5, -0.5),
+		NewPoint(-0.5, 100.2),
+	}
+
+	for _, p := range points {
+		ref, err := EncodeMGRS(p, 5)
+		if err != nil {
+			t.Fatalf("Should not encounter an error when encoding %v, but got %v", p, err)
+		}
+
+		back, err := DecodeMGRS(ref)
+		if err != nil {
+			t.Fatalf("Should not encounter an error when decoding %s, but got %v", ref, err)
+		}
+
+		if d := p.GreatCircleDistance(back); d > 2*Meter {
+			t.Errorf("Expected %s to decode to within 2m of %v, but got %v (%v away)", ref, p, back, d)
+		}
+	}
+
+	back, err := DecodeMGRS("31U DQ 48251 11932")
+	if err != nil || NewPoint(48.8582, 2.2945).GreatCircleDistance(back) > 2*Meter {
+		t.Errorf("Expected a spaced reference to decode, but got %v (%v)", back, err)
+	}
+
+	for _, invalid := range []string{"", "31", "61UDQ", "31IDQ", "31UDQ123", "31UDQ12a4"} {
+		if _, err := DecodeMGRS(invalid); err == nil {
+			t.Errorf("Expected an error when decoding %q", invalid)
+		}
+	}
+}
@@ -0,0 +1,15 @@
+//go:build !unix
+
+package geo
+
+import "os"
+
+// mapFile reads the file at path into memory, on platforms without mmap support.
+func mapFile(path string) ([]byte, func() error, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return data, func() error { return nil }, nil
+}
@@ -0,0 +1,33 @@
+//go:build unix
+
+package geo
+
+import (
+	"os"
+	"syscall"
+)
+
+// mapFile maps the file at path into memory read-only, returning the mapping and a function releasing it.
+func mapFile(path string) ([]byte, func() error, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if info.Size() == 0 {
+		return nil, func() error { return nil }, nil
+	}
+
+	data, err := syscall.Mmap(int(f.Fd()), 0, int(info.Size()), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, nil, &os.PathError{Op: "mmap", Path: path, Err: err}
+	}
+
+	return data, func() error { return syscall.Munmap(data) }, nil
+}
@@ -0,0 +1,127 @@
+package geo
+
+import "fmt"
+
+// MongoDocument is a filter or GeoJSON fragment that can be passed straight
+// to the official MongoDB driver.  It shares its underlying type with bson.M.
+type MongoDocument map[string]interface{}
+
+// MongoPoint is a GeoJSON Point as stored in MongoDB.
+// Decode query results into it and call Point to recover a Point.
+type MongoPoint struct {
+	Type        string    `bson:"type" json:"type"`
+	Coordinates []float64 `bson:"coordinates" json:"coordinates"`
+}
+
+// MongoPolygon is a GeoJSON Polygon as stored in MongoDB.
+// Decode query results into it and call Polygon to recover a Polygon.
+type MongoPolygon struct {
+	Type        string        `bson:"type" json:"type"`
+	Coordinates [][][]float64 `bson:"coordinates" json:"coordinates"`
+}
+
+// NewMongoPoint returns the GeoJSON representation of Point p.
+func NewMongoPoint(p Point) MongoPoint {
+	return MongoPoint{Type: "Point", Coordinates: []float64{p.lng, p.lat}}
+}
+
+// NewMongoPolygon returns the GeoJSON representation of Polygon p.
+// The ring is closed by repeating the first point if necessary, as MongoDB requires.
+func NewMongoPolygon(p Polygon) MongoPolygon {
+	ring := make([][]float64, 0, len(p.points)+1)
+	for _, point := range p.points {
+		ring = append(ring, []float64{point.lng, point.lat})
+	}
+
+	if len(p.points) > 0 && p.points[0] != p.points[len(p.points)-1] {
+		ring = append(ring, []float64{p.points[0].lng, p.points[0].lat})
+	}
+
+	return MongoPolygon{Type: "Polygon", Coordinates: [][][]float64{ring}}
+}
+
+// Point returns the Point described by the GeoJSON document.
+func (m MongoPoint) Point() (Point, error) {
+	if m.Type != "Point" {
+		return Point{}, fmt.Errorf("%w: unexpected GeoJSON type %q, expected Point", ErrUnsupportedGeometry, m.Type)
+	}
+
+	if len(m.Coordinates) < 2 {
+		return Point{}, fmt.Errorf("%w: GeoJSON Point has %d coordinates, expected 2", ErrInvalidFormat, len(m.Coordinates))
+	}
+
+	return NewPoint(m.Coordinates[1], m.Coordinates[0]), nil
+}
+
+// Polygon returns the Polygon described by the outer ring of the GeoJSON document.
+// The closing point of the ring is dropped.
+func (m MongoPolygon) Polygon() (Polygon, error) {
+	if m.Type != "Polygon" {
+		return Polygon{}, fmt.Errorf("%w: unexpected GeoJSON type %q, expected Polygon", ErrUnsupportedGeometry, m.Type)
+	}
+
+	if len(m.Coordinates) == 0 {
+		return Polygon{}, fmt.Errorf("%w: GeoJSON Polygon has no rings", ErrUnclosedPolygon)
+	}
+
+	ring := m.Coordinates[0]
+	points := make([]Point, 0, len(ring))
+	for i, c := range ring {
+		if len(c) < 2 {
+			return Polygon{}, fmt.Errorf("%w: GeoJSON Polygon position %d has %d coordinates, expected 2", ErrInvalidFormat, i, len(c))
+		}
+		points = append(points, NewPoint(c[1], c[0]))
+	}
+
+	if len(points) > 1 && points[0] == points[len(points)-1] {
+		points = points[:len(points)-1]
+	}
+
+	return NewPolygon(points), nil
+}
+
+// MongoGeoWithin returns a filter matching documents whose field lies entirely within Polygon p.
+func MongoGeoWithin(field string, p Polygon) MongoDocument {
+	return MongoDocument{
+		field: MongoDocument{
+			"$geoWithin": MongoDocument{"$geometry": NewMongoPolygon(p)},
+		},
+	}
+}
+
+// MongoGeoWithinRadius returns a filter matching documents whose field lies
+// within radius of center, measured on a sphere.
+func MongoGeoWithinRadius(field string, center Point, radius Distance) MongoDocument {
+	radians := radius.Meters() / (EARTH_RADIUS * Kilometer).Meters()
+	return MongoDocument{
+		field: MongoDocument{
+			"$geoWithin": MongoDocument{
+				"$centerSphere": []interface{}{[]float64{center.lng, center.lat}, radians},
+			},
+		},
+	}
+}
+
+// MongoGeoIntersects returns a filter matching documents whose field intersects Polygon p.
+func MongoGeoIntersects(field string, p Polygon) MongoDocument {
+	return MongoDocument{
+		field: MongoDocument{
+			"$geoIntersects": MongoDocument{"$geometry": NewMongoPolygon(p)},
+		},
+	}
+}
+
+// MongoNear returns a filter that sorts documents by distance from p, nearest first.
+// Documents closer than min or further than max are excluded; a zero bound is ignored.
+// The field must be covered by a 2dsphere index.
+func MongoNear(field string, p Point, min, max Distance) MongoDocument {
+	near := MongoDocument{"$geometry": NewMongoPoint(p)}
+	if min > 0 {
+		near["$minDistance"] = min.Meters()
+	}
+	if max > 0 {
+		near["$maxDistance"] = max.Meters()
+	}
+
+	return MongoDocument{field: MongoDocument{"$near": near}}
+}
@@ -0,0 +1,79 @@
+package geo
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// Ensures that a $geoWithin filter renders the polygon as a closed GeoJSON ring.
+func TestMongoGeoWithin(t *testing.T) {
+	poly := NewPolygon([]Point{NewPoint(0, 0), NewPoint(0, 1), NewPoint(1, 1)})
+	res, err := json.Marshal(MongoGeoWithin("loc", poly))
+	if err != nil {
+		t.Fatalf("Should not encounter an error when marshalling a filter, but got %v", err)
+	}
+
+	expected := `{"loc":{"$geoWithin":{"$geometry":{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,0]]]}}}}`
+	if string(res) != expected {
+		t.Errorf("Expected filter %s, but got %s instead", expected, res)
+	}
+}
+
+// Ensures that a $near filter only includes the bounds that were set.
+func TestMongoNear(t *testing.T) {
+	res, err := json.Marshal(MongoNear("loc", NewPoint(40.7486, -73.9864), 0, 500*Meter))
+	if err != nil {
+		t.Fatalf("Should not encounter an error when marshalling a filter, but got %v", err)
+	}
+
+	expected := `{"loc":{"$near":{"$geometry":{"type":"Point","coordinates":[-73.9864,40.7486]},"$maxDistance":500}}}`
+	if string(res) != expected {
+		t.Errorf("Expected filter %s, but got %s instead", expected, res)
+	}
+}
+
+// Ensures that the $centerSphere radius is expressed in radians.
+func TestMongoGeoWithinRadius(t *testing.T) {
+	doc := MongoGeoWithinRadius("loc", NewPoint(0, 0), EARTH_RADIUS*Kilometer)
+	within := doc["loc"].(MongoDocument)["$geoWithin"].(MongoDocument)
+	sphere := within["$centerSphere"].([]interface{})
+
+	if sphere[1].(float64) != 1 {
+		t.Errorf("Expected a radius of one Earth radius to be 1 radian, but got %v instead", sphere[1])
+	}
+}
+
+// Ensures that an $geoIntersects filter is keyed by the passed in field.
+func TestMongoGeoIntersects(t *testing.T) {
+	doc := MongoGeoIntersects("area", NewPolygon([]Point{NewPoint(0, 0), NewPoint(0, 1), NewPoint(1, 1)}))
+	if _, ok := doc["area"].(MongoDocument)["$geoIntersects"]; !ok {
+		t.Error("Expected the filter to contain a $geoIntersects operator")
+	}
+}
+
+// Ensures that GeoJSON documents decoded from MongoDB round trip back to geometries.
+func TestMongoRoundTrip(t *testing.T) {
+	var mp MongoPoint
+	if err := json.Unmarshal([]byte(`{"type":"Point","coordinates":[-73.9864,40.7486]}`), &mp); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := mp.Point()
+	if err != nil || p != NewPoint(40.7486, -73.9864) {
+		t.Errorf("Expected the decoded point to be 40.7486,-73.9864, but got %v (%v)", p, err)
+	}
+
+	poly := NewPolygon([]Point{NewPoint(0, 0), NewPoint(0, 1), NewPoint(1, 1)})
+	back, err := NewMongoPolygon(poly).Polygon()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(back.Points()) != 3 {
+		t.Errorf("Expected the closing point to be dropped, but got %d points", len(back.Points()))
+	}
+
+	if _, err := (MongoPoint{Type: "LineString"}).Point(); err == nil {
+		t.Error("Expected an error when decoding a non-Point document as a Point")
+	}
+}
@@ -0,0 +1,85 @@
+package geo
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+)
+
+// A MultiPolygon is a collection of Polygons treated as a single shape,
+// such as a country made up of a mainland and islands.
+type MultiPolygon struct {
+	polygons []Polygon
+}
+
+// NewMultiPolygon returns a new MultiPolygon composed of the passed in polygons.
+func NewMultiPolygon(polygons ...Polygon) MultiPolygon {
+	return MultiPolygon{polygons: polygons}
+}
+
+// Polygons returns the polygons of the MultiPolygon.
+func (m MultiPolygon) Polygons() []Polygon {
+	return m.polygons
+}
+
+// Bounds returns the smallest BoundingBox containing every polygon of the MultiPolygon.
+func (m MultiPolygon) Bounds() BoundingBox {
+	var corners []Point
+	for _, p := range m.polygons {
+		if len(p.points) > 0 {
+			b := p.Bounds()
+			corners = append(corners, b.sw, b.ne)
+		}
+	}
+	return pointsBounds(corners)
+}
+
+// String renders the MultiPolygon as its polygon count and bounds.
+func (m MultiPolygon) String() string {
+	return fmt.Sprintf("MultiPolygon(%d polygons, %v)", len(m.polygons), m.Bounds())
+}
+
+// Contains returns whether or not any polygon of the MultiPolygon contains the passed in Point.
+func (m MultiPolygon) Contains(point Point) bool {
+	addMetric(MetricContainsCalls, "multi_polygon", 1)
+	for _, p := range m.polygons {
+		if p.contains(point) {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsMany reports, for each of the passed in points, whether the MultiPolygon contains it.
+// The points are split across runtime.GOMAXPROCS(0) goroutines.
+func (m MultiPolygon) ContainsMany(points []Point) []bool {
+	return m.ContainsManyWith(points, runtime.GOMAXPROCS(0))
+}
+
+// ContainsManyWith is like ContainsMany, but splits the points across the passed in number of goroutines.
+func (m MultiPolygon) ContainsManyWith(points []Point, workers int) []bool {
+	results, _ := m.containsManyCtx(context.Background(), points, workers)
+	return results
+}
+
+// ContainsManyCtx is like ContainsMany, but stops early and returns ctx.Err() once ctx is done.
+func (m MultiPolygon) ContainsManyCtx(ctx context.Context, points []Point) ([]bool, error) {
+	return m.containsManyCtx(ctx, points, runtime.GOMAXPROCS(0))
+}
+
+func (m MultiPolygon) containsManyCtx(ctx context.Context, points []Point, workers int) ([]bool, error) {
+	prepared := make([]*PreparedPolygon, len(m.polygons))
+	for i, p := range m.polygons {
+		prepared[i] = NewPreparedPolygon(p)
+	}
+
+	return containsMany(ctx, points, workers, func(point Point) bool {
+		addMetric(MetricContainsCalls, "multi_polygon", 1)
+		for _, pp := range prepared {
+			if pp.contains(point) {
+				return true
+			}
+		}
+		return false
+	})
+}
@@ -0,0 +1,27 @@
+package geo
+
+import "testing"
+
+// Ensures that a MultiPolygon contains the points of each of its polygons.
+func TestMultiPolygon(t *testing.T) {
+	west := NewPolygon([]Point{NewPoint(0, 0), NewPoint(0, 1), NewPoint(1, 1), NewPoint(1, 0)})
+	east := NewPolygon([]Point{NewPoint(0, 10), NewPoint(0, 11), NewPoint(1, 11), NewPoint(1, 10)})
+	m := NewMultiPolygon(west, east)
+
+	if b := m.Bounds(); b != NewBoundingBox(NewPoint(0, 0), NewPoint(1, 11)) {
+		t.Errorf("Expected bounds 0,0 1,11, but got %v", b)
+	}
+
+	points := []Point{NewPoint(0.5, 0.5), NewPoint(0.5, 5), NewPoint(0.5, 10.5)}
+	expected := []bool{true, false, true}
+
+	for i, contained := range m.ContainsManyWith(points, 2) {
+		if contained != expected[i] || m.Contains(points[i]) != expected[i] {
+			t.Errorf("Expected containment of %v to be %v", points[i], expected[i])
+		}
+	}
+
+	if s := m.String(); s != "MultiPolygon(2 polygons, 0,0 1,11)" {
+		t.Errorf("Expected MultiPolygon(2 polygons, 0,0 1,11), but got %s", s)
+	}
+}
@@ -0,0 +1,42 @@
+package geo
+
+import "math"
+
+// PointsWithinRadius returns the indices of the points within radius r of center, in order.
+// Points outside of the box enclosing the circle are rejected before computing their
+// Haversine distance.  Use an index such as KDTree for large or repeatedly queried sets.
+func PointsWithinRadius(points []Point, center Point, r Distance) []int {
+	var indices []int
+	if r < 0 {
+		return indices
+	}
+
+	bounds := BoundsAround(center, r)
+	for i, p := range points {
+		if bounds.Contains(p) && center.GreatCircleDistance(p) <= r {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// NearestPoint returns the index of the point nearest to target and its Haversine distance,
+// or -1 and an infinite distance when there are no points.  Points whose difference in
+// latitude alone exceeds the best distance so far are skipped without the full calculation.
+func NearestPoint(points []Point, target Point) (int, Distance) {
+	best, bestDistance := -1, Distance(math.Inf(1))
+
+	// Kilometers per degree of latitude along a meridian.
+	kmPerDegree := EARTH_RADIUS * math.Pi / 180
+	for i, p := range points {
+		if Distance(math.Abs(p.lat-target.lat)*kmPerDegree)*Kilometer >= bestDistance {
+			continue
+		}
+
+		if d := target.GreatCircleDistance(p); d < bestDistance {
+			best, bestDistance = i, d
+		}
+	}
+
+	return best, bestDistance
+}
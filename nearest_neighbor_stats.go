@@ -0,0 +1,85 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+)
+
+// NearestNeighborStats holds the average nearest neighbor statistic of a point pattern, which
+// compares the mean distance from each point to its nearest neighbor with the mean expected if
+// the same number of points were spread over the study area by complete spatial randomness.
+type NearestNeighborStats struct {
+	// Observed is the mean distance from each point to its nearest neighbor.
+	Observed Distance
+	// Expected is the mean distance expected under complete spatial randomness.
+	Expected Distance
+	// Ratio is Observed divided by Expected: below 1 the points are clustered, above 1 dispersed.
+	Ratio float64
+	// ZScore is the number of standard errors between Observed and Expected.
+	ZScore float64
+	// PValue is the probability of a ZScore at least as far from zero under complete spatial randomness.
+	PValue float64
+}
+
+// AverageNearestNeighbor returns the average nearest neighbor statistic of Clark and Evans, "Distance
+// to nearest neighbor as a measure of spatial relationships in populations" (1954), of the points
+// inside study, the Polygon of the area the points could have fallen in.  The result depends heavily
+// on the study area: the same points look clustered within a large area and dispersed within a small one.
+// It returns an error wrapping ErrUnclosedPolygon or ErrDegeneratePolygon for a study area without
+// area, and ErrNoResults for fewer than two points inside it.
+func AverageNearestNeighbor(points []Point, study Polygon) (NearestNeighborStats, error) {
+	if !study.IsClosed() {
+		return NearestNeighborStats{}, fmt.Errorf("%w: %d points", ErrUnclosedPolygon, len(study.points))
+	}
+	area := sphericalArea(study.points)
+	if area == 0 {
+		return NearestNeighborStats{}, fmt.Errorf("%w: study area has no area", ErrDegeneratePolygon)
+	}
+
+	var inside []Point
+	for _, p := range points {
+		if study.contains(p) {
+			inside = append(inside, p)
+		}
+	}
+	n := float64(len(inside))
+	if len(inside) < 2 {
+		return NearestNeighborStats{}, fmt.Errorf("%w: %d points inside the study area", ErrNoResults, len(inside))
+	}
+
+	tree := NewKDTree(inside)
+	var sum Distance
+	for _, p := range inside {
+		// The nearest point to p is p itself.
+		sum += tree.Nearest(p, 2)[1].Distance
+	}
+
+	stats := NearestNeighborStats{
+		Observed: sum / Distance(n),
+		Expected: Distance(0.5 / math.Sqrt(n/area)),
+	}
+	stderr := 0.26136 / math.Sqrt(n*n/area)
+	stats.Ratio = float64(stats.Observed / stats.Expected)
+	stats.ZScore = float64(stats.Observed-stats.Expected) / stderr
+	stats.PValue = math.Erfc(math.Abs(stats.ZScore) / math.Sqrt2)
+	return stats, nil
+}
+
+// sphericalArea returns the area in square meters enclosed by a ring of points on the sphere,
+// by the method of Chamberlain and Duquette, "Some algorithms for polygons on a sphere" (2007).
+func sphericalArea(ring []Point) float64 {
+	var sum float64
+	for i, p := range ring {
+		q := ring[(i+1)%len(ring)]
+		dLng := q.lng - p.lng
+		// Take the short way round between longitudes either side of the antimeridian.
+		if dLng > 180 {
+			dLng -= 360
+		} else if dLng < -180 {
+			dLng += 360
+		}
+		sum += dLng * math.Pi / 180 * (2 + math.Sin(p.lat*math.Pi/180) + math.Sin(q.lat*math.Pi/180))
+	}
+	radius := EARTH_RADIUS * float64(Kilometer)
+	return math.Abs(sum) * radius * radius / 2
+}
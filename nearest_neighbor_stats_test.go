@@ -0,0 +1,72 @@
+package geo
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// Ensures that random points score near 1, and clustered and regular points below and above it.
+func TestAverageNearestNeighbor(t *testing.T) {
+	study := NewPolygon([]Point{NewPoint(0, 0), NewPoint(0, 0.1), NewPoint(0.1, 0.1), NewPoint(0.1, 0)})
+	r := rand.New(rand.NewSource(4))
+
+	var random, clustered, regular []Point
+	for range 400 {
+		random = append(random, NewPoint(r.Float64()*0.1, r.Float64()*0.1))
+		clustered = append(clustered, NewPoint(0.05+r.NormFloat64()*0.002, 0.05+r.NormFloat64()*0.002))
+	}
+	for i := range 20 {
+		for j := range 20 {
+			regular = append(regular, NewPoint(0.0025+float64(i)*0.005, 0.0025+float64(j)*0.005))
+		}
+	}
+	// Points outside the study area are left out.
+	random = append(random, NewPoint(1, 1))
+
+	stats, err := AverageNearestNeighbor(random, study)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 400 points over about 123.6 square kilometers.
+	if math.Abs(stats.Expected.Meters()-0.5/math.Sqrt(400/123.6e6)) > 1 {
+		t.Errorf("Unexpected expected distance %v", stats.Expected)
+	}
+	if math.Abs(stats.Ratio-1) > 0.1 || stats.PValue < 0.01 {
+		t.Errorf("Expected random points to score near 1, but got %+v", stats)
+	}
+
+	if stats, _ := AverageNearestNeighbor(clustered, study); stats.Ratio > 0.5 || stats.ZScore > -10 || stats.PValue > 1e-6 {
+		t.Errorf("Expected clustered points to score well below 1, but got %+v", stats)
+	}
+	if stats, _ := AverageNearestNeighbor(regular, study); stats.Ratio < 1.8 || stats.ZScore < 10 {
+		t.Errorf("Expected regular points to score near 2, but got %+v", stats)
+	}
+
+	if _, err := AverageNearestNeighbor(random[:1], study); !errors.Is(err, ErrNoResults) {
+		t.Errorf("Expected ErrNoResults, but got %v", err)
+	}
+	if _, err := AverageNearestNeighbor(random, NewPolygon(study.Points()[:2])); !errors.Is(err, ErrUnclosedPolygon) {
+		t.Errorf("Expected ErrUnclosedPolygon, but got %v", err)
+	}
+}
+
+// Ensures that the area of rings on the sphere is measured, including across the antimeridian.
+func TestSphericalArea(t *testing.T) {
+	// A degree square at the equator is about 12364 square kilometers.
+	square := []Point{NewPoint(0, 0), NewPoint(0, 1), NewPoint(1, 1), NewPoint(1, 0)}
+	if a := sphericalArea(square); math.Abs(a/1e6-12363.7) > 1 {
+		t.Errorf("Expected about 12363.7 square kilometers, but got %v", a/1e6)
+	}
+	across := []Point{NewPoint(0, 179.5), NewPoint(0, -179.5), NewPoint(1, -179.5), NewPoint(1, 179.5)}
+	if a := sphericalArea(across); math.Abs(a-sphericalArea(square)) > 1 {
+		t.Errorf("Expected the same area across the antimeridian, but got %v", a/1e6)
+	}
+	// The northern hemisphere.
+	hemisphere := []Point{NewPoint(0, -180), NewPoint(0, -90), NewPoint(0, 0), NewPoint(0, 90)}
+	radius := EARTH_RADIUS * float64(Kilometer)
+	if a := sphericalArea(hemisphere); math.Abs(a-2*math.Pi*radius*radius)/a > 1e-9 {
+		t.Errorf("Expected the area of a hemisphere, but got %v", a)
+	}
+}
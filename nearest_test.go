@@ -0,0 +1,45 @@
+package geo
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// Ensures that the brute force helpers agree with a plain Haversine scan.
+func TestPointsWithinRadius(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	points := make([]Point, 1000)
+	for i := range points {
+		points[i] = NewPoint(r.Float64()*40-20, r.Float64()*40+160).Normalized()
+	}
+
+	center := NewPoint(0, 179)
+	radius := 1500 * Kilometer
+
+	var expected []int
+	for i, p := range points {
+		if center.GreatCircleDistance(p) <= radius {
+			expected = append(expected, i)
+		}
+	}
+
+	if found := PointsWithinRadius(points, center, radius); !sameInts(found, expected) {
+		t.Errorf("Expected %d points within %v, but got %d", len(expected), radius, len(found))
+	}
+
+	best, bestDistance := -1, Distance(math.Inf(1))
+	for i, p := range points {
+		if d := center.GreatCircleDistance(p); d < bestDistance {
+			best, bestDistance = i, d
+		}
+	}
+
+	if i, d := NearestPoint(points, center); i != best || d != bestDistance {
+		t.Errorf("Expected the nearest point to be %d at %v, but got %d at %v", best, bestDistance, i, d)
+	}
+
+	if i, d := NearestPoint(nil, center); i != -1 || !math.IsInf(float64(d), 1) {
+		t.Errorf("Expected no nearest point, but got %d at %v", i, d)
+	}
+}
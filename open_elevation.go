@@ -0,0 +1,103 @@
+package geo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// OpenElevationURL is the lookup endpoint of the public Open-Elevation API.
+const OpenElevationURL = "https://api.open-elevation.com/api/v1/lookup"
+
+// DefaultOpenElevationBatchSize is the number of points an OpenElevationElevator sends
+// in each request when none is configured.
+const DefaultOpenElevationBatchSize = 100
+
+// OpenElevationOptions configures an OpenElevationElevator.
+type OpenElevationOptions struct {
+	// BaseURL replaces OpenElevationURL, usually with the lookup endpoint of a self-hosted
+	// server or of another service speaking the same protocol.
+	BaseURL string
+	// BatchSize caps the number of points sent in each request.  Defaults to DefaultOpenElevationBatchSize.
+	BatchSize int
+	// Client sends the requests.  Defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+// An OpenElevationElevator is an Elevator backed by an Open-Elevation style lookup API,
+// which takes a JSON list of locations and replies with their elevations in meters.
+// It is safe for concurrent use.
+type OpenElevationElevator struct {
+	opts OpenElevationOptions
+}
+
+var _ Elevator = (*OpenElevationElevator)(nil)
+
+// NewOpenElevationElevator returns a new OpenElevationElevator.
+func NewOpenElevationElevator(opts OpenElevationOptions) *OpenElevationElevator {
+	if opts.BaseURL == "" {
+		opts.BaseURL = OpenElevationURL
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultOpenElevationBatchSize
+	}
+	return &OpenElevationElevator{opts: opts}
+}
+
+type openElevationLocation struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// ElevationAt returns the elevation of the terrain at Point p.
+func (e *OpenElevationElevator) ElevationAt(ctx context.Context, p Point) (Distance, error) {
+	elevations, err := e.ElevationsAt(ctx, []Point{p})
+	if err != nil {
+		return 0, err
+	}
+	return elevations[0], nil
+}
+
+// ElevationsAt returns the elevation of the terrain at each of the passed in points,
+// sending them in batches of up to the configured BatchSize.
+func (e *OpenElevationElevator) ElevationsAt(ctx context.Context, points []Point) ([]Distance, error) {
+	u, err := url.Parse(e.opts.BaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("open-elevation: %w", err)
+	}
+
+	elevations := make([]Distance, 0, len(points))
+	for start := 0; start < len(points); start += e.opts.BatchSize {
+		batch := points[start:min(start+e.opts.BatchSize, len(points))]
+
+		var req struct {
+			Locations []openElevationLocation `json:"locations"`
+		}
+		req.Locations = make([]openElevationLocation, len(batch))
+		for i, p := range batch {
+			req.Locations[i] = openElevationLocation{Latitude: p.lat, Longitude: p.lng}
+		}
+
+		var resp struct {
+			Results []struct {
+				Elevation *float64 `json:"elevation"`
+			} `json:"results"`
+		}
+		if err := postJSON(ctx, e.opts.Client, "open-elevation", u, req, &resp); err != nil {
+			return nil, err
+		}
+		if len(resp.Results) != len(batch) {
+			return nil, fmt.Errorf("%w: open-elevation: %d results for %d locations", ErrUnexpectedReply, len(resp.Results), len(batch))
+		}
+
+		for i, r := range resp.Results {
+			if r.Elevation == nil {
+				return nil, fmt.Errorf("%w: open-elevation: no elevation data at %v", ErrNoResults, batch[i])
+			}
+			elevations = append(elevations, Distance(*r.Elevation))
+		}
+	}
+
+	return elevations, nil
+}
@@ -0,0 +1,56 @@
+package geo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Ensures that the Open-Elevation elevator sends points in batches and returns their elevations in order.
+func TestOpenElevationElevator(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		var req struct {
+			Locations []openElevationLocation `json:"locations"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("Expected a JSON request, but got %v", err)
+		}
+
+		// Each elevation is the latitude, except at the equator where there is no data.
+		var resp struct {
+			Results []map[string]*float64 `json:"results"`
+		}
+		for _, l := range req.Locations {
+			var elevation *float64
+			if l.Latitude != 0 {
+				elevation = &l.Latitude
+			}
+			resp.Results = append(resp.Results, map[string]*float64{"elevation": elevation})
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	e := NewOpenElevationElevator(OpenElevationOptions{BaseURL: server.URL, BatchSize: 2, Client: server.Client()})
+	ctx := context.Background()
+
+	elevations, err := e.ElevationsAt(ctx, []Point{NewPoint(10, 1), NewPoint(20, 2), NewPoint(30, 3)})
+	if err != nil || len(elevations) != 3 || elevations[0] != 10 || elevations[1] != 20 || elevations[2] != 30 {
+		t.Errorf("Expected [10 20 30], but got %v, %v", elevations, err)
+	}
+	if requests != 2 {
+		t.Errorf("Expected 3 points to be sent in 2 requests, but got %d", requests)
+	}
+
+	if elevation, err := e.ElevationAt(ctx, NewPoint(40, 4)); err != nil || elevation != 40 {
+		t.Errorf("Expected 40, but got %v, %v", elevation, err)
+	}
+	if _, err := e.ElevationAt(ctx, NewPoint(0, 5)); !errors.Is(err, ErrNoResults) {
+		t.Errorf("Expected a null elevation to return ErrNoResults, but got %v", err)
+	}
+}
@@ -0,0 +1,118 @@
+package geo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// OpenCageGeocodeURL is the endpoint of the OpenCage Geocoding API.
+const OpenCageGeocodeURL = "https://api.opencagedata.com/geocode/v1/json"
+
+// OpenCageGeocoderOptions configures an OpenCageGeocoder.
+type OpenCageGeocoderOptions struct {
+	// Language is the language results are returned in, such as "en".  Defaults to the service's choice.
+	Language string
+	// BaseURL replaces OpenCageGeocodeURL, for example to point at a proxy.
+	BaseURL string
+	// Client sends the requests.  Defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+// An OpenCageGeocoder is a Geocoder backed by the OpenCage Geocoding API.
+// It is safe for concurrent use.
+type OpenCageGeocoder struct {
+	apiKey string
+	opts   OpenCageGeocoderOptions
+}
+
+var _ Geocoder = (*OpenCageGeocoder)(nil)
+
+// NewOpenCageGeocoder returns a new OpenCageGeocoder that authenticates with the passed in API key.
+func NewOpenCageGeocoder(apiKey string, opts OpenCageGeocoderOptions) *OpenCageGeocoder {
+	if opts.BaseURL == "" {
+		opts.BaseURL = OpenCageGeocodeURL
+	}
+	return &OpenCageGeocoder{apiKey: apiKey, opts: opts}
+}
+
+type openCageGeocodeResponse struct {
+	Results []struct {
+		Formatted string `json:"formatted"`
+		Geometry  struct {
+			Lat float64 `json:"lat"`
+			Lng float64 `json:"lng"`
+		} `json:"geometry"`
+		Components map[string]interface{} `json:"components"`
+	} `json:"results"`
+}
+
+// Geocode returns the Point of OpenCage's best match for address.
+func (g *OpenCageGeocoder) Geocode(ctx context.Context, address string) (Point, error) {
+	resp, err := g.request(ctx, address)
+	if err != nil {
+		return Point{}, err
+	}
+
+	geometry := resp.Results[0].Geometry
+	return NewPoint(geometry.Lat, geometry.Lng), nil
+}
+
+// ReverseGeocode returns the Address of OpenCage's best match for Point p.
+func (g *OpenCageGeocoder) ReverseGeocode(ctx context.Context, p Point) (Address, error) {
+	resp, err := g.request(ctx, strconv.FormatFloat(p.lat, 'f', -1, 64)+","+strconv.FormatFloat(p.lng, 'f', -1, 64))
+	if err != nil {
+		return Address{}, err
+	}
+
+	result := resp.Results[0]
+
+	// Components also holds non-string values, such as the ISO 3166-2 codes of a region.
+	component := func(keys ...string) string {
+		for _, key := range keys {
+			if v, ok := result.Components[key].(string); ok && v != "" {
+				return v
+			}
+		}
+		return ""
+	}
+
+	return Address{
+		Formatted:   result.Formatted,
+		HouseNumber: component("house_number"),
+		Street:      component("road"),
+		Locality:    component("city", "town", "village", "hamlet"),
+		Region:      component("state", "province"),
+		PostalCode:  component("postcode"),
+		Country:     component("country"),
+		CountryCode: strings.ToUpper(component("country_code")),
+	}, nil
+}
+
+// request queries the geocoding API, which takes addresses and "lat,lng" pairs alike,
+// and only returns its reply without error if it holds at least one result.
+func (g *OpenCageGeocoder) request(ctx context.Context, q string) (*openCageGeocodeResponse, error) {
+	u, err := url.Parse(g.opts.BaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("opencage geocoder: %w", err)
+	}
+	query := url.Values{"q": {q}, "key": {g.apiKey}, "limit": {"1"}, "no_annotations": {"1"}}
+	if g.opts.Language != "" {
+		query.Set("language", g.opts.Language)
+	}
+	u.RawQuery = query.Encode()
+
+	var resp openCageGeocodeResponse
+	if err := getJSON(ctx, g.opts.Client, "opencage geocoder", u, &resp); err != nil {
+		return nil, err
+	}
+
+	if len(resp.Results) == 0 {
+		return nil, fmt.Errorf("%w: opencage geocoder", ErrNoResults)
+	}
+
+	return &resp, nil
+}
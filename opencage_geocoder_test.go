@@ -0,0 +1,67 @@
+package geo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Ensures that the OpenCage geocoder sends addresses and coordinates as queries and decodes the first result.
+func TestOpenCageGeocoder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("key") != "secret" {
+			t.Errorf("Expected the API key to be sent, but got %q", q.Get("key"))
+		}
+
+		switch q.Get("q") {
+		case "1 Macquarie St":
+			w.Write([]byte(`{"results": [{"geometry": {"lat": -33.8688, "lng": 151.2093}}]}`))
+		case "-33.8688,151.2093":
+			w.Write([]byte(`{"results": [{
+				"formatted": "1 Macquarie Street, Sydney NSW 2000, Australia",
+				"components": {
+					"house_number": "1",
+					"road": "Macquarie Street",
+					"town": "Sydney",
+					"state": "New South Wales",
+					"postcode": "2000",
+					"country": "Australia",
+					"country_code": "au",
+					"ISO_3166-2": ["AU-NSW"]
+				}}]}`))
+		default:
+			w.Write([]byte(`{"results": []}`))
+		}
+	}))
+	defer server.Close()
+
+	g := NewOpenCageGeocoder("secret", OpenCageGeocoderOptions{BaseURL: server.URL, Client: server.Client()})
+	ctx := context.Background()
+
+	p, err := g.Geocode(ctx, "1 Macquarie St")
+	if err != nil || p != NewPoint(-33.8688, 151.2093) {
+		t.Errorf("Expected -33.8688,151.2093, but got %v, %v", p, err)
+	}
+
+	address, err := g.ReverseGeocode(ctx, NewPoint(-33.8688, 151.2093))
+	expected := Address{
+		Formatted:   "1 Macquarie Street, Sydney NSW 2000, Australia",
+		HouseNumber: "1",
+		Street:      "Macquarie Street",
+		Locality:    "Sydney",
+		Region:      "New South Wales",
+		PostalCode:  "2000",
+		Country:     "Australia",
+		CountryCode: "AU",
+	}
+	if err != nil || address != expected {
+		t.Errorf("Expected %+v, but got %+v, %v", expected, address, err)
+	}
+
+	if _, err := g.Geocode(ctx, "nowhere"); !errors.Is(err, ErrNoResults) {
+		t.Errorf("Expected ErrNoResults, but got %v", err)
+	}
+}
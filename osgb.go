@@ -0,0 +1,153 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Reference systems of Ordnance Survey data.
+const (
+	OSGB36              CRS = 4277
+	BritishNationalGrid CRS = 27700
+)
+
+// Parameters of the British National Grid projection.
+const (
+	osgbScaleFactor   = 0.9996012717
+	osgbOriginLat     = 49.0
+	osgbOriginLng     = -2.0
+	osgbFalseEasting  = 400000.0
+	osgbFalseNorthing = -100000.0
+)
+
+var (
+	airy1830TransverseMercator = newTransverseMercator(Airy1830Ellipsoid.SemiMajorAxis, Airy1830Ellipsoid.Flattening)
+	_, osgbOriginNorthing      = airy1830TransverseMercator.forward(osgbOriginLat, osgbOriginLng, osgbOriginLng)
+)
+
+func init() {
+	RegisterTransformer(BritishNationalGrid, britishNationalGridTransformer{})
+}
+
+// ToBritishNationalGrid returns the Ordnance Survey National Grid easting and northing
+// in meters of the WGS84 Point p.  The datum shift uses the Helmert transformation
+// published by Ordnance Survey, which is accurate to within about 5 meters.
+func ToBritishNationalGrid(p Point) (easting float64, northing float64) {
+	return osgbGrid(OSGB36Datum.FromWGS84(p))
+}
+
+// FromBritishNationalGrid returns the WGS84 Point at the passed in National Grid easting and northing.
+func FromBritishNationalGrid(easting float64, northing float64) Point {
+	return OSGB36Datum.ToWGS84Point(osgbPoint(easting, northing))
+}
+
+// FormatOSGridReference renders a National Grid easting and northing as a lettered grid
+// reference such as "TQ 30047 80403".  The precision is the number of digits used for
+// each of the easting and northing, from 1 (10km) to 5 (1m).
+func FormatOSGridReference(easting float64, northing float64, precision int) (string, error) {
+	if precision < 1 || precision > 5 {
+		return "", fmt.Errorf("%w: grid reference precision %d out of range [1, 5]", ErrInvalidPrecision, precision)
+	}
+
+	e100k := int(math.Floor(easting / 100000))
+	n100k := int(math.Floor(northing / 100000))
+	if e100k < 0 || e100k > 6 || n100k < 0 || n100k > 12 {
+		return "", fmt.Errorf("%w: easting %v and northing %v lie outside of the National Grid", ErrOutOfBounds, easting, northing)
+	}
+
+	// The first letter selects a 500km square and the second a 100km square within it,
+	// both counted from the north-west in a 5x5 grid of letters without I.
+	l1 := (19 - n100k) - (19-n100k)%5 + (e100k+10)/5
+	l2 := (19-n100k)*5%25 + e100k%5
+	letters := string([]byte{osgbLetter(l1), osgbLetter(l2)})
+
+	scale := math.Pow10(5 - precision)
+	e := int(math.Floor(math.Mod(easting, 100000) / scale))
+	n := int(math.Floor(math.Mod(northing, 100000) / scale))
+
+	return fmt.Sprintf("%s %0*d %0*d", letters, precision, e, precision, n), nil
+}
+
+// ParseOSGridReference returns the easting and northing of the south-west corner of the
+// square described by a lettered grid reference such as "TQ 30047 80403".
+func ParseOSGridReference(ref string) (easting float64, northing float64, err error) {
+	s := strings.ToUpper(strings.Join(strings.Fields(ref), ""))
+	if len(s) < 2 || len(s)%2 != 0 || len(s) > 12 {
+		return 0, 0, fmt.Errorf("%w: invalid grid reference %q", ErrInvalidFormat, ref)
+	}
+
+	l1, ok1 := osgbLetterIndex(s[0])
+	l2, ok2 := osgbLetterIndex(s[1])
+	if !ok1 || !ok2 {
+		return 0, 0, fmt.Errorf("%w: invalid grid reference letters %q in %q", ErrInvalidFormat, s[:2], ref)
+	}
+
+	e100k := (l1-2)%5*5 + l2%5
+	n100k := 19 - l1/5*5 - l2/5
+	if e100k < 0 || e100k > 6 || n100k < 0 || n100k > 12 {
+		return 0, 0, fmt.Errorf("%w: grid square %q lies outside of the National Grid", ErrOutOfBounds, s[:2])
+	}
+
+	digits := s[2:]
+	precision := len(digits) / 2
+	var e, n int
+	if precision > 0 {
+		var errE, errN error
+		e, errE = strconv.Atoi(digits[:precision])
+		n, errN = strconv.Atoi(digits[precision:])
+		if errE != nil || errN != nil || strings.ContainsAny(digits, "+-") {
+			return 0, 0, fmt.Errorf("%w: invalid grid reference digits %q in %q", ErrInvalidFormat, digits, ref)
+		}
+	}
+
+	scale := math.Pow10(5 - precision)
+	return float64(e100k)*100000 + float64(e)*scale, float64(n100k)*100000 + float64(n)*scale, nil
+}
+
+// osgbLetter returns the grid letter at index i of the alphabet without I.
+func osgbLetter(i int) byte {
+	if i > 7 {
+		i++
+	}
+	return byte('A' + i)
+}
+
+// osgbLetterIndex is the inverse of osgbLetter.
+func osgbLetterIndex(c byte) (int, bool) {
+	if c < 'A' || c > 'Z' || c == 'I' {
+		return 0, false
+	}
+
+	i := int(c - 'A')
+	if i > 8 {
+		i--
+	}
+	return i, true
+}
+
+// osgbGrid projects an OSGB36 Point onto the National Grid.
+func osgbGrid(p Point) (easting float64, northing float64) {
+	x, y := airy1830TransverseMercator.forward(p.lat, p.lng, osgbOriginLng)
+	return osgbFalseEasting + osgbScaleFactor*x, osgbFalseNorthing + osgbScaleFactor*(y-osgbOriginNorthing)
+}
+
+// osgbPoint is the inverse of osgbGrid.
+func osgbPoint(easting float64, northing float64) Point {
+	x := (easting - osgbFalseEasting) / osgbScaleFactor
+	y := (northing-osgbFalseNorthing)/osgbScaleFactor + osgbOriginNorthing
+	lat, lng := airy1830TransverseMercator.inverse(x, y, osgbOriginLng)
+	return NewPoint(lat, lng)
+}
+
+// britishNationalGridTransformer converts WGS84 Points to National Grid eastings and northings.
+type britishNationalGridTransformer struct{}
+
+func (britishNationalGridTransformer) Forward(p Point) (float64, float64) {
+	return ToBritishNationalGrid(p)
+}
+
+func (britishNationalGridTransformer) Inverse(x float64, y float64) Point {
+	return FromBritishNationalGrid(x, y)
+}
@@ -0,0 +1,77 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+// Ensures that the National Grid projection matches the worked example from Ordnance Survey's
+// "A guide to coordinate systems in Great Britain".
+func TestOSGBGrid(t *testing.T) {
+	p := NewPoint(52+39.0/60+27.2531/3600, 1+43.0/60+4.5177/3600)
+	easting, northing := osgbGrid(p)
+
+	if math.Abs(easting-651409.903) > 0.01 || math.Abs(northing-313177.270) > 0.01 {
+		t.Errorf("Expected 651409.903, 313177.270 but got %v, %v", easting, northing)
+	}
+
+	back := osgbPoint(easting, northing)
+	if math.Abs(back.lat-p.lat) > 1e-7 || math.Abs(back.lng-p.lng) > 1e-7 {
+		t.Errorf("Expected the grid coordinates to convert back to %v, but got %v", p, back)
+	}
+}
+
+// Ensures that the WGS84 to OSGB36 datum shift moves the Greenwich meridian by the expected ~100m.
+func TestOSGB36DatumShift(t *testing.T) {
+	// The Airy transit circle defines the OSGB36 prime meridian,
+	// and lies about 0.0015° west of the WGS84 prime meridian.
+	airy := OSGB36Datum.ToWGS84Point(NewPoint(51.477811, 0))
+	if math.Abs(airy.lng+0.00147) > 0.0002 {
+		t.Errorf("Expected the Airy transit circle to be at about -0.00147° in WGS84, but got %v", airy.lng)
+	}
+
+	p := NewPoint(51.500729, -0.124625)
+	easting, northing := ToBritishNationalGrid(p)
+	back := FromBritishNationalGrid(easting, northing)
+	if d := p.GreatCircleDistance(back); d > 0.1*Meter {
+		t.Errorf("Expected %v to round trip through the National Grid, but got %v (%v away)", p, back, d)
+	}
+
+	grid, err := TransformPoint(p, WGS84, BritishNationalGrid)
+	if err != nil || grid.Lng() != easting || grid.Lat() != northing {
+		t.Errorf("Expected EPSG:27700 to be registered, but got %v (%v)", grid, err)
+	}
+}
+
+// Ensures that lettered grid references are formatted and parsed.
+func TestOSGridReference(t *testing.T) {
+	ref, err := FormatOSGridReference(651409.903, 313177.270, 5)
+	if err != nil || ref != "TG 51409 13177" {
+		t.Errorf("Expected TG 51409 13177, but got %s (%v)", ref, err)
+	}
+
+	ref, _ = FormatOSGridReference(530047, 180403, 3)
+	if ref != "TQ 300 804" {
+		t.Errorf("Expected TQ 300 804, but got %s", ref)
+	}
+
+	easting, northing, err := ParseOSGridReference("TG 51409 13177")
+	if err != nil || easting != 651409 || northing != 313177 {
+		t.Errorf("Expected 651409, 313177 but got %v, %v (%v)", easting, northing, err)
+	}
+
+	easting, northing, err = ParseOSGridReference("su")
+	if err != nil || easting != 400000 || northing != 100000 {
+		t.Errorf("Expected SU to be at 400000, 100000 but got %v, %v (%v)", easting, northing, err)
+	}
+
+	for _, invalid := range []string{"", "T", "TG1", "IG 1 1", "AA 1 1", "TG 5x 13"} {
+		if _, _, err := ParseOSGridReference(invalid); err == nil {
+			t.Errorf("Expected an error when parsing %q", invalid)
+		}
+	}
+
+	if _, err := FormatOSGridReference(-1, 0, 5); err == nil {
+		t.Error("Expected an error when formatting a coordinate outside of the grid")
+	}
+}
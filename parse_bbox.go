@@ -0,0 +1,97 @@
+package geo
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var _ flag.Getter = (*BBoxFlag)(nil)
+
+// ParseBBox parses a BoundingBox from "minLng,minLat,maxLng,maxLat", the order of GeoJSON, WMS and
+// most web map APIs, optionally in square brackets.  A minimum longitude greater than the maximum
+// gives a box crossing the antimeridian.  It returns an error wrapping ErrInvalidFormat unless there
+// are four numbers, ErrNonFiniteCoordinate, ErrInvalidLatitude or ErrInvalidLongitude for a corner out of
+// range, or ErrInvalidLatitude for a minimum latitude north of the maximum.
+func ParseBBox(s string) (BoundingBox, error) {
+	return parseBBox(s, false)
+}
+
+// ParseBBoxLatFirst is like ParseBBox, but parses "minLat,minLng,maxLat,maxLng".
+func ParseBBoxLatFirst(s string) (BoundingBox, error) {
+	return parseBBox(s, true)
+}
+
+func parseBBox(s string, latFirst bool) (BoundingBox, error) {
+	parts := strings.Split(trimBrackets(strings.TrimSpace(s), false), ",")
+	if len(parts) != 4 {
+		return BoundingBox{}, fmt.Errorf("%w: expected four comma separated coordinates in %q", ErrInvalidFormat, s)
+	}
+	var v [4]float64
+	for i, part := range parts {
+		f, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return BoundingBox{}, fmt.Errorf("%w: invalid coordinate in %q: %v", ErrInvalidFormat, s, err)
+		}
+		v[i] = f
+	}
+	if !latFirst {
+		v[0], v[1], v[2], v[3] = v[1], v[0], v[3], v[2]
+	}
+
+	sw, err := newParsedPoint(s, v[0], v[1])
+	if err != nil {
+		return BoundingBox{}, err
+	}
+	ne, err := newParsedPoint(s, v[2], v[3])
+	if err != nil {
+		return BoundingBox{}, err
+	}
+	if sw.lat > ne.lat {
+		return BoundingBox{}, fmt.Errorf("%w: minimum latitude %v north of maximum %v in %q", ErrInvalidLatitude, sw.lat, ne.lat, s)
+	}
+	return NewBoundingBox(sw, ne), nil
+}
+
+// A BBoxFlag is a flag.Value holding a BoundingBox parsed by ParseBBox, or by ParseBBoxLatFirst if
+// LatFirst is set, for command line tools:
+//
+//	var bbox geo.BBoxFlag
+//	flag.Var(&bbox, "bbox", "area of interest as minLng,minLat,maxLng,maxLat")
+type BBoxFlag struct {
+	Bounds   BoundingBox
+	LatFirst bool
+}
+
+// String renders the BoundingBox in the order it is parsed in.
+func (f *BBoxFlag) String() string {
+	if f == nil {
+		return ""
+	}
+	sw, ne := f.Bounds.sw, f.Bounds.ne
+	v := [4]float64{sw.lng, sw.lat, ne.lng, ne.lat}
+	if f.LatFirst {
+		v = [4]float64{sw.lat, sw.lng, ne.lat, ne.lng}
+	}
+	parts := make([]string, len(v))
+	for i, x := range v {
+		parts[i] = strconv.FormatFloat(x, 'f', -1, 64)
+	}
+	return strings.Join(parts, ",")
+}
+
+// Set parses the BoundingBox from s.
+func (f *BBoxFlag) Set(s string) error {
+	b, err := parseBBox(s, f.LatFirst)
+	if err != nil {
+		return err
+	}
+	f.Bounds = b
+	return nil
+}
+
+// Get returns the BoundingBox, for flag.Getter.
+func (f *BBoxFlag) Get() any {
+	return f.Bounds
+}
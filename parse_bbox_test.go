@@ -0,0 +1,72 @@
+package geo
+
+import (
+	"errors"
+	"flag"
+	"testing"
+)
+
+// Ensures that boxes are parsed in either order and validated.
+func TestParseBBox(t *testing.T) {
+	tests := []struct {
+		s        string
+		latFirst bool
+		expected BoundingBox
+		err      error
+	}{
+		{"-0.5,51.3,0.3,51.7", false, NewBoundingBox(NewPoint(51.3, -0.5), NewPoint(51.7, 0.3)), nil},
+		{" [ -0.5, 51.3, 0.3, 51.7 ] ", false, NewBoundingBox(NewPoint(51.3, -0.5), NewPoint(51.7, 0.3)), nil},
+		{"51.3,-0.5,51.7,0.3", true, NewBoundingBox(NewPoint(51.3, -0.5), NewPoint(51.7, 0.3)), nil},
+		{"170,-20,-170,-10", false, NewBoundingBox(NewPoint(-20, 170), NewPoint(-10, -170)), nil},
+		{"1,2,3", false, BoundingBox{}, ErrInvalidFormat},
+		{"a,2,3,4", false, BoundingBox{}, ErrInvalidFormat},
+		{"0,91,1,92", false, BoundingBox{}, ErrInvalidLatitude},
+		{"0,10,1,5", false, BoundingBox{}, ErrInvalidLatitude},
+		{"0,10,181,11", false, BoundingBox{}, ErrInvalidLongitude},
+		{"0,NaN,1,2", false, BoundingBox{}, ErrNonFiniteCoordinate},
+	}
+	for _, tt := range tests {
+		parse := ParseBBox
+		if tt.latFirst {
+			parse = ParseBBoxLatFirst
+		}
+		b, err := parse(tt.s)
+		if !errors.Is(err, tt.err) || (tt.err == nil && err != nil) {
+			t.Errorf("Expected error %v parsing %q, but got %v", tt.err, tt.s, err)
+			continue
+		}
+		if b != tt.expected {
+			t.Errorf("Expected %q parsed as %v, but got %v", tt.s, tt.expected, b)
+		}
+	}
+	if b, _ := ParseBBox("170,-20,-170,-10"); !b.CrossesAntimeridian() {
+		t.Errorf("Expected %v to cross the antimeridian", b)
+	}
+}
+
+// Ensures that a BBoxFlag parses command line arguments and renders them back.
+func TestBBoxFlag(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	var bbox BBoxFlag
+	latFirst := BBoxFlag{LatFirst: true}
+	fs.Var(&bbox, "bbox", "")
+	fs.Var(&latFirst, "latbbox", "")
+
+	if err := fs.Parse([]string{"-bbox", "-0.5,51.3,0.3,51.7", "-latbbox", "51.3,-0.5,51.7,0.3"}); err != nil {
+		t.Fatal(err)
+	}
+	expected := NewBoundingBox(NewPoint(51.3, -0.5), NewPoint(51.7, 0.3))
+	if bbox.Bounds != expected || latFirst.Bounds != expected || fs.Lookup("bbox").Value.(flag.Getter).Get() != expected {
+		t.Errorf("Expected both flags to hold %v, but got %v and %v", expected, bbox.Bounds, latFirst.Bounds)
+	}
+	if s := bbox.String(); s != "-0.5,51.3,0.3,51.7" {
+		t.Errorf("Expected the flag rendered lng first, but got %q", s)
+	}
+	if s := latFirst.String(); s != "51.3,-0.5,51.7,0.3" {
+		t.Errorf("Expected the flag rendered lat first, but got %q", s)
+	}
+
+	if err := bbox.Set("1,2"); !errors.Is(err, ErrInvalidFormat) || bbox.Bounds != expected {
+		t.Errorf("Expected ErrInvalidFormat leaving the flag unchanged, but got %v and %v", err, bbox.Bounds)
+	}
+}
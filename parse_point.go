@@ -0,0 +1,134 @@
+package geo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseOptions controls how ParsePointWith interprets its input.
+type ParseOptions struct {
+	// LngFirst reads pairs without hemisphere letters as longitude then latitude,
+	// as used by GeoJSON and WKT.  By default pairs are read latitude first.
+	LngFirst bool
+	// Strict only accepts two signed decimal numbers separated by a comma,
+	// optionally wrapped in parentheses, and never swaps the pair on a guess.
+	Strict bool
+}
+
+// ParsePoint parses a Point from user entered text using the default, tolerant ParseOptions.
+// It accepts "lat,lng", "lat lng", parenthesized or bracketed pairs, WKT such as
+// "POINT(lng lat)", hemisphere letters and anything understood by ParseDMS.
+func ParsePoint(s string) (Point, error) {
+	return ParsePointWith(s, ParseOptions{})
+}
+
+// ParsePointWith parses a Point from text according to the passed in options.
+// In tolerant mode a pair whose first value can only be a longitude is swapped.
+func ParsePointWith(s string, opts ParseOptions) (Point, error) {
+	p, err := parsePoint(s, opts)
+	if err != nil {
+		debugf("geo: unable to parse point %q: %v", s, err)
+	}
+	return p, err
+}
+
+func parsePoint(s string, opts ParseOptions) (Point, error) {
+	t := strings.TrimSpace(s)
+	lngFirst := opts.LngFirst
+
+	if !opts.Strict {
+		if len(t) >= 5 && strings.EqualFold(t[:5], "POINT") {
+			t = strings.TrimSpace(t[5:])
+			lngFirst = true
+		}
+	}
+	t = trimBrackets(t, opts.Strict)
+
+	if opts.Strict {
+		return parseStrictPoint(s, t, lngFirst)
+	}
+
+	first, second, ok := splitCoordinatePair(t)
+	if !ok {
+		return Point{}, fmt.Errorf("%w: unable to split %q into two coordinates", ErrInvalidFormat, s)
+	}
+
+	a, axisA, err := ParseDMSCoordinate(first)
+	if err != nil {
+		return Point{}, err
+	}
+
+	b, axisB, err := ParseDMSCoordinate(second)
+	if err != nil {
+		return Point{}, err
+	}
+
+	switch {
+	case axisA == axisB && axisA != 0:
+		return Point{}, fmt.Errorf("%w: both coordinates of %q are on the same axis", ErrInvalidFormat, s)
+	case axisA == 'E' || axisB == 'N':
+		a, b = b, a
+	case axisA == 0 && axisB == 0 && lngFirst:
+		a, b = b, a
+	case axisA == 0 && axisB == 0 && outOfLatRange(a) && !outOfLatRange(b):
+		a, b = b, a
+	}
+
+	return newParsedPoint(s, a, b)
+}
+
+// parseStrictPoint accepts exactly two decimal numbers separated by a comma.
+func parseStrictPoint(s string, t string, lngFirst bool) (Point, error) {
+	parts := strings.Split(t, ",")
+	if len(parts) != 2 {
+		return Point{}, fmt.Errorf("%w: expected two comma separated coordinates in %q", ErrInvalidFormat, s)
+	}
+
+	a, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil {
+		return Point{}, fmt.Errorf("%w: invalid coordinate in %q: %v", ErrInvalidFormat, s, err)
+	}
+
+	b, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err != nil {
+		return Point{}, fmt.Errorf("%w: invalid coordinate in %q: %v", ErrInvalidFormat, s, err)
+	}
+
+	if lngFirst {
+		a, b = b, a
+	}
+
+	return newParsedPoint(s, a, b)
+}
+
+// newParsedPoint range checks a parsed latitude and longitude.
+func newParsedPoint(s string, lat float64, lng float64) (Point, error) {
+	p, err := NewPointValidated(lat, lng)
+	if err != nil {
+		return Point{}, fmt.Errorf("%w in %q", err, s)
+	}
+
+	return p, nil
+}
+
+func outOfLatRange(lat float64) bool {
+	return lat < -90 || lat > 90
+}
+
+// trimBrackets removes one pair of enclosing parentheses, or in tolerant mode
+// also square or curly brackets.
+func trimBrackets(s string, strict bool) string {
+	pairs := []string{"()"}
+	if !strict {
+		pairs = append(pairs, "[]", "{}")
+	}
+
+	for _, pair := range pairs {
+		if len(s) >= 2 && s[0] == pair[0] && s[len(s)-1] == pair[1] {
+			return strings.TrimSpace(s[1 : len(s)-1])
+		}
+	}
+
+	return s
+}
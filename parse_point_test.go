@@ -0,0 +1,61 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+// Ensures that free-form user entered coordinates are parsed.
+func TestParsePoint(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected Point
+	}{
+		{"40.7486,-73.9864", NewPoint(40.7486, -73.9864)},
+		{" 40.7486 -73.9864 ", NewPoint(40.7486, -73.9864)},
+		{"(40.7486, -73.9864)", NewPoint(40.7486, -73.9864)},
+		{"[40.7486, -73.9864]", NewPoint(40.7486, -73.9864)},
+		{"POINT(-73.9864 40.7486)", NewPoint(40.7486, -73.9864)},
+		{"40.7486N 73.9864W", NewPoint(40.7486, -73.9864)},
+		{"73.9864W, 40.7486N", NewPoint(40.7486, -73.9864)},
+		{`40°44'55"N 73°59'11"W`, NewPoint(40.748611, -73.986389)},
+		// The first value can only be a longitude, so the pair is swapped.
+		{"151.2153, -33.8568", NewPoint(-33.8568, 151.2153)},
+	}
+
+	for _, tt := range tests {
+		p, err := ParsePoint(tt.s)
+		if err != nil {
+			t.Errorf("Should not encounter an error when parsing %q, but got %v", tt.s, err)
+			continue
+		}
+		if math.Abs(p.lat-tt.expected.lat) > 1e-6 || math.Abs(p.lng-tt.expected.lng) > 1e-6 {
+			t.Errorf("Expected %q to parse as %v, but got %v", tt.s, tt.expected, p)
+		}
+	}
+
+	for _, invalid := range []string{"", "40.7486", "(40.7486)", "120, 100", "forty, two", "40N, 73N"} {
+		if _, err := ParsePoint(invalid); err == nil {
+			t.Errorf("Expected an error when parsing %q", invalid)
+		}
+	}
+}
+
+// Ensures that the options control the pair order and strictness.
+func TestParsePointWith(t *testing.T) {
+	p, err := ParsePointWith("-73.9864 40.7486", ParseOptions{LngFirst: true})
+	if err != nil || p != NewPoint(40.7486, -73.9864) {
+		t.Errorf("Expected a longitude first pair to parse as 40.7486, -73.9864, but got %v, %v", p, err)
+	}
+
+	p, err = ParsePointWith("(40.7486, -73.9864)", ParseOptions{Strict: true})
+	if err != nil || p != NewPoint(40.7486, -73.9864) {
+		t.Errorf("Expected a strict pair to parse as 40.7486, -73.9864, but got %v, %v", p, err)
+	}
+
+	for _, invalid := range []string{"40.7486 -73.9864", "40.7486N, 73.9864W", "POINT(-73.9864 40.7486)", "151.2153, -33.8568"} {
+		if _, err := ParsePointWith(invalid, ParseOptions{Strict: true}); err == nil {
+			t.Errorf("Expected an error when strictly parsing %q", invalid)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package geo
+
+import "math"
+
+// PathIntersection returns where the great circle paths leaving p1 at bearing1 and p2 at bearing2,
+// in degrees clockwise from true north, cross ahead of both, as when working out where two aircraft
+// tracks conflict or triangulating a position from two bearings.  It returns false when the paths
+// only cross behind one of them, or follow the same great circle.
+func PathIntersection(p1 Point, bearing1 float64, p2 Point, bearing2 float64) (Point, bool) {
+	v1, v2 := unitVector(p1), unitVector(p2)
+	d1, d2 := pathDirection(v1, bearing1), pathDirection(v2, bearing2)
+	i, ok := normalize3(cross3(cross3(v1, d1), cross3(v2, d2)))
+	if !ok {
+		return Point{}, false
+	}
+
+	// The great circles cross at i and its antipode, and the one ahead of p1 is ahead of p2 or neither is.
+	if dot3(d1, i) < 0 {
+		i = [3]float64{-i[0], -i[1], -i[2]}
+	}
+	if dot3(d1, i) <= 0 || dot3(d2, i) <= 0 {
+		return Point{}, false
+	}
+	return vectorPoint(i), true
+}
+
+// ArcIntersection returns where the shorter great circle arcs from a1 to a2 and from b1 to b2 cross.
+// It returns false when they do not, or when they follow the same great circle.
+func ArcIntersection(a1 Point, a2 Point, b1 Point, b2 Point) (Point, bool) {
+	va1, va2, vb1, vb2 := unitVector(a1), unitVector(a2), unitVector(b1), unitVector(b2)
+	na, nb := cross3(va1, va2), cross3(vb1, vb2)
+	i, ok := normalize3(cross3(na, nb))
+	if !ok {
+		return Point{}, false
+	}
+
+	within := func(v [3]float64, u1 [3]float64, u2 [3]float64, n [3]float64) bool {
+		return dot3(cross3(u1, v), n) >= 0 && dot3(cross3(v, u2), n) >= 0
+	}
+	for _, c := range [2][3]float64{i, {-i[0], -i[1], -i[2]}} {
+		if within(c, va1, va2, na) && within(c, vb1, vb2, nb) {
+			return vectorPoint(c), true
+		}
+	}
+	return Point{}, false
+}
+
+// pathDirection returns the unit vector tangent to the sphere at v pointing along bearing degrees
+// clockwise from true north.
+func pathDirection(v [3]float64, bearing float64) [3]float64 {
+	east, ok := normalize3(cross3([3]float64{0, 0, 1}, v))
+	if !ok {
+		// At a pole, bearings are measured as if from the prime meridian.
+		east = [3]float64{0, 1, 0}
+	}
+	north := cross3(v, east)
+	sin, cos := math.Sincos(bearing * math.Pi / 180)
+	return [3]float64{north[0]*cos + east[0]*sin, north[1]*cos + east[1]*sin, north[2]*cos + east[2]*sin}
+}
+
+// normalize3 returns v scaled to unit length, or false if v is too short to have a direction.
+func normalize3(v [3]float64) ([3]float64, bool) {
+	l := math.Sqrt(dot3(v, v))
+	if l < 1e-12 {
+		return v, false
+	}
+	return [3]float64{v[0] / l, v[1] / l, v[2] / l}, true
+}
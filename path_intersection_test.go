@@ -0,0 +1,50 @@
+package geo
+
+import (
+	"testing"
+)
+
+// Ensures that paths given by bearings cross ahead of both or not at all.
+func TestPathIntersection(t *testing.T) {
+	tests := []struct {
+		p1       Point
+		bearing1 float64
+		p2       Point
+		bearing2 float64
+		expected Point
+		ok       bool
+	}{
+		{NewPoint(0, 0), 90, NewPoint(-1, 1), 0, NewPoint(0, 1), true},
+		// Veness' worked example of paths leaving Stansted and Charles de Gaulle.
+		{NewPoint(51.8853, 0.2545), 108.547, NewPoint(49.0034, 2.5735), 32.435, NewPoint(50.9078, 4.5084), true},
+		{NewPoint(0, 0), 270, NewPoint(-1, 1), 0, Point{}, false},
+		{NewPoint(0, 0), 90, NewPoint(0, 10), 90, Point{}, false},
+	}
+	for _, tt := range tests {
+		p, ok := PathIntersection(tt.p1, tt.bearing1, tt.p2, tt.bearing2)
+		if ok != tt.ok || ok && p.GreatCircleDistance(tt.expected) > 10*Meter {
+			t.Errorf("Expected the paths from %v and %v to cross at %v, %v, but got %v, %v", tt.p1, tt.p2, tt.expected, tt.ok, p, ok)
+		}
+	}
+}
+
+// Ensures that arcs cross only within both of them.
+func TestArcIntersection(t *testing.T) {
+	tests := []struct {
+		a1, a2, b1, b2 Point
+		expected       Point
+		ok             bool
+	}{
+		{NewPoint(0, -1), NewPoint(0, 1), NewPoint(-1, 0), NewPoint(1, 0), NewPoint(0, 0), true},
+		{NewPoint(0, 179), NewPoint(0, -179), NewPoint(-1, 180), NewPoint(1, 180), NewPoint(0, 180), true},
+		{NewPoint(0, -1), NewPoint(0, 1), NewPoint(1, 0), NewPoint(2, 0), Point{}, false},
+		{NewPoint(0, -1), NewPoint(0, 1), NewPoint(0, 2), NewPoint(0, 3), Point{}, false},
+		{NewPoint(0, -1), NewPoint(0, 1), NewPoint(0, 1), NewPoint(1, 1), NewPoint(0, 1), true},
+	}
+	for _, tt := range tests {
+		p, ok := ArcIntersection(tt.a1, tt.a2, tt.b1, tt.b2)
+		if ok != tt.ok || ok && p.GreatCircleDistance(tt.expected) > 0.01*Meter {
+			t.Errorf("Expected %v-%v and %v-%v to cross at %v, %v, but got %v, %v", tt.a1, tt.a2, tt.b1, tt.b2, tt.expected, tt.ok, p, ok)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package geo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// PeliasGeocodeURL is the base URL of the hosted Pelias service run by Geocode Earth.
+const PeliasGeocodeURL = "https://api.geocode.earth/v1"
+
+// PeliasGeocoderOptions configures a PeliasGeocoder.
+type PeliasGeocoderOptions struct {
+	// Language is the language results are returned in, such as "en".  Defaults to the server's choice.
+	Language string
+	// BaseURL replaces PeliasGeocodeURL, usually with the "/v1" URL of a self-hosted Pelias server.
+	// Requests are sent to BaseURL + "/search" and BaseURL + "/reverse".
+	BaseURL string
+	// Client sends the requests.  Defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+// A PeliasGeocoder is a Geocoder backed by a Pelias server, either hosted or self-hosted.
+// It is safe for concurrent use.
+type PeliasGeocoder struct {
+	apiKey string
+	opts   PeliasGeocoderOptions
+}
+
+var _ Geocoder = (*PeliasGeocoder)(nil)
+
+// NewPeliasGeocoder returns a new PeliasGeocoder that authenticates with the passed in API key.
+// Self-hosted servers usually need no key, which is then left empty.
+func NewPeliasGeocoder(apiKey string, opts PeliasGeocoderOptions) *PeliasGeocoder {
+	if opts.BaseURL == "" {
+		opts.BaseURL = PeliasGeocodeURL
+	}
+	return &PeliasGeocoder{apiKey: apiKey, opts: opts}
+}
+
+type peliasGeocodeResponse struct {
+	Features []struct {
+		Geometry struct {
+			Coordinates [2]float64 `json:"coordinates"`
+		} `json:"geometry"`
+		Properties struct {
+			Label       string `json:"label"`
+			HouseNumber string `json:"housenumber"`
+			Street      string `json:"street"`
+			Locality    string `json:"locality"`
+			Region      string `json:"region"`
+			PostalCode  string `json:"postalcode"`
+			Country     string `json:"country"`
+			CountryCode string `json:"country_code"`
+		} `json:"properties"`
+	} `json:"features"`
+}
+
+// Geocode returns the Point of Pelias's best match for address.
+func (g *PeliasGeocoder) Geocode(ctx context.Context, address string) (Point, error) {
+	resp, err := g.request(ctx, "/search", url.Values{"text": {address}})
+	if err != nil {
+		return Point{}, err
+	}
+
+	coordinates := resp.Features[0].Geometry.Coordinates
+	return NewPoint(coordinates[1], coordinates[0]), nil
+}
+
+// ReverseGeocode returns the Address of Pelias's best match for Point p.
+func (g *PeliasGeocoder) ReverseGeocode(ctx context.Context, p Point) (Address, error) {
+	resp, err := g.request(ctx, "/reverse", url.Values{
+		"point.lat": {strconv.FormatFloat(p.lat, 'f', -1, 64)},
+		"point.lon": {strconv.FormatFloat(p.lng, 'f', -1, 64)},
+	})
+	if err != nil {
+		return Address{}, err
+	}
+
+	props := resp.Features[0].Properties
+	return Address{
+		Formatted:   props.Label,
+		HouseNumber: props.HouseNumber,
+		Street:      props.Street,
+		Locality:    props.Locality,
+		Region:      props.Region,
+		PostalCode:  props.PostalCode,
+		Country:     props.Country,
+		CountryCode: props.CountryCode,
+	}, nil
+}
+
+// request queries path under the base URL and only returns its reply without error
+// if it holds at least one feature.
+func (g *PeliasGeocoder) request(ctx context.Context, path string, query url.Values) (*peliasGeocodeResponse, error) {
+	u, err := url.Parse(strings.TrimSuffix(g.opts.BaseURL, "/") + path)
+	if err != nil {
+		return nil, fmt.Errorf("pelias geocoder: %w", err)
+	}
+	if g.apiKey != "" {
+		query.Set("api_key", g.apiKey)
+	}
+	query.Set("size", "1")
+	if g.opts.Language != "" {
+		query.Set("lang", g.opts.Language)
+	}
+	u.RawQuery = query.Encode()
+
+	var resp peliasGeocodeResponse
+	if err := getJSON(ctx, g.opts.Client, "pelias geocoder", u, &resp); err != nil {
+		return nil, err
+	}
+
+	if len(resp.Features) == 0 {
+		return nil, fmt.Errorf("%w: pelias geocoder", ErrNoResults)
+	}
+
+	return &resp, nil
+}
@@ -0,0 +1,65 @@
+package geo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Ensures that the Pelias geocoder queries the search and reverse endpoints and decodes the first feature.
+func TestPeliasGeocoder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Has("api_key") {
+			t.Errorf("Expected no API key for a self-hosted server, but got %q", q.Get("api_key"))
+		}
+
+		switch {
+		case r.URL.Path == "/v1/search" && q.Get("text") == "1 Macquarie St":
+			w.Write([]byte(`{"features": [{"geometry": {"coordinates": [151.2093, -33.8688]}}]}`))
+		case r.URL.Path == "/v1/reverse" && q.Get("point.lat") == "-33.8688" && q.Get("point.lon") == "151.2093":
+			w.Write([]byte(`{"features": [{"properties": {
+				"label": "1 Macquarie Street, Sydney, NSW, Australia",
+				"housenumber": "1",
+				"street": "Macquarie Street",
+				"locality": "Sydney",
+				"region": "New South Wales",
+				"postalcode": "2000",
+				"country": "Australia",
+				"country_code": "AU"
+			}}]}`))
+		default:
+			w.Write([]byte(`{"features": []}`))
+		}
+	}))
+	defer server.Close()
+
+	g := NewPeliasGeocoder("", PeliasGeocoderOptions{BaseURL: server.URL + "/v1/", Client: server.Client()})
+	ctx := context.Background()
+
+	p, err := g.Geocode(ctx, "1 Macquarie St")
+	if err != nil || p != NewPoint(-33.8688, 151.2093) {
+		t.Errorf("Expected -33.8688,151.2093, but got %v, %v", p, err)
+	}
+
+	address, err := g.ReverseGeocode(ctx, NewPoint(-33.8688, 151.2093))
+	expected := Address{
+		Formatted:   "1 Macquarie Street, Sydney, NSW, Australia",
+		HouseNumber: "1",
+		Street:      "Macquarie Street",
+		Locality:    "Sydney",
+		Region:      "New South Wales",
+		PostalCode:  "2000",
+		Country:     "Australia",
+		CountryCode: "AU",
+	}
+	if err != nil || address != expected {
+		t.Errorf("Expected %+v, but got %+v, %v", expected, address, err)
+	}
+
+	if _, err := g.Geocode(ctx, "nowhere"); !errors.Is(err, ErrNoResults) {
+		t.Errorf("Expected ErrNoResults, but got %v", err)
+	}
+}
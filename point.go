@@ -5,7 +5,8 @@ import (
 	"encoding/binary"
 	"encoding/json"
 	"fmt"
-	"log"
+	"math"
+	"strconv"
 )
 
 // Represents a Physical Point in geographic notation [lat, lng].
@@ -24,6 +25,25 @@ func NewPoint(lat float64, lng float64) Point {
 	return Point{lat: lat, lng: lng}
 }
 
+// NewPointValidated returns a new Point like NewPoint, but returns an error when the latitude
+// is outside [-90, 90] or the longitude is outside [-180, 180].  This catches swapped pairs only
+// when the longitude is outside [-90, 90], such as (122.30, 47.45) for (47.45, 122.30).
+func NewPointValidated(lat float64, lng float64) (Point, error) {
+	if !isFinite(lat) || !isFinite(lng) {
+		return Point{}, fmt.Errorf("%w: %v,%v", ErrNonFiniteCoordinate, lat, lng)
+	}
+
+	if lat < -90 || lat > 90 {
+		return Point{}, fmt.Errorf("%w: %v is not within [-90, 90]", ErrInvalidLatitude, lat)
+	}
+
+	if lng < -180 || lng > 180 {
+		return Point{}, fmt.Errorf("%w: %v is not within [-180, 180]", ErrInvalidLongitude, lng)
+	}
+
+	return NewPoint(lat, lng), nil
+}
+
 // Lat returns Point p's latitude.
 func (p Point) Lat() float64 {
 	return p.lat
@@ -34,20 +54,99 @@ func (p Point) Lng() float64 {
 	return p.lng
 }
 
+// WithLat returns a copy of Point p with its latitude replaced by lat.
+func (p Point) WithLat(lat float64) Point {
+	return NewPoint(lat, p.lng)
+}
+
+// WithLng returns a copy of Point p with its longitude replaced by lng.
+func (p Point) WithLng(lng float64) Point {
+	return NewPoint(p.lat, lng)
+}
+
+// Offset returns a copy of Point p moved by dLat degrees of latitude and dLng degrees of longitude.
+// The result is not normalized; call Normalized to wrap it back into range.
+func (p Point) Offset(dLat float64, dLng float64) Point {
+	return NewPoint(p.lat+dLat, p.lng+dLng)
+}
+
+// IsValidCoordinate reports whether lat and lng are finite numbers within [-90, 90] and [-180, 180].
+// NaN and infinite coordinates are never valid.
+func IsValidCoordinate(lat float64, lng float64) bool {
+	return isFinite(lat) && isFinite(lng) && lat >= -90 && lat <= 90 && lng >= -180 && lng <= 180
+}
+
+func isFinite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
+// Normalized returns a copy of Point p with its latitude clamped by ClampLat
+// and its longitude wrapped by NormalizeLng.
+func (p Point) Normalized() Point {
+	return NewPoint(ClampLat(p.lat), NormalizeLng(p.lng))
+}
+
+// NormalizeLng wraps a longitude into [-180, 180], so that 370 becomes 10 and -190 becomes 170.
+// Longitudes already in range, including ±180, are returned unchanged.
+func NormalizeLng(lng float64) float64 {
+	if lng >= -180 && lng <= 180 {
+		return lng
+	}
+
+	lng = math.Mod(lng+180, 360)
+	if lng < 0 {
+		lng += 360
+	}
+	return lng - 180
+}
+
+// ClampLat limits a latitude to [-90, 90].
+func ClampLat(lat float64) float64 {
+	return math.Max(-90, math.Min(90, lat))
+}
+
+// String renders Point p as "lat,lng", which ParsePoint reads back.
+func (p Point) String() string {
+	return strconv.FormatFloat(p.lat, 'f', -1, 64) + "," + strconv.FormatFloat(p.lng, 'f', -1, 64)
+}
+
+// Bounds returns a BoundingBox whose corners are both Point p.
+func (p Point) Bounds() BoundingBox {
+	return NewBoundingBox(p, p)
+}
+
+// GreatCircleDistance calculates the Haversine distance between Point p and the passed in Point p2.
+// Original Implementation from: http://www.movable-type.co.uk/scripts/latlong.html
+func (p Point) GreatCircleDistance(p2 Point) Distance {
+	dLat := (p2.lat - p.lat) * (math.Pi / 180.0)
+	dLng := (p2.lng - p.lng) * (math.Pi / 180.0)
+
+	lat1 := p.lat * (math.Pi / 180.0)
+	lat2 := p2.lat * (math.Pi / 180.0)
+
+	a1 := math.Sin(dLat/2) * math.Sin(dLat/2)
+	a2 := math.Sin(dLng/2) * math.Sin(dLng/2) * math.Cos(lat1) * math.Cos(lat2)
+
+	a := a1 + a2
+
+	c := 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+
+	return Distance(EARTH_RADIUS*c) * Kilometer
+}
+
 // MarshalBinary renders the current point to a byte slice.
 // Implements the encoding.BinaryMarshaler Interface.
 func (p *Point) MarshalBinary() ([]byte, error) {
-	var buf bytes.Buffer
-	err := binary.Write(&buf, binary.LittleEndian, p.lat)
-	if err != nil {
-		return nil, fmt.Errorf("unable to encode lat %v: %v", p.lat, err)
-	}
-	err = binary.Write(&buf, binary.LittleEndian, p.lng)
-	if err != nil {
-		return nil, fmt.Errorf("unable to encode lng %v: %v", p.lng, err)
-	}
+	return p.AppendBinary(make([]byte, 0, 16))
+}
 
-	return buf.Bytes(), nil
+// AppendBinary appends the binary form of the current point, as written by MarshalBinary, to dst
+// and returns the extended slice.  It never allocates when dst has room for 16 bytes.
+// Implements the encoding.BinaryAppender Interface.
+func (p Point) AppendBinary(dst []byte) ([]byte, error) {
+	dst = binary.LittleEndian.AppendUint64(dst, math.Float64bits(p.lat))
+	dst = binary.LittleEndian.AppendUint64(dst, math.Float64bits(p.lng))
+	return dst, nil
 }
 
 func (p *Point) UnmarshalBinary(data []byte) error {
@@ -56,13 +155,17 @@ func (p *Point) UnmarshalBinary(data []byte) error {
 	var lat float64
 	err := binary.Read(buf, binary.LittleEndian, &lat)
 	if err != nil {
-		return fmt.Errorf("binary.Read failed: %v", err)
+		return fmt.Errorf("binary.Read failed: %w", err)
 	}
 
 	var lng float64
 	err = binary.Read(buf, binary.LittleEndian, &lng)
 	if err != nil {
-		return fmt.Errorf("binary.Read failed: %v", err)
+		return fmt.Errorf("binary.Read failed: %w", err)
+	}
+
+	if !isFinite(lat) || !isFinite(lng) {
+		return fmt.Errorf("%w: %v,%v", ErrNonFiniteCoordinate, lat, lng)
 	}
 
 	p.lat = lat
@@ -73,23 +176,35 @@ func (p *Point) UnmarshalBinary(data []byte) error {
 // MarshalJSON renders the current Point to valid JSON.
 // Implements the json.Marshaller Interface.
 func (p Point) MarshalJSON() ([]byte, error) {
-	res := fmt.Sprintf(`{"lat":%v, "lng":%v}`, p.lat, p.lng)
-	return []byte(res), nil
+	return p.AppendJSON(make([]byte, 0, 48)), nil
+}
+
+// AppendJSON appends the JSON form of the current Point, as written by MarshalJSON, to dst
+// and returns the extended slice.  It never allocates when dst has enough spare capacity.
+func (p Point) AppendJSON(dst []byte) []byte {
+	dst = append(dst, `{"lat":`...)
+	dst = strconv.AppendFloat(dst, p.lat, 'g', -1, 64)
+	dst = append(dst, `, "lng":`...)
+	dst = strconv.AppendFloat(dst, p.lng, 'g', -1, 64)
+	return append(dst, '}')
 }
 
 // UnmarshalJSON decodes the current Point from a JSON body.
 // Throws an error if the body of the point cannot be interpreted by the JSON body
 func (p *Point) UnmarshalJSON(data []byte) error {
-	// TODO throw an error if there is an issue parsing the body.
 	dec := json.NewDecoder(bytes.NewReader(data))
 	var values map[string]float64
 	err := dec.Decode(&values)
 
 	if err != nil {
-		log.Print(err)
+		debugf("geo: unable to decode point %q: %v", data, err)
 		return err
 	}
 
+	if !isFinite(values["lat"]) || !isFinite(values["lng"]) {
+		return fmt.Errorf("%w: %v,%v", ErrNonFiniteCoordinate, values["lat"], values["lng"])
+	}
+
 	*p = NewPoint(values["lat"], values["lng"])
 
 	return nil
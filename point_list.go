@@ -0,0 +1,131 @@
+package geo
+
+import (
+	"fmt"
+	"iter"
+	"math"
+)
+
+// A PointList stores a large number of points as separate latitude and longitude slices,
+// which uses less memory and scans faster than a []Point.  In single precision mode,
+// created with NewPointList32, coordinates are stored as float32: half the memory
+// at a resolution of around a meter.
+type PointList struct {
+	lats, lngs     []float64
+	lats32, lngs32 []float32
+	single         bool
+}
+
+// NewPointList returns an empty PointList with room for capacity points.
+func NewPointList(capacity int) *PointList {
+	return &PointList{
+		lats: make([]float64, 0, capacity),
+		lngs: make([]float64, 0, capacity),
+	}
+}
+
+// NewPointList32 returns an empty single precision PointList with room for capacity points.
+func NewPointList32(capacity int) *PointList {
+	return &PointList{
+		lats32: make([]float32, 0, capacity),
+		lngs32: make([]float32, 0, capacity),
+		single: true,
+	}
+}
+
+// Len returns the number of points in the list.
+func (l *PointList) Len() int {
+	if l.single {
+		return len(l.lats32)
+	}
+	return len(l.lats)
+}
+
+// At returns the i'th point of the list.
+func (l *PointList) At(i int) Point {
+	if l.single {
+		return NewPoint(float64(l.lats32[i]), float64(l.lngs32[i]))
+	}
+	return NewPoint(l.lats[i], l.lngs[i])
+}
+
+// Append adds the passed in points to the end of the list.
+func (l *PointList) Append(points ...Point) {
+	for _, p := range points {
+		if l.single {
+			l.lats32 = append(l.lats32, float32(p.lat))
+			l.lngs32 = append(l.lngs32, float32(p.lng))
+		} else {
+			l.lats = append(l.lats, p.lat)
+			l.lngs = append(l.lngs, p.lng)
+		}
+	}
+}
+
+// AppendLatLngs adds the points whose coordinates are given by the passed in parallel slices.
+func (l *PointList) AppendLatLngs(lats []float64, lngs []float64) error {
+	if len(lats) != len(lngs) {
+		return fmt.Errorf("got %d latitudes but %d longitudes", len(lats), len(lngs))
+	}
+
+	if !l.single {
+		l.lats = append(l.lats, lats...)
+		l.lngs = append(l.lngs, lngs...)
+		return nil
+	}
+
+	for i := range lats {
+		l.lats32 = append(l.lats32, float32(lats[i]))
+		l.lngs32 = append(l.lngs32, float32(lngs[i]))
+	}
+	return nil
+}
+
+// All returns an iterator over the indices and points of the list.
+func (l *PointList) All() iter.Seq2[int, Point] {
+	return func(yield func(int, Point) bool) {
+		for i := 0; i < l.Len(); i++ {
+			if !yield(i, l.At(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Bounds returns the smallest BoundingBox containing every point of the list.
+func (l *PointList) Bounds() BoundingBox {
+	if l.Len() == 0 {
+		return BoundingBox{}
+	}
+
+	sw, ne := l.At(0), l.At(0)
+	for i := 1; i < l.Len(); i++ {
+		p := l.At(i)
+		sw.lat = math.Min(sw.lat, p.lat)
+		sw.lng = math.Min(sw.lng, p.lng)
+		ne.lat = math.Max(ne.lat, p.lat)
+		ne.lng = math.Max(ne.lng, p.lng)
+	}
+
+	return NewBoundingBox(sw, ne)
+}
+
+// Distances appends the Haversine distance from p to every point of the list to dst and returns it.
+// Passing a dst with enough capacity avoids allocating.
+func (l *PointList) Distances(p Point, dst []Distance) []Distance {
+	for i := 0; i < l.Len(); i++ {
+		dst = append(dst, p.GreatCircleDistance(l.At(i)))
+	}
+	return dst
+}
+
+// ContainedIn returns the indices of the points of the list that lie within Polygon poly.
+func (l *PointList) ContainedIn(poly Polygon) []int {
+	var indices []int
+	for i := 0; i < l.Len(); i++ {
+		if p := l.At(i); poly.Contains(p) {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
@@ -0,0 +1,50 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+// Ensures that double and single precision point lists store, bound and filter points.
+func TestPointList(t *testing.T) {
+	square := NewPolygon([]Point{NewPoint(0, 0), NewPoint(0, 10), NewPoint(10, 10), NewPoint(10, 0)})
+
+	for _, l := range []*PointList{NewPointList(4), NewPointList32(4)} {
+		l.Append(NewPoint(5, 5), NewPoint(-20, 30))
+		if err := l.AppendLatLngs([]float64{1.5, 40}, []float64{2.5, -100}); err != nil {
+			t.Fatalf("Should not encounter an error when appending, but got %v", err)
+		}
+
+		if l.Len() != 4 || l.At(2) != NewPoint(1.5, 2.5) {
+			t.Errorf("Expected 4 points with 1.5,2.5 third, but got %d and %v", l.Len(), l.At(2))
+		}
+
+		if b := l.Bounds(); b != NewBoundingBox(NewPoint(-20, -100), NewPoint(40, 30)) {
+			t.Errorf("Expected bounds -20,-100 40,30, but got %v", b)
+		}
+
+		if in := l.ContainedIn(square); len(in) != 2 || in[0] != 0 || in[1] != 2 {
+			t.Errorf("Expected points 0 and 2 inside the square, but got %v", in)
+		}
+
+		d := l.Distances(NewPoint(5, 5), nil)
+		if len(d) != 4 || d[0] != 0 || math.Abs(float64(d[1]-NewPoint(5, 5).GreatCircleDistance(NewPoint(-20, 30)))) > 1 {
+			t.Errorf("Unexpected distances %v", d)
+		}
+
+		n := 0
+		for i, p := range l.All() {
+			if p != l.At(i) {
+				t.Errorf("Expected point %d to be %v, but got %v", i, l.At(i), p)
+			}
+			n++
+		}
+		if n != 4 {
+			t.Errorf("Expected to iterate over 4 points, but got %d", n)
+		}
+	}
+
+	if err := NewPointList(0).AppendLatLngs([]float64{1}, nil); err == nil {
+		t.Error("Expected an error when appending mismatched slices")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/binary"
 	"encoding/json"
 	"log"
+	"math"
 	"testing"
 )
 
@@ -92,6 +93,30 @@ func TestMarshalBinary(t *testing.T) {
 	}
 }
 
+// Ensures that the append variants match the marshalers and do not allocate.
+func TestAppendJSONAndBinary(t *testing.T) {
+	p := NewPoint(40.7486, -73.9864)
+	buf := make([]byte, 0, 64)
+
+	marshaled, _ := p.MarshalJSON()
+	if appended := p.AppendJSON(buf[:0]); !bytes.Equal(appended, marshaled) {
+		t.Errorf("Expected AppendJSON to produce %s, but got %s", marshaled, appended)
+	}
+
+	marshaled, _ = p.MarshalBinary()
+	if appended, err := p.AppendBinary([]byte("prefix")); err != nil || !bytes.Equal(appended, append([]byte("prefix"), marshaled...)) {
+		t.Errorf("Expected AppendBinary to append %v, but got %v, %v", marshaled, appended, err)
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = p.AppendJSON(buf[:0])
+		buf, _ = p.AppendBinary(buf[:0])
+	})
+	if allocs != 0 {
+		t.Errorf("Expected no allocations, but got %v", allocs)
+	}
+}
+
 // Ensure that a point can be unmarshalled from a slice of binaries
 func TestUnmarshalBinary(t *testing.T) {
 	lat, long := 40.7486, -73.9864
@@ -131,3 +156,122 @@ func assertPointsEqual(p1, p2 Point, precision int) bool {
 	roundedLat2, roundedLng2 := int(p2.lat*float64(precision))/precision, int(p2.lng*float64(precision))/precision
 	return roundedLat1 == roundedLat2 && roundedLng1 == roundedLng2
 }
+
+// Ensures that the Haversine distance between two points is calculated correctly.
+func TestGreatCircleDistance(t *testing.T) {
+	// Test that SEA and SFO are ~ 1091km apart, accurate to 100 meters.
+	sea := NewPoint(47.4489, -122.3094)
+	sfo := NewPoint(37.6160933, -122.3924223)
+	sfoToSea := 1093.379199082169 * Kilometer
+
+	dist := sea.GreatCircleDistance(sfo)
+
+	if math.Abs(float64(dist-sfoToSea)) > 100 {
+		t.Errorf("Expected the distance between SEA and SFO to be %v, but got %v instead", sfoToSea, dist)
+	}
+
+	if d := sea.GreatCircleDistance(sea); d != 0 {
+		t.Errorf("Expected the distance from a point to itself to be 0, but got %v instead", d)
+	}
+}
+
+// Ensures that points render as a lat,lng pair that parses back to the same point.
+func TestPointString(t *testing.T) {
+	p := NewPoint(40.7486, -73.9864)
+	if s := p.String(); s != "40.7486,-73.9864" {
+		t.Errorf("Expected 40.7486,-73.9864, but got %s instead", s)
+	}
+
+	if back, err := ParsePoint(p.String()); err != nil || back != p {
+		t.Errorf("Expected %v to round trip through its string form, but got %v, %v", p, back, err)
+	}
+}
+
+// Ensures that out of range coordinates are rejected by NewPointValidated.
+func TestNewPointValidated(t *testing.T) {
+	if p, err := NewPointValidated(47.45, -122.30); err != nil || p != NewPoint(47.45, -122.30) {
+		t.Errorf("Expected 47.45,-122.30 to be valid, but got %v, %v", p, err)
+	}
+
+	for _, invalid := range []Point{NewPoint(-122.30, 47.45), NewPoint(90.1, 0), NewPoint(0, 180.5), NewPoint(0, -181)} {
+		if _, err := NewPointValidated(invalid.lat, invalid.lng); err == nil {
+			t.Errorf("Expected an error when validating %v", invalid)
+		}
+	}
+}
+
+// Ensures that longitudes wrap and latitudes clamp into range.
+func TestNormalized(t *testing.T) {
+	lngs := map[float64]float64{370: 10, -190: 170, 180: 180, -180: -180, 540: -180, 720: 0, -725: -5, 45.5: 45.5}
+	for lng, expected := range lngs {
+		if n := NormalizeLng(lng); math.Abs(n-expected) > 1e-9 {
+			t.Errorf("Expected %v to normalize to %v, but got %v", lng, expected, n)
+		}
+	}
+
+	if p := NewPoint(95, -190).Normalized(); p != NewPoint(90, 170) {
+		t.Errorf("Expected 95,-190 to normalize to 90,170, but got %v", p)
+	}
+
+	if lat := ClampLat(-91); lat != -90 {
+		t.Errorf("Expected -91 to clamp to -90, but got %v", lat)
+	}
+}
+
+// Ensures that NaN and infinite coordinates are rejected.
+func TestNonFiniteCoordinates(t *testing.T) {
+	nan, inf := math.NaN(), math.Inf(1)
+
+	for _, c := range [][2]float64{{nan, 0}, {0, nan}, {inf, 0}, {0, -inf}, {91, 0}} {
+		if IsValidCoordinate(c[0], c[1]) {
+			t.Errorf("Expected %v to be an invalid coordinate", c)
+		}
+		if _, err := NewPointValidated(c[0], c[1]); err == nil {
+			t.Errorf("Expected an error when validating %v", c)
+		}
+	}
+
+	if !IsValidCoordinate(-90, 180) {
+		t.Error("Expected -90,180 to be a valid coordinate")
+	}
+
+	p := NewPoint(nan, 1)
+	data, _ := p.MarshalBinary()
+	if err := new(Point).UnmarshalBinary(data); err == nil {
+		t.Error("Expected an error when unmarshalling a NaN point")
+	}
+
+	if _, err := ParsePoint("NaN, 1"); err == nil {
+		t.Error("Expected an error when parsing a NaN point")
+	}
+
+	if NewBoundingBox(NewPoint(0, 170), NewPoint(1, -170)).Contains(NewPoint(0.5, inf)) {
+		t.Error("Expected a bounding box not to contain an infinite point")
+	}
+
+	square := NewPolygon([]Point{NewPoint(-1, -1), NewPoint(-1, 1), NewPoint(1, 1), NewPoint(1, -1)})
+	if square.Contains(NewPoint(nan, 0)) || square.Contains(NewPoint(0, inf)) {
+		t.Error("Expected a polygon not to contain a non-finite point")
+	}
+}
+
+// Ensures that the With and Offset methods return new points and leave the original unchanged.
+func TestPointWith(t *testing.T) {
+	p := NewPoint(10, 20)
+
+	if q := p.WithLat(-5); q != NewPoint(-5, 20) {
+		t.Errorf("Expected -5,20, but got %v", q)
+	}
+
+	if q := p.WithLng(170); q != NewPoint(10, 170) {
+		t.Errorf("Expected 10,170, but got %v", q)
+	}
+
+	if q := p.Offset(1.5, 165).Normalized(); q != NewPoint(11.5, -175) {
+		t.Errorf("Expected 11.5,-175, but got %v", q)
+	}
+
+	if p != NewPoint(10, 20) {
+		t.Errorf("Expected the original point to be unchanged, but got %v", p)
+	}
+}
@@ -0,0 +1,114 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// DefaultPoissonDiskAttempts is the PoissonDiskOptions.Attempts used when none is configured.
+const DefaultPoissonDiskAttempts = 30
+
+// randomPointTries is how many random points are drawn from the bounds of an area
+// before giving up on finding one inside it.
+const randomPointTries = 10000
+
+// PoissonDiskOptions configures PoissonDiskSample.
+type PoissonDiskOptions struct {
+	// Seed seeds the random placement of the points, so that runs can be repeated.
+	Seed int64
+	// Attempts is how many candidates are tried around each point before giving up on
+	// placing more near it.  More attempts pack the points more tightly, at the cost of time.
+	// Defaults to DefaultPoissonDiskAttempts.
+	Attempts int
+	// MaxPoints stops sampling once this many points are placed.  Zero places as many as fit.
+	MaxPoints int
+}
+
+// PoissonDiskSample returns random points inside area that are no closer than minDistance to each
+// other, yet leave no gap wider than about twice minDistance: evenly spread without the regularity of
+// a grid, such as sites for sensors or candidate positions for map labels.  It follows Bridson, "Fast
+// Poisson disk sampling in arbitrary dimensions" (2007), growing outwards from random points along
+// great circles.  It returns an error wrapping ErrUnclosedPolygon for an area of fewer than three
+// points, ErrNoResults if no point inside area can be found, or ErrInvalidDistance for a minDistance
+// that is not positive.
+func PoissonDiskSample(area Polygon, minDistance Distance, opts PoissonDiskOptions) ([]Point, error) {
+	if opts.Attempts <= 0 {
+		opts.Attempts = DefaultPoissonDiskAttempts
+	}
+	if minDistance <= 0 {
+		return nil, fmt.Errorf("%w: minimum distance %v", ErrInvalidDistance, minDistance)
+	}
+	if !area.IsClosed() {
+		return nil, fmt.Errorf("%w: %d points", ErrUnclosedPolygon, len(area.points))
+	}
+
+	r := rand.New(rand.NewSource(opts.Seed))
+	index := NewGridIndex[int](minDistance.Kilometers() / EARTH_RADIUS * 180 / math.Pi)
+	var points, active []Point
+	full := func() bool {
+		return opts.MaxPoints > 0 && len(points) >= opts.MaxPoints
+	}
+	fits := func(p Point) bool {
+		return area.contains(p) && len(index.Within(p, minDistance)) == 0
+	}
+	place := func(p Point) {
+		index.Set(len(points), p)
+		points = append(points, p)
+		active = append(active, p)
+	}
+
+	// Parts of the area out of reach of the points so far are seeded from further random points.
+	for misses := 0; misses < opts.Attempts && !full(); {
+		seed, ok := randomPointIn(area, r)
+		if !ok && len(points) == 0 {
+			return nil, fmt.Errorf("%w: no point found inside %v", ErrNoResults, area)
+		}
+		if !ok || !fits(seed) {
+			misses++
+			continue
+		}
+		place(seed)
+
+		for len(active) > 0 && !full() {
+			i := r.Intn(len(active))
+			p := active[i]
+			placed := false
+			for range opts.Attempts {
+				// Draw candidates uniformly from the annulus between minDistance and twice it.
+				d := minDistance * Distance(math.Sqrt(1+3*r.Float64()))
+				candidate := destinationPoint(p, r.Float64()*360, d)
+				if fits(candidate) {
+					place(candidate)
+					placed = true
+					break
+				}
+			}
+			if !placed {
+				active[i] = active[len(active)-1]
+				active = active[:len(active)-1]
+			}
+		}
+	}
+	return points, nil
+}
+
+// randomPointIn returns a point drawn uniformly from area, and false if none was found.
+func randomPointIn(area Polygon, r *rand.Rand) (Point, bool) {
+	b := area.Bounds()
+	width := b.ne.lng - b.sw.lng
+	if width < 0 {
+		width += 360
+	}
+	sinSouth, sinNorth := math.Sin(b.sw.lat*math.Pi/180), math.Sin(b.ne.lat*math.Pi/180)
+
+	for range randomPointTries {
+		// Drawing the sine of the latitude uniformly spreads points evenly over the sphere.
+		lat := math.Asin(sinSouth+r.Float64()*(sinNorth-sinSouth)) * 180 / math.Pi
+		p := NewPoint(lat, NormalizeLng(b.sw.lng+r.Float64()*width))
+		if area.contains(p) {
+			return p, true
+		}
+	}
+	return Point{}, false
+}
@@ -0,0 +1,63 @@
+package geo
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// Ensures that samples keep their distance from each other, fill the area and stay inside it.
+func TestPoissonDiskSample(t *testing.T) {
+	// A U shape about a kilometer across, whose arms only meet at the bottom.
+	area := NewPolygon([]Point{
+		NewPoint(0, 0), NewPoint(0, 0.009), NewPoint(0.009, 0.009), NewPoint(0.009, 0.006),
+		NewPoint(0.003, 0.006), NewPoint(0.003, 0.003), NewPoint(0.009, 0.003), NewPoint(0.009, 0),
+	})
+
+	points, err := PoissonDiskSample(area, 50*Meter, PoissonDiskOptions{Seed: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(points) < 150 || len(points) > 400 {
+		t.Errorf("Expected a few hundred points, but got %d", len(points))
+	}
+	for i, p := range points {
+		if !area.Contains(p) {
+			t.Errorf("Expected %v inside the area", p)
+		}
+		for _, q := range points[i+1:] {
+			if d := p.GreatCircleDistance(q); d < 50*Meter {
+				t.Errorf("Expected points at least 50m apart, but %v and %v are %v apart", p, q, d)
+			}
+		}
+	}
+
+	// Every part of the area is within twice the distance of a sample.
+	tree := NewKDTree(points)
+	for lat := 0.0001; lat < 0.009; lat += 0.0005 {
+		for lng := 0.0001; lng < 0.009; lng += 0.0005 {
+			if p := NewPoint(lat, lng); area.Contains(p) && tree.Nearest(p, 1)[0].Distance > 100*Meter {
+				t.Errorf("Expected a sample near %v", p)
+			}
+		}
+	}
+
+	again, _ := PoissonDiskSample(area, 50*Meter, PoissonDiskOptions{Seed: 3})
+	if !reflect.DeepEqual(again, points) {
+		t.Errorf("Expected the same seed to repeat the samples")
+	}
+	if few, _ := PoissonDiskSample(area, 50*Meter, PoissonDiskOptions{MaxPoints: 10}); len(few) != 10 {
+		t.Errorf("Expected 10 points, but got %d", len(few))
+	}
+
+	if _, err := PoissonDiskSample(area, 0, PoissonDiskOptions{}); !errors.Is(err, ErrInvalidDistance) {
+		t.Errorf("Expected ErrInvalidDistance, but got %v", err)
+	}
+	if _, err := PoissonDiskSample(NewPolygon(nil), 50*Meter, PoissonDiskOptions{}); !errors.Is(err, ErrUnclosedPolygon) {
+		t.Errorf("Expected ErrUnclosedPolygon, but got %v", err)
+	}
+	line := NewPolygon([]Point{NewPoint(0, 0), NewPoint(1, 1), NewPoint(2, 2)})
+	if _, err := PoissonDiskSample(line, 50*Meter, PoissonDiskOptions{}); !errors.Is(err, ErrNoResults) {
+		t.Errorf("Expected ErrNoResults for an area without points inside, but got %v", err)
+	}
+}
@@ -2,34 +2,131 @@
 
 package geo
 
-import "math"
+import (
+	"fmt"
+	"math"
+)
 
 // A Polygon is carved out of a 2D plane by a set of (possibly disjoint) contours.
 // It can thus contain holes, and can be self-intersecting.
 type Polygon struct {
 	points []Point
+	crs    CRS
+
+	// bounds caches the bounding box of points when bounded is set.
+	bounds  BoundingBox
+	bounded bool
 }
 
 // NewPolygon: Creates and returns a new pointer to a Polygon
 // composed of the passed in points.  Points are
 // considered to be in order such that the last point
-// forms an edge with the first point.
+// forms an edge with the first point.  The Polygon keeps
+// the passed in slice, which must not be modified afterwards.
 func NewPolygon(points []Point) Polygon {
-	return Polygon{points: points}
+	return Polygon{points: points, bounds: pointsBounds(points), bounded: true}
+}
+
+// NewPolygonValidated returns a new Polygon like NewPolygon, but returns an error when any point
+// is out of range or the polygon is degenerate: fewer than three distinct points or no area.
+func NewPolygonValidated(points []Point) (Polygon, error) {
+	for i, p := range points {
+		if _, err := NewPointValidated(p.lat, p.lng); err != nil {
+			return Polygon{}, fmt.Errorf("point %d: %w", i, err)
+		}
+	}
+
+	distinct := make(map[Point]bool)
+	for _, p := range points {
+		distinct[p] = true
+	}
+	if len(distinct) < 3 {
+		return Polygon{}, fmt.Errorf("%w: polygon needs at least 3 distinct points, but got %d", ErrDegeneratePolygon, len(distinct))
+	}
+
+	if !hasPlanarArea(points) {
+		return Polygon{}, fmt.Errorf("%w: polygon has no area", ErrDegeneratePolygon)
+	}
+
+	return NewPolygon(points), nil
+}
+
+// hasPlanarArea reports whether the ring encloses an area beyond the rounding error of the shoelace
+// formula, so that collinear points whose products do not cancel exactly are still rejected.
+func hasPlanarArea(points []Point) bool {
+	area, scale := 0.0, 0.0
+	for i, p := range points {
+		q := points[(i+1)%len(points)]
+		area += p.lng*q.lat - q.lng*p.lat
+		scale += math.Abs(p.lng*q.lat) + math.Abs(q.lng*p.lat)
+	}
+	return math.Abs(area) > 1e-12*scale
 }
 
-// Points returns the points of the current Polygon.
+// Points returns a copy of the points of the current Polygon.  Modifying it leaves the Polygon
+// unchanged; build a new Polygon from it instead, so that its cached bounds stay correct.
 func (p Polygon) Points() []Point {
-	return p.points
+	return append([]Point(nil), p.points...)
+}
+
+// CRS returns the coordinate reference system the points of the Polygon are expressed in.
+// Polygons are in WGS84 unless stated otherwise.
+func (p Polygon) CRS() CRS {
+	if p.crs == 0 {
+		return WGS84
+	}
+	return p.crs
+}
+
+// WithCRS returns a copy of the Polygon labelled with the passed in reference system.
+// The points themselves are not converted; use Transform for that.
+func (p Polygon) WithCRS(crs CRS) Polygon {
+	p.crs = crs
+	return p
+}
+
+// Transform returns a new Polygon with every point converted into the passed in reference system.
+func (p Polygon) Transform(to CRS) (Polygon, error) {
+	points, err := transformPoints(p.points, p.CRS(), to)
+	if err != nil {
+		return Polygon{}, err
+	}
+
+	return NewPolygon(points).WithCRS(to), nil
 }
 
 // Add: Appends the passed in contour to the current Polygon and returns
-// a new polygon.
+// a new polygon.  The current Polygon is left unchanged, and never shares
+// its points with the result; use a PolygonBuilder to add many points.
 func (p Polygon) Add(point Point) Polygon {
-	p.points = append(p.points, point)
+	switch {
+	case len(p.points) == 0:
+		p.bounds, p.bounded = point.Bounds(), true
+	case p.bounded:
+		p.bounds = NewBoundingBox(
+			NewPoint(math.Min(p.bounds.sw.lat, point.lat), math.Min(p.bounds.sw.lng, point.lng)),
+			NewPoint(math.Max(p.bounds.ne.lat, point.lat), math.Max(p.bounds.ne.lng, point.lng)),
+		)
+	}
+
+	p.points = append(p.points[:len(p.points):len(p.points)], point)
 	return p
 }
 
+// Bounds returns the smallest BoundingBox containing every point of the Polygon.
+func (p Polygon) Bounds() BoundingBox {
+	if p.bounded {
+		return p.bounds
+	}
+	return pointsBounds(p.points)
+}
+
+// String renders the Polygon as its vertex count and bounds, for example
+// "Polygon(4 points, -1,-1 1,1)", rather than printing every vertex.
+func (p Polygon) String() string {
+	return fmt.Sprintf("Polygon(%d points, %v)", len(p.points), p.Bounds())
+}
+
 // IsClosed returns whether or not the polygon is closed.
 // TODO:  This can obviously be improved, but for now,
 //
@@ -44,34 +141,68 @@ func (p Polygon) IsClosed() bool {
 }
 
 // Contains returns whether or not the current Polygon contains the passed in Point.
+// NaN and infinite points are never contained.
 func (p Polygon) Contains(point Point) bool {
-	if !p.IsClosed() {
+	addMetric(MetricContainsCalls, "polygon", 1)
+	return p.contains(point)
+}
+
+func (p Polygon) contains(point Point) bool {
+	if !p.IsClosed() || !isFinite(point.lat) || !isFinite(point.lng) {
 		return false
 	}
+
+	// Points outside of the bounding box cannot be inside, so skip the raycast.
+	if !p.Bounds().Contains(point) {
+		return false
+	}
+
 	// Look here for further options: https://github.com/kellydunn/golang-geo/pull/71#discussion_r303040014
 	for _, p := range p.points {
-		// this for loop avoids cases where the ray goes directly through a vertex
-		for point.lat == p.lat {
-			newLat := math.Nextafter(point.lat, math.Inf(1))
-			point = NewPoint(newLat, point.lng)
-		}
+		point = nudgeOffVertex(point, p)
+	}
 
-		// move point so it isn't a vertex
-		for point.lng == p.lng {
-			newLng := math.Nextafter(point.lng, math.Inf(1))
-			point = NewPoint(point.lat, newLng)
-		}
+	return raycast(p.points, point)
+}
+
+// nudgeOffVertex moves point by the smallest possible steps north and east until it shares neither
+// a latitude nor a longitude with vertex, which keeps the ray cast by raycast from passing through it.
+func nudgeOffVertex(point Point, vertex Point) Point {
+	if vertex.lat != point.lat && vertex.lng != point.lng {
+		return point
+	}
+
+	// this for loop avoids cases where the ray goes directly through a vertex
+	for point.lat == vertex.lat {
+		point.lat = math.Nextafter(point.lat, math.Inf(1))
 	}
 
-	start := len(p.points) - 1
-	end := 0
+	// move point so it isn't a vertex
+	for point.lng == vertex.lng {
+		point.lng = math.Nextafter(point.lng, math.Inf(1))
+	}
+
+	return point
+}
 
-	contains := p.intersectsWithRaycast(point, &p.points[start], &p.points[end])
+// raycast returns whether the ray cast east from point crosses an odd number of the edges of ring.
+// point must not share a latitude with any vertex, which Contains guarantees, so an edge can only
+// cross the ray when its endpoints lie on opposite sides of it. That test needs only latitudes and
+// rejects almost every edge of a large polygon in a single sequential pass over the ring, so the
+// slope comparison in crossesRay only runs for the few edges that straddle the ray.
+func raycast(ring []Point, point Point) bool {
+	contains := false
+	prev := ring[len(ring)-1]
+	below := prev.lat < point.lat
 
-	for i := 1; i < len(p.points); i++ {
-		if p.intersectsWithRaycast(point, &p.points[i-1], &p.points[i]) {
-			contains = !contains
+	for _, q := range ring {
+		if qBelow := q.lat < point.lat; qBelow != below {
+			if crossesRay(point, prev, q) {
+				contains = !contains
+			}
+			below = qBelow
 		}
+		prev = q
 	}
 
 	return contains
@@ -82,7 +213,7 @@ func (p Polygon) Contains(point Point) bool {
 // Original implementation: http://rosettacode.org/wiki/Ray-casting_algorithm#Go although
 // this implementation has bugs if the x point is equal to the x of the start.
 // As far as I can tell, the ray that is being cast to the right
-func (p Polygon) intersectsWithRaycast(point Point, start *Point, end *Point) bool {
+func crossesRay(point Point, start Point, end Point) bool {
 	// Always ensure that the the first point
 	// has a y coordinate that is less than the second point
 	if start.lat > end.lat {
@@ -118,3 +249,20 @@ func (p Polygon) intersectsWithRaycast(point Point, start *Point, end *Point) bo
 	diagSlope := (end.lat - start.lat) / (end.lng - start.lng)
 	return raySlope >= diagSlope
 }
+
+// pointsBounds returns the smallest BoundingBox containing all of the passed in points.
+func pointsBounds(points []Point) BoundingBox {
+	if len(points) == 0 {
+		return BoundingBox{}
+	}
+
+	sw, ne := points[0], points[0]
+	for _, point := range points[1:] {
+		sw.lat = math.Min(sw.lat, point.lat)
+		sw.lng = math.Min(sw.lng, point.lng)
+		ne.lat = math.Max(ne.lat, point.lat)
+		ne.lng = math.Max(ne.lng, point.lng)
+	}
+
+	return NewBoundingBox(sw, ne)
+}
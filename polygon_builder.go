@@ -0,0 +1,42 @@
+package geo
+
+// A PolygonBuilder accumulates points for a Polygon without the copying of repeated Polygon.Add calls.
+// The zero value is an empty builder ready to use.
+type PolygonBuilder struct {
+	points []Point
+	crs    CRS
+}
+
+// Add appends the passed in points to the builder and returns it, so calls can be chained.
+func (b *PolygonBuilder) Add(points ...Point) *PolygonBuilder {
+	b.points = append(b.points, points...)
+	return b
+}
+
+// WithCRS labels the Polygons built with the passed in reference system.
+func (b *PolygonBuilder) WithCRS(crs CRS) *PolygonBuilder {
+	b.crs = crs
+	return b
+}
+
+// Len returns the number of points added so far.
+func (b *PolygonBuilder) Len() int {
+	return len(b.points)
+}
+
+// Build returns a Polygon of the points added so far.  The Polygon owns a copy
+// of the points, so later calls to Add do not change it.
+func (b *PolygonBuilder) Build() Polygon {
+	points := make([]Point, len(b.points))
+	copy(points, b.points)
+	return NewPolygon(points).WithCRS(b.crs)
+}
+
+// BuildValidated is like Build, but validates the points as NewPolygonValidated does.
+func (b *PolygonBuilder) BuildValidated() (Polygon, error) {
+	if _, err := NewPolygonValidated(b.points); err != nil {
+		return Polygon{}, err
+	}
+
+	return b.Build(), nil
+}
@@ -0,0 +1,27 @@
+package geo
+
+import "testing"
+
+// Ensures that built polygons are isolated from later additions to the builder.
+func TestPolygonBuilder(t *testing.T) {
+	var b PolygonBuilder
+	b.Add(NewPoint(-1, -1), NewPoint(-1, 1)).Add(NewPoint(1, 1))
+
+	triangle := b.Build()
+	b.Add(NewPoint(1, -1))
+	square := b.WithCRS(WGS84).Build()
+
+	if len(triangle.Points()) != 3 || len(square.Points()) != 4 || b.Len() != 4 {
+		t.Errorf("Expected 3 and 4 points, but got %d and %d", len(triangle.Points()), len(square.Points()))
+	}
+
+	if _, err := b.BuildValidated(); err != nil {
+		t.Errorf("Expected the square to be valid, but got %v", err)
+	}
+
+	var line PolygonBuilder
+	line.Add(NewPoint(0, 0), NewPoint(1, 1), NewPoint(2, 2))
+	if _, err := line.BuildValidated(); err == nil {
+		t.Error("Expected an error when building a degenerate polygon")
+	}
+}
@@ -0,0 +1,123 @@
+package geo
+
+import (
+	"context"
+	"sort"
+)
+
+// A PolygonIndex answers which of many named polygons contain a point or intersect a box,
+// such as which country, timezone or delivery zone a location falls in.
+// It is immutable once built and safe for concurrent use.
+type PolygonIndex struct {
+	ids      []string
+	polygons []*PreparedPolygon
+	tree     *RTree[indexedBounds]
+}
+
+// NewPolygonIndex returns a new PolygonIndex over the passed in polygons, keyed by their IDs.
+func NewPolygonIndex(polygons map[string]Polygon) *PolygonIndex {
+	idx, _ := NewPolygonIndexCtx(context.Background(), polygons)
+	return idx
+}
+
+// NewPolygonIndexCtx is like NewPolygonIndex, but stops early and returns ctx.Err() once ctx is done.
+// Preparing many large polygons is the slow part of building an index.
+func NewPolygonIndexCtx(ctx context.Context, polygons map[string]Polygon) (*PolygonIndex, error) {
+	return NewPolygonIndexWith(ctx, polygons, BatchOptions{})
+}
+
+// NewPolygonIndexWith is like NewPolygonIndexCtx, but controlled by the passed in options.
+// Its progress is counted in polygons.
+func NewPolygonIndexWith(ctx context.Context, polygons map[string]Polygon, opts BatchOptions) (*PolygonIndex, error) {
+	ids := make([]string, 0, len(polygons))
+	for id := range polygons {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	idx := &PolygonIndex{ids: ids, polygons: make([]*PreparedPolygon, len(ids))}
+	bounds := make([]BoundingBox, len(ids))
+	prog := newProgress(opts.Progress, len(ids))
+	for i, id := range ids {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		idx.polygons[i] = NewPreparedPolygon(polygons[id])
+		bounds[i] = idx.polygons[i].Bounds()
+		prog.add(1)
+	}
+	idx.tree = newIndexRTree(bounds)
+
+	return idx, nil
+}
+
+// Len returns the number of polygons in the index.
+func (idx *PolygonIndex) Len() int {
+	return len(idx.ids)
+}
+
+// Polygon returns the polygon indexed under id.
+func (idx *PolygonIndex) Polygon(id string) (Polygon, bool) {
+	i := sort.SearchStrings(idx.ids, id)
+	if i == len(idx.ids) || idx.ids[i] != id {
+		return Polygon{}, false
+	}
+	return idx.polygons[i].Polygon(), true
+}
+
+// FindContaining returns the IDs of every polygon containing Point p, in ascending order.
+func (idx *PolygonIndex) FindContaining(p Point) []string {
+	return idx.find(p.Bounds(), func(i int) bool {
+		return idx.polygons[i].Contains(p)
+	})
+}
+
+// FindIntersecting returns the IDs of every polygon sharing any area with b or touching it, in ascending
+// order.  Like Polygon.Contains, edges are straight lines in latitude and longitude.
+func (idx *PolygonIndex) FindIntersecting(b BoundingBox) []string {
+	return idx.find(b, func(i int) bool {
+		return idx.polygons[i].intersectsBox(b)
+	})
+}
+
+func (idx *PolygonIndex) find(b BoundingBox, match func(i int) bool) []string {
+	defer timeIndexQuery("polygon_index")()
+
+	var found []int
+	idx.tree.SearchFunc(b, func(item indexedBounds) bool {
+		if match(item.i) {
+			found = append(found, item.i)
+		}
+		return true
+	})
+	sort.Ints(found)
+
+	ids := make([]string, len(found))
+	for i, j := range found {
+		ids[i] = idx.ids[j]
+	}
+	return ids
+}
+
+// intersectsBox reports whether the PreparedPolygon shares any area with b or touches it.
+func (pp *PreparedPolygon) intersectsBox(b BoundingBox) bool {
+	p := pp.polygon
+	if !p.IsClosed() {
+		return false
+	}
+
+	ring := p.ring()
+	for _, lngs := range b.lngIntervals() {
+		box := NewBoundingBox(NewPoint(b.sw.lat, lngs[0]), NewPoint(b.ne.lat, lngs[1]))
+		// Either a vertex lies in the box, the box lies inside the polygon, or their outlines cross.
+		for _, q := range p.points {
+			if box.Contains(q) {
+				return true
+			}
+		}
+		if pp.Contains(box.sw) || ring.Intersects(box.ToPolygon().ring()) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+package geo
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// Ensures that the index finds the polygons containing a point and intersecting a box.
+func TestPolygonIndex(t *testing.T) {
+	nsw, err := polygonFromFile("test/data/nsw.json")
+	if err != nil {
+		t.Fatal("nsw json file failed to parse: ", err)
+	}
+
+	act, err := polygonFromFile("test/data/act.json")
+	if err != nil {
+		t.Fatal("act json file failed to parse: ", err)
+	}
+
+	brunei, err := polygonFromFile("test/data/brunei.json")
+	if err != nil {
+		t.Fatal("brunei json file failed to parse: ", err)
+	}
+
+	idx := NewPolygonIndex(map[string]Polygon{"nsw": nsw, "act": act, "brunei": brunei})
+
+	tests := []struct {
+		p        Point
+		expected []string
+	}{
+		{NewPoint(-35.2819998, 149.1286843), []string{"act", "nsw"}},
+		{NewPoint(-33.866, 151.209), []string{"nsw"}},
+		{NewPoint(4.9403, 114.9481), []string{"brunei"}},
+		{NewPoint(0, 0), []string{}},
+	}
+
+	for _, tt := range tests {
+		if found := idx.FindContaining(tt.p); !reflect.DeepEqual(found, tt.expected) {
+			t.Errorf("Expected %v to be contained by %v, but got %v", tt.p, tt.expected, found)
+		}
+	}
+
+	australia := NewBoundingBox(NewPoint(-44, 112), NewPoint(-10, 154))
+	if found := idx.FindIntersecting(australia); !reflect.DeepEqual(found, []string{"act", "nsw"}) {
+		t.Errorf("Expected act and nsw to intersect Australia, but got %v", found)
+	}
+
+	if p, ok := idx.Polygon("brunei"); !ok || len(p.Points()) != len(brunei.Points()) || idx.Len() != 3 {
+		t.Error("Expected to look up brunei by its ID")
+	}
+
+	if _, ok := idx.Polygon("tasmania"); ok {
+		t.Error("Expected no polygon for an unknown ID")
+	}
+}
+
+// Ensures that building an index with a cancelled context returns the context's error.
+func TestNewPolygonIndexCtx(t *testing.T) {
+	square := NewPolygon([]Point{NewPoint(0, 0), NewPoint(0, 2), NewPoint(2, 2), NewPoint(2, 0)})
+
+	idx, err := NewPolygonIndexCtx(context.Background(), map[string]Polygon{"square": square})
+	if err != nil || idx.Len() != 1 {
+		t.Errorf("Expected an index of 1 polygon, but got %v, %v", idx, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := NewPolygonIndexCtx(ctx, map[string]Polygon{"square": square}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, but got %v", err)
+	}
+}
+
+// Ensures that boxes overlapping only the bounding box of a polygon do not count as intersecting it.
+func TestPolygonIndexFindIntersectingExact(t *testing.T) {
+	// A triangle whose bounding box is 0,0 to 10,10, leaving the north west corner empty.
+	triangle := NewPolygon([]Point{NewPoint(0, 0), NewPoint(0, 10), NewPoint(10, 10)})
+	idx := NewPolygonIndex(map[string]Polygon{"triangle": triangle})
+
+	tests := []struct {
+		b        BoundingBox
+		expected []string
+	}{
+		{NewBoundingBox(NewPoint(8, 0), NewPoint(10, 2)), []string{}},
+		{NewBoundingBox(NewPoint(4, 4), NewPoint(6, 6)), []string{"triangle"}},
+		{NewBoundingBox(NewPoint(1, 5), NewPoint(2, 6)), []string{"triangle"}},
+		{NewBoundingBox(NewPoint(-1, -1), NewPoint(11, 11)), []string{"triangle"}},
+		{NewBoundingBox(NewPoint(5, -2), NewPoint(6, 12)), []string{"triangle"}},
+		{NewBoundingBox(NewPoint(20, 20), NewPoint(30, 30)), []string{}},
+	}
+	for _, tt := range tests {
+		if found := idx.FindIntersecting(tt.b); !reflect.DeepEqual(found, tt.expected) {
+			t.Errorf("Expected %v to intersect %v, but got %v", tt.b, tt.expected, found)
+		}
+	}
+}
+
+// Ensures that the prepared polygons of the index agree with Contains for points on vertex longitudes.
+func TestPolygonIndexVertexLongitude(t *testing.T) {
+	p, points := vertexLongitudePolygon()
+	idx := NewPolygonIndex(map[string]Polygon{"triangle": p})
+
+	for _, point := range points {
+		expected := []string{}
+		if p.Contains(point) {
+			expected = []string{"triangle"}
+		}
+		if found := idx.FindContaining(point); !reflect.DeepEqual(found, expected) {
+			t.Errorf("Expected %v to be contained by %v, but got %v", point, expected, found)
+		}
+	}
+
+	if found := idx.FindIntersecting(NewBoundingBox(NewPoint(15.5, 10), NewPoint(15.5, 10))); len(found) != 0 {
+		t.Errorf("Expected a box outside of the triangle on its apex longitude to intersect nothing, but got %v", found)
+	}
+}
@@ -0,0 +1,195 @@
+package geo
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"unsafe"
+)
+
+// The polygon store format is a header, an index of fixed size records describing
+// each polygon, and the packed little endian coordinates of every polygon:
+//
+//	header: magic "GEOPOLY1", uint32 version, uint32 polygon count
+//	record: float64 south, west, north, east, uint64 coordinate offset, uint64 point count
+//	coordinates: float64 lat, float64 lng for every point
+const (
+	polygonStoreMagic      = "GEOPOLY1"
+	polygonStoreVersion    = 1
+	polygonStoreHeaderSize = 16
+	polygonStoreRecordSize = 48
+)
+
+// WritePolygonStore writes the passed in polygons to w in the polygon store format read by OpenPolygonStore.
+func WritePolygonStore(w io.Writer, polygons []Polygon) error {
+	bw := bufio.NewWriter(w)
+
+	header := make([]byte, 0, polygonStoreHeaderSize)
+	header = append(header, polygonStoreMagic...)
+	header = binary.LittleEndian.AppendUint32(header, polygonStoreVersion)
+	header = binary.LittleEndian.AppendUint32(header, uint32(len(polygons)))
+	if _, err := bw.Write(header); err != nil {
+		return err
+	}
+
+	offset := uint64(polygonStoreHeaderSize + polygonStoreRecordSize*len(polygons))
+	record := make([]byte, 0, polygonStoreRecordSize)
+	for _, p := range polygons {
+		b := p.Bounds()
+		record = record[:0]
+		for _, v := range []float64{b.sw.lat, b.sw.lng, b.ne.lat, b.ne.lng} {
+			record = binary.LittleEndian.AppendUint64(record, math.Float64bits(v))
+		}
+		record = binary.LittleEndian.AppendUint64(record, offset)
+		record = binary.LittleEndian.AppendUint64(record, uint64(len(p.points)))
+		if _, err := bw.Write(record); err != nil {
+			return err
+		}
+		offset += 16 * uint64(len(p.points))
+	}
+
+	var coordinates [16]byte
+	for _, p := range polygons {
+		for _, point := range p.points {
+			binary.LittleEndian.PutUint64(coordinates[:8], math.Float64bits(point.lat))
+			binary.LittleEndian.PutUint64(coordinates[8:], math.Float64bits(point.lng))
+			if _, err := bw.Write(coordinates[:]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return bw.Flush()
+}
+
+// A PolygonStore serves the polygons of a polygon store file directly from a read-only
+// memory mapping, so that country scale datasets can be queried without loading them
+// onto the heap.  On platforms without mmap the file is read into memory instead.
+// It is safe for concurrent use until it is closed.
+type PolygonStore struct {
+	data    []byte
+	release func() error
+	bounds  []BoundingBox
+	tree    *RTree[indexedBounds]
+}
+
+// OpenPolygonStore opens a polygon store file written by WritePolygonStore.
+func OpenPolygonStore(path string) (*PolygonStore, error) {
+	data, release, err := mapFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := newPolygonStore(data)
+	if err != nil {
+		release()
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	s.release = release
+
+	return s, nil
+}
+
+// nativeLittleEndian reports whether the platform stores float64s in little endian byte order.
+var nativeLittleEndian = binary.NativeEndian.Uint16([]byte{1, 0}) == 1
+
+func newPolygonStore(data []byte) (*PolygonStore, error) {
+	if len(data) < polygonStoreHeaderSize || string(data[:8]) != polygonStoreMagic {
+		return nil, fmt.Errorf("%w: not a polygon store", ErrInvalidFormat)
+	}
+	if v := binary.LittleEndian.Uint32(data[8:]); v != polygonStoreVersion {
+		return nil, fmt.Errorf("%w: unsupported polygon store version %d", ErrInvalidFormat, v)
+	}
+
+	n := int(binary.LittleEndian.Uint32(data[12:]))
+	if len(data) < polygonStoreHeaderSize+n*polygonStoreRecordSize {
+		return nil, fmt.Errorf("%w: truncated polygon store index", ErrInvalidFormat)
+	}
+
+	s := &PolygonStore{data: data, bounds: make([]BoundingBox, n)}
+	for i := range s.bounds {
+		r := s.record(i)
+		f := func(j int) float64 { return math.Float64frombits(binary.LittleEndian.Uint64(r[8*j:])) }
+		s.bounds[i] = NewBoundingBox(NewPoint(f(0), f(1)), NewPoint(f(2), f(3)))
+
+		offset, count := binary.LittleEndian.Uint64(r[32:]), binary.LittleEndian.Uint64(r[40:])
+		if offset%8 != 0 || offset > uint64(len(data)) || count > (uint64(len(data))-offset)/16 {
+			return nil, fmt.Errorf("%w: polygon %d lies outside of the polygon store", ErrInvalidFormat, i)
+		}
+	}
+	s.tree = newIndexRTree(s.bounds)
+
+	return s, nil
+}
+
+func (s *PolygonStore) record(i int) []byte {
+	start := polygonStoreHeaderSize + i*polygonStoreRecordSize
+	return s.data[start : start+polygonStoreRecordSize]
+}
+
+// Close releases the memory mapping.  Polygons returned by the store remain valid.
+func (s *PolygonStore) Close() error {
+	if s.release == nil {
+		return nil
+	}
+
+	err := s.release()
+	s.data, s.release = nil, nil
+	return err
+}
+
+// Len returns the number of polygons in the store.
+func (s *PolygonStore) Len() int {
+	return len(s.bounds)
+}
+
+// Bounds returns the bounding box of the i'th polygon, read from the index.
+func (s *PolygonStore) Bounds(i int) BoundingBox {
+	return s.bounds[i]
+}
+
+// Polygon returns the i'th polygon.  Its points are copied out of the mapped file, so the Polygon
+// may be modified and remains valid after Close.
+func (s *PolygonStore) Polygon(i int) Polygon {
+	view := s.view(i)
+	return Polygon{points: append([]Point(nil), view.points...), bounds: view.bounds, bounded: true}
+}
+
+// view returns the i'th polygon without copying its points on little endian platforms.  Its points
+// then refer directly to the read-only mapping, so it must not be modified, retained or used after Close.
+func (s *PolygonStore) view(i int) Polygon {
+	r := s.record(i)
+	offset, count := binary.LittleEndian.Uint64(r[32:]), int(binary.LittleEndian.Uint64(r[40:]))
+
+	var points []Point
+	if count > 0 && nativeLittleEndian {
+		// Point is two float64s, matching the packed little endian coordinates.
+		points = unsafe.Slice((*Point)(unsafe.Pointer(&s.data[offset])), count)
+	} else if count > 0 {
+		points = make([]Point, count)
+		for j := range points {
+			c := s.data[offset+16*uint64(j):]
+			points[j] = NewPoint(math.Float64frombits(binary.LittleEndian.Uint64(c)), math.Float64frombits(binary.LittleEndian.Uint64(c[8:])))
+		}
+	}
+
+	return Polygon{points: points, bounds: s.bounds[i], bounded: true}
+}
+
+// FindContaining returns the indices of the polygons containing Point p, in ascending order.
+func (s *PolygonStore) FindContaining(p Point) []int {
+	defer timeIndexQuery("polygon_store")()
+
+	var found []int
+	s.tree.SearchFunc(p.Bounds(), func(item indexedBounds) bool {
+		if s.view(item.i).Contains(p) {
+			found = append(found, item.i)
+		}
+		return true
+	})
+	sort.Ints(found)
+	return found
+}
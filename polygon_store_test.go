@@ -0,0 +1,108 @@
+package geo
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// Ensures that polygons written to a store are served back unchanged from the mapped file.
+func TestPolygonStore(t *testing.T) {
+	nsw, err := polygonFromFile("test/data/nsw.json")
+	if err != nil {
+		t.Fatal("nsw json file failed to parse: ", err)
+	}
+
+	act, err := polygonFromFile("test/data/act.json")
+	if err != nil {
+		t.Fatal("act json file failed to parse: ", err)
+	}
+
+	brunei, err := polygonFromFile("test/data/brunei.json")
+	if err != nil {
+		t.Fatal("brunei json file failed to parse: ", err)
+	}
+
+	polygons := []Polygon{nsw, act, brunei, NewPolygon(nil)}
+	path := filepath.Join(t.TempDir(), "polygons.store")
+
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := WritePolygonStore(f, polygons); err != nil {
+		t.Fatalf("Should not encounter an error when writing the store, but got %v", err)
+	}
+	f.Close()
+
+	s, err := OpenPolygonStore(path)
+	if err != nil {
+		t.Fatalf("Should not encounter an error when opening the store, but got %v", err)
+	}
+	defer s.Close()
+
+	if s.Len() != len(polygons) {
+		t.Fatalf("Expected %d polygons, but got %d", len(polygons), s.Len())
+	}
+
+	for i, p := range polygons {
+		if got := s.Polygon(i); !reflect.DeepEqual(got.Points(), p.Points()) || s.Bounds(i) != p.Bounds() {
+			t.Errorf("Expected polygon %d to round trip", i)
+		}
+	}
+
+	if found := s.FindContaining(NewPoint(-35.2819998, 149.1286843)); !reflect.DeepEqual(found, []int{0, 1}) {
+		t.Errorf("Expected Canberra to be in polygons 0 and 1, but got %v", found)
+	}
+
+	if found := s.FindContaining(NewPoint(0, 0)); len(found) != 0 {
+		t.Errorf("Expected no polygons to contain 0,0, but got %v", found)
+	}
+}
+
+// Ensures that polygons returned by a store can be modified and used after it is closed.
+func TestPolygonStorePolygonCopied(t *testing.T) {
+	square := NewPolygon([]Point{NewPoint(0, 0), NewPoint(0, 1), NewPoint(1, 1), NewPoint(1, 0)})
+	path := filepath.Join(t.TempDir(), "polygons.store")
+
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := WritePolygonStore(f, []Polygon{square}); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	s, err := OpenPolygonStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := s.Polygon(0)
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	p.Points()[0] = NewPoint(-1, -1)
+	if !reflect.DeepEqual(p.Points()[1:], square.Points()[1:]) {
+		t.Errorf("Expected the polygon to be readable after Close, but got %v", p.Points())
+	}
+}
+
+// Ensures that files that are not polygon stores are rejected.
+func TestOpenPolygonStoreInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "invalid.store")
+	if err := os.WriteFile(path, []byte("GEOPOLY1\x01\x00\x00\x00\xff\x00\x00\x00"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := OpenPolygonStore(path); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("Expected a truncated store to be rejected, but got %v", err)
+	}
+
+	if _, err := OpenPolygonStore("test/data/nsw.json"); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("Expected a JSON file to be rejected, but got %v", err)
+	}
+}
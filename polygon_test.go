@@ -931,3 +931,127 @@ func TestOnlyContainsInOne(t *testing.T) {
 	}
 	t.Error("Should be in one shape")
 }
+
+// Ensures that the bounds of a polygon span all of its points.
+func TestPolygonBounds(t *testing.T) {
+	poly := NewPolygon([]Point{NewPoint(1, -2), NewPoint(3, 4), NewPoint(-5, 0)})
+	b := poly.Bounds()
+
+	if b.SouthWest() != NewPoint(-5, -2) || b.NorthEast() != NewPoint(3, 4) {
+		t.Errorf("Expected bounds (-5,-2) to (3,4), but got %v to %v", b.SouthWest(), b.NorthEast())
+	}
+
+	if (Polygon{}).Bounds() != (BoundingBox{}) {
+		t.Error("Expected an empty polygon to have empty bounds")
+	}
+}
+
+// Ensures that polygons render as their vertex count and bounds.
+func TestPolygonString(t *testing.T) {
+	p := NewPolygon([]Point{NewPoint(-1, -1), NewPoint(-1, 1), NewPoint(1, 1), NewPoint(1, -1)})
+	if s := p.String(); s != "Polygon(4 points, -1,-1 1,1)" {
+		t.Errorf("Expected Polygon(4 points, -1,-1 1,1), but got %s instead", s)
+	}
+
+	if s := NewLineString(p.Points()[:2]).String(); s != "LineString(2 points, -1,-1 -1,1)" {
+		t.Errorf("Expected LineString(2 points, -1,-1 -1,1), but got %s instead", s)
+	}
+}
+
+// Ensures that degenerate and out of range polygons are rejected by NewPolygonValidated.
+func TestNewPolygonValidated(t *testing.T) {
+	square := []Point{NewPoint(-1, -1), NewPoint(-1, 1), NewPoint(1, 1), NewPoint(1, -1)}
+	if _, err := NewPolygonValidated(square); err != nil {
+		t.Errorf("Expected a square to be valid, but got %v", err)
+	}
+
+	invalid := [][]Point{
+		nil,
+		{NewPoint(0, 0), NewPoint(1, 1)},
+		{NewPoint(0, 0), NewPoint(1, 1), NewPoint(0, 0), NewPoint(1, 1)},
+		{NewPoint(0, 0), NewPoint(1, 1), NewPoint(2, 2)},
+		{NewPoint(0, 0), NewPoint(1, 1), NewPoint(100, 0)},
+		// Collinear, although the shoelace products do not cancel exactly.
+		{NewPoint(47.1, -122.3), NewPoint(47.2, -122.2), NewPoint(47.35, -122.05)},
+	}
+
+	for _, points := range invalid {
+		if _, err := NewPolygonValidated(points); err == nil {
+			t.Errorf("Expected an error when validating %v", points)
+		}
+	}
+}
+
+// Ensures that adding to two copies of the same polygon does not let them corrupt each other.
+func TestPolygonAddCopyOnWrite(t *testing.T) {
+	base := NewPolygon(make([]Point, 0, 8)).Add(NewPoint(0, 0)).Add(NewPoint(0, 1))
+
+	a := base.Add(NewPoint(1, 1))
+	b := base.Add(NewPoint(-1, -1))
+
+	if a.Points()[2] != NewPoint(1, 1) || b.Points()[2] != NewPoint(-1, -1) {
+		t.Errorf("Expected independent polygons, but got %v and %v", a.Points(), b.Points())
+	}
+
+	if len(base.Points()) != 2 {
+		t.Errorf("Expected the original polygon to keep 2 points, but got %d", len(base.Points()))
+	}
+}
+
+// Ensures that the cached bounds follow Add and reject far away points.
+func TestPolygonCachedBounds(t *testing.T) {
+	p := Polygon{}.Add(NewPoint(0, 0)).Add(NewPoint(0, 2)).Add(NewPoint(2, 2))
+	grown := p.Add(NewPoint(-3, 1))
+
+	if b := p.Bounds(); b != NewBoundingBox(NewPoint(0, 0), NewPoint(2, 2)) {
+		t.Errorf("Expected bounds 0,0 2,2, but got %v", b)
+	}
+
+	if b := grown.Bounds(); b != NewBoundingBox(NewPoint(-3, 0), NewPoint(2, 2)) {
+		t.Errorf("Expected bounds -3,0 2,2, but got %v", b)
+	}
+
+	if !p.Contains(NewPoint(1, 1.5)) || p.Contains(NewPoint(50, 50)) || p.Contains(NewPoint(1, -0.5)) {
+		t.Error("Expected containment to agree with the cached bounds")
+	}
+}
+
+// Ensures that screening edges by latitude in raycast agrees with testing every edge of the ring.
+func TestRaycastMatchesEveryEdge(t *testing.T) {
+	nsw, err := polygonFromFile("test/data/nsw.json")
+	if err != nil {
+		t.Fatal("nsw json file failed to parse: ", err)
+	}
+
+	ring := nsw.Points()
+	for i := 0; i < 500; i++ {
+		point := NewPoint(-37.5+float64(i%25)*0.35+0.0013, 140.5+float64(i/25)*0.65+0.0017)
+
+		expected := false
+		prev := ring[len(ring)-1]
+		for _, q := range ring {
+			if crossesRay(point, prev, q) {
+				expected = !expected
+			}
+			prev = q
+		}
+
+		if got := raycast(ring, point); got != expected {
+			t.Errorf("Expected raycast of %v to be %v, but got %v", point, expected, got)
+		}
+	}
+}
+
+// Ensures that modifying the points returned by a polygon cannot leave its cached bounds stale.
+func TestPolygonPointsCopied(t *testing.T) {
+	p := NewPolygon([]Point{NewPoint(0, 0), NewPoint(0, 1), NewPoint(1, 1), NewPoint(1, 0)})
+	points := p.Points()
+	points[2] = NewPoint(5, 5)
+
+	if !p.Contains(NewPoint(0.5, 0.5)) || p.Contains(NewPoint(2, 2)) {
+		t.Errorf("Expected the polygon to be unchanged, but got %v", p.Points())
+	}
+	if p.Bounds() != NewBoundingBox(NewPoint(0, 0), NewPoint(1, 1)) {
+		t.Errorf("Expected the bounds to be unchanged, but got %v", p.Bounds())
+	}
+}
@@ -0,0 +1,182 @@
+package geo
+
+import (
+	"math"
+	"math/bits"
+)
+
+// preparedEdgesPerBand is the average number of edges NewPreparedPolygon aims to place in each latitude band.
+const preparedEdgesPerBand = 8
+
+// preparedChunk is the number of edges Contains screens by latitude at a time.
+const preparedChunk = 8
+
+// A PreparedPolygon answers containment queries against a Polygon that is queried very frequently,
+// such as a delivery zone checked against every incoming order.
+// It buckets the polygon's edges into equal latitude bands, so Contains only tests
+// the edges whose latitude range overlaps the query point's band rather than every edge.
+// The edges of each band are stored in flat slices of latitudes and longitudes, which Contains
+// screens a chunk of edges at a time without branching.
+// It is immutable once built and safe for concurrent use.
+type PreparedPolygon struct {
+	polygon  Polygon
+	bounds   BoundingBox
+	minLat   float64
+	bandSize float64
+
+	// The edges of band i are those from offsets[i] up to offsets[i+1].  Edge j runs from
+	// lats[2j], lngs[2j] to lats[2j+1], lngs[2j+1], so that screening edges reads only latitudes.
+	offsets []int
+	lats    []float64
+	lngs    []float64
+}
+
+// NewPreparedPolygon returns a new PreparedPolygon over the passed in Polygon.
+// Building it takes time and memory proportional to the number of edges,
+// plus one copy of each edge for every extra band it crosses.
+func NewPreparedPolygon(p Polygon) *PreparedPolygon {
+	pp := &PreparedPolygon{polygon: p, bounds: p.Bounds()}
+	if !p.IsClosed() {
+		return pp
+	}
+
+	points := p.points
+	bands := max(1, len(points)/preparedEdgesPerBand)
+	pp.minLat = pp.bounds.SouthWest().Lat()
+	pp.bandSize = (pp.bounds.NorthEast().Lat() - pp.minLat) / float64(bands)
+	if pp.bandSize == 0 {
+		bands = 1
+	}
+
+	// Count the edges overlapping each band, then fill them in a second pass.
+	counts := make([]int, bands+1)
+	prev := points[len(points)-1]
+	for _, q := range points {
+		lo, hi := pp.bandRange(prev, q, bands)
+		for b := lo; b <= hi; b++ {
+			counts[b+1]++
+		}
+		prev = q
+	}
+	for b := 1; b <= bands; b++ {
+		counts[b] += counts[b-1]
+	}
+
+	pp.offsets = counts
+	pp.lats = make([]float64, 2*counts[bands])
+	pp.lngs = make([]float64, 2*counts[bands])
+	next := append([]int(nil), counts[:bands]...)
+	prev = points[len(points)-1]
+	for _, q := range points {
+		lo, hi := pp.bandRange(prev, q, bands)
+		for b := lo; b <= hi; b++ {
+			j := 2 * next[b]
+			pp.lats[j], pp.lngs[j], pp.lats[j+1], pp.lngs[j+1] = prev.lat, prev.lng, q.lat, q.lng
+			next[b]++
+		}
+		prev = q
+	}
+
+	return pp
+}
+
+// bandRange returns the first and last bands overlapped by the edge from start to end.
+func (pp *PreparedPolygon) bandRange(start, end Point, bands int) (int, int) {
+	lo, hi := math.Min(start.lat, end.lat), math.Max(start.lat, end.lat)
+	return pp.band(lo, bands), pp.band(hi, bands)
+}
+
+// band returns the band containing latitude lat, clamped to the valid bands.
+func (pp *PreparedPolygon) band(lat float64, bands int) int {
+	if pp.bandSize == 0 {
+		return 0
+	}
+	b := int((lat - pp.minLat) / pp.bandSize)
+	return min(max(b, 0), bands-1)
+}
+
+// Polygon returns the Polygon the PreparedPolygon was built from.
+func (pp *PreparedPolygon) Polygon() Polygon {
+	return pp.polygon
+}
+
+// Bounds returns the smallest BoundingBox containing the prepared Polygon.
+func (pp *PreparedPolygon) Bounds() BoundingBox {
+	return pp.bounds
+}
+
+// Contains returns whether or not the prepared Polygon contains the passed in Point.
+// NaN and infinite points are never contained.
+func (pp *PreparedPolygon) Contains(point Point) bool {
+	addMetric(MetricContainsCalls, "prepared_polygon", 1)
+	return pp.contains(point)
+}
+
+func (pp *PreparedPolygon) contains(point Point) bool {
+	if len(pp.lats) == 0 || !isFinite(point.lat) || !isFinite(point.lng) {
+		return false
+	}
+
+	// Points outside of the bounding box cannot be inside, so skip the raycast.
+	if !pp.bounds.Contains(point) {
+		return false
+	}
+
+	bands := len(pp.offsets) - 1
+	b := pp.band(point.lat, bands)
+
+	// Move the point off of both ends of every edge in its band, as Polygon.Contains does for every
+	// vertex, and start over if that carried it into the next band.
+	for {
+		lats, lngs := pp.lats[2*pp.offsets[b]:2*pp.offsets[b+1]], pp.lngs[2*pp.offsets[b]:2*pp.offsets[b+1]]
+		for i, lat := range lats {
+			if lat == point.lat || lngs[i] == point.lng {
+				point = nudgeOffVertex(point, Point{lat: lat, lng: lngs[i]})
+			}
+		}
+		if nb := pp.band(point.lat, bands); nb != b {
+			b = nb
+			continue
+		}
+		return crossings(lats, lngs, point)
+	}
+}
+
+// crossings returns whether the ray cast east from point crosses an odd number of the edges in lats
+// and lngs, laid out as in PreparedPolygon.  Like raycast, it relies on point sharing no latitude with
+// a vertex.  Edges are screened by latitude preparedChunk at a time into a mask of those straddling
+// the ray, so that whole chunks are skipped after one branch and the slope comparison in crossesRay
+// only runs for the edges in the mask.
+func crossings(lats, lngs []float64, point Point) bool {
+	contains := false
+	i := 0
+	for ; i+2*preparedChunk <= len(lats); i += 2 * preparedChunk {
+		chunk := lats[i : i+2*preparedChunk]
+		var straddles uint
+		for k := 0; k < preparedChunk; k++ {
+			straddles |= (b2u(chunk[2*k] < point.lat) ^ b2u(chunk[2*k+1] < point.lat)) << k
+		}
+		for ; straddles != 0; straddles &= straddles - 1 {
+			j := i + 2*bits.TrailingZeros(straddles)
+			if crossesRay(point, Point{lat: lats[j], lng: lngs[j]}, Point{lat: lats[j+1], lng: lngs[j+1]}) {
+				contains = !contains
+			}
+		}
+	}
+
+	for ; i < len(lats); i += 2 {
+		if (lats[i] < point.lat) != (lats[i+1] < point.lat) &&
+			crossesRay(point, Point{lat: lats[i], lng: lngs[i]}, Point{lat: lats[i+1], lng: lngs[i+1]}) {
+			contains = !contains
+		}
+	}
+	return contains
+}
+
+// b2u returns 1 for true and 0 for false, which the compiler turns into a flag read rather than a branch.
+func b2u(b bool) uint {
+	if b {
+		return 1
+	}
+	return 0
+}
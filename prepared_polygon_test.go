@@ -0,0 +1,127 @@
+package geo
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// Ensures that a PreparedPolygon agrees with Polygon.Contains across a grid of points and on every vertex.
+func TestPreparedPolygonMatchesPolygon(t *testing.T) {
+	for _, file := range []string{"test/data/nsw.json", "test/data/act.json", "test/data/brunei.json"} {
+		p, err := polygonFromFile(file)
+		if err != nil {
+			t.Fatal(file, " failed to parse: ", err)
+		}
+
+		pp := NewPreparedPolygon(p)
+		sw, ne := p.Bounds().SouthWest(), p.Bounds().NorthEast()
+		latStep, lngStep := (ne.Lat()-sw.Lat())/37, (ne.Lng()-sw.Lng())/41
+
+		for lat := sw.Lat() - latStep; lat <= ne.Lat()+latStep; lat += latStep {
+			for lng := sw.Lng() - lngStep; lng <= ne.Lng()+lngStep; lng += lngStep {
+				point := NewPoint(lat, lng)
+				if got, expected := pp.Contains(point), p.Contains(point); got != expected {
+					t.Errorf("%s: Expected Contains(%v) to be %v, but got %v", file, point, expected, got)
+				}
+			}
+		}
+
+		for _, point := range p.Points() {
+			if got, expected := pp.Contains(point), p.Contains(point); got != expected {
+				t.Errorf("%s: Expected Contains(%v) on a vertex to be %v, but got %v", file, point, expected, got)
+			}
+		}
+	}
+
+	// Points on the longitude of a vertex whose edge starts, but does not end, in their band.
+	p, points := vertexLongitudePolygon()
+	pp := NewPreparedPolygon(p)
+	for _, point := range points {
+		if got, expected := pp.Contains(point), p.Contains(point); got != expected {
+			t.Errorf("Expected Contains(%v) on a vertex longitude to be %v, but got %v", point, expected, got)
+		}
+	}
+}
+
+// Ensures that a PreparedPolygon handles polygons too small or too flat to need more than one band.
+func TestPreparedPolygonEdgeCases(t *testing.T) {
+	square := NewPolygon([]Point{NewPoint(0, 0), NewPoint(0, 2), NewPoint(2, 2), NewPoint(2, 0)})
+	pp := NewPreparedPolygon(square)
+
+	if !pp.Contains(NewPoint(1, 1)) || pp.Contains(NewPoint(3, 1)) {
+		t.Error("Expected the prepared square to contain only points inside it")
+	}
+
+	if pp.Contains(NewPoint(math.NaN(), 1)) || pp.Contains(NewPoint(1, math.Inf(1))) {
+		t.Error("Expected non-finite points to never be contained")
+	}
+
+	if b := pp.Bounds(); b != square.Bounds() {
+		t.Errorf("Expected bounds %v, but got %v", square.Bounds(), b)
+	}
+
+	if len(pp.Polygon().Points()) != 4 {
+		t.Errorf("Expected the prepared polygon to keep its 4 points, but got %d", len(pp.Polygon().Points()))
+	}
+
+	flat := NewPreparedPolygon(NewPolygon([]Point{NewPoint(1, 0), NewPoint(1, 1), NewPoint(1, 2)}))
+	if flat.Contains(NewPoint(1, 1)) {
+		t.Error("Expected a polygon with no area to contain nothing")
+	}
+
+	open := NewPreparedPolygon(NewPolygon([]Point{NewPoint(0, 0), NewPoint(1, 1)}))
+	if open.Contains(NewPoint(0.5, 0.5)) {
+		t.Error("Expected an unclosed polygon to contain nothing")
+	}
+}
+
+// vertexLongitudePolygon returns a triangle with extra vertices along one leg, whose band layout puts
+// the start but not the end of an edge in the band of points on the longitude of its apex, together
+// with points on the longitudes of its vertices.
+func vertexLongitudePolygon() (Polygon, []Point) {
+	points := []Point{NewPoint(0, 0), NewPoint(0, 10), NewPoint(20, 0)}
+	for lat := 19; lat >= 1; lat-- {
+		points = append(points, NewPoint(float64(lat), 0))
+	}
+
+	var queries []Point
+	for lat := -0.5; lat <= 20.5; lat++ {
+		for lng := 0; lng <= 10; lng++ {
+			queries = append(queries, NewPoint(lat, float64(lng)))
+		}
+	}
+	return NewPolygon(points), queries
+}
+
+// Measures Contains on a prepared polygon of several thousand vertices against Polygon.Contains,
+// which tests every edge.
+func BenchmarkPreparedPolygonContains(b *testing.B) {
+	// A ragged coastline of 4000 vertices around a circle, wandering in and out by up to a third of its radius.
+	r := rand.New(rand.NewSource(1))
+	points := make([]Point, 4000)
+	radius := 1.5
+	for i := range points {
+		angle := 2 * math.Pi * float64(i) / float64(len(points))
+		radius = min(max(radius+(r.Float64()-0.5)*0.05, 1), 2)
+		points[i] = NewPoint(radius*math.Sin(angle), radius*math.Cos(angle))
+	}
+	p := NewPolygon(points)
+	pp := NewPreparedPolygon(p)
+
+	queries := make([]Point, 1024)
+	for i := range queries {
+		queries[i] = NewPoint(r.Float64()*4-2, r.Float64()*4-2)
+	}
+
+	b.Run("Polygon", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			p.Contains(queries[i%len(queries)])
+		}
+	})
+	b.Run("PreparedPolygon", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			pp.Contains(queries[i%len(queries)])
+		}
+	})
+}
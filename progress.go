@@ -0,0 +1,46 @@
+package geo
+
+import "sync"
+
+// A ProgressFunc receives the number of items a batch operation has processed so far out of its total.
+// Calls for one operation are never concurrent and done never decreases.  Once the operation
+// completes, the last call has done equal to total.
+type ProgressFunc func(done, total int)
+
+// BatchOptions controls the batch operations of this package, such as SpatialJoinWith,
+// NewPolygonIndexWith, BuildLazyPolygonIndexWith and BatchGeocodeWith.
+type BatchOptions struct {
+	// Progress, if set, receives the progress of the operation it is passed to.  It is called
+	// periodically rather than after every item, so it should be cheap.
+	Progress ProgressFunc
+}
+
+// progress counts the items a batch operation has processed and reports them to
+// its ProgressFunc.  A nil *progress reports nothing.
+type progress struct {
+	f     ProgressFunc
+	total int
+
+	mu   sync.Mutex
+	done int
+}
+
+// newProgress returns a progress reporting to f for an operation over total items, or nil if f is nil.
+func newProgress(f ProgressFunc, total int) *progress {
+	if f == nil {
+		return nil
+	}
+	return &progress{f: f, total: total}
+}
+
+// add records that n more items have been processed.
+func (p *progress) add(n int) {
+	if p == nil || n == 0 {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.done += n
+	p.f(p.done, p.total)
+}
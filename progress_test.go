@@ -0,0 +1,82 @@
+package geo
+
+import (
+	"context"
+	"testing"
+)
+
+// recordProgress returns options reporting progress to the returned slice of done counts,
+// failing t if the total changes or done ever decreases.
+func recordProgress(t *testing.T, total int) (BatchOptions, *[]int) {
+	var calls []int
+	opts := BatchOptions{Progress: func(done, n int) {
+		if n != total {
+			t.Errorf("Expected a total of %d, but got %d", total, n)
+		}
+		if len(calls) > 0 && done < calls[len(calls)-1] {
+			t.Errorf("Expected progress to never decrease, but went from %d to %d", calls[len(calls)-1], done)
+		}
+		calls = append(calls, done)
+	}}
+	return opts, &calls
+}
+
+// Ensures that the batch operations report progress up to their total.
+func TestProgress(t *testing.T) {
+	square := NewPolygon([]Point{NewPoint(0, 0), NewPoint(0, 2), NewPoint(2, 2), NewPoint(2, 0)})
+	points := make([]Point, 10*ctxCheckInterval+7)
+	for i := range points {
+		points[i] = NewPoint(float64(i%5), float64(i%3))
+	}
+
+	for _, workers := range []int{1, 4} {
+		opts, calls := recordProgress(t, len(points))
+		if err := parallelForCtx(context.Background(), len(points), workers, opts.Progress, func(int) {}); err != nil {
+			t.Fatal(err)
+		}
+		if len(*calls) < 2 || (*calls)[len(*calls)-1] != len(points) {
+			t.Errorf("Expected periodic progress ending at %d with %d workers, but got %v", len(points), workers, *calls)
+		}
+	}
+
+	opts, calls := recordProgress(t, len(points))
+	if _, err := SpatialJoinWith(context.Background(), points, []Polygon{square}, opts); err != nil {
+		t.Fatal(err)
+	}
+	if len(*calls) == 0 || (*calls)[len(*calls)-1] != len(points) {
+		t.Errorf("Expected the spatial join's progress to end at %d, but got %v", len(points), *calls)
+	}
+
+	opts, calls = recordProgress(t, 3)
+	if _, err := NewPolygonIndexWith(context.Background(), map[string]Polygon{"a": square, "b": square, "c": square}, opts); err != nil {
+		t.Fatal(err)
+	}
+	if len(*calls) != 3 || (*calls)[2] != 3 {
+		t.Errorf("Expected progress after each of 3 polygons, but got %v", *calls)
+	}
+
+	// Each geocode runs a spatial join of its own, whose progress must not reach the batch's.
+	g := geocoderFuncs{
+		geocode: func(ctx context.Context, address string) (Point, error) {
+			_, err := SpatialJoinCtx(ctx, points, []Polygon{square})
+			return Point{}, err
+		},
+	}
+	opts, calls = recordProgress(t, 5)
+	if _, err := BatchGeocodeWith(context.Background(), g, make([]string, 5), 1, opts); err != nil {
+		t.Fatal(err)
+	}
+	if len(*calls) != 5 || (*calls)[4] != 5 {
+		t.Errorf("Expected progress after each of 5 addresses, but got %v", *calls)
+	}
+}
+
+// Ensures that operations without a ProgressFunc report nothing.
+func TestProgressNil(t *testing.T) {
+	if prog := newProgress(nil, 10); prog != nil {
+		t.Errorf("Expected no progress without a ProgressFunc, but got %v", prog)
+	}
+
+	var prog *progress
+	prog.add(1)
+}
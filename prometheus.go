@@ -0,0 +1,193 @@
+package geo
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultPrometheusBuckets are the upper bounds in seconds of the histogram buckets of a
+// PrometheusMetrics created without any.
+var DefaultPrometheusBuckets = []float64{0.0001, 0.0005, 0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 5, 10}
+
+// A PrometheusMetrics is a Metrics that keeps every measurement in memory and serves them
+// in the Prometheus text exposition format, so the package's metrics can be scraped without
+// depending on the Prometheus client library.  Install it with SetMetrics and mount it as the
+// handler of a metrics endpoint, or write it into an existing exposition with WriteTo.
+// It is safe for concurrent use.
+type PrometheusMetrics struct {
+	buckets []float64
+
+	mu         sync.Mutex
+	counters   map[metricKey]float64
+	histograms map[metricKey]*prometheusHistogram
+}
+
+// metricKey identifies the measurements of a metric for one label value.
+type metricKey struct {
+	name, label string
+}
+
+// prometheusHistogram holds the observations of a histogram for one label value.
+type prometheusHistogram struct {
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+var (
+	_ Metrics      = (*PrometheusMetrics)(nil)
+	_ http.Handler = (*PrometheusMetrics)(nil)
+)
+
+// NewPrometheusMetrics returns a new PrometheusMetrics whose histograms count observations into
+// buckets with the passed in upper bounds, or DefaultPrometheusBuckets when none are passed.
+func NewPrometheusMetrics(buckets ...float64) *PrometheusMetrics {
+	if len(buckets) == 0 {
+		buckets = DefaultPrometheusBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+
+	return &PrometheusMetrics{
+		buckets:    buckets,
+		counters:   make(map[metricKey]float64),
+		histograms: make(map[metricKey]*prometheusHistogram),
+	}
+}
+
+// Add adds delta to the counter name for the passed in label value.
+func (p *PrometheusMetrics) Add(name string, label string, delta float64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.counters[metricKey{name, label}] += delta
+}
+
+// Observe records value in the histogram name for the passed in label value.
+func (p *PrometheusMetrics) Observe(name string, label string, value float64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key := metricKey{name, label}
+	h := p.histograms[key]
+	if h == nil {
+		h = &prometheusHistogram{counts: make([]uint64, len(p.buckets))}
+		p.histograms[key] = h
+	}
+	if i := sort.SearchFloat64s(p.buckets, value); i < len(h.counts) {
+		h.counts[i]++
+	}
+	h.sum += value
+	h.count++
+}
+
+// ServeHTTP writes the metrics in the Prometheus text exposition format.
+func (p *PrometheusMetrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	p.WriteTo(w)
+}
+
+// WriteTo writes the metrics to w in the Prometheus text exposition format, ordered by name and label.
+func (p *PrometheusMetrics) WriteTo(w io.Writer) (int64, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	cw := &countingWriter{w: w}
+	bw := bufio.NewWriter(cw)
+
+	type family struct {
+		histogram bool
+		keys      []metricKey
+	}
+	families := make(map[string]*family)
+	for key := range p.counters {
+		if families[key.name] == nil {
+			families[key.name] = &family{}
+		}
+		families[key.name].keys = append(families[key.name].keys, key)
+	}
+	for key := range p.histograms {
+		if families[key.name] == nil {
+			families[key.name] = &family{histogram: true}
+		}
+		families[key.name].keys = append(families[key.name].keys, key)
+	}
+	names := make([]string, 0, len(families))
+	for name := range families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		f := families[name]
+		sort.Slice(f.keys, func(i, j int) bool { return f.keys[i].label < f.keys[j].label })
+
+		labelName := "label"
+		if meta, ok := metricLabels[name]; ok {
+			labelName = meta.label
+			bw.WriteString("# HELP " + name + " " + meta.help + "\n")
+		}
+		if !f.histogram {
+			bw.WriteString("# TYPE " + name + " counter\n")
+			for _, key := range f.keys {
+				bw.WriteString(name + "{" + labelName + "=" + quotePrometheusLabel(key.label) + "} " + formatPrometheusValue(p.counters[key]) + "\n")
+			}
+			continue
+		}
+
+		bw.WriteString("# TYPE " + name + " histogram\n")
+		for _, key := range f.keys {
+			h := p.histograms[key]
+			labels := labelName + "=" + quotePrometheusLabel(key.label)
+			var cumulative uint64
+			for i, bound := range p.buckets {
+				cumulative += h.counts[i]
+				bw.WriteString(name + "_bucket{" + labels + ",le=\"" + formatPrometheusValue(bound) + "\"} " + strconv.FormatUint(cumulative, 10) + "\n")
+			}
+			bw.WriteString(name + "_bucket{" + labels + ",le=\"+Inf\"} " + strconv.FormatUint(h.count, 10) + "\n")
+			bw.WriteString(name + "_sum{" + labels + "} " + formatPrometheusValue(h.sum) + "\n")
+			bw.WriteString(name + "_count{" + labels + "} " + strconv.FormatUint(h.count, 10) + "\n")
+		}
+	}
+
+	err := bw.Flush()
+	return cw.n, err
+}
+
+// countingWriter counts the bytes written through it.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(b []byte) (int, error) {
+	n, err := c.w.Write(b)
+	c.n += int64(n)
+	return n, err
+}
+
+var prometheusLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// quotePrometheusLabel quotes a label value, escaping it as the exposition format requires.
+func quotePrometheusLabel(v string) string {
+	return `"` + prometheusLabelEscaper.Replace(v) + `"`
+}
+
+// formatPrometheusValue formats a sample value as the exposition format requires.
+func formatPrometheusValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	default:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+}
@@ -0,0 +1,54 @@
+package geo
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Ensures that the Prometheus adapter serves counters and cumulative histograms in the text exposition format.
+func TestPrometheusMetrics(t *testing.T) {
+	p := NewPrometheusMetrics(0.01, 0.001)
+	p.Add(MetricGeofenceEvents, "enter", 2)
+	p.Add(MetricGeofenceEvents, "exit", 1)
+	p.Add(MetricGeofenceEvents, "enter", 1)
+	p.Add("custom_total", "a \"quoted\"\nvalue", 1)
+	p.Observe(MetricIndexQueryDuration, "geofence", 0.0005)
+	p.Observe(MetricIndexQueryDuration, "geofence", 0.005)
+	p.Observe(MetricIndexQueryDuration, "geofence", 0.5)
+
+	expected := `# TYPE custom_total counter
+custom_total{label="a \"quoted\"\nvalue"} 1
+# HELP geo_geofence_events_total Geofence events by type.
+# TYPE geo_geofence_events_total counter
+geo_geofence_events_total{type="enter"} 3
+geo_geofence_events_total{type="exit"} 1
+# HELP geo_index_query_duration_seconds Seconds taken by point queries of spatial indexes.
+# TYPE geo_index_query_duration_seconds histogram
+geo_index_query_duration_seconds_bucket{index="geofence",le="0.001"} 1
+geo_index_query_duration_seconds_bucket{index="geofence",le="0.01"} 2
+geo_index_query_duration_seconds_bucket{index="geofence",le="+Inf"} 3
+geo_index_query_duration_seconds_sum{index="geofence"} 0.5055
+geo_index_query_duration_seconds_count{index="geofence"} 3
+`
+
+	var sb strings.Builder
+	if n, err := p.WriteTo(&sb); err != nil || n != int64(sb.Len()) {
+		t.Errorf("Expected %d bytes to be written, but got %d, %v", sb.Len(), n, err)
+	}
+	if sb.String() != expected {
+		t.Errorf("Expected:\n%s\nbut got:\n%s", expected, sb.String())
+	}
+
+	w := httptest.NewRecorder()
+	p.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	body, _ := io.ReadAll(w.Result().Body)
+	if string(body) != expected || !strings.HasPrefix(w.Header().Get("Content-Type"), "text/plain; version=0.0.4") {
+		t.Errorf("Expected the exposition to be served as text, but got %s:\n%s", w.Header().Get("Content-Type"), body)
+	}
+
+	if buckets := NewPrometheusMetrics().buckets; len(buckets) != len(DefaultPrometheusBuckets) {
+		t.Errorf("Expected the default buckets, but got %v", buckets)
+	}
+}
@@ -0,0 +1,56 @@
+package geo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Quadkey returns the Bing Maps quadkey of the Tile.
+// Each digit selects a quadrant one zoom level down, so the key has t.Z digits
+// and the quadkey of a parent tile is a prefix of the quadkeys of its children.
+func (t Tile) Quadkey() string {
+	var sb strings.Builder
+	sb.Grow(t.Z)
+
+	for i := t.Z; i > 0; i-- {
+		digit := byte('0')
+		mask := 1 << uint(i-1)
+		if t.X&mask != 0 {
+			digit++
+		}
+		if t.Y&mask != 0 {
+			digit += 2
+		}
+		sb.WriteByte(digit)
+	}
+
+	return sb.String()
+}
+
+// TileFromQuadkey returns the Tile described by the passed in Bing Maps quadkey.
+func TileFromQuadkey(quadkey string) (Tile, error) {
+	t := Tile{Z: len(quadkey)}
+
+	for i := 0; i < len(quadkey); i++ {
+		mask := 1 << uint(t.Z-i-1)
+		switch quadkey[i] {
+		case '0':
+		case '1':
+			t.X |= mask
+		case '2':
+			t.Y |= mask
+		case '3':
+			t.X |= mask
+			t.Y |= mask
+		default:
+			return Tile{}, fmt.Errorf("%w: invalid quadkey digit %q in %q", ErrInvalidFormat, quadkey[i], quadkey)
+		}
+	}
+
+	return t, nil
+}
+
+// QuadkeyAt returns the quadkey of the Tile containing Point p at the passed in zoom level.
+func QuadkeyAt(p Point, zoom int) string {
+	return TileAt(p, zoom).Quadkey()
+}
@@ -0,0 +1,42 @@
+package geo
+
+import (
+	"strings"
+	"testing"
+)
+
+// Ensures that tiles encode to the quadkeys documented by Bing Maps.
+func TestQuadkey(t *testing.T) {
+	if q := NewTile(3, 5, 3).Quadkey(); q != "213" {
+		t.Errorf("Expected tile 3/3/5 to have quadkey 213, but got %s instead", q)
+	}
+
+	if q := NewTile(0, 0, 0).Quadkey(); q != "" {
+		t.Errorf("Expected the root tile to have an empty quadkey, but got %s instead", q)
+	}
+}
+
+// Ensures that quadkeys decode back to the tiles they were encoded from.
+func TestTileFromQuadkey(t *testing.T) {
+	tile := TileAt(NewPoint(52.52, 13.405), 17)
+	back, err := TileFromQuadkey(tile.Quadkey())
+	if err != nil {
+		t.Fatalf("Should not encounter an error when decoding a quadkey, but got %v", err)
+	}
+
+	if back != tile {
+		t.Errorf("Expected quadkey %s to decode to %v, but got %v instead", tile.Quadkey(), tile, back)
+	}
+
+	if _, err := TileFromQuadkey("1204"); err == nil {
+		t.Error("Expected an error when decoding a quadkey with an invalid digit")
+	}
+}
+
+// Ensures that the quadkey of a parent is a prefix of the quadkey of its children.
+func TestQuadkeyAt(t *testing.T) {
+	p := NewPoint(47.6062, -122.3321)
+	if !strings.HasPrefix(QuadkeyAt(p, 12), QuadkeyAt(p, 6)) {
+		t.Errorf("Expected %s to be a prefix of %s", QuadkeyAt(p, 6), QuadkeyAt(p, 12))
+	}
+}
@@ -0,0 +1,191 @@
+package geo
+
+import "fmt"
+
+// DefaultQuadtreeCapacity is the number of points a Quadtree node holds before it
+// splits, used when a capacity below 1 is passed to NewQuadtree.
+const DefaultQuadtreeCapacity = 8
+
+// quadtreeMaxDepth bounds the depth of a Quadtree, so that many points
+// at the same location cannot split nodes forever.
+const quadtreeMaxDepth = 24
+
+// A QuadtreeItem is a point stored in a Quadtree together with its value.
+type QuadtreeItem[T any] struct {
+	Point Point
+	Value T
+}
+
+// A Quadtree is a point index that recursively divides the world into quadrants.
+// It is cheaper to update than an RTree, which suits frequently moving points such as
+// live vehicle positions.  It is not safe for concurrent modification.
+type Quadtree[T any] struct {
+	root     quadtreeNode[T]
+	size     int
+	capacity int
+}
+
+type quadtreeNode[T any] struct {
+	bounds   BoundingBox
+	items    []QuadtreeItem[T]
+	children *[4]quadtreeNode[T]
+}
+
+// NewQuadtree returns an empty Quadtree whose nodes split once they hold more than capacity points.
+func NewQuadtree[T any](capacity int) *Quadtree[T] {
+	if capacity < 1 {
+		capacity = DefaultQuadtreeCapacity
+	}
+
+	return &Quadtree[T]{
+		root:     quadtreeNode[T]{bounds: NewBoundingBox(NewPoint(-90, -180), NewPoint(90, 180))},
+		capacity: capacity,
+	}
+}
+
+// Len returns the number of points in the tree.
+func (q *Quadtree[T]) Len() int {
+	return q.size
+}
+
+// Insert adds Point p with its value to the tree, returning an error if p is not a valid coordinate.
+func (q *Quadtree[T]) Insert(p Point, value T) error {
+	if _, err := NewPointValidated(p.lat, p.lng); err != nil {
+		return fmt.Errorf("cannot insert %v: %w", p, err)
+	}
+
+	q.root.insert(QuadtreeItem[T]{Point: p, Value: value}, q.capacity, 0)
+	q.size++
+	return nil
+}
+
+func (n *quadtreeNode[T]) insert(item QuadtreeItem[T], capacity int, depth int) {
+	if n.children != nil {
+		n.children[n.quadrant(item.Point)].insert(item, capacity, depth+1)
+		return
+	}
+
+	n.items = append(n.items, item)
+	if len(n.items) <= capacity || depth >= quadtreeMaxDepth {
+		return
+	}
+
+	center := n.bounds.Center()
+	sw, ne := n.bounds.sw, n.bounds.ne
+	n.children = &[4]quadtreeNode[T]{
+		{bounds: NewBoundingBox(sw, center)},
+		{bounds: NewBoundingBox(NewPoint(sw.lat, center.lng), NewPoint(center.lat, ne.lng))},
+		{bounds: NewBoundingBox(NewPoint(center.lat, sw.lng), NewPoint(ne.lat, center.lng))},
+		{bounds: NewBoundingBox(center, ne)},
+	}
+
+	items := n.items
+	n.items = nil
+	for _, it := range items {
+		n.children[n.quadrant(it.Point)].insert(it, capacity, depth+1)
+	}
+}
+
+// quadrant returns the index of the child of n holding Point p:
+// 0 south-west, 1 south-east, 2 north-west and 3 north-east.
+func (n *quadtreeNode[T]) quadrant(p Point) int {
+	center := n.bounds.Center()
+	i := 0
+	if p.lng >= center.lng {
+		i |= 1
+	}
+	if p.lat >= center.lat {
+		i |= 2
+	}
+	return i
+}
+
+// Remove deletes one item at Point p for which match returns true, reporting whether one was removed.
+func (q *Quadtree[T]) Remove(p Point, match func(value T) bool) bool {
+	if !q.root.remove(p, match, q.capacity) {
+		return false
+	}
+	q.size--
+	return true
+}
+
+func (n *quadtreeNode[T]) remove(p Point, match func(value T) bool, capacity int) bool {
+	if n.children == nil {
+		for i, it := range n.items {
+			if it.Point == p && match(it.Value) {
+				n.items = append(n.items[:i], n.items[i+1:]...)
+				return true
+			}
+		}
+		return false
+	}
+
+	if !n.children[n.quadrant(p)].remove(p, match, capacity) {
+		return false
+	}
+
+	// Merge the children back once they fit in a single node again.
+	if n.count() <= capacity {
+		var items []QuadtreeItem[T]
+		n.collect(&items)
+		n.children, n.items = nil, items
+	}
+	return true
+}
+
+func (n *quadtreeNode[T]) count() int {
+	if n.children == nil {
+		return len(n.items)
+	}
+
+	total := 0
+	for i := range n.children {
+		total += n.children[i].count()
+	}
+	return total
+}
+
+func (n *quadtreeNode[T]) collect(items *[]QuadtreeItem[T]) {
+	*items = append(*items, n.items...)
+	if n.children != nil {
+		for i := range n.children {
+			n.children[i].collect(items)
+		}
+	}
+}
+
+// SearchFunc calls f with every item inside BoundingBox b, stopping early if f returns false.
+func (q *Quadtree[T]) SearchFunc(b BoundingBox, f func(item QuadtreeItem[T]) bool) {
+	q.root.search(b, f)
+}
+
+func (n *quadtreeNode[T]) search(b BoundingBox, f func(item QuadtreeItem[T]) bool) bool {
+	if !n.bounds.Intersects(b) {
+		return true
+	}
+
+	for _, it := range n.items {
+		if b.Contains(it.Point) && !f(it) {
+			return false
+		}
+	}
+
+	if n.children != nil {
+		for i := range n.children {
+			if !n.children[i].search(b, f) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Search returns every item inside BoundingBox b.
+func (q *Quadtree[T]) Search(b BoundingBox) []QuadtreeItem[T] {
+	var items []QuadtreeItem[T]
+	q.SearchFunc(b, func(item QuadtreeItem[T]) bool {
+		items = append(items, item)
+		return true
+	})
+	return items
+}
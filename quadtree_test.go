@@ -0,0 +1,74 @@
+package geo
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// Ensures that quadtree queries match a brute force scan as points are inserted and removed.
+func TestQuadtree(t *testing.T) {
+	r := rand.New(rand.NewSource(4))
+	q := NewQuadtree[int](4)
+
+	points := make([]Point, 3000)
+	for i := range points {
+		points[i] = NewPoint(r.Float64()*180-90, r.Float64()*360-180)
+		if i%10 == 0 {
+			// Stack some points on the same location to exercise the depth limit.
+			points[i] = NewPoint(12.5, 45.5)
+		}
+		if err := q.Insert(points[i], i); err != nil {
+			t.Fatalf("Should not encounter an error when inserting %v, but got %v", points[i], err)
+		}
+	}
+
+	check := func(removed func(i int) bool) {
+		for _, b := range []BoundingBox{
+			NewBoundingBox(NewPoint(10, 40), NewPoint(20, 50)),
+			NewBoundingBox(NewPoint(-30, 170), NewPoint(30, -170)),
+			NewBoundingBox(NewPoint(-90, -180), NewPoint(90, 180)),
+		} {
+			expected := 0
+			for i, p := range points {
+				if !removed(i) && b.Contains(p) {
+					expected++
+				}
+			}
+
+			found := q.Search(b)
+			for _, it := range found {
+				if removed(it.Value) || it.Point != points[it.Value] {
+					t.Errorf("Unexpected item %v", it)
+				}
+			}
+			if len(found) != expected {
+				t.Errorf("Expected %d points in %v, but got %d", expected, b, len(found))
+			}
+		}
+	}
+
+	check(func(int) bool { return false })
+
+	for i, p := range points {
+		if i%3 != 0 {
+			continue
+		}
+		i := i
+		if !q.Remove(p, func(v int) bool { return v == i }) {
+			t.Fatalf("Expected to remove point %d", i)
+		}
+	}
+
+	check(func(i int) bool { return i%3 == 0 })
+	if q.Len() != 2000 {
+		t.Errorf("Expected 2000 points after removing, but got %d", q.Len())
+	}
+
+	if q.Remove(NewPoint(1, 1), func(int) bool { return true }) {
+		t.Error("Expected no removal of a point that is not in the tree")
+	}
+
+	if err := q.Insert(NewPoint(95, 0), 0); err == nil {
+		t.Error("Expected an error when inserting an invalid point")
+	}
+}
@@ -0,0 +1,95 @@
+package geo
+
+import (
+	"math"
+	"slices"
+)
+
+// RasterizeOptions controls how RasterizeWith decides which pixels a Polygon covers.
+type RasterizeOptions struct {
+	// AllTouched sets every pixel the Polygon touches, rather than only those whose center it contains.
+	AllTouched bool
+}
+
+// Rasterize returns a mask of width by height pixels over bounds, set where the Polygon contains the
+// center of the pixel, using the default RasterizeOptions.  Pixels run in rows from north to south
+// and columns from west to east, the pixel at column x and row y being at index y*width+x, as in
+// images.  Like Contains, the last Point of the Polygon is joined to the first, and the mask is
+// empty unless the Polygon is closed.
+func Rasterize(p Polygon, bounds BoundingBox, width int, height int) []bool {
+	return RasterizeWith(p, bounds, width, height, RasterizeOptions{})
+}
+
+// RasterizeWith returns a mask of width by height pixels over bounds covered by the Polygon, laid out as
+// by Rasterize, according to the passed in options.  Pixels span equal steps of latitude and longitude.
+func RasterizeWith(p Polygon, bounds BoundingBox, width int, height int, opts RasterizeOptions) []bool {
+	if width <= 0 || height <= 0 {
+		return nil
+	}
+	mask := make([]bool, width*height)
+	if !p.IsClosed() {
+		return mask
+	}
+
+	// Place the vertices in pixels, unwrapping longitudes east of the antimeridian when bounds cross it.
+	spanLng := bounds.ne.lng - bounds.sw.lng
+	if bounds.CrossesAntimeridian() {
+		spanLng += 360
+	}
+	xs, ys := make([]float64, len(p.points)), make([]float64, len(p.points))
+	for i, v := range p.points {
+		x := v.lng - bounds.sw.lng
+		if bounds.CrossesAntimeridian() && x < 0 {
+			x += 360
+		}
+		xs[i] = x / spanLng * float64(width)
+		ys[i] = (bounds.ne.lat - v.lat) / (bounds.ne.lat - bounds.sw.lat) * float64(height)
+	}
+
+	// Fill the pixels whose centers lie between pairs of crossings of each row, by the even-odd rule.
+	var crossings []float64
+	for row := range height {
+		y := float64(row) + 0.5
+		crossings = crossings[:0]
+		for i, j := 0, len(xs)-1; i < len(xs); j, i = i, i+1 {
+			if (ys[j] > y) != (ys[i] > y) {
+				crossings = append(crossings, xs[j]+(y-ys[j])*(xs[i]-xs[j])/(ys[i]-ys[j]))
+			}
+		}
+		slices.Sort(crossings)
+		for k := 0; k+1 < len(crossings); k += 2 {
+			first := max(int(math.Ceil(crossings[k]-0.5)), 0)
+			last := min(int(math.Ceil(crossings[k+1]-0.5)), width)
+			for col := first; col < last; col++ {
+				mask[row*width+col] = true
+			}
+		}
+	}
+
+	// The pixels touched without their centers being covered are those the outline passes through.
+	if opts.AllTouched {
+		for i, j := 0, len(xs)-1; i < len(xs); j, i = i, i+1 {
+			rasterizeSegment(mask, width, height, xs[j], ys[j], xs[i], ys[i])
+		}
+	}
+	return mask
+}
+
+// rasterizeSegment sets every pixel of mask the segment from x1, y1 to x2, y2 passes through,
+// by working out the columns it spans within each row.
+func rasterizeSegment(mask []bool, width int, height int, x1 float64, y1 float64, x2 float64, y2 float64) {
+	top, bottom := math.Min(y1, y2), math.Max(y1, y2)
+	for row := max(int(math.Floor(top)), 0); row <= min(int(math.Floor(bottom)), height-1); row++ {
+		xa, xb := x1, x2
+		if y1 != y2 {
+			at := func(y float64) float64 {
+				return x1 + (y-y1)*(x2-x1)/(y2-y1)
+			}
+			xa, xb = at(math.Max(top, float64(row))), at(math.Min(bottom, float64(row+1)))
+		}
+		left, right := math.Min(xa, xb), math.Max(xa, xb)
+		for col := max(int(math.Floor(left)), 0); col <= min(int(math.Floor(right)), width-1); col++ {
+			mask[row*width+col] = true
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package geo
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// Ensures that pixels are set where the Polygon contains their centers.
+func TestRasterize(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	bounds := NewBoundingBox(NewPoint(0, 0), NewPoint(1, 2))
+	points := make([]Point, 12)
+	for i := range points {
+		points[i] = NewPoint(r.Float64()*1.2-0.1, r.Float64()*2.4-0.2)
+	}
+	polygon := NewPolygon(append(points, points[0]))
+
+	mask := Rasterize(polygon, bounds, 40, 20)
+	g := NewGrid(bounds, 20, 40)
+	var set int
+	for row := range 20 {
+		for col := range 40 {
+			if mask[row*40+col] {
+				set++
+			}
+			if c := g.CellCenter(row, col); mask[row*40+col] != polygon.Contains(c) {
+				t.Errorf("Expected pixel %d,%d at %v set to be %v", col, row, c, polygon.Contains(c))
+			}
+		}
+	}
+	if set == 0 {
+		t.Error("Expected pixels set")
+	}
+
+	if mask := Rasterize(NewPolygon(points[:2]), bounds, 4, 2); len(mask) != 8 || mask[0] || mask[5] {
+		t.Errorf("Expected an empty mask for an unclosed polygon, but got %v", mask)
+	}
+	if mask := Rasterize(polygon, bounds, 0, 2); mask != nil {
+		t.Errorf("Expected no mask without pixels, but got %v", mask)
+	}
+}
+
+// Ensures that with AllTouched every pixel the Polygon overlaps is set.
+func TestRasterizeAllTouched(t *testing.T) {
+	bounds := NewBoundingBox(NewPoint(0, 0), NewPoint(4, 4))
+
+	// A sliver within a single pixel covers no center.
+	sliver := NewPolygon([]Point{NewPoint(2.2, 1.2), NewPoint(2.3, 1.2), NewPoint(2.3, 1.8), NewPoint(2.2, 1.2)})
+	if mask := Rasterize(sliver, bounds, 4, 4); mask[1*4+1] {
+		t.Errorf("Expected the sliver to cover no pixel, but got %v", mask)
+	}
+	mask := RasterizeWith(sliver, bounds, 4, 4, RasterizeOptions{AllTouched: true})
+	for i, set := range mask {
+		if set != (i == 1*4+1) {
+			t.Errorf("Expected only pixel 1,1 set, but got %v", mask)
+			break
+		}
+	}
+
+	// A diagonal triangle touches the pixels along its edges as well as those it covers the centers of.
+	triangle := NewPolygon([]Point{NewPoint(0.1, 0.1), NewPoint(0.1, 3.9), NewPoint(3.7, 3.9), NewPoint(0.1, 0.1)})
+	centers := Rasterize(triangle, bounds, 4, 4)
+	touched := RasterizeWith(triangle, bounds, 4, 4, RasterizeOptions{AllTouched: true})
+	for i := range centers {
+		if centers[i] && !touched[i] {
+			t.Errorf("Expected pixel %d touched as its center is covered", i)
+		}
+		row, col := i/4, i%4
+		if expected := col >= 3-row; touched[i] != expected {
+			t.Errorf("Expected pixel %d,%d touched to be %v", col, row, expected)
+		}
+	}
+}
+
+// Ensures that bounds across the antimeridian are rasterized.
+func TestRasterizeAntimeridian(t *testing.T) {
+	bounds := NewBoundingBox(NewPoint(-1, 178), NewPoint(1, -178))
+	polygon := NewPolygon([]Point{NewPoint(-1, 179), NewPoint(1, 179), NewPoint(1, -179), NewPoint(-1, -179), NewPoint(-1, 179)})
+
+	mask := Rasterize(polygon, bounds, 4, 1)
+	if mask[0] || !mask[1] || !mask[2] || mask[3] {
+		t.Errorf("Expected the middle pixels set, but got %v", mask)
+	}
+}
@@ -0,0 +1,237 @@
+package geo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// RedisClient executes a single Redis command and returns its raw reply.
+// It is intentionally small so that any Redis driver can be adapted to it.
+// For example, with go-redis:
+//
+//	client := geo.RedisClientFunc(func(ctx context.Context, args ...interface{}) (interface{}, error) {
+//		return rdb.Do(ctx, args...).Result()
+//	})
+type RedisClient interface {
+	Do(ctx context.Context, args ...interface{}) (interface{}, error)
+}
+
+// RedisClientFunc adapts an ordinary function to the RedisClient interface.
+type RedisClientFunc func(ctx context.Context, args ...interface{}) (interface{}, error)
+
+// Do calls f(ctx, args...).
+func (f RedisClientFunc) Do(ctx context.Context, args ...interface{}) (interface{}, error) {
+	return f(ctx, args...)
+}
+
+// RedisGeoResult is a single member returned from a RedisGeoStore search.
+type RedisGeoResult struct {
+	Member   string
+	Point    Point
+	Distance Distance
+}
+
+// RedisGeoStore stores named Points in a Redis sorted set with GEOADD
+// and answers proximity queries with GEOSEARCH.
+// Note that Redis only accepts latitudes between -85.05112878 and 85.05112878.
+type RedisGeoStore struct {
+	client RedisClient
+	key    string
+}
+
+// NewRedisGeoStore returns a new RedisGeoStore that keeps its members
+// in the sorted set stored at key.
+func NewRedisGeoStore(client RedisClient, key string) *RedisGeoStore {
+	return &RedisGeoStore{client: client, key: key}
+}
+
+// Key returns the Redis key the store writes to.
+func (s *RedisGeoStore) Key() string {
+	return s.key
+}
+
+// Add stores the Point p under the passed in member name,
+// replacing any previous position of that member.
+func (s *RedisGeoStore) Add(ctx context.Context, member string, p Point) error {
+	return s.AddMany(ctx, map[string]Point{member: p})
+}
+
+// AddMany stores all of the passed in members with a single GEOADD command.
+func (s *RedisGeoStore) AddMany(ctx context.Context, members map[string]Point) error {
+	if len(members) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(members))
+	for name := range members {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	args := make([]interface{}, 0, 2+3*len(names))
+	args = append(args, "GEOADD", s.key)
+	for _, name := range names {
+		p := members[name]
+		args = append(args, p.lng, p.lat, name)
+	}
+
+	if _, err := s.client.Do(ctx, args...); err != nil {
+		return fmt.Errorf("redis GEOADD %s: %w", s.key, err)
+	}
+
+	return nil
+}
+
+// Remove deletes the passed in member from the store.
+func (s *RedisGeoStore) Remove(ctx context.Context, member string) error {
+	if _, err := s.client.Do(ctx, "ZREM", s.key, member); err != nil {
+		return fmt.Errorf("redis ZREM %s: %w", s.key, err)
+	}
+
+	return nil
+}
+
+// Position returns the stored Point of member.
+// The boolean result is false if the member does not exist.
+func (s *RedisGeoStore) Position(ctx context.Context, member string) (Point, bool, error) {
+	reply, err := s.client.Do(ctx, "GEOPOS", s.key, member)
+	if err != nil {
+		return Point{}, false, fmt.Errorf("redis GEOPOS %s: %w", s.key, err)
+	}
+
+	positions, ok := reply.([]interface{})
+	if !ok || len(positions) != 1 {
+		return Point{}, false, fmt.Errorf("%w: GEOPOS %v", ErrUnexpectedReply, reply)
+	}
+
+	if positions[0] == nil {
+		return Point{}, false, nil
+	}
+
+	p, err := redisPoint(positions[0])
+	if err != nil {
+		return Point{}, false, err
+	}
+
+	return p, true, nil
+}
+
+// SearchRadius returns the members within radius of center, nearest first.
+// A limit greater than zero caps the number of results.
+func (s *RedisGeoStore) SearchRadius(ctx context.Context, center Point, radius Distance, limit int) ([]RedisGeoResult, error) {
+	return s.search(ctx, center, limit, "BYRADIUS", radius.Meters(), "m")
+}
+
+// SearchBox returns the members within an axis-aligned box of the passed in
+// width and height centered on center, nearest first.
+// A limit greater than zero caps the number of results.
+func (s *RedisGeoStore) SearchBox(ctx context.Context, center Point, width, height Distance, limit int) ([]RedisGeoResult, error) {
+	return s.search(ctx, center, limit, "BYBOX", width.Meters(), height.Meters(), "m")
+}
+
+func (s *RedisGeoStore) search(ctx context.Context, center Point, limit int, shape ...interface{}) ([]RedisGeoResult, error) {
+	args := []interface{}{"GEOSEARCH", s.key, "FROMLONLAT", center.lng, center.lat}
+	args = append(args, shape...)
+	args = append(args, "ASC")
+	if limit > 0 {
+		args = append(args, "COUNT", limit)
+	}
+	args = append(args, "WITHDIST", "WITHCOORD")
+
+	reply, err := s.client.Do(ctx, args...)
+	if err != nil {
+		return nil, fmt.Errorf("redis GEOSEARCH %s: %w", s.key, err)
+	}
+
+	items, ok := reply.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: GEOSEARCH %v", ErrUnexpectedReply, reply)
+	}
+
+	results := make([]RedisGeoResult, 0, len(items))
+	for _, item := range items {
+		res, err := redisGeoResult(item)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, res)
+	}
+
+	return results, nil
+}
+
+// redisGeoResult decodes a single [member, distance, [lng, lat]] GEOSEARCH entry.
+func redisGeoResult(item interface{}) (RedisGeoResult, error) {
+	fields, ok := item.([]interface{})
+	if !ok || len(fields) != 3 {
+		return RedisGeoResult{}, fmt.Errorf("%w: GEOSEARCH entry %v", ErrUnexpectedReply, item)
+	}
+
+	member, err := redisString(fields[0])
+	if err != nil {
+		return RedisGeoResult{}, err
+	}
+
+	dist, err := redisFloat(fields[1])
+	if err != nil {
+		return RedisGeoResult{}, err
+	}
+
+	p, err := redisPoint(fields[2])
+	if err != nil {
+		return RedisGeoResult{}, err
+	}
+
+	return RedisGeoResult{Member: member, Point: p, Distance: Distance(dist)}, nil
+}
+
+// redisPoint decodes a [lng, lat] coordinate reply.
+func redisPoint(v interface{}) (Point, error) {
+	coords, ok := v.([]interface{})
+	if !ok || len(coords) != 2 {
+		return Point{}, fmt.Errorf("%w: coordinate %v", ErrUnexpectedReply, v)
+	}
+
+	lng, err := redisFloat(coords[0])
+	if err != nil {
+		return Point{}, err
+	}
+
+	lat, err := redisFloat(coords[1])
+	if err != nil {
+		return Point{}, err
+	}
+
+	return NewPoint(lat, lng), nil
+}
+
+// redisString accepts the string representations returned by common Redis drivers.
+func redisString(v interface{}) (string, error) {
+	switch s := v.(type) {
+	case string:
+		return s, nil
+	case []byte:
+		return string(s), nil
+	}
+
+	return "", fmt.Errorf("%w: string %v", ErrUnexpectedReply, v)
+}
+
+// redisFloat accepts the numeric representations returned by common Redis drivers.
+func redisFloat(v interface{}) (float64, error) {
+	switch f := v.(type) {
+	case float64:
+		return f, nil
+	case int64:
+		return float64(f), nil
+	}
+
+	s, err := redisString(v)
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseFloat(s, 64)
+}
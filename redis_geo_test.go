@@ -0,0 +1,106 @@
+package geo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// A fake RedisClient that records commands and replays a canned reply.
+type fakeRedis struct {
+	commands [][]interface{}
+	reply    interface{}
+	err      error
+}
+
+func (f *fakeRedis) Do(ctx context.Context, args ...interface{}) (interface{}, error) {
+	f.commands = append(f.commands, args)
+	return f.reply, f.err
+}
+
+// Ensures that Add issues a GEOADD with lng before lat, as Redis expects.
+func TestRedisGeoStoreAdd(t *testing.T) {
+	client := &fakeRedis{reply: int64(1)}
+	store := NewRedisGeoStore(client, "stops")
+
+	if err := store.Add(context.Background(), "bandar", NewPoint(4.9403, 114.9481)); err != nil {
+		t.Fatalf("Should not encounter an error when adding a point, but got %v", err)
+	}
+
+	got := fmt.Sprint(client.commands[0])
+	expected := "[GEOADD stops 114.9481 4.9403 bandar]"
+	if got != expected {
+		t.Errorf("Expected command %s, but got %s instead", expected, got)
+	}
+}
+
+// Ensures that a GEOSEARCH reply is converted back into Points with distances.
+func TestRedisGeoStoreSearchRadius(t *testing.T) {
+	client := &fakeRedis{reply: []interface{}{
+		[]interface{}{"a", "0.0000", []interface{}{"114.9481", "4.9403"}},
+		[]interface{}{[]byte("b"), []byte("152.3"), []interface{}{[]byte("114.95"), []byte("4.941")}},
+	}}
+	store := NewRedisGeoStore(client, "stops")
+
+	results, err := store.SearchRadius(context.Background(), NewPoint(4.9403, 114.9481), 500*Meter, 10)
+	if err != nil {
+		t.Fatalf("Should not encounter an error when searching, but got %v", err)
+	}
+
+	got := fmt.Sprint(client.commands[0])
+	expected := "[GEOSEARCH stops FROMLONLAT 114.9481 4.9403 BYRADIUS 500 m ASC COUNT 10 WITHDIST WITHCOORD]"
+	if got != expected {
+		t.Errorf("Expected command %s, but got %s instead", expected, got)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, but got %d instead", len(results))
+	}
+
+	if results[1].Member != "b" || results[1].Distance != 152.3 || results[1].Point != NewPoint(4.941, 114.95) {
+		t.Errorf("Unexpected second result %+v", results[1])
+	}
+}
+
+// Ensures that SearchBox uses the BYBOX shape.
+func TestRedisGeoStoreSearchBox(t *testing.T) {
+	client := &fakeRedis{reply: []interface{}{}}
+	store := NewRedisGeoStore(client, "stops")
+
+	if _, err := store.SearchBox(context.Background(), NewPoint(1, 2), 2*Kilometer, Kilometer, 0); err != nil {
+		t.Fatalf("Should not encounter an error when searching, but got %v", err)
+	}
+
+	got := fmt.Sprint(client.commands[0])
+	expected := "[GEOSEARCH stops FROMLONLAT 2 1 BYBOX 2000 1000 m ASC WITHDIST WITHCOORD]"
+	if got != expected {
+		t.Errorf("Expected command %s, but got %s instead", expected, got)
+	}
+}
+
+// Ensures that Position reports missing members and decodes present ones.
+func TestRedisGeoStorePosition(t *testing.T) {
+	client := &fakeRedis{reply: []interface{}{nil}}
+	store := NewRedisGeoStore(client, "stops")
+
+	if _, ok, err := store.Position(context.Background(), "missing"); ok || err != nil {
+		t.Errorf("Expected a missing member to be reported as not found, got ok=%v err=%v", ok, err)
+	}
+
+	client.reply = []interface{}{[]interface{}{"114.9481", "4.9403"}}
+	p, ok, err := store.Position(context.Background(), "bandar")
+	if !ok || err != nil || p != NewPoint(4.9403, 114.9481) {
+		t.Errorf("Expected the stored position to be decoded, got %v ok=%v err=%v", p, ok, err)
+	}
+}
+
+// Ensures that client errors are surfaced to the caller.
+func TestRedisGeoStoreError(t *testing.T) {
+	client := &fakeRedis{err: errors.New("connection refused")}
+	store := NewRedisGeoStore(client, "stops")
+
+	if err := store.Remove(context.Background(), "a"); err == nil {
+		t.Error("Expected the client error to be returned")
+	}
+}
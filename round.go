@@ -0,0 +1,88 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+)
+
+// metersPerDegree is the length of a degree of latitude, or of longitude on the equator, in meters.
+const metersPerDegree = EARTH_RADIUS * float64(Kilometer) * math.Pi / 180
+
+// MaxDecimals is the most decimal places of a degree worth keeping, resolving about a nanometer,
+// beyond the precision of a float64 for most longitudes.
+const MaxDecimals = 15
+
+// Round returns Point p with its latitude and longitude rounded to decimals decimal places, for
+// publishing locations at a deliberate precision rather than with the noise of every float64 digit.
+// A negative number of decimals rounds to tens, hundreds and so on of degrees, and the result is
+// normalized as by Point.Normalized, so that latitudes rounded past ±90 are clamped and longitudes
+// rounded past ±180 wrapped.  Decimals are clamped to [-MaxDecimals, MaxDecimals], as scales beyond
+// those overflow a float64.
+func Round(p Point, decimals int) Point {
+	decimals = min(max(decimals, -MaxDecimals), MaxDecimals)
+	scale := math.Pow(10, float64(decimals))
+	return NewPoint(math.Round(p.lat*scale)/scale, math.Round(p.lng*scale)/scale).Normalized()
+}
+
+// RoundGeometry returns a copy of g with every Point rounded as by Round.  Points, LineStrings,
+// Polygons, MultiPolygons, BoundingBoxes, Circles, Ellipses, Caps and Tracks are supported, the shapes
+// keeping their sizes while their centers are rounded.  It returns an error wrapping
+// ErrUnsupportedGeometry for any other Geometry.
+func RoundGeometry(g Geometry, decimals int) (Geometry, error) {
+	round := func(points []Point) []Point {
+		rounded := make([]Point, len(points))
+		for i, p := range points {
+			rounded[i] = Round(p, decimals)
+		}
+		return rounded
+	}
+
+	switch g := g.(type) {
+	case Point:
+		return Round(g, decimals), nil
+	case LineString:
+		return LineString{points: round(g.points), crs: g.crs}, nil
+	case Polygon:
+		return NewPolygon(round(g.points)).WithCRS(g.crs), nil
+	case MultiPolygon:
+		polygons := make([]Polygon, len(g.polygons))
+		for i, p := range g.polygons {
+			polygons[i] = NewPolygon(round(p.points)).WithCRS(p.crs)
+		}
+		return NewMultiPolygon(polygons...), nil
+	case BoundingBox:
+		return NewBoundingBox(Round(g.sw, decimals), Round(g.ne, decimals)), nil
+	case Circle:
+		return NewCircle(Round(g.center, decimals), g.radius), nil
+	case Ellipse:
+		g.center = Round(g.center, decimals)
+		return g, nil
+	case Cap:
+		return NewCap(Round(g.center, decimals), g.angle), nil
+	case Track:
+		t := make(Track, len(g))
+		for i, fix := range g {
+			fix.Point = Round(fix.Point, decimals)
+			t[i] = fix
+		}
+		return t, nil
+	default:
+		return nil, fmt.Errorf("%w: cannot round %T", ErrUnsupportedGeometry, g)
+	}
+}
+
+// DecimalsPrecision returns the ground distance of a step in the last of decimals decimal places of
+// latitude, or of longitude on the equator, which shrinks towards the poles: about 1.1m for 5 decimals.
+func DecimalsPrecision(decimals int) Distance {
+	return Distance(metersPerDegree * math.Pow(10, -float64(decimals)))
+}
+
+// DecimalsFor returns the fewest decimal places of a degree that resolve the passed in ground distance,
+// as DecimalsPrecision measures it, for example 4 for 50m.  It returns 0 for distances of a degree or
+// more, and for distances that are not positive, MaxDecimals.
+func DecimalsFor(precision Distance) int {
+	if !(precision > 0) {
+		return MaxDecimals
+	}
+	return min(max(int(math.Ceil(math.Log10(metersPerDegree/precision.Meters())-1e-9)), 0), MaxDecimals)
+}
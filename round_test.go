@@ -0,0 +1,84 @@
+package geo
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+// Ensures that points are rounded to the given decimal places.
+func TestRound(t *testing.T) {
+	tests := []struct {
+		p        Point
+		decimals int
+		expected Point
+	}{
+		{NewPoint(51.507351, -0.127758), 3, NewPoint(51.507, -0.128)},
+		{NewPoint(51.507351, -0.127758), 0, NewPoint(52, 0)},
+		{NewPoint(51.507351, -0.127758), -1, NewPoint(50, 0)},
+		{NewPoint(0.29999, 179.99999), 2, NewPoint(0.3, 180)},
+		{NewPoint(51.507351, -0.127758), 400, Round(NewPoint(51.507351, -0.127758), MaxDecimals)},
+		{NewPoint(51.507351, -0.127758), -400, NewPoint(0, 0)},
+		{NewPoint(51.5, -0.13), -2, NewPoint(90, 0)},
+		{NewPoint(-51.5, 150), -2, NewPoint(-90, -160)},
+	}
+	for _, tt := range tests {
+		if p := Round(tt.p, tt.decimals); p != tt.expected {
+			t.Errorf("Expected %v rounded to %d decimals to be %v, but got %v", tt.p, tt.decimals, tt.expected, p)
+		}
+	}
+}
+
+// Ensures that every point of a geometry is rounded and that unknown geometries are refused.
+func TestRoundGeometry(t *testing.T) {
+	line := NewLineString([]Point{NewPoint(1.234, 5.678), NewPoint(2.345, 6.789)})
+	rounded, err := RoundGeometry(line, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if points := rounded.(LineString).Points(); points[0] != NewPoint(1.2, 5.7) || points[1] != NewPoint(2.3, 6.8) {
+		t.Errorf("Expected the line rounded, but got %v", points)
+	}
+	if line.Points()[0] != NewPoint(1.234, 5.678) {
+		t.Errorf("Expected the line left unchanged, but got %v", line.Points())
+	}
+
+	track := Track{{Point: NewPoint(1.26, 1.24), Time: time.Unix(0, 0), Accuracy: 5 * Meter}}
+	rounded, err = RoundGeometry(track, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fix := rounded.(Track)[0]; fix.Point != NewPoint(1.3, 1.2) || fix.Accuracy != 5*Meter {
+		t.Errorf("Expected the fix rounded, but got %v", fix)
+	}
+
+	if rounded, err := RoundGeometry(NewCircle(NewPoint(1.26, 1.24), Kilometer), 1); err != nil || rounded.(Circle).Center() != NewPoint(1.3, 1.2) || rounded.(Circle).Radius() != Kilometer {
+		t.Errorf("Expected the circle's center rounded, but got %v, %v", rounded, err)
+	}
+	if _, err := RoundGeometry(NewPreparedPolygon(NewPolygon(line.Points())), 1); !errors.Is(err, ErrUnsupportedGeometry) {
+		t.Errorf("Expected ErrUnsupportedGeometry, but got %v", err)
+	}
+}
+
+// Ensures that decimal places are related to ground distances both ways.
+func TestDecimalsPrecision(t *testing.T) {
+	if d := DecimalsPrecision(5); math.Abs(d.Meters()-1.112) > 0.001 {
+		t.Errorf("Expected about 1.1m for 5 decimals, but got %v", d)
+	}
+	tests := []struct {
+		precision Distance
+		expected  int
+	}{
+		{50 * Meter, 4},
+		{11 * Meter, 5},
+		{DecimalsPrecision(3), 3},
+		{1000 * Kilometer, 0},
+		{0, MaxDecimals},
+	}
+	for _, tt := range tests {
+		if d := DecimalsFor(tt.precision); d != tt.expected {
+			t.Errorf("Expected %d decimals for %v, but got %d", tt.expected, tt.precision, d)
+		}
+	}
+}
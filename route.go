@@ -0,0 +1,120 @@
+package geo
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// A Route chains waypoints with great circle legs, for planning flights or passages that need no
+// road network.  Use NewRoute to create one.
+type Route struct {
+	waypoints []Point
+	legs      []RouteLeg
+}
+
+// A RouteLeg is the great circle between two consecutive waypoints of a Route.
+type RouteLeg struct {
+	From, To Point
+	Distance Distance
+	// Bearing is the initial bearing of the leg in degrees clockwise from true north.
+	Bearing float64
+}
+
+// A SpeedProfile describes how fast a Route is travelled, in meters per second.
+type SpeedProfile struct {
+	// Cruise is the speed on legs without one of their own.
+	Cruise float64
+	// Legs holds the speed on each leg, by index.  Zero speeds, and legs past its end, use Cruise.
+	Legs []float64
+	// Dwell is the time spent at each waypoint between the first and the last.
+	Dwell time.Duration
+}
+
+// A ScheduledLeg is a RouteLeg with the times it is started and finished.
+type ScheduledLeg struct {
+	RouteLeg
+	Departure, Arrival time.Time
+}
+
+// NewRoute returns a new Route through the waypoints, in order.
+func NewRoute(waypoints ...Point) Route {
+	waypoints = slices.Clone(waypoints)
+	r := Route{waypoints: waypoints}
+	for i := 1; i < len(waypoints); i++ {
+		from, to := waypoints[i-1], waypoints[i]
+		r.legs = append(r.legs, RouteLeg{From: from, To: to, Distance: from.GreatCircleDistance(to), Bearing: initialBearing(from, to)})
+	}
+	return r
+}
+
+// Waypoints returns a copy of the waypoints of the Route.
+func (r Route) Waypoints() []Point {
+	return slices.Clone(r.waypoints)
+}
+
+// Legs returns a copy of the legs between consecutive waypoints of the Route.
+func (r Route) Legs() []RouteLeg {
+	return slices.Clone(r.legs)
+}
+
+// Distance returns the total length of the legs of the Route.
+func (r Route) Distance() Distance {
+	var d Distance
+	for _, l := range r.legs {
+		d += l.Distance
+	}
+	return d
+}
+
+// String renders the Route as its waypoint count and total distance, for example "Route(3 waypoints, 1234.5km)".
+func (r Route) String() string {
+	return fmt.Sprintf("Route(%d waypoints, %v)", len(r.waypoints), r.Distance())
+}
+
+// LineString returns the Route as a LineString following each leg in segments no longer than maxSegment,
+// as GreatCircleArc does, or joining the waypoints directly for a maxSegment of zero.
+func (r Route) LineString(maxSegment Distance) LineString {
+	if len(r.legs) == 0 {
+		return NewLineString(slices.Clone(r.waypoints))
+	}
+	points := []Point{r.waypoints[0]}
+	for _, l := range r.legs {
+		points = append(points, GreatCircleArc(l.From, l.To, maxSegment).points[1:]...)
+	}
+	return NewLineString(points)
+}
+
+// Schedule returns the legs of the Route with the times they are started and finished when leaving
+// at departure and travelling according to profile.  It returns an error wrapping ErrInvalidSpeed
+// if a leg has no positive speed.
+func (r Route) Schedule(departure time.Time, profile SpeedProfile) ([]ScheduledLeg, error) {
+	legs := make([]ScheduledLeg, len(r.legs))
+	t := departure
+	for i, l := range r.legs {
+		speed := profile.Cruise
+		if i < len(profile.Legs) && profile.Legs[i] != 0 {
+			speed = profile.Legs[i]
+		}
+		if !(speed > 0) || !isFinite(speed) {
+			return nil, fmt.Errorf("%w: speed %v on leg %d", ErrInvalidSpeed, speed, i)
+		}
+		if i > 0 {
+			t = t.Add(profile.Dwell)
+		}
+		arrival := t.Add(time.Duration(l.Distance.Meters() / speed * float64(time.Second)))
+		legs[i] = ScheduledLeg{RouteLeg: l, Departure: t, Arrival: arrival}
+		t = arrival
+	}
+	return legs, nil
+}
+
+// ETA returns the time the Route is finished when leaving at departure and travelling according to
+// profile, as Schedule works out.
+func (r Route) ETA(departure time.Time, profile SpeedProfile) (time.Time, error) {
+	legs, err := r.Schedule(departure, profile)
+	if err != nil || len(legs) == 0 {
+		return departure, err
+	}
+	return legs[len(legs)-1].Arrival, nil
+}
@@ -0,0 +1,76 @@
+package geo
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+// Ensures that a Route adds up its legs and follows the great circles between them.
+func TestRoute(t *testing.T) {
+	a, b, c := NewPoint(0, 0), NewPoint(0, 1), NewPoint(1, 1)
+	r := NewRoute(a, b, c)
+
+	legs := r.Legs()
+	if len(legs) != 2 || legs[0].From != a || legs[1].To != c {
+		t.Fatalf("Expected 2 legs from %v to %v, but got %v", a, c, legs)
+	}
+	if math.Abs(legs[0].Bearing-90) > 1e-9 || math.Abs(legs[1].Bearing) > 1e-9 {
+		t.Errorf("Expected bearings of 90 and 0, but got %v and %v", legs[0].Bearing, legs[1].Bearing)
+	}
+	if d := r.Distance(); d != a.GreatCircleDistance(b)+b.GreatCircleDistance(c) {
+		t.Errorf("Expected the legs to add up, but got %v", d)
+	}
+
+	r.Waypoints()[1] = c
+	r.Legs()[0].To = c
+	if r.Waypoints()[1] != b || r.Legs()[0].To != b {
+		t.Errorf("Expected the route to be unchanged by modifying its waypoints and legs, but got %v", r)
+	}
+	waypoints := []Point{a, b}
+	copied := NewRoute(waypoints...)
+	waypoints[1] = c
+	if copied.Waypoints()[1] != b {
+		t.Errorf("Expected the route to be unchanged by modifying the waypoints it was built from, but got %v", copied)
+	}
+
+	line := r.LineString(10 * Kilometer).Points()
+	if line[0] != a || line[len(line)-1] != c || len(line) != 25 {
+		t.Errorf("Expected 24 segments of at most 10km, but got %v", line)
+	}
+	if line := NewRoute(a).LineString(0).Points(); len(line) != 1 {
+		t.Errorf("Expected a single point, but got %v", line)
+	}
+}
+
+// Ensures that a schedule takes each leg at its speed and dwells at the waypoints between.
+func TestRouteSchedule(t *testing.T) {
+	r := NewRoute(NewPoint(0, 0), NewPoint(0, 1), NewPoint(1, 1))
+	departure := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	profile := SpeedProfile{Cruise: 100, Legs: []float64{0, 50}, Dwell: time.Hour}
+
+	legs, err := r.Schedule(departure, profile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := time.Duration(r.Legs()[0].Distance.Meters() / 100 * float64(time.Second))
+	second := time.Duration(r.Legs()[1].Distance.Meters() / 50 * float64(time.Second))
+	if !legs[0].Departure.Equal(departure) || !legs[0].Arrival.Equal(departure.Add(first)) {
+		t.Errorf("Expected the first leg to take %v, but got %v", first, legs[0])
+	}
+	if !legs[1].Departure.Equal(legs[0].Arrival.Add(time.Hour)) || legs[1].Arrival.Sub(legs[1].Departure) != second {
+		t.Errorf("Expected the second leg to take %v after an hour, but got %v", second, legs[1])
+	}
+
+	eta, err := r.ETA(departure, profile)
+	if err != nil || !eta.Equal(legs[1].Arrival) {
+		t.Errorf("Expected an ETA of %v, but got %v, %v", legs[1].Arrival, eta, err)
+	}
+	if _, err := r.ETA(departure, SpeedProfile{Legs: []float64{100}}); !errors.Is(err, ErrInvalidSpeed) {
+		t.Errorf("Expected ErrInvalidSpeed for a leg without speed, but got %v", err)
+	}
+	if eta, err := NewRoute(NewPoint(0, 0)).ETA(departure, SpeedProfile{}); err != nil || !eta.Equal(departure) {
+		t.Errorf("Expected to arrive on departure, but got %v, %v", eta, err)
+	}
+}
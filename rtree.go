@@ -0,0 +1,405 @@
+package geo
+
+import (
+	"iter"
+	"math"
+	"sort"
+)
+
+// DefaultRTreeNodeCapacity is the maximum number of entries of an RTree node used
+// when a capacity below 4 is passed to NewRTree or BulkLoadRTree.
+const DefaultRTreeNodeCapacity = 16
+
+// An RTree is an in-memory R-tree over items that expose their bounds, such as Points,
+// Polygons or application types implementing Geometry.  Items are grouped into nested
+// bounding boxes so that range queries only visit the parts of the tree near the query.
+// It is not safe for concurrent modification.
+type RTree[T Geometry] struct {
+	root     *rtreeNode[T]
+	size     int
+	capacity int
+}
+
+type rtreeNode[T Geometry] struct {
+	// height is zero for leaves, whose entries hold items rather than child nodes.
+	height  int
+	entries []rtreeEntry[T]
+}
+
+type rtreeEntry[T Geometry] struct {
+	bounds BoundingBox
+	item   T
+	child  *rtreeNode[T]
+}
+
+// NewRTree returns an empty RTree whose nodes hold up to capacity entries.
+func NewRTree[T Geometry](capacity int) *RTree[T] {
+	if capacity < 4 {
+		capacity = DefaultRTreeNodeCapacity
+	}
+	return &RTree[T]{root: &rtreeNode[T]{}, capacity: capacity}
+}
+
+// BulkLoadRTree returns an RTree over the passed in items, packed with the Sort-Tile-Recursive
+// algorithm.  Bulk loading is much faster than repeated inserts and produces a better tree.
+func BulkLoadRTree[T Geometry](items []T, capacity int) *RTree[T] {
+	t := NewRTree[T](capacity)
+
+	entries := make([]rtreeEntry[T], len(items))
+	for i, item := range items {
+		entries[i] = rtreeEntry[T]{bounds: item.Bounds(), item: item}
+	}
+
+	height := 0
+	for len(entries) > t.capacity {
+		entries = strPack(entries, t.capacity, height)
+		height++
+	}
+
+	t.root = &rtreeNode[T]{height: height, entries: entries}
+	t.size = len(items)
+	return t
+}
+
+// strPack groups entries into nodes of up to capacity entries, sorting them into vertical
+// slices by longitude and then by latitude within each slice.
+func strPack[T Geometry](entries []rtreeEntry[T], capacity int, height int) []rtreeEntry[T] {
+	nodes := int(math.Ceil(float64(len(entries)) / float64(capacity)))
+	slices := int(math.Ceil(math.Sqrt(float64(nodes))))
+	sliceSize := slices * capacity
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].bounds.sw.lng+entries[i].bounds.ne.lng < entries[j].bounds.sw.lng+entries[j].bounds.ne.lng
+	})
+
+	packed := make([]rtreeEntry[T], 0, nodes)
+	for start := 0; start < len(entries); start += sliceSize {
+		slice := entries[start:min(start+sliceSize, len(entries))]
+		sort.Slice(slice, func(i, j int) bool {
+			return slice[i].bounds.sw.lat+slice[i].bounds.ne.lat < slice[j].bounds.sw.lat+slice[j].bounds.ne.lat
+		})
+
+		for i := 0; i < len(slice); i += capacity {
+			group := slice[i:min(i+capacity, len(slice))]
+			node := &rtreeNode[T]{height: height, entries: append([]rtreeEntry[T](nil), group...)}
+			packed = append(packed, rtreeEntry[T]{bounds: node.bounds(), child: node})
+		}
+	}
+
+	return packed
+}
+
+// Len returns the number of items in the tree.
+func (t *RTree[T]) Len() int {
+	return t.size
+}
+
+// Bounds returns the smallest BoundingBox containing every item of the tree.
+func (t *RTree[T]) Bounds() BoundingBox {
+	return t.root.bounds()
+}
+
+// Insert adds an item to the tree.
+func (t *RTree[T]) Insert(item T) {
+	t.insert(rtreeEntry[T]{bounds: item.Bounds(), item: item}, 0)
+	t.size++
+}
+
+// insert places e into a node at the passed in height, growing a new root if the old one splits.
+func (t *RTree[T]) insert(e rtreeEntry[T], height int) {
+	if sibling := t.insertAt(t.root, e, height); sibling != nil {
+		old := t.root
+		t.root = &rtreeNode[T]{
+			height: old.height + 1,
+			entries: []rtreeEntry[T]{
+				{bounds: old.bounds(), child: old},
+				{bounds: sibling.bounds(), child: sibling},
+			},
+		}
+	}
+}
+
+// insertAt inserts e below n, returning a new sibling of n if n had to be split.
+func (t *RTree[T]) insertAt(n *rtreeNode[T], e rtreeEntry[T], height int) *rtreeNode[T] {
+	if n.height == height {
+		n.entries = append(n.entries, e)
+	} else {
+		i := n.chooseSubtree(e.bounds)
+		child := n.entries[i].child
+		sibling := t.insertAt(child, e, height)
+		n.entries[i].bounds = child.bounds()
+		if sibling != nil {
+			n.entries = append(n.entries, rtreeEntry[T]{bounds: sibling.bounds(), child: sibling})
+		}
+	}
+
+	if len(n.entries) > t.capacity {
+		return t.split(n)
+	}
+	return nil
+}
+
+// chooseSubtree returns the index of the entry needing the least enlargement to hold b.
+func (n *rtreeNode[T]) chooseSubtree(b BoundingBox) int {
+	best, bestGrowth, bestArea := 0, math.Inf(1), math.Inf(1)
+	for i, e := range n.entries {
+		area := boundsArea(e.bounds)
+		growth := boundsArea(unionBounds(e.bounds, b)) - area
+		if growth < bestGrowth || (growth == bestGrowth && area < bestArea) {
+			best, bestGrowth, bestArea = i, growth, area
+		}
+	}
+	return best
+}
+
+// split divides the entries of n between n and a new sibling using Guttman's quadratic split.
+func (t *RTree[T]) split(n *rtreeNode[T]) *rtreeNode[T] {
+	entries := n.entries
+	minFill := max(2, t.capacity*2/5)
+
+	// Pick the two entries that would waste the most area in a node together.
+	seedA, seedB, worst := 0, 1, math.Inf(-1)
+	for i := range entries {
+		for j := i + 1; j < len(entries); j++ {
+			waste := boundsArea(unionBounds(entries[i].bounds, entries[j].bounds)) - boundsArea(entries[i].bounds) - boundsArea(entries[j].bounds)
+			if waste > worst {
+				seedA, seedB, worst = i, j, waste
+			}
+		}
+	}
+
+	a := []rtreeEntry[T]{entries[seedA]}
+	b := []rtreeEntry[T]{entries[seedB]}
+	boundsA, boundsB := entries[seedA].bounds, entries[seedB].bounds
+
+	var rest []rtreeEntry[T]
+	for i, e := range entries {
+		if i != seedA && i != seedB {
+			rest = append(rest, e)
+		}
+	}
+
+	for len(rest) > 0 {
+		if len(a)+len(rest) == minFill {
+			a = append(a, rest...)
+			break
+		}
+		if len(b)+len(rest) == minFill {
+			b = append(b, rest...)
+			break
+		}
+
+		// Assign the entry with the strongest preference for one of the groups first.
+		pick, pickDiff := 0, math.Inf(-1)
+		for i, e := range rest {
+			growA := boundsArea(unionBounds(boundsA, e.bounds)) - boundsArea(boundsA)
+			growB := boundsArea(unionBounds(boundsB, e.bounds)) - boundsArea(boundsB)
+			if diff := math.Abs(growA - growB); diff > pickDiff {
+				pick, pickDiff = i, diff
+			}
+		}
+
+		e := rest[pick]
+		rest = append(rest[:pick], rest[pick+1:]...)
+
+		growA := boundsArea(unionBounds(boundsA, e.bounds)) - boundsArea(boundsA)
+		growB := boundsArea(unionBounds(boundsB, e.bounds)) - boundsArea(boundsB)
+		if growA < growB || (growA == growB && len(a) <= len(b)) {
+			a = append(a, e)
+			boundsA = unionBounds(boundsA, e.bounds)
+		} else {
+			b = append(b, e)
+			boundsB = unionBounds(boundsB, e.bounds)
+		}
+	}
+
+	n.entries = a
+	return &rtreeNode[T]{height: n.height, entries: b}
+}
+
+// Delete removes one item whose bounds intersect b and for which match returns true,
+// reporting whether an item was removed.
+func (t *RTree[T]) Delete(b BoundingBox, match func(item T) bool) bool {
+	var orphans []rtreeEntry[T]
+	if !t.delete(t.root, b, match, &orphans) {
+		return false
+	}
+	t.size--
+
+	for t.root.height > 0 && len(t.root.entries) == 1 {
+		t.root = t.root.entries[0].child
+	}
+	if len(t.root.entries) == 0 {
+		t.root = &rtreeNode[T]{}
+	}
+
+	for _, e := range orphans {
+		t.insert(e, 0)
+	}
+	return true
+}
+
+// delete removes a matching item below n, collecting the items of nodes left underfull in orphans.
+func (t *RTree[T]) delete(n *rtreeNode[T], b BoundingBox, match func(item T) bool, orphans *[]rtreeEntry[T]) bool {
+	for i, e := range n.entries {
+		if !e.bounds.Intersects(b) {
+			continue
+		}
+
+		if n.height == 0 {
+			if match(e.item) {
+				n.entries = append(n.entries[:i], n.entries[i+1:]...)
+				return true
+			}
+			continue
+		}
+
+		if !t.delete(e.child, b, match, orphans) {
+			continue
+		}
+
+		if len(e.child.entries) < max(2, t.capacity*2/5) {
+			e.child.collect(orphans)
+			n.entries = append(n.entries[:i], n.entries[i+1:]...)
+		} else {
+			n.entries[i].bounds = e.child.bounds()
+		}
+		return true
+	}
+
+	return false
+}
+
+// collect appends the item entries below n to entries.
+func (n *rtreeNode[T]) collect(entries *[]rtreeEntry[T]) {
+	for _, e := range n.entries {
+		if n.height == 0 {
+			*entries = append(*entries, e)
+		} else {
+			e.child.collect(entries)
+		}
+	}
+}
+
+// SearchFunc calls f with every item whose bounds intersect b, stopping early if f returns false.
+func (t *RTree[T]) SearchFunc(b BoundingBox, f func(item T) bool) {
+	t.root.search(b, f)
+}
+
+func (n *rtreeNode[T]) search(b BoundingBox, f func(item T) bool) bool {
+	for _, e := range n.entries {
+		if !e.bounds.Intersects(b) {
+			continue
+		}
+		if n.height == 0 {
+			if !f(e.item) {
+				return false
+			}
+		} else if !e.child.search(b, f) {
+			return false
+		}
+	}
+	return true
+}
+
+// Search returns every item whose bounds intersect b.
+func (t *RTree[T]) Search(b BoundingBox) []T {
+	var items []T
+	t.SearchFunc(b, func(item T) bool {
+		items = append(items, item)
+		return true
+	})
+	return items
+}
+
+// Within returns every item whose bounds lie entirely inside b.
+func (t *RTree[T]) Within(b BoundingBox) []T {
+	var items []T
+	t.SearchFunc(b, func(item T) bool {
+		if containsBounds(b, item.Bounds()) {
+			items = append(items, item)
+		}
+		return true
+	})
+	return items
+}
+
+// All returns an iterator over every item of the tree.
+func (t *RTree[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var entries []rtreeEntry[T]
+		t.root.collect(&entries)
+		for _, e := range entries {
+			if !yield(e.item) {
+				return
+			}
+		}
+	}
+}
+
+// indexedBounds is an RTree item standing for the i'th element of a slice.
+type indexedBounds struct {
+	bounds BoundingBox
+	i      int
+}
+
+// Bounds implements the Geometry interface.
+func (b indexedBounds) Bounds() BoundingBox {
+	return b.bounds
+}
+
+// newIndexRTree bulk loads an RTree over the positions of the passed in bounding boxes.
+func newIndexRTree(bounds []BoundingBox) *RTree[indexedBounds] {
+	items := make([]indexedBounds, len(bounds))
+	for i, b := range bounds {
+		items[i] = indexedBounds{bounds: b, i: i}
+	}
+	return BulkLoadRTree(items, DefaultRTreeNodeCapacity)
+}
+
+func (n *rtreeNode[T]) bounds() BoundingBox {
+	if len(n.entries) == 0 {
+		return BoundingBox{}
+	}
+
+	b := n.entries[0].bounds
+	for _, e := range n.entries[1:] {
+		b = unionBounds(b, e.bounds)
+	}
+	return b
+}
+
+// unionBounds returns the smallest box containing a and b.  Boxes crossing
+// the antimeridian widen the union to every longitude.
+func unionBounds(a BoundingBox, b BoundingBox) BoundingBox {
+	sw := NewPoint(math.Min(a.sw.lat, b.sw.lat), math.Min(a.sw.lng, b.sw.lng))
+	ne := NewPoint(math.Max(a.ne.lat, b.ne.lat), math.Max(a.ne.lng, b.ne.lng))
+	if a.CrossesAntimeridian() || b.CrossesAntimeridian() {
+		sw.lng, ne.lng = -180, 180
+	}
+	return NewBoundingBox(sw, ne)
+}
+
+// boundsArea returns the area of b in square degrees.
+func boundsArea(b BoundingBox) float64 {
+	width := b.ne.lng - b.sw.lng
+	if b.CrossesAntimeridian() {
+		width += 360
+	}
+	return width * (b.ne.lat - b.sw.lat)
+}
+
+// containsBounds reports whether inner lies entirely inside outer.
+func containsBounds(outer BoundingBox, inner BoundingBox) bool {
+	if !outer.Contains(inner.sw) || !outer.Contains(inner.ne) {
+		return false
+	}
+
+	switch {
+	case inner.CrossesAntimeridian():
+		return outer.CrossesAntimeridian() && inner.sw.lng >= outer.sw.lng && inner.ne.lng <= outer.ne.lng
+	case outer.CrossesAntimeridian():
+		return inner.sw.lng >= outer.sw.lng || inner.ne.lng <= outer.ne.lng
+	}
+	return true
+}
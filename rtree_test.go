@@ -0,0 +1,136 @@
+package geo
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func randomBoxes(r *rand.Rand, n int) []BoundingBox {
+	boxes := make([]BoundingBox, n)
+	for i := range boxes {
+		lat, lng := r.Float64()*160-80, r.Float64()*340-170
+		boxes[i] = NewBoundingBox(NewPoint(lat, lng), NewPoint(lat+r.Float64()*5, lng+r.Float64()*5))
+	}
+	return boxes
+}
+
+// assertSearch checks a tree's intersection and range queries against a brute force scan of boxes.
+func assertSearch(t *testing.T, tree *RTree[BoundingBox], boxes []BoundingBox, query BoundingBox) {
+	t.Helper()
+
+	var intersecting, within []BoundingBox
+	for _, b := range boxes {
+		if b.Intersects(query) {
+			intersecting = append(intersecting, b)
+			if containsBounds(query, b) {
+				within = append(within, b)
+			}
+		}
+	}
+
+	if found := tree.Search(query); !sameBoxes(found, intersecting) {
+		t.Errorf("Expected %d boxes to intersect %v, but got %d", len(intersecting), query, len(found))
+	}
+
+	if found := tree.Within(query); !sameBoxes(found, within) {
+		t.Errorf("Expected %d boxes within %v, but got %d", len(within), query, len(found))
+	}
+}
+
+func sameBoxes(a, b []BoundingBox) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	less := func(s []BoundingBox) func(i, j int) bool {
+		return func(i, j int) bool { return s[i].String() < s[j].String() }
+	}
+	sort.Slice(a, less(a))
+	sort.Slice(b, less(b))
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Ensures that inserts, deletes and bulk loads all answer queries like a brute force scan.
+func TestRTree(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	boxes := randomBoxes(r, 2000)
+	queries := randomBoxes(r, 50)
+	queries = append(queries, NewBoundingBox(NewPoint(-90, 170), NewPoint(90, -170)))
+
+	tree := NewRTree[BoundingBox](8)
+	for _, b := range boxes {
+		tree.Insert(b)
+	}
+
+	bulk := BulkLoadRTree(boxes, 8)
+	for _, q := range queries {
+		assertSearch(t, tree, boxes, q)
+		assertSearch(t, bulk, boxes, q)
+	}
+
+	// Delete every other box from both trees.
+	var kept []BoundingBox
+	for i, b := range boxes {
+		if i%2 == 1 {
+			kept = append(kept, b)
+			continue
+		}
+
+		for _, tr := range []*RTree[BoundingBox]{tree, bulk} {
+			if !tr.Delete(b, func(item BoundingBox) bool { return item == b }) {
+				t.Fatalf("Expected to delete %v", b)
+			}
+		}
+	}
+
+	if tree.Len() != len(kept) || bulk.Len() != len(kept) {
+		t.Errorf("Expected %d items after deleting, but got %d and %d", len(kept), tree.Len(), bulk.Len())
+	}
+
+	for _, q := range queries {
+		assertSearch(t, tree, kept, q)
+		assertSearch(t, bulk, kept, q)
+	}
+
+	if tree.Delete(boxes[1], func(BoundingBox) bool { return false }) {
+		t.Error("Expected no deletion when nothing matches")
+	}
+
+	n := 0
+	for range tree.All() {
+		n++
+	}
+	if n != len(kept) {
+		t.Errorf("Expected to iterate over %d items, but got %d", len(kept), n)
+	}
+}
+
+// Ensures that a tree can be emptied and reused, and that it works over points.
+func TestRTreePoints(t *testing.T) {
+	tree := NewRTree[Point](0)
+	points := []Point{NewPoint(1, 1), NewPoint(2, 2), NewPoint(-5, 100)}
+	for _, p := range points {
+		tree.Insert(p)
+	}
+
+	if found := tree.Search(NewBoundingBox(NewPoint(0, 0), NewPoint(3, 3))); len(found) != 2 {
+		t.Errorf("Expected 2 points near the origin, but got %v", found)
+	}
+
+	for _, p := range points {
+		p := p
+		tree.Delete(p.Bounds(), func(item Point) bool { return item == p })
+	}
+
+	tree.Insert(NewPoint(7, 7))
+	if tree.Len() != 1 || tree.Bounds() != NewPoint(7, 7).Bounds() {
+		t.Errorf("Expected a single point after emptying the tree, but got %d with bounds %v", tree.Len(), tree.Bounds())
+	}
+}
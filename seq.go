@@ -0,0 +1,53 @@
+package geo
+
+import "iter"
+
+// PointsSeq returns an iterator over the points of the Polygon, without copying them.
+func (p Polygon) PointsSeq() iter.Seq[Point] {
+	return pointsSeq(p.points)
+}
+
+// EdgesSeq returns an iterator over the edges of the Polygon as start and end points,
+// including the closing edge from the last point back to the first.  A Polygon that is not closed,
+// with fewer than three points, has no edges.
+func (p Polygon) EdgesSeq() iter.Seq2[Point, Point] {
+	return func(yield func(Point, Point) bool) {
+		if !p.IsClosed() {
+			return
+		}
+
+		n := len(p.points)
+
+		for i := 0; i < n; i++ {
+			if !yield(p.points[i], p.points[(i+1)%n]) {
+				return
+			}
+		}
+	}
+}
+
+// PointsSeq returns an iterator over the points of the LineString, without copying them.
+func (l LineString) PointsSeq() iter.Seq[Point] {
+	return pointsSeq(l.points)
+}
+
+// SegmentsSeq returns an iterator over the segments of the LineString as start and end points.
+func (l LineString) SegmentsSeq() iter.Seq2[Point, Point] {
+	return func(yield func(Point, Point) bool) {
+		for i := 1; i < len(l.points); i++ {
+			if !yield(l.points[i-1], l.points[i]) {
+				return
+			}
+		}
+	}
+}
+
+func pointsSeq(points []Point) iter.Seq[Point] {
+	return func(yield func(Point) bool) {
+		for _, p := range points {
+			if !yield(p) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package geo
+
+import "testing"
+
+// Ensures that the iterators visit every point, edge and segment and stop early when asked.
+func TestGeometrySeqs(t *testing.T) {
+	square := NewPolygon([]Point{NewPoint(0, 0), NewPoint(0, 1), NewPoint(1, 1), NewPoint(1, 0)})
+
+	n := 0
+	for p := range square.PointsSeq() {
+		if p != square.Points()[n] {
+			t.Errorf("Expected point %d to be %v, but got %v", n, square.Points()[n], p)
+		}
+		n++
+	}
+	if n != 4 {
+		t.Errorf("Expected 4 points, but got %d", n)
+	}
+
+	var last [2]Point
+	edges := 0
+	for a, b := range square.EdgesSeq() {
+		last = [2]Point{a, b}
+		edges++
+	}
+	if edges != 4 || last != [2]Point{NewPoint(1, 0), NewPoint(0, 0)} {
+		t.Errorf("Expected 4 edges ending with the closing edge, but got %d ending with %v", edges, last)
+	}
+
+	line := NewLineString(square.Points())
+	segments := 0
+	for a, b := range line.SegmentsSeq() {
+		if a == NewPoint(1, 1) && b == NewPoint(1, 0) {
+			break
+		}
+		segments++
+	}
+	if segments != 2 {
+		t.Errorf("Expected to stop after 2 segments, but got %d", segments)
+	}
+
+	for range NewPolygon(nil).EdgesSeq() {
+		t.Error("Expected an empty polygon to have no edges")
+	}
+	for range NewPolygon([]Point{NewPoint(0, 0), NewPoint(1, 1)}).EdgesSeq() {
+		t.Error("Expected a polygon of 2 points to have no edges")
+	}
+}
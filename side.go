@@ -0,0 +1,43 @@
+package geo
+
+import "math"
+
+// Sides of a line, as returned by SideOf and GreatCircleSideOf.
+const (
+	RightSide = -1
+	OnLine    = 0
+	LeftSide  = 1
+)
+
+// SideOf returns which side of the line through a and b, facing from a towards b, Point p lies on:
+// LeftSide, RightSide, or OnLine when the three are collinear.  The line is straight in degrees of
+// latitude and longitude, as the edges of a Polygon are, and takes the short way across the
+// antimeridian.  Use GreatCircleSideOf for lines following great circles.
+func SideOf(a Point, b Point, p Point) int {
+	wrap := func(lng float64) float64 {
+		return math.Mod(lng+540, 360) - 180
+	}
+	cross := wrap(b.lng-a.lng)*(p.lat-a.lat) - (b.lat-a.lat)*wrap(p.lng-a.lng)
+	switch {
+	case cross > 0:
+		return LeftSide
+	case cross < 0:
+		return RightSide
+	}
+	return OnLine
+}
+
+// GreatCircleSideOf returns which side of the great circle through a and b, facing from a towards b,
+// Point p lies on, like SideOf.  Points within rounding error of the great circle, a few micrometers,
+// lie OnLine, as do all points when a and b are the same or antipodal.
+func GreatCircleSideOf(a Point, b Point, p Point) int {
+	n := cross3(unitVector(a), unitVector(b))
+	s := dot3(n, unitVector(p))
+	switch norm := math.Sqrt(dot3(n, n)); {
+	case s > 1e-12*norm && norm > 1e-15:
+		return LeftSide
+	case s < -1e-12*norm && norm > 1e-15:
+		return RightSide
+	}
+	return OnLine
+}
@@ -0,0 +1,30 @@
+package geo
+
+import (
+	"testing"
+)
+
+// Ensures that points are placed on the correct side of straight and great circle lines.
+func TestSideOf(t *testing.T) {
+	tests := []struct {
+		a, b, p      Point
+		side, circle int
+	}{
+		{NewPoint(0, 0), NewPoint(0, 1), NewPoint(1, 0.5), LeftSide, LeftSide},
+		{NewPoint(0, 0), NewPoint(0, 1), NewPoint(-1, 0.5), RightSide, RightSide},
+		{NewPoint(0, 1), NewPoint(0, 0), NewPoint(1, 0.5), RightSide, RightSide},
+		{NewPoint(0, 0), NewPoint(0, 1), NewPoint(0, 5), OnLine, OnLine},
+		{NewPoint(0, 179), NewPoint(0, -179), NewPoint(1, 180), LeftSide, LeftSide},
+		// North of the parallel but south of the great circle, which bows towards the pole.
+		{NewPoint(60, -30), NewPoint(60, 30), NewPoint(62, 0), LeftSide, RightSide},
+		{NewPoint(10, 10), NewPoint(10, 10), NewPoint(20, 20), OnLine, OnLine},
+	}
+	for _, tt := range tests {
+		if side := SideOf(tt.a, tt.b, tt.p); side != tt.side {
+			t.Errorf("Expected %v on side %d of %v to %v, but got %d", tt.p, tt.side, tt.a, tt.b, side)
+		}
+		if side := GreatCircleSideOf(tt.a, tt.b, tt.p); side != tt.circle {
+			t.Errorf("Expected %v on side %d of the great circle from %v to %v, but got %d", tt.p, tt.circle, tt.a, tt.b, side)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package geo
+
+// Simplify returns the LineString with the points dropped that lie within tolerance of the great circles
+// between those kept, using the Douglas-Peucker algorithm.  The first and last points are always kept.
+func (l LineString) Simplify(tolerance Distance) LineString {
+	return LineString{points: simplify(l.points, tolerance), crs: l.crs}
+}
+
+// Simplify returns the Polygon with the points of its ring dropped that lie within tolerance of the great
+// circles between those kept, like LineString.Simplify.  The first point is always kept, and a Polygon
+// that would be left with fewer than 3 points is returned unchanged.
+func (p Polygon) Simplify(tolerance Distance) Polygon {
+	ring := p.ring().points
+	if len(ring) < 4 {
+		return p
+	}
+	kept := simplify(ring, tolerance)
+	if len(kept) < 4 {
+		return p
+	}
+	return NewPolygon(kept[:len(kept)-1]).WithCRS(p.crs)
+}
+
+// simplify returns the points kept by Douglas-Peucker simplification with the passed in tolerance.
+func simplify(points []Point, tolerance Distance) []Point {
+	if len(points) < 3 {
+		return append([]Point(nil), points...)
+	}
+
+	keep := make([]bool, len(points))
+	keep[0], keep[len(points)-1] = true, true
+	spans := [][2]int{{0, len(points) - 1}}
+	for len(spans) > 0 {
+		span := spans[len(spans)-1]
+		spans = spans[:len(spans)-1]
+
+		a, b := points[span[0]], points[span[1]]
+		farthest, distance := -1, tolerance
+		for i := span[0] + 1; i < span[1]; i++ {
+			if d := points[i].GreatCircleDistance(closestOnArc(points[i], a, b)); d > distance {
+				farthest, distance = i, d
+			}
+		}
+		if farthest >= 0 {
+			keep[farthest] = true
+			spans = append(spans, [2]int{span[0], farthest}, [2]int{farthest, span[1]})
+		}
+	}
+
+	var kept []Point
+	for i, p := range points {
+		if keep[i] {
+			kept = append(kept, p)
+		}
+	}
+	return kept
+}
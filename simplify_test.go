@@ -0,0 +1,55 @@
+package geo
+
+import "testing"
+
+// Ensures that points close to the line between their neighbors are dropped and the ends are kept.
+func TestLineStringSimplify(t *testing.T) {
+	line := NewLineString([]Point{
+		NewPoint(0, 0),
+		NewPoint(0.00001, 0.5),
+		NewPoint(0, 1),
+		NewPoint(0.5, 1.00001),
+		NewPoint(1, 1),
+	})
+
+	points := line.Simplify(10 * Meter).Points()
+	expected := []Point{NewPoint(0, 0), NewPoint(0, 1), NewPoint(1, 1)}
+	if len(points) != len(expected) {
+		t.Fatalf("Expected %v, but got %v", expected, points)
+	}
+	for i := range expected {
+		if points[i] != expected[i] {
+			t.Errorf("Expected %v, but got %v", expected, points)
+		}
+	}
+
+	if n := len(line.Simplify(Meter).Points()); n != 5 {
+		t.Errorf("Expected a tolerance of 1m to keep every point, but kept %d", n)
+	}
+	if n := len(NewLineString(nil).Simplify(Meter).Points()); n != 0 {
+		t.Errorf("Expected an empty line to stay empty, but got %d points", n)
+	}
+}
+
+// Ensures that polygon rings are simplified without collapsing below a triangle.
+func TestPolygonSimplify(t *testing.T) {
+	square := NewPolygon([]Point{
+		NewPoint(0, 0),
+		NewPoint(0, 0.5),
+		NewPoint(0, 1),
+		NewPoint(1, 1),
+		NewPoint(1, 0),
+	})
+
+	simplified := square.Simplify(10 * Meter)
+	if n := len(simplified.Points()); n != 4 {
+		t.Errorf("Expected the square to keep its 4 corners, but got %v", simplified.Points())
+	}
+	if !simplified.Contains(NewPoint(0.5, 0.5)) {
+		t.Errorf("Expected the simplified square to contain its center")
+	}
+
+	if n := len(square.Simplify(1000 * Kilometer).Points()); n != 5 {
+		t.Errorf("Expected a collapsing polygon to be returned unchanged, but got %d points", n)
+	}
+}
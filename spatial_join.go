@@ -0,0 +1,51 @@
+package geo
+
+import (
+	"context"
+	"runtime"
+	"sort"
+)
+
+// SpatialJoin returns, for each of the passed in points, the indices of the polygons containing it
+// in ascending order.  The polygons are indexed by their bounding boxes so each point is only
+// tested against the polygons near it, and the points are processed in parallel.
+func SpatialJoin(points []Point, polygons []Polygon) [][]int {
+	results, _ := SpatialJoinCtx(context.Background(), points, polygons)
+	return results
+}
+
+// SpatialJoinCtx is like SpatialJoin, but stops early and returns ctx.Err() once ctx is done.
+func SpatialJoinCtx(ctx context.Context, points []Point, polygons []Polygon) ([][]int, error) {
+	return SpatialJoinWith(ctx, points, polygons, BatchOptions{})
+}
+
+// SpatialJoinWith is like SpatialJoinCtx, but controlled by the passed in options.
+// Its progress is counted in points.
+func SpatialJoinWith(ctx context.Context, points []Point, polygons []Polygon, opts BatchOptions) ([][]int, error) {
+	bounds := make([]BoundingBox, len(polygons))
+	for i, p := range polygons {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		bounds[i] = p.Bounds()
+	}
+	tree := newIndexRTree(bounds)
+
+	results := make([][]int, len(points))
+	err := parallelForCtx(ctx, len(points), runtime.GOMAXPROCS(0), opts.Progress, func(i int) {
+		var ids []int
+		tree.SearchFunc(points[i].Bounds(), func(item indexedBounds) bool {
+			if polygons[item.i].Contains(points[i]) {
+				ids = append(ids, item.i)
+			}
+			return true
+		})
+		sort.Ints(ids)
+		results[i] = ids
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
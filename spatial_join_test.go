@@ -0,0 +1,60 @@
+package geo
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// Ensures that the spatial join assigns each point the same polygons as a brute force scan.
+func TestSpatialJoin(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+
+	// A grid of overlapping squares, so points fall into zero, one or several polygons.
+	var polygons []Polygon
+	for lat := 0.0; lat < 20; lat += 1.5 {
+		for lng := 0.0; lng < 20; lng += 1.5 {
+			polygons = append(polygons, NewPolygon([]Point{
+				NewPoint(lat, lng), NewPoint(lat, lng+2), NewPoint(lat+2, lng+2), NewPoint(lat+2, lng),
+			}))
+		}
+	}
+
+	points := make([]Point, 500)
+	for i := range points {
+		points[i] = NewPoint(r.Float64()*24-2, r.Float64()*24-2)
+	}
+
+	results := SpatialJoin(points, polygons)
+	for i, point := range points {
+		var expected []int
+		for j, p := range polygons {
+			if p.Contains(point) {
+				expected = append(expected, j)
+			}
+		}
+
+		if !reflect.DeepEqual(results[i], expected) {
+			t.Errorf("Expected %v to be joined to %v, but got %v", point, expected, results[i])
+		}
+	}
+}
+
+// Ensures that the context-aware spatial join returns the context's error once it is cancelled.
+func TestSpatialJoinCtx(t *testing.T) {
+	polygons := []Polygon{NewPolygon([]Point{NewPoint(0, 0), NewPoint(0, 2), NewPoint(2, 2), NewPoint(2, 0)})}
+	points := []Point{NewPoint(1, 1), NewPoint(3, 3)}
+
+	results, err := SpatialJoinCtx(context.Background(), points, polygons)
+	if err != nil || !reflect.DeepEqual(results, [][]int{{0}, nil}) {
+		t.Errorf("Expected [[0] []], but got %v, %v", results, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := SpatialJoinCtx(ctx, points, polygons); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, but got %v", err)
+	}
+}
@@ -0,0 +1,132 @@
+package geo
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// srtmVoid marks a sample without data in an SRTM tile.
+const srtmVoid = -32768
+
+// An SRTMElevator is an Elevator backed by a directory of SRTM ".hgt" tiles, such as
+// N37W123.hgt, each covering one degree of latitude and longitude at either three
+// (1201x1201 samples) or one (3601x3601 samples) arc second resolution.
+// Tiles are loaded on first use and kept in memory.  It is safe for concurrent use.
+type SRTMElevator struct {
+	dir string
+
+	mu    sync.Mutex
+	tiles map[string]*elevationGrid
+}
+
+var _ Elevator = (*SRTMElevator)(nil)
+
+// NewSRTMElevator returns a new SRTMElevator that reads its tiles from dir.
+func NewSRTMElevator(dir string) *SRTMElevator {
+	return &SRTMElevator{dir: dir, tiles: make(map[string]*elevationGrid)}
+}
+
+// ElevationAt returns the elevation of the terrain at Point p, bilinearly interpolated
+// between the surrounding samples.  It returns an error wrapping ErrOutOfBounds
+// if the directory holds no tile for p.
+func (e *SRTMElevator) ElevationAt(ctx context.Context, p Point) (Distance, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	tile, err := e.tile(p)
+	if err != nil {
+		return 0, err
+	}
+	return tile.elevationAt(p)
+}
+
+// ElevationsAt returns the elevation of the terrain at each of the passed in points, in order.
+func (e *SRTMElevator) ElevationsAt(ctx context.Context, points []Point) ([]Distance, error) {
+	return elevationsAt(ctx, points, e.ElevationAt)
+}
+
+// tile returns the tile covering Point p, loading it if it has not been used before.
+func (e *SRTMElevator) tile(p Point) (*elevationGrid, error) {
+	lat, lng := int(math.Floor(p.lat)), int(math.Floor(p.lng))
+	name := srtmTileName(lat, lng)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if tile, ok := e.tiles[name]; ok {
+		if tile == nil {
+			return nil, fmt.Errorf("%w: no srtm tile %s for %v", ErrOutOfBounds, name, p)
+		}
+		return tile, nil
+	}
+
+	tile, err := loadSRTMTile(filepath.Join(e.dir, name), lat, lng)
+	if errors.Is(err, fs.ErrNotExist) {
+		// Remember the missing tile, so that oceans are not looked for over and over again.
+		e.tiles[name] = nil
+		return nil, fmt.Errorf("%w: no srtm tile %s for %v", ErrOutOfBounds, name, p)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	e.tiles[name] = tile
+	return tile, nil
+}
+
+// srtmTileName returns the name of the tile whose southwest corner is at lat, lng.
+func srtmTileName(lat, lng int) string {
+	ns, ew := 'N', 'E'
+	if lat < 0 {
+		ns, lat = 'S', -lat
+	}
+	if lng < 0 {
+		ew, lng = 'W', -lng
+	}
+	return fmt.Sprintf("%c%02d%c%03d.hgt", ns, lat, ew, lng)
+}
+
+// loadSRTMTile reads the tile at path, whose southwest corner is at lat, lng.
+// Its samples are big endian 16 bit integers in meters, in rows from north to south.
+func loadSRTMTile(path string, lat, lng int) (*elevationGrid, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var size int
+	switch len(data) {
+	case 1201 * 1201 * 2:
+		size = 1201
+	case 3601 * 3601 * 2:
+		size = 3601
+	default:
+		return nil, fmt.Errorf("%w: srtm tile %s has unexpected size %d", ErrInvalidFormat, path, len(data))
+	}
+
+	heights := make([]int16, size*size)
+	for i := range heights {
+		heights[i] = int16(binary.BigEndian.Uint16(data[2*i:]))
+	}
+
+	step := 1 / float64(size-1)
+	return &elevationGrid{
+		rows:      size,
+		cols:      size,
+		north:     float64(lat + 1),
+		west:      float64(lng),
+		latStep:   step,
+		lngStep:   step,
+		heights:   heights,
+		noData:    srtmVoid,
+		hasNoData: true,
+	}, nil
+}
@@ -0,0 +1,75 @@
+package geo
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Ensures that the SRTM elevator finds the tile of a point and interpolates between its samples.
+func TestSRTMElevator(t *testing.T) {
+	// The height of each sample of the tile rises by a meter per row southwards and per column eastwards.
+	const size = 1201
+	data := make([]byte, size*size*2)
+	for r := 0; r < size; r++ {
+		for c := 0; c < size; c++ {
+			binary.BigEndian.PutUint16(data[2*(r*size+c):], uint16(r+c))
+		}
+	}
+	v := int16(srtmVoid)
+	binary.BigEndian.PutUint16(data[2*(600*size+600):], uint16(v))
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "S34E151.hgt"), data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	e := NewSRTMElevator(dir)
+	ctx := context.Background()
+	step := 1.0 / (size - 1)
+
+	tests := []struct {
+		p        Point
+		expected Distance
+	}{
+		{NewPoint(-33-step, 151), 1},
+		{NewPoint(-34+step, 152-step), 2398},
+		{NewPoint(-33-10*step, 151+20*step), 30},
+		{NewPoint(-33-10.5*step, 151+20.25*step), 30.75},
+	}
+	for _, tt := range tests {
+		if elevation, err := e.ElevationAt(ctx, tt.p); err != nil || math.Abs(float64(elevation-tt.expected)) > 1e-6 {
+			t.Errorf("Expected %v at %v, but got %v, %v", tt.expected, tt.p, elevation, err)
+		}
+	}
+
+	if _, err := e.ElevationAt(ctx, NewPoint(-33-600.5*step, 151+600.5*step)); !errors.Is(err, ErrNoResults) {
+		t.Errorf("Expected a void to return ErrNoResults, but got %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := e.ElevationsAt(ctx, []Point{NewPoint(-33.25, 151.25), NewPoint(-33.25, 150.25)}); !errors.Is(err, ErrOutOfBounds) {
+			t.Errorf("Expected a point without a tile to return ErrOutOfBounds, but got %v", err)
+		}
+	}
+}
+
+// Ensures that SRTM tiles are named after their southwest corner.
+func TestSRTMTileName(t *testing.T) {
+	tests := []struct {
+		lat, lng int
+		expected string
+	}{
+		{37, -123, "N37W123.hgt"},
+		{-34, 151, "S34E151.hgt"},
+		{0, 0, "N00E000.hgt"},
+	}
+	for _, tt := range tests {
+		if name := srtmTileName(tt.lat, tt.lng); name != tt.expected {
+			t.Errorf("Expected %s, but got %s", tt.expected, name)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package geo
+
+import (
+	"math"
+	"time"
+)
+
+// sunCoordinates returns the right ascension and declination of the sun in radians, and the
+// Greenwich mean sidereal time in radians, at time t.  It follows the low precision solar
+// coordinates of the Astronomical Almanac, which are accurate to about 0.01 degrees.
+func sunCoordinates(t time.Time) (ra float64, dec float64, gmst float64) {
+	// Days since the J2000.0 epoch.
+	n := float64(t.UnixNano())/float64(24*time.Hour) + 2440587.5 - 2451545.0
+
+	meanLng := (280.460 + 0.9856474*n) * math.Pi / 180
+	meanAnomaly := (357.528 + 0.9856003*n) * math.Pi / 180
+	eclipticLng := meanLng + (1.915*math.Sin(meanAnomaly)+0.020*math.Sin(2*meanAnomaly))*math.Pi/180
+	obliquity := (23.439 - 0.0000004*n) * math.Pi / 180
+
+	sinLng := math.Sin(eclipticLng)
+	ra = math.Atan2(math.Cos(obliquity)*sinLng, math.Cos(eclipticLng))
+	dec = math.Asin(math.Sin(obliquity) * sinLng)
+	gmst = math.Mod(18.697374558+24.06570982441908*n, 24) * 15 * math.Pi / 180
+	return ra, dec, gmst
+}
+
+// SubsolarPoint returns the Point at which the sun is directly overhead at time t.
+func SubsolarPoint(t time.Time) Point {
+	ra, dec, gmst := sunCoordinates(t)
+	return NewPoint(dec*180/math.Pi, NormalizeLng((ra-gmst)*180/math.Pi))
+}
+
+// SunPosition returns the position of the center of the sun as seen from Point p at time t:
+// its azimuth in degrees clockwise from true north, and its elevation in degrees above the
+// horizon, which is negative at night.  Atmospheric refraction, which lifts the sun by about
+// half a degree at the horizon, is not accounted for.
+func SunPosition(p Point, t time.Time) (azimuth float64, elevation float64) {
+	ra, dec, gmst := sunCoordinates(t)
+	hourAngle := gmst + p.lng*math.Pi/180 - ra
+	lat := p.lat * math.Pi / 180
+
+	sinLat, cosLat := math.Sincos(lat)
+	sinDec, cosDec := math.Sincos(dec)
+	sinH, cosH := math.Sincos(hourAngle)
+
+	elevation = math.Asin(sinLat*sinDec + cosLat*cosDec*cosH)
+	azimuth = math.Atan2(-sinH*cosDec, cosLat*sinDec-sinLat*cosDec*cosH)
+	return math.Mod(azimuth*180/math.Pi+360, 360), elevation * 180 / math.Pi
+}
+
+// IsDaylight reports whether the center of the sun is above the horizon at Point p at time t.
+func IsDaylight(p Point, t time.Time) bool {
+	_, elevation := SunPosition(p, t)
+	return elevation > 0
+}
+
+// NightPolygon returns the Polygon of the part of the earth in darkness at time t, bounded by
+// the day-night terminator sampled at n+1 evenly spaced longitudes from -180 to 180 and closed
+// along the pole in darkness.  n is raised to at least 2.
+func NightPolygon(t time.Time, n int) Polygon {
+	n = max(n, 2)
+	sun := SubsolarPoint(t)
+
+	// At the equinoxes the terminator runs through both poles; keep it a function of longitude.
+	dec := sun.lat * math.Pi / 180
+	if math.Abs(dec) < 1e-6 {
+		dec = math.Copysign(1e-6, dec)
+	}
+	tanDec := math.Tan(dec)
+
+	points := make([]Point, 0, n+3)
+	for i := 0; i <= n; i++ {
+		lng := -180 + 360*float64(i)/float64(n)
+		lat := math.Atan(-math.Cos((lng-sun.lng)*math.Pi/180)/tanDec) * 180 / math.Pi
+		points = append(points, NewPoint(lat, lng))
+	}
+
+	pole := -90.0
+	if dec < 0 {
+		pole = 90
+	}
+	points = append(points, NewPoint(pole, 180), NewPoint(pole, -180))
+	return NewPolygon(points)
+}
@@ -0,0 +1,75 @@
+package geo
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// Ensures that the subsolar point follows the declination of the sun and the equation of time.
+func TestSubsolarPoint(t *testing.T) {
+	tests := []struct {
+		t        time.Time
+		expected Point
+	}{
+		// The June solstice and the March equinox.
+		{time.Date(2024, time.June, 20, 20, 51, 0, 0, time.UTC), NewPoint(23.44, -132.3)},
+		{time.Date(2024, time.March, 20, 3, 6, 0, 0, time.UTC), NewPoint(0, 135.4)},
+		// The sun runs about 16 minutes ahead of the clock in early November.
+		{time.Date(2024, time.November, 3, 12, 0, 0, 0, time.UTC), NewPoint(-15.3, -4.1)},
+	}
+
+	for _, tt := range tests {
+		p := SubsolarPoint(tt.t)
+		if math.Abs(p.lat-tt.expected.lat) > 0.05 || math.Abs(p.lng-tt.expected.lng) > 0.1 {
+			t.Errorf("Expected the sun over %v at %v, but got %v", tt.expected, tt.t, p)
+		}
+	}
+}
+
+// Ensures that the position of the sun is seen from the observer's horizon.
+func TestSunPosition(t *testing.T) {
+	greenwich := NewPoint(51.4769, 0)
+	noon := time.Date(2024, time.June, 21, 12, 0, 0, 0, time.UTC)
+
+	azimuth, elevation := SunPosition(greenwich, noon)
+	if math.Abs(azimuth-180) > 1 || math.Abs(elevation-(90-51.4769+23.44)) > 0.05 {
+		t.Errorf("Expected the sun due south at 61.96 degrees, but got %v, %v", azimuth, elevation)
+	}
+	if !IsDaylight(greenwich, noon) {
+		t.Error("Expected daylight at noon in Greenwich")
+	}
+
+	// Sydney's winter morning sun rises in the northeast.
+	azimuth, elevation = SunPosition(NewPoint(-33.8688, 151.2093), time.Date(2024, time.June, 21, 0, 0, 0, 0, time.UTC))
+	if azimuth < 20 || azimuth > 70 || elevation < 5 || elevation > 30 {
+		t.Errorf("Expected a low northeastern sun, but got %v, %v", azimuth, elevation)
+	}
+	if IsDaylight(greenwich, noon.Add(12*time.Hour)) {
+		t.Error("Expected darkness at midnight in Greenwich")
+	}
+}
+
+// Ensures that the night polygon holds exactly the points where the sun is below the horizon.
+func TestNightPolygon(t *testing.T) {
+	for _, instant := range []time.Time{
+		time.Date(2024, time.June, 21, 12, 0, 0, 0, time.UTC),
+		time.Date(2024, time.December, 21, 3, 0, 0, 0, time.UTC),
+		time.Date(2024, time.March, 20, 3, 6, 0, 0, time.UTC),
+	} {
+		night := NightPolygon(instant, 360)
+		for lat := -85.0; lat <= 85; lat += 10 {
+			for lng := -175.0; lng <= 175; lng += 10 {
+				p := NewPoint(lat, lng)
+				_, elevation := SunPosition(p, instant)
+				// Points close to the terminator fall between the sampled longitudes.
+				if math.Abs(elevation) < 1 {
+					continue
+				}
+				if night.Contains(p) != (elevation < 0) {
+					t.Errorf("Expected night at %v on %v to be %v", p, instant, elevation < 0)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+)
+
+// MaxTileLatitude is the northern and southern limit of the Web Mercator projection.
+const MaxTileLatitude = 85.05112877980659
+
+// A Tile is a square XYZ ("slippy map") web map tile.
+// X grows eastwards from the antimeridian, Y grows southwards from MaxTileLatitude.
+type Tile struct {
+	X int
+	Y int
+	Z int
+}
+
+// NewTile returns a new Tile at the passed in coordinates and zoom level.
+func NewTile(x int, y int, z int) Tile {
+	return Tile{X: x, Y: y, Z: z}
+}
+
+// TileAt returns the Tile containing Point p at the passed in zoom level.
+// Latitudes beyond MaxTileLatitude are clamped to the edge of the map.
+func TileAt(p Point, zoom int) Tile {
+	x, y := tileFraction(p, zoom)
+	n := 1 << uint(zoom)
+
+	return Tile{X: clampTile(int(math.Floor(x)), n), Y: clampTile(int(math.Floor(y)), n), Z: zoom}
+}
+
+// TilesInBounds returns every Tile at the passed in zoom level intersecting the BoundingBox b.
+func TilesInBounds(b BoundingBox, zoom int) []Tile {
+	var tiles []Tile
+	for _, lngs := range b.lngIntervals() {
+		sw := TileAt(NewPoint(b.sw.lat, lngs[0]), zoom)
+		ne := TileAt(NewPoint(b.ne.lat, lngs[1]), zoom)
+		for x := sw.X; x <= ne.X; x++ {
+			for y := ne.Y; y <= sw.Y; y++ {
+				tiles = append(tiles, Tile{X: x, Y: y, Z: zoom})
+			}
+		}
+	}
+
+	return tiles
+}
+
+// Valid returns whether or not the Tile lies within the map at its zoom level.
+func (t Tile) Valid() bool {
+	if t.Z < 0 || t.Z > 30 {
+		return false
+	}
+
+	n := 1 << uint(t.Z)
+	return t.X >= 0 && t.X < n && t.Y >= 0 && t.Y < n
+}
+
+// Bounds returns the area covered by the Tile.
+func (t Tile) Bounds() BoundingBox {
+	return NewBoundingBox(tileCorner(t.X, t.Y+1, t.Z), tileCorner(t.X+1, t.Y, t.Z))
+}
+
+// Polygon returns the outline of the Tile as a Polygon, starting at its south-west corner.
+func (t Tile) Polygon() Polygon {
+	b := t.Bounds()
+	return NewPolygon([]Point{
+		b.sw,
+		NewPoint(b.ne.lat, b.sw.lng),
+		b.ne,
+		NewPoint(b.sw.lat, b.ne.lng),
+	})
+}
+
+// Center returns the Point in the middle of the Tile in projected space.
+func (t Tile) Center() Point {
+	return tileCornerFraction(float64(t.X)+0.5, float64(t.Y)+0.5, t.Z)
+}
+
+// Parent returns the Tile one zoom level up that contains t.
+// The parent of a zoom level 0 tile is itself.
+func (t Tile) Parent() Tile {
+	if t.Z == 0 {
+		return t
+	}
+
+	return Tile{X: t.X >> 1, Y: t.Y >> 1, Z: t.Z - 1}
+}
+
+// Children returns the four Tiles one zoom level down that make up t,
+// in the order top-left, top-right, bottom-left, bottom-right.
+func (t Tile) Children() [4]Tile {
+	x, y, z := t.X<<1, t.Y<<1, t.Z+1
+	return [4]Tile{{x, y, z}, {x + 1, y, z}, {x, y + 1, z}, {x + 1, y + 1, z}}
+}
+
+// String renders the Tile in the z/x/y form used by tile URLs.
+func (t Tile) String() string {
+	return fmt.Sprintf("%d/%d/%d", t.Z, t.X, t.Y)
+}
+
+// tileFraction returns the fractional tile coordinates of Point p at the passed in zoom level.
+func tileFraction(p Point, zoom int) (x float64, y float64) {
+	lat := math.Max(-MaxTileLatitude, math.Min(MaxTileLatitude, p.lat))
+	n := math.Exp2(float64(zoom))
+	rad := lat * math.Pi / 180
+
+	x = (p.lng + 180) / 360 * n
+	y = (1 - math.Log(math.Tan(rad)+1/math.Cos(rad))/math.Pi) / 2 * n
+	return x, y
+}
+
+// tileCorner returns the north-west corner of tile x, y at the passed in zoom level.
+func tileCorner(x int, y int, zoom int) Point {
+	return tileCornerFraction(float64(x), float64(y), zoom)
+}
+
+func tileCornerFraction(x float64, y float64, zoom int) Point {
+	n := math.Exp2(float64(zoom))
+	lng := x/n*360 - 180
+	lat := math.Atan(math.Sinh(math.Pi*(1-2*y/n))) * 180 / math.Pi
+	return NewPoint(lat, lng)
+}
+
+func clampTile(v int, n int) int {
+	if v < 0 {
+		return 0
+	}
+	if v >= n {
+		return n - 1
+	}
+	return v
+}
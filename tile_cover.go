@@ -0,0 +1,108 @@
+package geo
+
+import "math"
+
+// TileCover returns every Tile at the passed in zoom level that intersects geom.
+// Points, LineStrings and Polygons are covered exactly; any other Geometry
+// is covered by the tiles of its bounding box.  Edges are drawn as straight lines
+// in Web Mercator space and are assumed not to cross the antimeridian.
+func TileCover(geom Geometry, zoom int) []Tile {
+	c := newTileCoverer(zoom)
+
+	switch g := geom.(type) {
+	case Point:
+		return []Tile{TileAt(g, zoom)}
+	case LineString:
+		c.coverLine(g.points, false)
+	case Polygon:
+		if !g.IsClosed() {
+			c.coverLine(g.points, false)
+			break
+		}
+		c.coverLine(g.points, true)
+		for _, t := range TilesInBounds(g.Bounds(), zoom) {
+			if !c.seen[t] && g.Contains(t.Center()) {
+				c.add(t.X, t.Y)
+			}
+		}
+	default:
+		return TilesInBounds(geom.Bounds(), zoom)
+	}
+
+	return c.tiles
+}
+
+// tileCoverer accumulates distinct tiles at a single zoom level.
+type tileCoverer struct {
+	zoom  int
+	n     int
+	seen  map[Tile]bool
+	tiles []Tile
+}
+
+func newTileCoverer(zoom int) *tileCoverer {
+	return &tileCoverer{zoom: zoom, n: 1 << uint(zoom), seen: make(map[Tile]bool)}
+}
+
+func (c *tileCoverer) add(x int, y int) {
+	t := Tile{X: clampTile(x, c.n), Y: clampTile(y, c.n), Z: c.zoom}
+	if !c.seen[t] {
+		c.seen[t] = true
+		c.tiles = append(c.tiles, t)
+	}
+}
+
+// coverLine adds every tile crossed by the edges between the passed in points,
+// including the edge back to the first point if closed is set.
+func (c *tileCoverer) coverLine(points []Point, closed bool) {
+	if len(points) == 1 {
+		x, y := tileFraction(points[0], c.zoom)
+		c.add(int(math.Floor(x)), int(math.Floor(y)))
+	}
+
+	for i := 1; i < len(points); i++ {
+		c.coverSegment(points[i-1], points[i])
+	}
+
+	if closed && len(points) > 2 {
+		c.coverSegment(points[len(points)-1], points[0])
+	}
+}
+
+// coverSegment walks the tile grid from a to b, adding each tile the segment passes through.
+func (c *tileCoverer) coverSegment(a Point, b Point) {
+	x0, y0 := tileFraction(a, c.zoom)
+	x1, y1 := tileFraction(b, c.zoom)
+	dx, dy := x1-x0, y1-y0
+
+	x, y := int(math.Floor(x0)), int(math.Floor(y0))
+	c.add(x, y)
+
+	sx, tMaxX, tDeltaX := tileStep(x0, dx)
+	sy, tMaxY, tDeltaY := tileStep(y0, dy)
+
+	for tMaxX < 1 || tMaxY < 1 {
+		if tMaxX < tMaxY {
+			tMaxX += tDeltaX
+			x += sx
+		} else {
+			tMaxY += tDeltaY
+			y += sy
+		}
+		c.add(x, y)
+	}
+}
+
+// tileStep returns the direction of travel along one axis, the fraction of the
+// segment until the first grid line is crossed, and the fraction between grid lines.
+func tileStep(start float64, delta float64) (step int, tMax float64, tDelta float64) {
+	if delta == 0 {
+		return 0, math.Inf(1), math.Inf(1)
+	}
+
+	if delta > 0 {
+		return 1, (math.Floor(start) + 1 - start) / delta, 1 / delta
+	}
+
+	return -1, (start - math.Floor(start)) / -delta, -1 / delta
+}
@@ -0,0 +1,62 @@
+package geo
+
+import "testing"
+
+// Ensures that a point is covered by exactly its own tile.
+func TestTileCoverPoint(t *testing.T) {
+	tiles := TileCover(NewPoint(52.52, 13.405), 10)
+	if len(tiles) != 1 || tiles[0] != NewTile(550, 335, 10) {
+		t.Errorf("Expected a point to be covered by its tile, but got %v", tiles)
+	}
+}
+
+// Ensures that a line is covered by each tile it passes through and no others.
+func TestTileCoverLineString(t *testing.T) {
+	// A diagonal from the south-west to the north-east quadrant at zoom 1
+	// crosses the prime meridian south of the equator, touching three tiles.
+	line := NewLineString([]Point{NewPoint(-10, -10), NewPoint(10, 20)})
+	tiles := TileCover(line, 1)
+	if len(tiles) != 3 || tiles[0] != NewTile(0, 1, 1) || tiles[1] != NewTile(1, 1, 1) || tiles[2] != NewTile(1, 0, 1) {
+		t.Errorf("Expected the diagonal to touch 1/0/1, 1/1/1 and 1/1/0, but got %v", tiles)
+	}
+
+	// A horizontal line across the whole map at zoom 2 touches each column once.
+	line = NewLineString([]Point{NewPoint(10, -170), NewPoint(10, 170)})
+	tiles = TileCover(line, 2)
+	if len(tiles) != 4 {
+		t.Errorf("Expected the horizontal line to touch 4 tiles, but got %v", tiles)
+	}
+	for _, tile := range tiles {
+		if tile.Y != 1 {
+			t.Errorf("Expected every tile to be in row 1, but got %v", tile)
+		}
+	}
+}
+
+// Ensures that a polygon is covered by its boundary tiles and the tiles inside of it.
+func TestTileCoverPolygon(t *testing.T) {
+	// Covering almost the whole map at zoom 2 should touch all 16 tiles,
+	// including the interior ones that no edge passes through.
+	poly := NewPolygon([]Point{
+		NewPoint(-80, -170), NewPoint(80, -170), NewPoint(80, 170), NewPoint(-80, 170),
+	})
+	tiles := TileCover(poly, 2)
+	if len(tiles) != 16 {
+		t.Errorf("Expected the polygon to touch all 16 tiles, but got %d", len(tiles))
+	}
+
+	// A polygon inside a single tile is covered by that tile alone.
+	small := NewPolygon([]Point{NewPoint(52.5, 13.4), NewPoint(52.51, 13.4), NewPoint(52.51, 13.41)})
+	tiles = TileCover(small, 10)
+	if len(tiles) != 1 || tiles[0] != NewTile(550, 335, 10) {
+		t.Errorf("Expected the small polygon to be covered by one tile, but got %v", tiles)
+	}
+}
+
+// Ensures that other geometries are covered by their bounding box.
+func TestTileCoverBoundingBox(t *testing.T) {
+	tiles := TileCover(NewBoundingBox(NewPoint(-10, -10), NewPoint(10, 10)), 1)
+	if len(tiles) != 4 {
+		t.Errorf("Expected a box around the origin to touch 4 tiles, but got %v", tiles)
+	}
+}
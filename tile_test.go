@@ -0,0 +1,82 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+// Ensures that points resolve to the expected XYZ tiles.
+func TestTileAt(t *testing.T) {
+	tests := []struct {
+		p        Point
+		zoom     int
+		expected Tile
+	}{
+		{NewPoint(52.52, 13.405), 10, NewTile(550, 335, 10)},
+		{NewPoint(0, 0), 1, NewTile(1, 1, 1)},
+		{NewPoint(89, 180), 2, NewTile(3, 0, 2)},
+		{NewPoint(-89, -180), 2, NewTile(0, 3, 2)},
+		{NewPoint(10, 10), 0, NewTile(0, 0, 0)},
+	}
+
+	for _, tt := range tests {
+		if got := TileAt(tt.p, tt.zoom); got != tt.expected {
+			t.Errorf("Expected %v at zoom %d to be in tile %v, but got %v instead", tt.p, tt.zoom, tt.expected, got)
+		}
+	}
+}
+
+// Ensures that the bounds of a tile contain the points that resolve to it.
+func TestTileBounds(t *testing.T) {
+	p := NewPoint(52.52, 13.405)
+	tile := TileAt(p, 10)
+	b := tile.Bounds()
+
+	if !b.Contains(p) {
+		t.Errorf("Expected tile %v bounds %v to contain %v", tile, b, p)
+	}
+
+	world := NewTile(0, 0, 0).Bounds()
+	if math.Abs(world.ne.lat-MaxTileLatitude) > 1e-9 || world.sw.lng != -180 || world.ne.lng != 180 {
+		t.Errorf("Expected the zoom 0 tile to cover the whole map, but got %v", world)
+	}
+
+	if !tile.Polygon().Contains(tile.Center()) {
+		t.Error("Expected the tile polygon to contain the tile center")
+	}
+}
+
+// Ensures that a box is covered by all tiles it touches.
+func TestTilesInBounds(t *testing.T) {
+	tiles := TilesInBounds(NewBoundingBox(NewPoint(-10, -10), NewPoint(10, 10)), 1)
+	if len(tiles) != 4 {
+		t.Errorf("Expected a box around the origin to touch all 4 zoom 1 tiles, but got %v", tiles)
+	}
+
+	tiles = TilesInBounds(NewBoundingBox(NewPoint(1, 170), NewPoint(2, -170)), 2)
+	if len(tiles) != 2 || tiles[0].X != 3 || tiles[1].X != 0 {
+		t.Errorf("Expected a box across the antimeridian to touch the first and last columns, but got %v", tiles)
+	}
+}
+
+// Ensures that parents and children are consistent with each other.
+func TestTileHierarchy(t *testing.T) {
+	tile := NewTile(550, 335, 10)
+	for _, child := range tile.Children() {
+		if child.Parent() != tile {
+			t.Errorf("Expected the parent of %v to be %v, but got %v instead", child, tile, child.Parent())
+		}
+	}
+
+	if NewTile(0, 0, 0).Parent() != NewTile(0, 0, 0) {
+		t.Error("Expected the root tile to be its own parent")
+	}
+
+	if !tile.Valid() || NewTile(4, 0, 2).Valid() {
+		t.Error("Expected validity to follow the number of tiles at the zoom level")
+	}
+
+	if tile.String() != "10/550/335" {
+		t.Errorf("Expected 10/550/335, but got %s instead", tile)
+	}
+}
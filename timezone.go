@@ -0,0 +1,199 @@
+package geo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// A TimezoneIndex finds the IANA time zone of a location from the boundaries published by the
+// timezone-boundary-builder project (https://github.com/evansiroky/timezone-boundary-builder).
+// Unlike the rest of the package, it honors the holes of each boundary, because time zones
+// enclose one another.  It is immutable once built and safe for concurrent use.
+//
+// Locations are loaded with time.LoadLocation, so programs running where the system has no
+// time zone database should import the time/tzdata package.
+type TimezoneIndex struct {
+	names []string
+	parts []timezonePart
+	tree  *RTree[indexedBounds]
+
+	locations sync.Map
+}
+
+// timezonePart is one polygon of the boundary of the zone names[zone].
+type timezonePart struct {
+	zone  int
+	outer Polygon
+	holes []Polygon
+}
+
+func (t timezonePart) contains(p Point) bool {
+	if !t.outer.Contains(p) {
+		return false
+	}
+	for _, hole := range t.holes {
+		if hole.Contains(p) {
+			return false
+		}
+	}
+	return true
+}
+
+// LoadTimezoneIndex returns a new TimezoneIndex over a GeoJSON FeatureCollection of time zone
+// boundaries, such as the combined.json release of timezone-boundary-builder.  Every feature must
+// have a Polygon or MultiPolygon geometry and name its zone in a "tzid" property.
+func LoadTimezoneIndex(r io.Reader) (*TimezoneIndex, error) {
+	var doc struct {
+		Type     string `json:"type"`
+		Features []struct {
+			Properties struct {
+				TZID string `json:"tzid"`
+			} `json:"properties"`
+			Geometry struct {
+				Type        string          `json:"type"`
+				Coordinates json.RawMessage `json:"coordinates"`
+			} `json:"geometry"`
+		} `json:"features"`
+	}
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFormat, err)
+	}
+	if doc.Type != "FeatureCollection" {
+		return nil, fmt.Errorf("%w: unexpected GeoJSON type %q, expected FeatureCollection", ErrUnsupportedGeometry, doc.Type)
+	}
+
+	x := &TimezoneIndex{}
+	for _, f := range doc.Features {
+		if f.Properties.TZID == "" {
+			return nil, fmt.Errorf("%w: time zone feature has no tzid", ErrInvalidFormat)
+		}
+
+		var polygons [][][][]float64
+		var err error
+		switch f.Geometry.Type {
+		case "Polygon":
+			polygons = make([][][][]float64, 1)
+			err = json.Unmarshal(f.Geometry.Coordinates, &polygons[0])
+		case "MultiPolygon":
+			err = json.Unmarshal(f.Geometry.Coordinates, &polygons)
+		default:
+			return nil, fmt.Errorf("%w: %s has a %q geometry", ErrUnsupportedGeometry, f.Properties.TZID, f.Geometry.Type)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidFormat, f.Properties.TZID, err)
+		}
+
+		zone := len(x.names)
+		x.names = append(x.names, f.Properties.TZID)
+		for _, rings := range polygons {
+			part, err := newTimezonePart(zone, rings)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", f.Properties.TZID, err)
+			}
+			x.parts = append(x.parts, part)
+		}
+	}
+
+	bounds := make([]BoundingBox, len(x.parts))
+	for i, part := range x.parts {
+		bounds[i] = part.outer.Bounds()
+	}
+	x.tree = newIndexRTree(bounds)
+
+	return x, nil
+}
+
+// newTimezonePart decodes the GeoJSON rings of one polygon of the zone.
+func newTimezonePart(zone int, rings [][][]float64) (timezonePart, error) {
+	if len(rings) == 0 {
+		return timezonePart{}, fmt.Errorf("%w: GeoJSON Polygon has no rings", ErrUnclosedPolygon)
+	}
+
+	part := timezonePart{zone: zone}
+	for i, ring := range rings {
+		points, err := geoJSONPositions(ring)
+		if err != nil {
+			return timezonePart{}, err
+		}
+		if len(points) > 1 && points[0] == points[len(points)-1] {
+			points = points[:len(points)-1]
+		}
+
+		p := NewPolygon(points)
+		if i == 0 {
+			part.outer = p
+		} else {
+			part.holes = append(part.holes, p)
+		}
+	}
+
+	return part, nil
+}
+
+// Len returns the number of time zones in the index.
+func (x *TimezoneIndex) Len() int {
+	return len(x.names)
+}
+
+// TimezoneNameAt returns the IANA name, such as "Australia/Sydney", of the time zone containing Point p.
+// Where boundaries overlap the first name in ascending order is returned.
+// It returns an error wrapping ErrNoResults if no boundary contains p.
+func (x *TimezoneIndex) TimezoneNameAt(p Point) (string, error) {
+	defer timeIndexQuery("timezone")()
+
+	var found []string
+	x.tree.SearchFunc(p.Bounds(), func(item indexedBounds) bool {
+		if part := x.parts[item.i]; part.contains(p) {
+			found = append(found, x.names[part.zone])
+		}
+		return true
+	})
+	if len(found) == 0 {
+		return "", fmt.Errorf("%w: no time zone contains %v", ErrNoResults, p)
+	}
+
+	sort.Strings(found)
+	return found[0], nil
+}
+
+// TimezoneAt returns the time zone containing Point p.
+// It returns an error wrapping ErrNoResults if no boundary contains p.
+func (x *TimezoneIndex) TimezoneAt(p Point) (*time.Location, error) {
+	name, err := x.TimezoneNameAt(p)
+	if err != nil {
+		return nil, err
+	}
+
+	if loc, ok := x.locations.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	x.locations.Store(name, loc)
+	return loc, nil
+}
+
+var timezones atomic.Pointer[TimezoneIndex]
+
+// SetTimezoneIndex installs x as the index used by TimezoneAt.  It is safe to call concurrently with TimezoneAt.
+func SetTimezoneIndex(x *TimezoneIndex) {
+	timezones.Store(x)
+}
+
+// TimezoneAt returns the time zone containing Point p, according to the index installed with SetTimezoneIndex.
+// It returns an error wrapping ErrNoIndex if no index is installed, or ErrNoResults if no boundary contains p.
+func TimezoneAt(p Point) (*time.Location, error) {
+	x := timezones.Load()
+	if x == nil {
+		return nil, fmt.Errorf("%w: no time zone index has been set", ErrNoIndex)
+	}
+	return x.TimezoneAt(p)
+}
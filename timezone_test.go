@@ -0,0 +1,96 @@
+package geo
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	_ "time/tzdata"
+)
+
+// A small boundary set: Sydney with a hole filled by an enclave of Broken Hill,
+// and Brisbane split across two polygons.
+const testTimezones = `{"type": "FeatureCollection", "features": [
+	{"type": "Feature", "properties": {"tzid": "Australia/Sydney"}, "geometry": {"type": "Polygon", "coordinates": [
+		[[148, -36], [152, -36], [152, -32], [148, -32], [148, -36]],
+		[[148.5, -35.5], [149.5, -35.5], [149.5, -35], [148.5, -35], [148.5, -35.5]]
+	]}},
+	{"type": "Feature", "properties": {"tzid": "Australia/Broken_Hill"}, "geometry": {"type": "Polygon", "coordinates": [
+		[[148.5, -35.5], [149.5, -35.5], [149.5, -35], [148.5, -35], [148.5, -35.5]]
+	]}},
+	{"type": "Feature", "properties": {"tzid": "Australia/Brisbane"}, "geometry": {"type": "MultiPolygon", "coordinates": [
+		[[[148, -32], [152, -32], [152, -28], [148, -28], [148, -32]]],
+		[[[153, -28], [154, -28], [154, -27], [153, -27], [153, -28]]]
+	]}}
+]}`
+
+// Ensures that the index finds the zone containing a point, including enclaves inside holes.
+func TestTimezoneIndex(t *testing.T) {
+	x, err := LoadTimezoneIndex(strings.NewReader(testTimezones))
+	if err != nil {
+		t.Fatalf("Should not encounter an error when loading time zones, but got %v", err)
+	}
+
+	if x.Len() != 3 {
+		t.Errorf("Expected 3 time zones, but got %d", x.Len())
+	}
+
+	tests := []struct {
+		p        Point
+		expected string
+	}{
+		{NewPoint(-33.87, 151.21), "Australia/Sydney"},
+		{NewPoint(-35.28, 149.13), "Australia/Broken_Hill"},
+		{NewPoint(-30, 150), "Australia/Brisbane"},
+		{NewPoint(-27.5, 153.5), "Australia/Brisbane"},
+	}
+
+	for _, tt := range tests {
+		loc, err := x.TimezoneAt(tt.p)
+		if err != nil || loc.String() != tt.expected {
+			t.Errorf("Expected %v to be in %s, but got %v, %v", tt.p, tt.expected, loc, err)
+		}
+	}
+
+	if _, err := x.TimezoneAt(NewPoint(0, 0)); !errors.Is(err, ErrNoResults) {
+		t.Errorf("Expected ErrNoResults outside of every zone, but got %v", err)
+	}
+}
+
+// Ensures that TimezoneAt uses the installed index.
+func TestTimezoneAt(t *testing.T) {
+	SetTimezoneIndex(nil)
+	if _, err := TimezoneAt(NewPoint(-33.87, 151.21)); !errors.Is(err, ErrNoIndex) {
+		t.Errorf("Expected ErrNoIndex without an index, but got %v", err)
+	}
+
+	x, err := LoadTimezoneIndex(strings.NewReader(testTimezones))
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetTimezoneIndex(x)
+	defer SetTimezoneIndex(nil)
+
+	if loc, err := TimezoneAt(NewPoint(-33.87, 151.21)); err != nil || loc.String() != "Australia/Sydney" {
+		t.Errorf("Expected Australia/Sydney, but got %v, %v", loc, err)
+	}
+}
+
+// Ensures that malformed boundary files are rejected.
+func TestLoadTimezoneIndexInvalid(t *testing.T) {
+	tests := []struct {
+		data     string
+		expected error
+	}{
+		{`not json`, ErrInvalidFormat},
+		{`{"type": "Feature"}`, ErrUnsupportedGeometry},
+		{`{"type": "FeatureCollection", "features": [{"properties": {}, "geometry": {"type": "Polygon", "coordinates": []}}]}`, ErrInvalidFormat},
+		{`{"type": "FeatureCollection", "features": [{"properties": {"tzid": "UTC"}, "geometry": {"type": "Point", "coordinates": [0, 0]}}]}`, ErrUnsupportedGeometry},
+		{`{"type": "FeatureCollection", "features": [{"properties": {"tzid": "UTC"}, "geometry": {"type": "Polygon", "coordinates": []}}]}`, ErrUnclosedPolygon},
+	}
+
+	for _, tt := range tests {
+		if _, err := LoadTimezoneIndex(strings.NewReader(tt.data)); !errors.Is(err, tt.expected) {
+			t.Errorf("Expected %s to fail with %v, but got %v", tt.data, tt.expected, err)
+		}
+	}
+}
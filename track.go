@@ -0,0 +1,170 @@
+package geo
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+)
+
+// A TrackPoint is a timestamped position fix, such as one reported by a GPS receiver.
+type TrackPoint struct {
+	Point Point
+	Time  time.Time
+	// Altitude is the height of the fix in meters above sea level, or nil if it is unknown.
+	Altitude *float64
+	// Accuracy is the estimated horizontal error of the fix, or zero if it is unknown.
+	Accuracy Distance
+}
+
+// A Track is a sequence of fixes in chronological order, such as the path of a vehicle
+// or a recorded hike.
+type Track []TrackPoint
+
+// NewTrackFromLineString returns a Track through the points of l, fixed at the passed in times.
+// It returns an error wrapping ErrInvalidFormat unless there is one time for each point.
+func NewTrackFromLineString(l LineString, times []time.Time) (Track, error) {
+	if len(times) != len(l.points) {
+		return nil, fmt.Errorf("%w: %d times for %d points", ErrInvalidFormat, len(times), len(l.points))
+	}
+
+	t := make(Track, len(times))
+	for i, p := range l.points {
+		t[i] = TrackPoint{Point: p, Time: times[i]}
+	}
+	return t, nil
+}
+
+// Points returns the positions of the fixes of the Track.
+func (t Track) Points() []Point {
+	points := make([]Point, len(t))
+	for i, tp := range t {
+		points[i] = tp.Point
+	}
+	return points
+}
+
+// LineString returns the path of the Track, dropping the times of its fixes.
+func (t Track) LineString() LineString {
+	return NewLineString(t.Points())
+}
+
+// Bounds returns the smallest BoundingBox containing every fix of the Track.
+func (t Track) Bounds() BoundingBox {
+	return pointsBounds(t.Points())
+}
+
+// Distance returns the length of the Track along the great circles between its fixes.
+func (t Track) Distance() Distance {
+	var d Distance
+	for i := 1; i < len(t); i++ {
+		d += t[i-1].Point.GreatCircleDistance(t[i].Point)
+	}
+	return d
+}
+
+// Duration returns the time between the first and last fixes of the Track.
+func (t Track) Duration() time.Duration {
+	if len(t) == 0 {
+		return 0
+	}
+	return t[len(t)-1].Time.Sub(t[0].Time)
+}
+
+// AverageSpeed returns the Distance of the Track divided by its Duration, in meters per second.
+// It returns zero for a Track without duration.
+func (t Track) AverageSpeed() float64 {
+	duration := t.Duration()
+	if duration <= 0 {
+		return 0
+	}
+	return t.Distance().Meters() / duration.Seconds()
+}
+
+// String renders the Track as its fix count and time span.
+func (t Track) String() string {
+	if len(t) == 0 {
+		return "Track(0 points)"
+	}
+	return fmt.Sprintf("Track(%d points, %s to %s)", len(t), t[0].Time.Format(time.RFC3339), t[len(t)-1].Time.Format(time.RFC3339))
+}
+
+// gpxDocument is the subset of the GPX 1.1 schema holding tracks.
+type gpxDocument struct {
+	XMLName xml.Name   `xml:"gpx"`
+	Version string     `xml:"version,attr"`
+	Creator string     `xml:"creator,attr"`
+	XMLNS   string     `xml:"xmlns,attr,omitempty"`
+	Tracks  []gpxTrack `xml:"trk"`
+}
+
+type gpxTrack struct {
+	Segments []gpxSegment `xml:"trkseg"`
+}
+
+type gpxSegment struct {
+	Points []gpxPoint `xml:"trkpt"`
+}
+
+type gpxPoint struct {
+	Lat       float64    `xml:"lat,attr"`
+	Lon       float64    `xml:"lon,attr"`
+	Elevation *float64   `xml:"ele,omitempty"`
+	Time      *time.Time `xml:"time,omitempty"`
+}
+
+// DecodeGPX decodes every track segment of a GPX document into a Track.
+// Fixes keep their elevation and time; GPX has no field for the accuracy of a fix in meters.
+func DecodeGPX(data []byte) ([]Track, error) {
+	var doc gpxDocument
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFormat, err)
+	}
+
+	var tracks []Track
+	for _, trk := range doc.Tracks {
+		for _, seg := range trk.Segments {
+			t := make(Track, len(seg.Points))
+			for i, pt := range seg.Points {
+				p, err := NewPointValidated(pt.Lat, pt.Lon)
+				if err != nil {
+					return nil, fmt.Errorf("%w: %v", ErrInvalidFormat, err)
+				}
+				t[i] = TrackPoint{Point: p, Altitude: pt.Elevation}
+				if pt.Time != nil {
+					t[i].Time = *pt.Time
+				}
+			}
+			tracks = append(tracks, t)
+		}
+	}
+	return tracks, nil
+}
+
+// WriteGPX writes the passed in tracks to w as a GPX 1.1 document, each as a track of one segment.
+// Fixes with a zero Time are written without one.
+func WriteGPX(w io.Writer, tracks ...Track) error {
+	doc := gpxDocument{Version: "1.1", Creator: "golang-geo", XMLNS: "http://www.topografix.com/GPX/1/1"}
+	for _, t := range tracks {
+		seg := gpxSegment{Points: make([]gpxPoint, len(t))}
+		for i, tp := range t {
+			seg.Points[i] = gpxPoint{Lat: tp.Point.lat, Lon: tp.Point.lng, Elevation: tp.Altitude}
+			if !tp.Time.IsZero() {
+				utc := tp.Time.UTC()
+				seg.Points[i].Time = &utc
+			}
+		}
+		doc.Tracks = append(doc.Tracks, gpxTrack{Segments: []gpxSegment{seg}})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,209 @@
+package geo
+
+import "math"
+
+const (
+	// DefaultKalmanProcessNoise is the KalmanOptions.ProcessNoise used when none is configured,
+	// suited to walking and city driving.
+	DefaultKalmanProcessNoise = 1.0
+	// DefaultKalmanAccuracy is the KalmanOptions.DefaultAccuracy used when none is configured,
+	// typical of phone GPS under open sky.
+	DefaultKalmanAccuracy = 10 * Meter
+)
+
+// KalmanOptions configures Track.Filter and Track.Smooth.
+type KalmanOptions struct {
+	// ProcessNoise is the standard deviation in meters per second squared of the unmodeled
+	// acceleration of the tracked object.  Higher values follow turns and braking more closely,
+	// lower values smooth more.  Defaults to DefaultKalmanProcessNoise.
+	ProcessNoise float64
+	// DefaultAccuracy is the horizontal error assumed for fixes with no Accuracy of their own.
+	// Defaults to DefaultKalmanAccuracy.
+	DefaultAccuracy Distance
+}
+
+// A TrackEstimate is the state of a tracked object estimated by a Kalman filter at the time of a fix.
+type TrackEstimate struct {
+	// TrackPoint holds the estimated position, and the time and altitude of the fix.
+	// Its Accuracy is the standard deviation of the estimated position along each axis.
+	TrackPoint
+	// VelocityEast and VelocityNorth are the estimated velocity in meters per second.
+	VelocityEast  float64
+	VelocityNorth float64
+}
+
+// Speed returns the estimated speed in meters per second.
+func (e TrackEstimate) Speed() float64 {
+	return math.Hypot(e.VelocityEast, e.VelocityNorth)
+}
+
+// Heading returns the direction of the estimated velocity in degrees clockwise from true north.
+func (e TrackEstimate) Heading() float64 {
+	return math.Mod(math.Atan2(e.VelocityEast, e.VelocityNorth)*180/math.Pi+360, 360)
+}
+
+// Filter runs a constant velocity Kalman filter over the Track, weighting each fix by its Accuracy,
+// and returns the estimated state at every fix.  Each estimate only depends on the fixes up to it,
+// so Filter suits processing a track as it is recorded; Smooth gives better estimates afterwards.
+func (t Track) Filter(opts KalmanOptions) []TrackEstimate {
+	k := t.kalman(opts)
+	return k.estimates(t, k.filtered)
+}
+
+// Smooth is like Filter, but also runs the Rauch-Tung-Striebel smoother backwards over the
+// filtered states, so that every estimate depends on the whole Track.
+func (t Track) Smooth(opts KalmanOptions) []TrackEstimate {
+	k := t.kalman(opts)
+	if len(t) == 0 {
+		return nil
+	}
+
+	smoothed := make([]kalmanState, len(t))
+	smoothed[len(t)-1] = k.filtered[len(t)-1]
+	for i := len(t) - 2; i >= 0; i-- {
+		f, pred, next := k.filtered[i], k.predicted[i+1], smoothed[i+1]
+		dt := k.dt[i+1]
+
+		// The gain C = P F' P_pred^-1 of the smoother.
+		pf := [2][2]float64{{f.p[0][0] + dt*f.p[0][1], f.p[0][1]}, {f.p[1][0] + dt*f.p[1][1], f.p[1][1]}}
+		inv := inverse2(pred.p)
+		c := mul2(pf, inv)
+
+		var s kalmanState
+		for axis := range 2 {
+			dx, dv := next.x[axis][0]-pred.x[axis][0], next.x[axis][1]-pred.x[axis][1]
+			s.x[axis] = [2]float64{f.x[axis][0] + c[0][0]*dx + c[0][1]*dv, f.x[axis][1] + c[1][0]*dx + c[1][1]*dv}
+		}
+		var dp [2][2]float64
+		for r := range 2 {
+			for col := range 2 {
+				dp[r][col] = next.p[r][col] - pred.p[r][col]
+			}
+		}
+		cdp := mul2(mul2(c, dp), [2][2]float64{{c[0][0], c[1][0]}, {c[0][1], c[1][1]}})
+		for r := range 2 {
+			for col := range 2 {
+				s.p[r][col] = f.p[r][col] + cdp[r][col]
+			}
+		}
+		smoothed[i] = s
+	}
+	return k.estimates(t, smoothed)
+}
+
+// kalmanState is the state of the filter: the position in meters and velocity in meters per
+// second along the east and north axes of a LocalFrame, and their covariance.  Both axes share
+// the same noise, so they share a single covariance matrix.
+type kalmanState struct {
+	x [2][2]float64
+	p [2][2]float64
+}
+
+// kalmanRun holds the states of a forward pass of the filter over a Track.
+type kalmanRun struct {
+	frame     LocalFrame
+	dt        []float64
+	predicted []kalmanState
+	filtered  []kalmanState
+}
+
+// kalman runs the filter forwards over the Track.
+func (t Track) kalman(opts KalmanOptions) kalmanRun {
+	if opts.ProcessNoise <= 0 {
+		opts.ProcessNoise = DefaultKalmanProcessNoise
+	}
+	if opts.DefaultAccuracy <= 0 {
+		opts.DefaultAccuracy = DefaultKalmanAccuracy
+	}
+	if len(t) == 0 {
+		return kalmanRun{}
+	}
+
+	k := kalmanRun{
+		frame:     NewLocalFrame(t[0].Point, 0),
+		dt:        make([]float64, len(t)),
+		predicted: make([]kalmanState, len(t)),
+		filtered:  make([]kalmanState, len(t)),
+	}
+	q := opts.ProcessNoise * opts.ProcessNoise
+
+	var s kalmanState
+	for i, tp := range t {
+		accuracy := tp.Accuracy
+		if accuracy <= 0 {
+			accuracy = opts.DefaultAccuracy
+		}
+		r := accuracy.Meters() * accuracy.Meters()
+		east, north, _ := k.frame.ToENU(tp.Point, 0)
+
+		if i == 0 {
+			// Start at the first fix, with no knowledge of the velocity.
+			s.x = [2][2]float64{{east, 0}, {north, 0}}
+			s.p = [2][2]float64{{r, 0}, {0, 1e4}}
+			k.predicted[i], k.filtered[i] = s, s
+			continue
+		}
+
+		dt := max(tp.Time.Sub(t[i-1].Time).Seconds(), 0)
+		k.dt[i] = dt
+		for axis := range 2 {
+			s.x[axis][0] += dt * s.x[axis][1]
+		}
+		p := s.p
+		s.p = [2][2]float64{
+			{p[0][0] + dt*(p[0][1]+p[1][0]) + dt*dt*p[1][1] + q*dt*dt*dt/3, p[0][1] + dt*p[1][1] + q*dt*dt/2},
+			{p[1][0] + dt*p[1][1] + q*dt*dt/2, p[1][1] + q*dt},
+		}
+		k.predicted[i] = s
+
+		gain := [2]float64{s.p[0][0] / (s.p[0][0] + r), s.p[1][0] / (s.p[0][0] + r)}
+		for axis, z := range [2]float64{east, north} {
+			innovation := z - s.x[axis][0]
+			s.x[axis][0] += gain[0] * innovation
+			s.x[axis][1] += gain[1] * innovation
+		}
+		p = s.p
+		s.p = [2][2]float64{
+			{(1 - gain[0]) * p[0][0], (1 - gain[0]) * p[0][1]},
+			{p[1][0] - gain[1]*p[0][0], p[1][1] - gain[1]*p[0][1]},
+		}
+		k.filtered[i] = s
+	}
+	return k
+}
+
+// estimates converts the passed in states at the fixes of t into TrackEstimates.
+func (k kalmanRun) estimates(t Track, states []kalmanState) []TrackEstimate {
+	if len(t) == 0 {
+		return nil
+	}
+	estimates := make([]TrackEstimate, len(t))
+	for i, s := range states {
+		p, _ := k.frame.FromENU(s.x[0][0], s.x[1][0], 0)
+		estimates[i] = TrackEstimate{
+			TrackPoint: TrackPoint{
+				Point:    p,
+				Time:     t[i].Time,
+				Altitude: t[i].Altitude,
+				Accuracy: Distance(math.Sqrt(s.p[0][0])),
+			},
+			VelocityEast:  s.x[0][1],
+			VelocityNorth: s.x[1][1],
+		}
+	}
+	return estimates
+}
+
+// mul2 multiplies two 2x2 matrices.
+func mul2(a [2][2]float64, b [2][2]float64) [2][2]float64 {
+	return [2][2]float64{
+		{a[0][0]*b[0][0] + a[0][1]*b[1][0], a[0][0]*b[0][1] + a[0][1]*b[1][1]},
+		{a[1][0]*b[0][0] + a[1][1]*b[1][0], a[1][0]*b[0][1] + a[1][1]*b[1][1]},
+	}
+}
+
+// inverse2 inverts a 2x2 matrix.
+func inverse2(a [2][2]float64) [2][2]float64 {
+	det := a[0][0]*a[1][1] - a[0][1]*a[1][0]
+	return [2][2]float64{{a[1][1] / det, -a[0][1] / det}, {-a[1][0] / det, a[0][0] / det}}
+}
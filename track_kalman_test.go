@@ -0,0 +1,95 @@
+package geo
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// noisyTrack returns a track driving east along the equator at 10 m/s for n seconds, with fixes
+// scattered by normally distributed errors of accuracy meters, and the true position of each fix.
+func noisyTrack(n int, accuracy float64) (Track, []Point) {
+	r := rand.New(rand.NewSource(3))
+	frame := NewLocalFrame(NewPoint(0, 0), 0)
+	start := time.Date(2024, time.May, 1, 9, 0, 0, 0, time.UTC)
+
+	track := make(Track, n)
+	truth := make([]Point, n)
+	for i := range track {
+		truth[i], _ = frame.FromENU(10*float64(i), 0, 0)
+		p, _ := frame.FromENU(10*float64(i)+accuracy*r.NormFloat64(), accuracy*r.NormFloat64(), 0)
+		track[i] = TrackPoint{Point: p, Time: start.Add(time.Duration(i) * time.Second), Accuracy: Distance(accuracy)}
+	}
+	return track, truth
+}
+
+// Ensures that filtering and smoothing reduce the error of noisy fixes and estimate the velocity.
+func TestTrackKalman(t *testing.T) {
+	track, truth := noisyTrack(120, 8)
+
+	rms := func(points []Point) float64 {
+		var sum float64
+		for i, p := range points[10:] {
+			d := p.GreatCircleDistance(truth[i+10]).Meters()
+			sum += d * d
+		}
+		return math.Sqrt(sum / float64(len(points)-10))
+	}
+	raw := rms(track.Points())
+
+	filtered := track.Filter(KalmanOptions{ProcessNoise: 0.5})
+	smoothed := track.Smooth(KalmanOptions{ProcessNoise: 0.5})
+	if len(filtered) != len(track) || len(smoothed) != len(track) {
+		t.Fatalf("Expected %d estimates, but got %d and %d", len(track), len(filtered), len(smoothed))
+	}
+	f, s := rms(estimatesPoints(filtered)), rms(estimatesPoints(smoothed))
+	if f >= raw/1.5 || s >= f {
+		t.Errorf("Expected smoothed error %v below filtered error %v below raw error %v", s, f, raw)
+	}
+
+	for _, estimates := range [][]TrackEstimate{filtered, smoothed} {
+		e := estimates[len(estimates)/2]
+		if math.Abs(e.Speed()-10) > 1 || math.Abs(e.Heading()-90) > 5 {
+			t.Errorf("Expected 10 m/s heading east, but got %v m/s heading %v", e.Speed(), e.Heading())
+		}
+		if e.Accuracy <= 0 || e.Accuracy >= 8*Meter {
+			t.Errorf("Expected an estimated accuracy better than the fixes, but got %v", e.Accuracy)
+		}
+		if !e.Time.Equal(track[len(estimates)/2].Time) {
+			t.Errorf("Expected the time of the fix, but got %v", e.Time)
+		}
+	}
+	if smoothed[60].Accuracy >= filtered[60].Accuracy {
+		t.Errorf("Expected smoothing to improve the accuracy %v, but got %v", filtered[60].Accuracy, smoothed[60].Accuracy)
+	}
+}
+
+// Ensures that fixes without an accuracy use the default and that duplicate times are tolerated.
+func TestTrackKalmanDefaults(t *testing.T) {
+	start := time.Date(2024, time.May, 1, 9, 0, 0, 0, time.UTC)
+	track := Track{
+		{Point: NewPoint(0, 0), Time: start},
+		{Point: NewPoint(0, 0), Time: start},
+		{Point: NewPoint(0, 0), Time: start.Add(time.Second)},
+	}
+	for _, estimates := range [][]TrackEstimate{track.Filter(KalmanOptions{}), track.Smooth(KalmanOptions{})} {
+		for _, e := range estimates {
+			if e.Point.GreatCircleDistance(NewPoint(0, 0)) > Meter || e.Accuracy > DefaultKalmanAccuracy || math.IsNaN(e.Speed()) {
+				t.Errorf("Unexpected estimate %+v", e)
+			}
+		}
+	}
+
+	if (Track{}).Smooth(KalmanOptions{}) != nil || (Track{}).Filter(KalmanOptions{}) != nil {
+		t.Errorf("Expected no estimates for an empty track")
+	}
+}
+
+func estimatesPoints(estimates []TrackEstimate) []Point {
+	points := make([]Point, len(estimates))
+	for i, e := range estimates {
+		points[i] = e.Point
+	}
+	return points
+}
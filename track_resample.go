@@ -0,0 +1,63 @@
+package geo
+
+import (
+	"sort"
+	"time"
+)
+
+// At returns the position of the Track at time tm, interpolated along the great circle between
+// the fixes either side of it.  Times before the first fix or after the last one are clamped to it.
+// An empty Track has no position and returns the zero Point.
+func (t Track) At(tm time.Time) Point {
+	if len(t) == 0 {
+		return Point{}
+	}
+	return t.fixAt(tm).Point
+}
+
+// fixAt returns the fix of the Track at time tm, interpolating its position along the great circle
+// and its altitude and accuracy linearly between the fixes either side of it.
+func (t Track) fixAt(tm time.Time) TrackPoint {
+	// i is the first fix after tm.
+	i := sort.Search(len(t), func(i int) bool { return t[i].Time.After(tm) })
+	if i == 0 {
+		return t[0]
+	}
+	if i == len(t) {
+		return t[len(t)-1]
+	}
+
+	a, b := t[i-1], t[i]
+	if a.Time.Equal(tm) {
+		return a
+	}
+	f := float64(tm.Sub(a.Time)) / float64(b.Time.Sub(a.Time))
+
+	fix := TrackPoint{
+		Point:    intermediatePoint(a.Point, b.Point, f),
+		Time:     tm,
+		Accuracy: a.Accuracy + Distance(f)*(b.Accuracy-a.Accuracy),
+	}
+	if a.Altitude != nil && b.Altitude != nil {
+		altitude := *a.Altitude + f*(*b.Altitude-*a.Altitude)
+		fix.Altitude = &altitude
+	}
+	return fix
+}
+
+// Resample returns a new Track with fixes every interval from the first fix of the Track to its last,
+// interpolated like At.  The last fix of the Track is only kept if it falls on the interval.
+// Altitudes are interpolated between fixes that both have one and are unknown otherwise.
+// It returns nil for an empty Track or an interval that is not positive.
+func (t Track) Resample(interval time.Duration) Track {
+	if len(t) == 0 || interval <= 0 {
+		return nil
+	}
+
+	start, end := t[0].Time, t[len(t)-1].Time
+	resampled := make(Track, 0, int(end.Sub(start)/interval)+1)
+	for tm := start; !tm.After(end); tm = tm.Add(interval) {
+		resampled = append(resampled, t.fixAt(tm))
+	}
+	return resampled
+}
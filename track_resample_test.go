@@ -0,0 +1,77 @@
+package geo
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// Ensures that the position of a track is interpolated between fixes and clamped to its ends.
+func TestTrackAt(t *testing.T) {
+	start := time.Date(2024, time.May, 1, 9, 0, 0, 0, time.UTC)
+	track := Track{
+		{Point: NewPoint(0, 0), Time: start},
+		{Point: NewPoint(0, 10), Time: start.Add(10 * time.Second)},
+		{Point: NewPoint(10, 10), Time: start.Add(30 * time.Second)},
+	}
+
+	tests := []struct {
+		at       time.Duration
+		expected Point
+	}{
+		{-time.Second, NewPoint(0, 0)},
+		{0, NewPoint(0, 0)},
+		{5 * time.Second, NewPoint(0, 5)},
+		{10 * time.Second, NewPoint(0, 10)},
+		{20 * time.Second, NewPoint(5, 10)},
+		{time.Minute, NewPoint(10, 10)},
+	}
+	for _, tt := range tests {
+		p := track.At(start.Add(tt.at))
+		if math.Abs(p.lat-tt.expected.lat) > 1e-9 || math.Abs(p.lng-tt.expected.lng) > 1e-9 {
+			t.Errorf("Expected %v after %v, but got %v", tt.expected, tt.at, p)
+		}
+	}
+
+	// Along a parallel the great circle bows towards the pole.
+	if p := (Track{{Point: NewPoint(45, 0), Time: start}, {Point: NewPoint(45, 90), Time: start.Add(time.Second)}}).At(start.Add(time.Second / 2)); p.lat <= 45 {
+		t.Errorf("Expected the great circle to pass north of the parallel, but got %v", p)
+	}
+	if p := (Track{}).At(start); p != (Point{}) {
+		t.Errorf("Expected the zero Point for an empty track, but got %v", p)
+	}
+}
+
+// Ensures that resampling yields fixes at regular intervals with interpolated altitudes.
+func TestTrackResample(t *testing.T) {
+	start := time.Date(2024, time.May, 1, 9, 0, 0, 0, time.UTC)
+	low, high := 100.0, 200.0
+	track := Track{
+		{Point: NewPoint(0, 0), Time: start, Altitude: &low, Accuracy: 10},
+		{Point: NewPoint(0, 0.001), Time: start.Add(3 * time.Second), Altitude: &high, Accuracy: 4},
+		{Point: NewPoint(0, 0.002), Time: start.Add(7 * time.Second)},
+	}
+
+	resampled := track.Resample(2 * time.Second)
+	if len(resampled) != 4 {
+		t.Fatalf("Expected fixes at 0s, 2s, 4s and 6s, but got %v", resampled)
+	}
+	for i, fix := range resampled {
+		if !fix.Time.Equal(start.Add(time.Duration(i) * 2 * time.Second)) {
+			t.Errorf("Expected fix %d at %v, but got %v", i, start.Add(time.Duration(i)*2*time.Second), fix.Time)
+		}
+	}
+	if a := resampled[1].Altitude; a == nil || math.Abs(*a-100-200.0/3) > 1e-9 || math.Abs(float64(resampled[1].Accuracy)-6) > 1e-9 {
+		t.Errorf("Expected an altitude of 166.7m and accuracy of 6m two thirds of the way up, but got %+v", resampled[1])
+	}
+	if resampled[2].Altitude != nil {
+		t.Errorf("Expected no altitude where the next fix has none, but got %v", *resampled[2].Altitude)
+	}
+	if math.Abs(resampled[2].Point.lng-0.00125) > 1e-9 {
+		t.Errorf("Expected 0,0.00125 at 4s, but got %v", resampled[2].Point)
+	}
+
+	if track.Resample(0) != nil || (Track{}).Resample(time.Second) != nil {
+		t.Error("Expected nothing from an empty interval or track")
+	}
+}
@@ -0,0 +1,180 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Defaults of TrackSimulatorOptions.
+const (
+	DefaultSimulatedWaypoints    = 10
+	DefaultSimulatedInterval     = time.Second
+	DefaultSimulatedMinSpeed     = 5.0
+	DefaultSimulatedMaxSpeed     = 15.0
+	DefaultSimulatedAcceleration = 1.0
+)
+
+// TrackSimulatorOptions configures a TrackSimulator.
+type TrackSimulatorOptions struct {
+	// Seed seeds the random waypoints, speeds and noise, so that runs can be repeated.
+	Seed int64
+	// Start is the time of the first fix of the first Track.  Each following Track starts
+	// at the time the one before ended.
+	Start time.Time
+	// Waypoints is how many random places inside the area a Track visits.
+	// Defaults to DefaultSimulatedWaypoints.
+	Waypoints int
+	// Interval is the time between fixes.  Defaults to DefaultSimulatedInterval.
+	Interval time.Duration
+	// MinSpeed and MaxSpeed bound the cruising speed in meters per second, drawn anew for each
+	// leg between waypoints.  They default to DefaultSimulatedMinSpeed and DefaultSimulatedMaxSpeed.
+	MinSpeed float64
+	MaxSpeed float64
+	// Acceleration is the rate in meters per second squared at which the subject speeds up
+	// leaving a waypoint and slows down reaching the next.  Defaults to DefaultSimulatedAcceleration.
+	Acceleration float64
+	// Pause is how long the subject stands still at each waypoint between the first and last.
+	Pause time.Duration
+	// Noise is the standard deviation of the GPS error added along each axis to every fix, which
+	// also becomes its Accuracy.  Without it, fixes lie exactly on the path.
+	Noise Distance
+}
+
+// A TrackSimulator generates random but plausible Tracks inside an area, for load testing geofencing,
+// clustering and other track processing without real data.  Each Track drives along great circles
+// between random waypoints inside the area, speeding up from and slowing down to a stop at each;
+// legs between waypoints of a concave area may leave it.
+// A TrackSimulator is not safe for concurrent use.
+type TrackSimulator struct {
+	area  Polygon
+	opts  TrackSimulatorOptions
+	rand  *rand.Rand
+	start time.Time
+	// inside is a point known to be inside the area, used if no other can be found.
+	inside Point
+}
+
+// NewTrackSimulator returns a new TrackSimulator generating Tracks inside area.
+// It returns an error wrapping ErrUnclosedPolygon if area has fewer than three points, or ErrNoResults
+// if no point inside area can be found.
+func NewTrackSimulator(area Polygon, opts TrackSimulatorOptions) (*TrackSimulator, error) {
+	if opts.Waypoints < 2 {
+		opts.Waypoints = DefaultSimulatedWaypoints
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultSimulatedInterval
+	}
+	if opts.MinSpeed <= 0 {
+		opts.MinSpeed = DefaultSimulatedMinSpeed
+	}
+	if opts.MaxSpeed < opts.MinSpeed {
+		opts.MaxSpeed = max(DefaultSimulatedMaxSpeed, opts.MinSpeed)
+	}
+	if opts.Acceleration <= 0 {
+		opts.Acceleration = DefaultSimulatedAcceleration
+	}
+
+	s := &TrackSimulator{area: area, opts: opts, rand: rand.New(rand.NewSource(opts.Seed)), start: opts.Start}
+	if !area.IsClosed() {
+		return nil, fmt.Errorf("%w: %d points", ErrUnclosedPolygon, len(area.points))
+	}
+	inside, ok := s.randomPoint()
+	if !ok {
+		return nil, fmt.Errorf("%w: no point found inside %v", ErrNoResults, area)
+	}
+	s.inside = inside
+	return s, nil
+}
+
+// randomPoint returns a point drawn uniformly from the area, and false if none was found.
+func (s *TrackSimulator) randomPoint() (Point, bool) {
+	return randomPointIn(s.area, s.rand)
+}
+
+// simulatedLeg is the drive between two waypoints, starting at the time start.
+type simulatedLeg struct {
+	from, to Point
+	start    time.Time
+	distance float64
+	// cruise is the top speed of the leg, reached after accelerating for ramp seconds,
+	// and held for hold seconds.
+	cruise, ramp, hold float64
+}
+
+// duration returns how long the leg takes, in seconds.
+func (l simulatedLeg) duration() float64 {
+	return 2*l.ramp + l.hold
+}
+
+// travelled returns the distance covered tau seconds into the leg.
+func (l simulatedLeg) travelled(tau float64, acceleration float64) float64 {
+	switch {
+	case tau <= 0:
+		return 0
+	case tau < l.ramp:
+		return acceleration * tau * tau / 2
+	case tau < l.ramp+l.hold:
+		return acceleration*l.ramp*l.ramp/2 + l.cruise*(tau-l.ramp)
+	case tau < l.duration():
+		rest := l.duration() - tau
+		return l.distance - acceleration*rest*rest/2
+	default:
+		return l.distance
+	}
+}
+
+// Track returns a new random Track.
+func (s *TrackSimulator) Track() Track {
+	a := s.opts.Acceleration
+	waypoints := make([]Point, s.opts.Waypoints)
+	for i := range waypoints {
+		p, ok := s.randomPoint()
+		if !ok {
+			p = s.inside
+		}
+		waypoints[i] = p
+	}
+
+	legs := make([]simulatedLeg, len(waypoints)-1)
+	start := s.start
+	for i := range legs {
+		l := simulatedLeg{from: waypoints[i], to: waypoints[i+1], start: start}
+		l.distance = l.from.GreatCircleDistance(l.to).Meters()
+		l.cruise = s.opts.MinSpeed + s.rand.Float64()*(s.opts.MaxSpeed-s.opts.MinSpeed)
+		if l.cruise*l.cruise/a > l.distance {
+			// Too short to reach cruising speed before slowing down again.
+			l.cruise = math.Sqrt(a * l.distance)
+		}
+		l.ramp = l.cruise / a
+		if l.cruise > 0 {
+			l.hold = (l.distance - l.cruise*l.cruise/a) / l.cruise
+		}
+		legs[i] = l
+		start = start.Add(time.Duration(l.duration()*float64(time.Second)) + s.opts.Pause)
+	}
+	end := start.Add(-s.opts.Pause)
+	s.start = end
+
+	var track Track
+	leg := 0
+	for tm := legs[0].start; !tm.After(end); tm = tm.Add(s.opts.Interval) {
+		for leg+1 < len(legs) && !tm.Before(legs[leg+1].start) {
+			leg++
+		}
+		l := legs[leg]
+		p := l.to
+		if l.distance > 0 {
+			p = intermediatePoint(l.from, l.to, l.travelled(tm.Sub(l.start).Seconds(), a)/l.distance)
+		}
+
+		fix := TrackPoint{Point: p, Time: tm, Accuracy: s.opts.Noise}
+		if s.opts.Noise > 0 {
+			east, north := s.rand.NormFloat64()*s.opts.Noise.Meters(), s.rand.NormFloat64()*s.opts.Noise.Meters()
+			fix.Point = destinationPoint(p, math.Atan2(east, north)*180/math.Pi, Distance(math.Hypot(east, north)))
+		}
+		track = append(track, fix)
+	}
+	return track
+}
@@ -0,0 +1,81 @@
+package geo
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// Ensures that simulated tracks stay inside the area, keep to the speed limits and are repeatable.
+func TestTrackSimulator(t *testing.T) {
+	area := NewPolygon([]Point{NewPoint(0, 0), NewPoint(0, 0.05), NewPoint(0.05, 0.05), NewPoint(0.05, 0)})
+	start := time.Date(2024, time.May, 1, 9, 0, 0, 0, time.UTC)
+	opts := TrackSimulatorOptions{Seed: 7, Start: start, Waypoints: 4, MinSpeed: 10, MaxSpeed: 20, Pause: time.Minute}
+
+	s, err := NewTrackSimulator(area, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	track := s.Track()
+	if len(track) < 100 || !track[0].Time.Equal(start) {
+		t.Fatalf("Expected a long track from %v, but got %v", start, track)
+	}
+
+	b := area.Bounds()
+	for i, tp := range track {
+		if !b.Contains(tp.Point) || tp.Accuracy != 0 {
+			t.Errorf("Expected fix %d inside %v, but got %+v", i, b, tp)
+		}
+		if i == 0 {
+			continue
+		}
+		if dt := tp.Time.Sub(track[i-1].Time); dt != time.Second {
+			t.Errorf("Expected fixes a second apart, but got %v", dt)
+		}
+		if speed := track[i-1].Point.GreatCircleDistance(tp.Point).Meters(); speed > 20.001 {
+			t.Errorf("Expected at most 20 m/s, but got %v at fix %d", speed, i)
+		}
+	}
+
+	// The subject starts and ends at rest, and stops at the waypoints in between.
+	if d := track[0].Point.GreatCircleDistance(track[1].Point); math.Abs(d.Meters()-0.5) > 1e-6 {
+		t.Errorf("Expected to move 0.5m in the first second, but got %v", d)
+	}
+	if stops := track.Stops(Meter, 50*time.Second); len(stops) != 2 {
+		t.Errorf("Expected 2 stops at waypoints, but got %+v", stops)
+	}
+
+	// The next track follows on, and the same seed repeats the same tracks.
+	if next := s.Track(); !next[0].Time.After(track[len(track)-1].Time.Add(-time.Second)) {
+		t.Errorf("Expected the next track to start after %v, but got %v", track[len(track)-1].Time, next[0].Time)
+	}
+	again, _ := NewTrackSimulator(area, opts)
+	if !reflect.DeepEqual(again.Track(), track) {
+		t.Errorf("Expected the same seed to repeat the track")
+	}
+
+	// Noise scatters fixes around the path.
+	opts.Noise = 5 * Meter
+	noisy, _ := NewTrackSimulator(area, opts)
+	var sum float64
+	for i, tp := range noisy.Track() {
+		if tp.Accuracy != 5*Meter {
+			t.Errorf("Expected an accuracy of 5m, but got %v", tp.Accuracy)
+		}
+		sum += tp.Point.GreatCircleDistance(track[i].Point).Meters()
+	}
+	// The mean of the Rayleigh distribution of the errors is 5 * sqrt(pi / 2).
+	if mean := sum / float64(len(track)); math.Abs(mean-6.27) > 0.5 {
+		t.Errorf("Expected a mean error of about 6.27m, but got %v", mean)
+	}
+
+	if _, err := NewTrackSimulator(NewPolygon([]Point{NewPoint(0, 0), NewPoint(1, 1)}), opts); !errors.Is(err, ErrUnclosedPolygon) {
+		t.Errorf("Expected ErrUnclosedPolygon, but got %v", err)
+	}
+	line := NewPolygon([]Point{NewPoint(0, 0), NewPoint(1, 1), NewPoint(2, 2)})
+	if _, err := NewTrackSimulator(line, opts); !errors.Is(err, ErrNoResults) {
+		t.Errorf("Expected ErrNoResults for an area without points inside, but got %v", err)
+	}
+}
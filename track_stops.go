@@ -0,0 +1,48 @@
+package geo
+
+import "time"
+
+// A Stop is a stretch of a Track during which it stayed within a small area, such as a vehicle
+// making a delivery or a person visiting a shop.
+type Stop struct {
+	// Point is the center of the fixes of the stop.
+	Point Point
+	// Arrival and Departure are the times of the first and last fixes of the stop.
+	Arrival   time.Time
+	Departure time.Time
+	// First and Last are the indices of the first and last fixes of the stop in the Track.
+	First, Last int
+}
+
+// Duration returns the time between the arrival and departure of the Stop.
+func (s Stop) Duration() time.Duration {
+	return s.Departure.Sub(s.Arrival)
+}
+
+// Stops returns the stops of the Track, in order: the runs of consecutive fixes that stay within
+// radius of the first fix of the run for at least minDuration.  It follows the stay point detection
+// of Li et al., "Mining user similarity based on location history" (2008).
+func (t Track) Stops(radius Distance, minDuration time.Duration) []Stop {
+	var stops []Stop
+	for i := 0; i < len(t); {
+		j := i + 1
+		for j < len(t) && t[i].Point.GreatCircleDistance(t[j].Point) <= radius {
+			j++
+		}
+
+		if t[j-1].Time.Sub(t[i].Time) < minDuration {
+			i++
+			continue
+		}
+
+		stops = append(stops, Stop{
+			Point:     MeanCenter(t[i:j].Points()),
+			Arrival:   t[i].Time,
+			Departure: t[j-1].Time,
+			First:     i,
+			Last:      j - 1,
+		})
+		i = j
+	}
+	return stops
+}
@@ -0,0 +1,53 @@
+package geo
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// Ensures that stops are found where a track lingers, and not where it only slows down.
+func TestTrackStops(t *testing.T) {
+	start := time.Date(2024, time.May, 1, 9, 0, 0, 0, time.UTC)
+	var track Track
+	fix := func(p Point, minutes int) {
+		track = append(track, TrackPoint{Point: p, Time: start.Add(time.Duration(minutes) * time.Minute)})
+	}
+
+	// Drive, wait ten minutes at a depot, pass slowly by a shop, and wait at a customer across the antimeridian.
+	fix(NewPoint(0, 0), 0)
+	fix(NewPoint(0, 0.1), 5)
+	fix(NewPoint(0, 0.1001), 8)
+	fix(NewPoint(0.0001, 0.1), 12)
+	fix(NewPoint(0, 0.1002), 15)
+	fix(NewPoint(0, 0.2), 20)
+	fix(NewPoint(0, 0.2001), 22)
+	fix(NewPoint(0, 179.9999), 40)
+	fix(NewPoint(0, -179.9999), 50)
+	fix(NewPoint(0, 179.9999), 60)
+
+	stops := track.Stops(50*Meter, 5*time.Minute)
+	if len(stops) != 2 {
+		t.Fatalf("Expected 2 stops, but got %+v", stops)
+	}
+
+	depot := stops[0]
+	if depot.First != 1 || depot.Last != 4 || !depot.Arrival.Equal(start.Add(5*time.Minute)) || depot.Duration() != 10*time.Minute {
+		t.Errorf("Unexpected depot stop %+v", depot)
+	}
+	if math.Abs(depot.Point.lat-0.000025) > 1e-9 || math.Abs(depot.Point.lng-0.100075) > 1e-9 {
+		t.Errorf("Expected the depot near 0.000025,0.100075, but got %v", depot.Point)
+	}
+
+	customer := stops[1]
+	if customer.First != 7 || customer.Last != 9 || customer.Duration() != 20*time.Minute {
+		t.Errorf("Unexpected customer stop %+v", customer)
+	}
+	if math.Abs(math.Abs(customer.Point.lng)-180) > 0.0001 {
+		t.Errorf("Expected the customer on the antimeridian, but got %v", customer.Point)
+	}
+
+	if stops := (Track{}).Stops(50, time.Minute); stops != nil {
+		t.Errorf("Expected no stops on an empty track, but got %v", stops)
+	}
+}
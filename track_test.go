@@ -0,0 +1,106 @@
+package geo
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// Ensures that a track measures its length, duration and speed.
+func TestTrack(t *testing.T) {
+	start := time.Date(2024, time.May, 1, 9, 0, 0, 0, time.UTC)
+	l := NewLineString([]Point{NewPoint(0, 0), NewPoint(0, 0.01), NewPoint(0.01, 0.01)})
+	track, err := NewTrackFromLineString(l, []time.Time{start, start.Add(time.Minute), start.Add(3 * time.Minute)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	leg := NewPoint(0, 0).GreatCircleDistance(NewPoint(0, 0.01))
+	if d := track.Distance(); math.Abs(float64(d-2*leg)) > 1e-6 {
+		t.Errorf("Expected %v, but got %v", 2*leg, d)
+	}
+	if d := track.Duration(); d != 3*time.Minute {
+		t.Errorf("Expected 3m0s, but got %v", d)
+	}
+	if s := track.AverageSpeed(); math.Abs(s-2*leg.Meters()/180) > 1e-9 {
+		t.Errorf("Expected %v m/s, but got %v", 2*leg.Meters()/180, s)
+	}
+	if b := track.Bounds(); b != l.Bounds() {
+		t.Errorf("Expected %v, but got %v", l.Bounds(), b)
+	}
+	if points := track.LineString().Points(); !reflect.DeepEqual(points, l.Points()) {
+		t.Errorf("Expected %v, but got %v", l.Points(), points)
+	}
+	if s := track.String(); s != "Track(3 points, 2024-05-01T09:00:00Z to 2024-05-01T09:03:00Z)" {
+		t.Errorf("Unexpected string %s", s)
+	}
+
+	var empty Track
+	if empty.Distance() != 0 || empty.Duration() != 0 || empty.AverageSpeed() != 0 || empty.String() != "Track(0 points)" {
+		t.Error("Expected an empty track to measure nothing")
+	}
+	if _, err := NewTrackFromLineString(l, []time.Time{start}); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("Expected ErrInvalidFormat for missing times, but got %v", err)
+	}
+}
+
+// Ensures that tracks survive a round trip through GPX, and that every segment decodes to a track.
+func TestGPX(t *testing.T) {
+	data := []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<gpx version="1.1" creator="test" xmlns="http://www.topografix.com/GPX/1/1">
+  <trk>
+    <name>Bridge walk</name>
+    <trkseg>
+      <trkpt lat="-33.8523" lon="151.2108"><ele>49.5</ele><time>2024-05-01T09:00:00Z</time></trkpt>
+      <trkpt lat="-33.8568" lon="151.2153"><time>2024-05-01T09:10:00+10:00</time></trkpt>
+    </trkseg>
+    <trkseg>
+      <trkpt lat="-33.8688" lon="151.2093"><time>2024-05-01T09:30:00Z</time></trkpt>
+    </trkseg>
+  </trk>
+</gpx>`)
+
+	tracks, err := DecodeGPX(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tracks) != 2 || len(tracks[0]) != 2 || len(tracks[1]) != 1 {
+		t.Fatalf("Expected segments of 2 and 1 points, but got %v", tracks)
+	}
+	first := tracks[0][0]
+	if first.Point != NewPoint(-33.8523, 151.2108) || first.Altitude == nil || *first.Altitude != 49.5 || !first.Time.Equal(time.Date(2024, time.May, 1, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected first fix %+v", first)
+	}
+	if tracks[0][1].Altitude != nil || tracks[0].Duration() != -10*time.Hour+10*time.Minute {
+		t.Errorf("Unexpected second fix %+v", tracks[0][1])
+	}
+
+	var buf bytes.Buffer
+	if err := WriteGPX(&buf, tracks...); err != nil {
+		t.Fatal(err)
+	}
+	again, err := DecodeGPX(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(again) != 2 {
+		t.Fatalf("Expected 2 tracks back, but got %d", len(again))
+	}
+	for i := range tracks {
+		for j := range tracks[i] {
+			a, b := tracks[i][j], again[i][j]
+			if a.Point != b.Point || !a.Time.Equal(b.Time) || (a.Altitude == nil) != (b.Altitude == nil) {
+				t.Errorf("Expected %+v, but got %+v", a, b)
+			}
+		}
+	}
+
+	for _, bad := range []string{"<gpx><trk>", `<gpx><trk><trkseg><trkpt lat="91" lon="0"/></trkseg></trk></gpx>`} {
+		if _, err := DecodeGPX([]byte(bad)); !errors.Is(err, ErrInvalidFormat) {
+			t.Errorf("Expected %q to return ErrInvalidFormat, but got %v", bad, err)
+		}
+	}
+}
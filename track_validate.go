@@ -0,0 +1,150 @@
+package geo
+
+import "sort"
+
+// DefaultAccuracyOutlierFactor is how many times worse than the median accuracy of a Track the
+// accuracy of a fix must be for Track.Validate to flag it, when no TrackValidationOptions.MaxAccuracy is set.
+const DefaultAccuracyOutlierFactor = 5
+
+// TrackAnomalyKind is the kind of a TrackAnomaly.
+type TrackAnomalyKind int
+
+// Kinds of TrackAnomaly.
+const (
+	// TrackTeleport marks a fix that is further from the previous valid fix than the
+	// maximum speed allows in the time between them.
+	TrackTeleport TrackAnomalyKind = iota + 1
+	// TrackDuplicateTime marks a fix whose time is not after that of the previous valid fix.
+	TrackDuplicateTime
+	// TrackInaccurate marks a fix whose accuracy is worse than the maximum accuracy.
+	TrackInaccurate
+)
+
+// String returns "teleport", "duplicate time" or "inaccurate".
+func (k TrackAnomalyKind) String() string {
+	switch k {
+	case TrackTeleport:
+		return "teleport"
+	case TrackDuplicateTime:
+		return "duplicate time"
+	case TrackInaccurate:
+		return "inaccurate"
+	default:
+		return "unknown"
+	}
+}
+
+// A TrackAnomaly records a fix of a Track that Track.Validate found invalid.
+type TrackAnomaly struct {
+	// Index is the index of the fix in the validated Track.
+	Index int
+	Kind  TrackAnomalyKind
+	// Speed is the speed in meters per second implied by a TrackTeleport fix.
+	Speed float64
+}
+
+// TrackRepair is what Track.Validate does with the fixes it flags.
+type TrackRepair int
+
+// Ways to repair a Track.
+const (
+	// TrackKeep keeps flagged fixes as they are.
+	TrackKeep TrackRepair = iota
+	// TrackDrop drops flagged fixes.
+	TrackDrop
+	// TrackInterpolate moves flagged fixes to the position of the Track at their time, interpolated
+	// between the valid fixes either side of them like Track.At.  Fixes with a duplicate time and
+	// fixes before the first or after the last valid fix cannot be placed and are dropped.
+	TrackInterpolate
+)
+
+// TrackValidationOptions configures Track.Validate.
+type TrackValidationOptions struct {
+	// MaxSpeed is the highest plausible speed in meters per second between fixes, such as 70 for
+	// road vehicles.  Zero disables the check for teleports.
+	MaxSpeed float64
+	// MaxAccuracy is the worst plausible accuracy of a fix.  Defaults to DefaultAccuracyOutlierFactor
+	// times the median accuracy of the fixes that report one.
+	MaxAccuracy Distance
+	// Repair is what to do with flagged fixes.  Defaults to TrackKeep.
+	Repair TrackRepair
+}
+
+// Validate checks every fix of the Track against the last valid fix before it, and returns the
+// Track repaired as opts.Repair says and the anomalies found, in order of index.  Each fix is
+// flagged with at most one anomaly, checking accuracy, then time, then speed.  Flagged fixes are
+// not used to check the ones after them, so a single stray fix is flagged alone rather than with
+// the fix that returns from it; a Track whose first fix is stray is flagged from then on.
+func (t Track) Validate(opts TrackValidationOptions) (Track, []TrackAnomaly) {
+	if opts.MaxAccuracy <= 0 {
+		opts.MaxAccuracy = DefaultAccuracyOutlierFactor * t.medianAccuracy()
+	}
+
+	var anomalies []TrackAnomaly
+	valid := make(Track, 0, len(t))
+	flagged := make([]bool, len(t))
+	for i, tp := range t {
+		var anomaly TrackAnomaly
+		switch {
+		case opts.MaxAccuracy > 0 && tp.Accuracy > opts.MaxAccuracy:
+			anomaly = TrackAnomaly{Index: i, Kind: TrackInaccurate}
+		case len(valid) > 0 && !tp.Time.After(valid[len(valid)-1].Time):
+			anomaly = TrackAnomaly{Index: i, Kind: TrackDuplicateTime}
+		case len(valid) > 0 && opts.MaxSpeed > 0:
+			last := valid[len(valid)-1]
+			speed := last.Point.GreatCircleDistance(tp.Point).Meters() / tp.Time.Sub(last.Time).Seconds()
+			if speed > opts.MaxSpeed {
+				anomaly = TrackAnomaly{Index: i, Kind: TrackTeleport, Speed: speed}
+			}
+		}
+
+		if anomaly.Kind != 0 {
+			anomalies = append(anomalies, anomaly)
+			flagged[i] = true
+			continue
+		}
+		valid = append(valid, tp)
+	}
+
+	switch opts.Repair {
+	case TrackKeep:
+		return t, anomalies
+	case TrackDrop:
+		return valid, anomalies
+	}
+
+	repaired := make(Track, 0, len(t))
+	for i, tp := range t {
+		if !flagged[i] {
+			repaired = append(repaired, tp)
+			continue
+		}
+		if len(valid) == 0 || !tp.Time.After(valid[0].Time) || !tp.Time.Before(valid[len(valid)-1].Time) {
+			continue
+		}
+		if len(repaired) > 0 && !tp.Time.After(repaired[len(repaired)-1].Time) {
+			continue
+		}
+		// A fix at the time of a valid one has nowhere else to go.
+		if j := sort.Search(len(valid), func(j int) bool { return !valid[j].Time.Before(tp.Time) }); valid[j].Time.Equal(tp.Time) {
+			continue
+		}
+		repaired = append(repaired, valid.fixAt(tp.Time))
+	}
+	return repaired, anomalies
+}
+
+// medianAccuracy returns the median accuracy of the fixes of the Track that report one, or zero.
+func (t Track) medianAccuracy() Distance {
+	var accuracies []Distance
+	for _, tp := range t {
+		if tp.Accuracy > 0 {
+			accuracies = append(accuracies, tp.Accuracy)
+		}
+	}
+	if len(accuracies) == 0 {
+		return 0
+	}
+	sort.Slice(accuracies, func(i, j int) bool { return accuracies[i] < accuracies[j] })
+	return accuracies[len(accuracies)/2]
+}
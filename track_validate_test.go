@@ -0,0 +1,65 @@
+package geo
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// Ensures that teleports, duplicate times and inaccurate fixes are flagged, kept, dropped or interpolated.
+func TestTrackValidate(t *testing.T) {
+	start := time.Date(2024, time.May, 1, 9, 0, 0, 0, time.UTC)
+	fix := func(lng float64, seconds int, accuracy Distance) TrackPoint {
+		return TrackPoint{Point: NewPoint(0, lng), Time: start.Add(time.Duration(seconds) * time.Second), Accuracy: accuracy}
+	}
+	// About 11 m/s east, with a jump of a kilometer, a repeated fix and a wild accuracy.
+	track := Track{
+		fix(0, 0, 5*Meter),
+		fix(0.0001, 1, 5*Meter),
+		fix(0.01, 2, 5*Meter),
+		fix(0.0003, 3, 4*Meter),
+		fix(0.0003, 3, 4*Meter),
+		fix(0.0004, 4, 200*Meter),
+		fix(0.0005, 5, 6*Meter),
+	}
+
+	kept, anomalies := track.Validate(TrackValidationOptions{MaxSpeed: 50})
+	expected := []TrackAnomaly{{Index: 2, Kind: TrackTeleport}, {Index: 4, Kind: TrackDuplicateTime}, {Index: 5, Kind: TrackInaccurate}}
+	if len(anomalies) != len(expected) {
+		t.Fatalf("Expected anomalies %v, but got %v", expected, anomalies)
+	}
+	for i, a := range anomalies {
+		if a.Index != expected[i].Index || a.Kind != expected[i].Kind {
+			t.Errorf("Expected anomaly %v, but got %v", expected[i], a)
+		}
+	}
+	if anomalies[0].Speed < 1000 || anomalies[0].Kind.String() != "teleport" {
+		t.Errorf("Expected a teleport at over 1000 m/s, but got %v", anomalies[0])
+	}
+	if len(kept) != len(track) {
+		t.Errorf("Expected every fix kept, but got %v", kept)
+	}
+
+	dropped, _ := track.Validate(TrackValidationOptions{MaxSpeed: 50, Repair: TrackDrop})
+	if len(dropped) != 4 || dropped[2].Point.lng != 0.0003 {
+		t.Errorf("Expected the flagged fixes dropped, but got %v", dropped.Points())
+	}
+
+	interpolated, _ := track.Validate(TrackValidationOptions{MaxSpeed: 50, Repair: TrackInterpolate})
+	if len(interpolated) != 6 {
+		t.Fatalf("Expected the duplicate dropped and the others interpolated, but got %v", interpolated.Points())
+	}
+	for i, lng := range []float64{0, 0.0001, 0.0002, 0.0003, 0.0004, 0.0005} {
+		if math.Abs(interpolated[i].Point.lng-lng) > 1e-9 || !interpolated[i].Time.Equal(start.Add(time.Duration(i)*time.Second)) {
+			t.Errorf("Expected fix %d at %v, but got %v at %v", i, lng, interpolated[i].Point, interpolated[i].Time)
+		}
+	}
+
+	// Without a maximum speed, only the duplicate and the inaccurate fix are flagged.
+	if _, anomalies := track.Validate(TrackValidationOptions{}); len(anomalies) != 2 {
+		t.Errorf("Expected 2 anomalies, but got %v", anomalies)
+	}
+	if _, anomalies := track.Validate(TrackValidationOptions{MaxAccuracy: 300 * Meter}); len(anomalies) != 1 {
+		t.Errorf("Expected only the duplicate, but got %v", anomalies)
+	}
+}
@@ -0,0 +1,158 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+	"sync"
+)
+
+// A Transformer converts geographic WGS84 Points to and from the planar or
+// geographic coordinates of another coordinate reference system.
+type Transformer interface {
+	// Forward converts Point p into the coordinates x, y of the reference system.
+	// Geographic reference systems return longitude as x and latitude as y.
+	Forward(p Point) (x float64, y float64)
+	// Inverse converts the coordinates x, y of the reference system back into a WGS84 Point.
+	Inverse(x float64, y float64) Point
+}
+
+// CRS identifies a coordinate reference system by its EPSG code.
+// The zero value is treated as WGS84.
+type CRS int
+
+// Coordinate reference systems registered by default.
+// UTM zones are available as 32601-32660 (north) and 32701-32760 (south).
+const (
+	WGS84       CRS = 4326
+	WebMercator CRS = 3857
+)
+
+var (
+	transformersMu sync.RWMutex
+	transformers   = map[CRS]Transformer{
+		WGS84:       identityTransformer{},
+		WebMercator: webMercatorTransformer{},
+	}
+)
+
+// RegisterTransformer makes a Transformer available under the passed in EPSG code,
+// replacing any previously registered Transformer for that code.
+func RegisterTransformer(code CRS, t Transformer) {
+	transformersMu.Lock()
+	defer transformersMu.Unlock()
+
+	transformers[code] = t
+}
+
+// Transformer returns the Transformer registered for the reference system.
+func (c CRS) Transformer() (Transformer, error) {
+	if c == 0 {
+		c = WGS84
+	}
+
+	transformersMu.RLock()
+	t, ok := transformers[c]
+	transformersMu.RUnlock()
+	if ok {
+		return t, nil
+	}
+
+	switch {
+	case c >= 32601 && c <= 32660:
+		return utmTransformer{zone: int(c - 32600), hemisphere: NorthernHemisphere}, nil
+	case c >= 32701 && c <= 32760:
+		return utmTransformer{zone: int(c - 32700), hemisphere: SouthernHemisphere}, nil
+	}
+
+	return nil, fmt.Errorf("%w: no transformer registered for EPSG:%d", ErrUnknownCRS, int(c))
+}
+
+// String renders the reference system as "EPSG:<code>".
+func (c CRS) String() string {
+	if c == 0 {
+		c = WGS84
+	}
+
+	return fmt.Sprintf("EPSG:%d", int(c))
+}
+
+// TransformPoint converts Point p from the reference system from into the reference system to.
+// Coordinates of non-WGS84 systems are carried in Points with y as the latitude and x as the longitude.
+func TransformPoint(p Point, from CRS, to CRS) (Point, error) {
+	points, err := transformPoints([]Point{p}, from, to)
+	if err != nil {
+		return Point{}, err
+	}
+
+	return points[0], nil
+}
+
+// transformPoints converts every passed in point from one reference system to another,
+// returning a new slice.
+func transformPoints(points []Point, from CRS, to CRS) ([]Point, error) {
+	src, err := from.Transformer()
+	if err != nil {
+		return nil, err
+	}
+
+	dst, err := to.Transformer()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]Point, len(points))
+	for i, p := range points {
+		x, y := dst.Forward(src.Inverse(p.lng, p.lat))
+		res[i] = NewPoint(y, x)
+	}
+
+	return res, nil
+}
+
+// identityTransformer treats WGS84 longitude and latitude as x and y.
+type identityTransformer struct{}
+
+func (identityTransformer) Forward(p Point) (float64, float64) {
+	return p.lng, p.lat
+}
+
+func (identityTransformer) Inverse(x float64, y float64) Point {
+	return NewPoint(y, x)
+}
+
+// webMercatorTransformer implements the spherical "Pseudo-Mercator" projection used by web maps.
+type webMercatorTransformer struct{}
+
+func (webMercatorTransformer) Forward(p Point) (float64, float64) {
+	x := wgs84SemiMajorAxis * p.lng * math.Pi / 180
+	y := wgs84SemiMajorAxis * math.Log(math.Tan(math.Pi/4+p.lat*math.Pi/360))
+	return x, y
+}
+
+func (webMercatorTransformer) Inverse(x float64, y float64) Point {
+	lng := x / wgs84SemiMajorAxis * 180 / math.Pi
+	lat := (2*math.Atan(math.Exp(y/wgs84SemiMajorAxis)) - math.Pi/2) * 180 / math.Pi
+	return NewPoint(lat, lng)
+}
+
+// utmTransformer projects into a single UTM zone.
+type utmTransformer struct {
+	zone       int
+	hemisphere Hemisphere
+}
+
+func (t utmTransformer) Forward(p Point) (float64, float64) {
+	x, y := wgs84TransverseMercator.forward(p.lat, p.lng, utmCentralMeridian(t.zone))
+
+	easting := utmFalseEasting + utmScaleFactor*x
+	northing := utmScaleFactor * y
+	if t.hemisphere == SouthernHemisphere {
+		northing += utmFalseNorthing
+	}
+
+	return easting, northing
+}
+
+func (t utmTransformer) Inverse(x float64, y float64) Point {
+	return fromUTM(t.zone, t.hemisphere == NorthernHemisphere, x, y)
+}
@@ -0,0 +1,88 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+// A Transformer that offsets coordinates, used to test registration.
+type offsetTransformer struct {
+	dx, dy float64
+}
+
+func (o offsetTransformer) Forward(p Point) (float64, float64) {
+	return p.lng + o.dx, p.lat + o.dy
+}
+
+func (o offsetTransformer) Inverse(x float64, y float64) Point {
+	return NewPoint(y-o.dy, x-o.dx)
+}
+
+// Ensures that points can be converted to and from Web Mercator.
+func TestTransformWebMercator(t *testing.T) {
+	p := NewPoint(51.5074, -0.1278)
+	merc, err := TransformPoint(p, WGS84, WebMercator)
+	if err != nil {
+		t.Fatalf("Should not encounter an error when transforming, but got %v", err)
+	}
+
+	// London in EPSG:3857 is roughly x=-14226, y=6711542.
+	if math.Abs(merc.Lng()+14226.6) > 1 || math.Abs(merc.Lat()-6711542.5) > 1 {
+		t.Errorf("Expected London to be at -14226.6, 6711542.5 but got %v, %v", merc.Lng(), merc.Lat())
+	}
+
+	back, _ := TransformPoint(merc, WebMercator, WGS84)
+	if math.Abs(back.lat-p.lat) > 1e-9 || math.Abs(back.lng-p.lng) > 1e-9 {
+		t.Errorf("Expected %v to round trip, but got %v", p, back)
+	}
+}
+
+// Ensures that UTM zones are resolved from their EPSG codes.
+func TestTransformUTM(t *testing.T) {
+	p := NewPoint(-33.8568, 151.2153)
+	utm, err := TransformPoint(p, 0, 32756)
+	if err != nil {
+		t.Fatalf("Should not encounter an error when transforming, but got %v", err)
+	}
+
+	_, _, easting, northing := ToUTM(p)
+	if utm.Lng() != easting || utm.Lat() != northing {
+		t.Errorf("Expected EPSG:32756 to match ToUTM, got %v, %v and %v, %v", utm.Lng(), utm.Lat(), easting, northing)
+	}
+
+	if _, err := CRS(32661).Transformer(); err == nil {
+		t.Error("Expected EPSG:32661 not to be resolved as a UTM zone")
+	}
+}
+
+// Ensures that registered transformers are used and that geometries carry their CRS.
+func TestRegisterTransformer(t *testing.T) {
+	RegisterTransformer(990001, offsetTransformer{dx: 10, dy: 20})
+
+	poly := NewPolygon([]Point{NewPoint(0, 0), NewPoint(0, 1), NewPoint(1, 1)})
+	if poly.CRS() != WGS84 {
+		t.Errorf("Expected a new polygon to be in WGS84, but got %v", poly.CRS())
+	}
+
+	moved, err := poly.Transform(990001)
+	if err != nil {
+		t.Fatalf("Should not encounter an error when transforming, but got %v", err)
+	}
+
+	if moved.CRS() != 990001 || moved.Points()[1] != NewPoint(20, 11) {
+		t.Errorf("Expected the polygon to be offset into EPSG:990001, but got %v in %v", moved.Points(), moved.CRS())
+	}
+
+	line, err := NewLineString(moved.Points()).WithCRS(990001).Transform(WGS84)
+	if err != nil || line.Points()[1] != NewPoint(0, 1) {
+		t.Errorf("Expected the line to be converted back to WGS84, but got %v (%v)", line.Points(), err)
+	}
+
+	if _, err := poly.Transform(1); err == nil {
+		t.Error("Expected an error when transforming into an unknown CRS")
+	}
+
+	if s := WebMercator.String(); s != "EPSG:3857" {
+		t.Errorf("Expected EPSG:3857, but got %s", s)
+	}
+}
@@ -0,0 +1,225 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+)
+
+// Parameters of the WGS84 ellipsoid.
+const (
+	wgs84SemiMajorAxis = 6378137.0
+	wgs84Flattening    = 1 / 298.257223563
+)
+
+// Parameters of the Universal Transverse Mercator projection.
+const (
+	utmScaleFactor   = 0.9996
+	utmFalseEasting  = 500000.0
+	utmFalseNorthing = 10000000.0
+	utmMinLatitude   = -80.0
+	utmMaxLatitude   = 84.0
+)
+
+// The latitude bands of UTM and MGRS grid zones, each 8° tall from 80°S except X which is 12°.
+const utmBands = "CDEFGHJKLMNPQRSTUVWX"
+
+// Hemisphere identifies which half of the globe a UTM coordinate is measured in.
+// Southern coordinates have a false northing of 10,000km.
+type Hemisphere byte
+
+// The UTM hemispheres.
+const (
+	NorthernHemisphere Hemisphere = 'N'
+	SouthernHemisphere Hemisphere = 'S'
+)
+
+// ToUTM returns the Universal Transverse Mercator coordinates of Point p in its standard zone,
+// taking the south-western Norway and Svalbard exceptions into account.
+// Easting and northing are in meters.  UTM is only defined between 80°S and 84°N;
+// points outside of that range are projected into their nominal zone regardless.
+func ToUTM(p Point) (zone int, hemisphere Hemisphere, easting float64, northing float64) {
+	zone = UTMZone(p)
+	hemisphere, easting, northing = ToUTMZone(p, zone)
+	return zone, hemisphere, easting, northing
+}
+
+// ToUTMZone returns the UTM coordinates of Point p projected into the passed in zone,
+// which need not be the zone p lies in.  This is useful for keeping data that straddles
+// a zone boundary in a single planar coordinate system.
+func ToUTMZone(p Point, zone int) (hemisphere Hemisphere, easting float64, northing float64) {
+	easting, northing = toUTM(p, zone)
+	if p.lat < 0 {
+		return SouthernHemisphere, easting, northing
+	}
+
+	return NorthernHemisphere, easting, northing
+}
+
+// FromUTM returns the Point at the passed in UTM coordinates.
+func FromUTM(zone int, hemisphere Hemisphere, easting float64, northing float64) (Point, error) {
+	if zone < 1 || zone > 60 {
+		return Point{}, fmt.Errorf("%w: UTM zone %d out of range [1, 60]", ErrOutOfBounds, zone)
+	}
+
+	if hemisphere != NorthernHemisphere && hemisphere != SouthernHemisphere {
+		return Point{}, fmt.Errorf("%w: invalid UTM hemisphere %q", ErrInvalidFormat, byte(hemisphere))
+	}
+
+	return fromUTM(zone, hemisphere == NorthernHemisphere, easting, northing), nil
+}
+
+// transverseMercator holds the Krüger series coefficients for an ellipsoid.
+// See Karney, "Transverse Mercator with an accuracy of a few nanometers" (2011).
+type transverseMercator struct {
+	a     float64 // rectifying radius A
+	e     float64 // first eccentricity
+	alpha [3]float64
+	beta  [3]float64
+	delta [3]float64
+}
+
+func newTransverseMercator(semiMajorAxis float64, flattening float64) transverseMercator {
+	n := flattening / (2 - flattening)
+	n2, n3 := n*n, n*n*n
+
+	return transverseMercator{
+		a: semiMajorAxis / (1 + n) * (1 + n2/4 + n2*n2/64),
+		e: math.Sqrt(flattening * (2 - flattening)),
+		alpha: [3]float64{
+			n/2 - 2*n2/3 + 5*n3/16,
+			13*n2/48 - 3*n3/5,
+			61 * n3 / 240,
+		},
+		beta: [3]float64{
+			n/2 - 2*n2/3 + 37*n3/96,
+			n2/48 + n3/15,
+			17 * n3 / 480,
+		},
+		delta: [3]float64{
+			2*n - 2*n2/3 - 2*n3,
+			7*n2/3 - 8*n3/5,
+			56 * n3 / 15,
+		},
+	}
+}
+
+var wgs84TransverseMercator = newTransverseMercator(wgs84SemiMajorAxis, wgs84Flattening)
+
+// forward projects lat/lng in degrees relative to the central meridian lng0,
+// returning unscaled easting and northing in meters from the central meridian and the equator.
+func (tm transverseMercator) forward(lat float64, lng float64, lng0 float64) (x float64, y float64) {
+	phi := lat * math.Pi / 180
+	dLng := math.Mod(lng-lng0+540, 360) - 180
+	dLambda := dLng * math.Pi / 180
+
+	sinPhi := math.Sin(phi)
+	t := math.Sinh(math.Atanh(sinPhi) - tm.e*math.Atanh(tm.e*sinPhi))
+	xiP := math.Atan2(t, math.Cos(dLambda))
+	etaP := math.Atanh(math.Sin(dLambda) / math.Sqrt(1+t*t))
+
+	xi, eta := xiP, etaP
+	for j, alpha := range tm.alpha {
+		k := 2 * float64(j+1)
+		xi += alpha * math.Sin(k*xiP) * math.Cosh(k*etaP)
+		eta += alpha * math.Cos(k*xiP) * math.Sinh(k*etaP)
+	}
+
+	return tm.a * eta, tm.a * xi
+}
+
+// inverse is the inverse of forward.
+func (tm transverseMercator) inverse(x float64, y float64, lng0 float64) (lat float64, lng float64) {
+	xi := y / tm.a
+	eta := x / tm.a
+
+	xiP, etaP := xi, eta
+	for j, beta := range tm.beta {
+		k := 2 * float64(j+1)
+		xiP -= beta * math.Sin(k*xi) * math.Cosh(k*eta)
+		etaP -= beta * math.Cos(k*xi) * math.Sinh(k*eta)
+	}
+
+	chi := math.Asin(math.Sin(xiP) / math.Cosh(etaP))
+	phi := chi
+	for j, delta := range tm.delta {
+		phi += delta * math.Sin(2*float64(j+1)*chi)
+	}
+
+	lambda := math.Atan2(math.Sinh(etaP), math.Cos(xiP))
+
+	return phi * 180 / math.Pi, lng0 + lambda*180/math.Pi
+}
+
+// UTMZone returns the UTM zone number of Point p, including the
+// exceptions for south-western Norway and Svalbard.
+func UTMZone(p Point) int {
+	lng := NormalizeLng(p.lng)
+
+	zone := int(math.Floor((lng+180)/6)) + 1
+	if zone > 60 {
+		zone = 60
+	}
+
+	if p.lat >= 56 && p.lat < 64 && lng >= 3 && lng < 12 {
+		return 32
+	}
+
+	if p.lat >= 72 && p.lat <= 84 && lng >= 0 && lng < 42 {
+		switch {
+		case lng < 9:
+			return 31
+		case lng < 21:
+			return 33
+		case lng < 33:
+			return 35
+		default:
+			return 37
+		}
+	}
+
+	return zone
+}
+
+// UTMBand returns the latitude band letter, from C to X, of the passed in latitude.
+// Latitudes outside of [-80, 84] are clamped to the outermost bands.
+func UTMBand(lat float64) byte {
+	i := int(math.Floor((lat - utmMinLatitude) / 8))
+	if i < 0 {
+		i = 0
+	}
+	if i >= len(utmBands) {
+		i = len(utmBands) - 1
+	}
+
+	return utmBands[i]
+}
+
+// utmCentralMeridian returns the central meridian of the passed in zone in degrees.
+func utmCentralMeridian(zone int) float64 {
+	return float64(zone-1)*6 - 180 + 3
+}
+
+// toUTM projects Point p into the passed in zone.
+func toUTM(p Point, zone int) (easting float64, northing float64) {
+	x, y := wgs84TransverseMercator.forward(p.lat, p.lng, utmCentralMeridian(zone))
+
+	easting = utmFalseEasting + utmScaleFactor*x
+	northing = utmScaleFactor * y
+	if p.lat < 0 {
+		northing += utmFalseNorthing
+	}
+
+	return easting, northing
+}
+
+// fromUTM is the inverse of toUTM.
+func fromUTM(zone int, north bool, easting float64, northing float64) Point {
+	x := (easting - utmFalseEasting) / utmScaleFactor
+	if !north {
+		northing -= utmFalseNorthing
+	}
+	y := northing / utmScaleFactor
+
+	lat, lng := wgs84TransverseMercator.inverse(x, y, utmCentralMeridian(zone))
+	return NewPoint(lat, NormalizeLng(lng))
+}
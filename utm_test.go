@@ -0,0 +1,93 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+// Ensures that points project to known UTM coordinates.
+func TestToUTM(t *testing.T) {
+	tests := []struct {
+		p          Point
+		zone       int
+		hemisphere Hemisphere
+		easting    float64
+		northing   float64
+	}{
+		{NewPoint(0, 0), 31, NorthernHemisphere, 166021.443, 0},
+		{NewPoint(48.8582, 2.2945), 31, NorthernHemisphere, 448251.8, 5411932.7},
+	}
+
+	for _, tt := range tests {
+		zone, hemisphere, easting, northing := ToUTM(tt.p)
+		if zone != tt.zone || hemisphere != tt.hemisphere {
+			t.Errorf("Expected %v to be in zone %d%c, but got %d%c instead", tt.p, tt.zone, tt.hemisphere, zone, hemisphere)
+		}
+		if math.Abs(easting-tt.easting) > 1 || math.Abs(northing-tt.northing) > 1 {
+			t.Errorf("Expected %v to project to %v, %v but got %v, %v instead", tt.p, tt.easting, tt.northing, easting, northing)
+		}
+	}
+}
+
+// Ensures that the Norway and Svalbard zone exceptions are applied.
+func TestUTMZone(t *testing.T) {
+	tests := []struct {
+		p    Point
+		zone int
+	}{
+		{NewPoint(47.6, -122.3), 10},
+		{NewPoint(60.39, 5.32), 32},
+		{NewPoint(60.39, 2.5), 31},
+		{NewPoint(78.22, 15.65), 33},
+		{NewPoint(78.22, 8.9), 31},
+		{NewPoint(78.22, 40), 37},
+		{NewPoint(0, 180), 60},
+		{NewPoint(0, -180), 1},
+	}
+
+	for _, tt := range tests {
+		if zone := UTMZone(tt.p); zone != tt.zone {
+			t.Errorf("Expected %v to be in zone %d, but got %d instead", tt.p, tt.zone, zone)
+		}
+	}
+
+	if UTMBand(48.8582) != 'U' || UTMBand(-85) != 'C' || UTMBand(83) != 'X' {
+		t.Error("Expected latitude bands to follow the UTM lettering")
+	}
+}
+
+// Ensures that UTM coordinates convert back to the points they were projected from.
+func TestFromUTM(t *testing.T) {
+	points := []Point{
+		NewPoint(48.8582, 2.2945),
+		NewPoint(-33.8568, 151.2153),
+		NewPoint(78.22, 15.65),
+		NewPoint(-79.9, -179.9),
+	}
+
+	for _, p := range points {
+		zone, hemisphere, easting, northing := ToUTM(p)
+		back, err := FromUTM(zone, hemisphere, easting, northing)
+		if err != nil {
+			t.Fatalf("Should not encounter an error when converting from UTM, but got %v", err)
+		}
+		if d := p.GreatCircleDistance(back); d > Distance(1e-3) {
+			t.Errorf("Expected %v to round trip through UTM, but got %v (%v away)", p, back, d)
+		}
+	}
+
+	// Projecting into a neighbouring zone must still round trip.
+	p := NewPoint(45, 5.9)
+	hemisphere, easting, northing := ToUTMZone(p, 32)
+	back, _ := FromUTM(32, hemisphere, easting, northing)
+	if d := p.GreatCircleDistance(back); d > Distance(1e-3) {
+		t.Errorf("Expected %v to round trip through zone 32, but got %v", p, back)
+	}
+
+	if _, err := FromUTM(61, NorthernHemisphere, 500000, 0); err == nil {
+		t.Error("Expected an error for zone 61")
+	}
+	if _, err := FromUTM(31, 'X', 500000, 0); err == nil {
+		t.Error("Expected an error for an invalid hemisphere")
+	}
+}
@@ -0,0 +1,54 @@
+package geo
+
+import "math"
+
+// vincentyIterations bounds the iterations of Vincenty's inverse method, which converges within a few
+// for all but nearly antipodal points.
+const vincentyIterations = 200
+
+// Distance returns the geodesic distance between p1 and p2 on the Ellipsoid, by Vincenty's inverse
+// method, which is accurate to within a millimeter.  For nearly antipodal points, where the method does
+// not converge, it falls back to the great circle distance on a sphere of the mean radius of the Ellipsoid.
+func (e Ellipsoid) Distance(p1 Point, p2 Point) Distance {
+	a, f := e.SemiMajorAxis, e.Flattening
+	b := a * (1 - f)
+	L := (p2.lng - p1.lng) * math.Pi / 180
+	u1 := math.Atan((1 - f) * math.Tan(p1.lat*math.Pi/180))
+	u2 := math.Atan((1 - f) * math.Tan(p2.lat*math.Pi/180))
+	sinU1, cosU1 := math.Sincos(u1)
+	sinU2, cosU2 := math.Sincos(u2)
+
+	lambda := L
+	for range vincentyIterations {
+		sinLambda, cosLambda := math.Sincos(lambda)
+		sinSigma := math.Hypot(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda)
+		if sinSigma == 0 {
+			return 0
+		}
+		cosSigma := sinU1*sinU2 + cosU1*cosU2*cosLambda
+		sigma := math.Atan2(sinSigma, cosSigma)
+		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+		cos2Alpha := 1 - sinAlpha*sinAlpha
+		cos2SigmaM := 0.0
+		if cos2Alpha != 0 {
+			// Points on the equator have no midpoint latitude.
+			cos2SigmaM = cosSigma - 2*sinU1*sinU2/cos2Alpha
+		}
+		c := f / 16 * cos2Alpha * (4 + f*(4-3*cos2Alpha))
+		prev := lambda
+		lambda = L + (1-c)*f*sinAlpha*(sigma+c*sinSigma*(cos2SigmaM+c*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+		if math.Abs(lambda-prev) > 1e-12 {
+			continue
+		}
+
+		uSq := cos2Alpha * (a*a - b*b) / (b * b)
+		A := 1 + uSq/16384*(4096+uSq*(-768+uSq*(320-175*uSq)))
+		B := uSq / 1024 * (256 + uSq*(-128+uSq*(74-47*uSq)))
+		deltaSigma := B * sinSigma * (cos2SigmaM + B/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+			B/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+		return Distance(b * A * (sigma - deltaSigma))
+	}
+
+	meanRadius := (2*a + b) / 3
+	return Distance(p1.GreatCircleDistance(p2).Kilometers() / EARTH_RADIUS * meanRadius)
+}
@@ -0,0 +1,30 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+// Ensures that geodesic distances on the ellipsoid match Vincenty's worked examples.
+func TestEllipsoidDistance(t *testing.T) {
+	tests := []struct {
+		p1, p2   Point
+		expected Distance
+	}{
+		// Flinders Peak to Buninyong, from Vincenty's paper as used by Geoscience Australia.
+		{NewPoint(-37.95103342, 144.42486789), NewPoint(-37.65282114, 143.92649554), 54972.271 * Meter},
+		{NewPoint(0, 0), NewPoint(0, 1), 111319.491 * Meter},
+		{NewPoint(0, 0), NewPoint(1, 0), 110574.389 * Meter},
+		{NewPoint(10, 10), NewPoint(10, 10), 0},
+	}
+	for _, tt := range tests {
+		if d := WGS84Ellipsoid.Distance(tt.p1, tt.p2); math.Abs(float64(d-tt.expected)) > 0.001 {
+			t.Errorf("Expected %v between %v and %v, but got %v", tt.expected, tt.p1, tt.p2, d)
+		}
+	}
+
+	// Nearly antipodal points fall back to the great circle distance.
+	if d := WGS84Ellipsoid.Distance(NewPoint(0, 0), NewPoint(0.5, 179.7)); math.Abs(d.Kilometers()-20000)/20000 > 0.01 {
+		t.Errorf("Expected about half the circumference, but got %v", d)
+	}
+}
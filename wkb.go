@@ -0,0 +1,97 @@
+package geo
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// WKB geometry type codes.
+const (
+	wkbPolygon   = 3
+	ewkbSRIDFlag = 0x20000000
+)
+
+// DecodeWKBPolygon decodes a Polygon from its Well-Known Binary representation, as stored
+// by PostGIS and most GIS tools.  Extended WKB with an embedded SRID is accepted.  Only the
+// outer ring is kept, without its closing point.  Coordinates are read as longitude, latitude.
+func DecodeWKBPolygon(data []byte) (Polygon, error) {
+	r := wkbReader{data: data}
+
+	switch r.byte() {
+	case 0:
+		r.order = binary.BigEndian
+	case 1:
+		r.order = binary.LittleEndian
+	default:
+		return Polygon{}, fmt.Errorf("%w: invalid WKB byte order", ErrInvalidFormat)
+	}
+
+	kind := r.uint32()
+	if kind&ewkbSRIDFlag != 0 {
+		r.uint32()
+		kind &^= ewkbSRIDFlag
+	}
+	if r.err == nil && kind != wkbPolygon {
+		return Polygon{}, fmt.Errorf("%w: WKB geometry type %d, expected Polygon", ErrUnsupportedGeometry, kind)
+	}
+
+	rings := r.uint32()
+	if r.err == nil && rings == 0 {
+		return Polygon{}, fmt.Errorf("%w: WKB Polygon has no rings", ErrUnclosedPolygon)
+	}
+
+	n := r.uint32()
+	if r.err == nil && uint64(n)*16 > uint64(len(r.data)-r.offset) {
+		return Polygon{}, fmt.Errorf("%w: WKB ring of %d points is truncated", ErrInvalidFormat, n)
+	}
+
+	points := make([]Point, 0, n)
+	for i := uint32(0); i < n && r.err == nil; i++ {
+		lng, lat := r.float64(), r.float64()
+		points = append(points, NewPoint(lat, lng))
+	}
+
+	if r.err != nil {
+		return Polygon{}, r.err
+	}
+
+	if len(points) > 1 && points[0] == points[len(points)-1] {
+		points = points[:len(points)-1]
+	}
+
+	return NewPolygon(points), nil
+}
+
+// wkbReader reads values from WKB, remembering the first read past the end of the data.
+type wkbReader struct {
+	data   []byte
+	offset int
+	order  binary.ByteOrder
+	err    error
+}
+
+func (r *wkbReader) next(n int) []byte {
+	if r.err != nil || len(r.data)-r.offset < n {
+		if r.err == nil {
+			r.err = fmt.Errorf("%w: WKB is truncated", ErrInvalidFormat)
+		}
+		return make([]byte, n)
+	}
+
+	b := r.data[r.offset : r.offset+n]
+	r.offset += n
+	return b
+}
+
+func (r *wkbReader) byte() byte {
+	return r.next(1)[0]
+}
+
+func (r *wkbReader) uint32() uint32 {
+	return r.order.Uint32(r.next(4))
+}
+
+func (r *wkbReader) float64() float64 {
+	return math.Float64frombits(r.order.Uint64(r.next(8)))
+}
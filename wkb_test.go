@@ -0,0 +1,57 @@
+package geo
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// encodeWKBPolygon encodes the outer ring of p as a WKB Polygon in the passed in byte order.
+func encodeWKBPolygon(p Polygon, order binary.AppendByteOrder) []byte {
+	b := []byte{1}
+	if order == binary.BigEndian {
+		b[0] = 0
+	}
+	b = order.AppendUint32(b, wkbPolygon)
+	b = order.AppendUint32(b, 1)
+	b = order.AppendUint32(b, uint32(len(p.points)+1))
+	for _, point := range append(p.Points(), p.points[0]) {
+		b = order.AppendUint64(b, math.Float64bits(point.lng))
+		b = order.AppendUint64(b, math.Float64bits(point.lat))
+	}
+	return b
+}
+
+// Ensures that WKB polygons decode in both byte orders, without their closing point.
+func TestDecodeWKBPolygon(t *testing.T) {
+	square := NewPolygon([]Point{NewPoint(0, 0), NewPoint(0, 1), NewPoint(1, 1), NewPoint(1, 0)})
+
+	for _, order := range []binary.AppendByteOrder{binary.LittleEndian, binary.BigEndian} {
+		p, err := DecodeWKBPolygon(encodeWKBPolygon(square, order))
+		if err != nil {
+			t.Fatalf("Should not encounter an error when decoding %v WKB, but got %v", order, err)
+		}
+		if !reflect.DeepEqual(p.Points(), square.Points()) {
+			t.Errorf("Expected %v WKB to decode to %v, but got %v", order, square.Points(), p.Points())
+		}
+	}
+}
+
+// Ensures that WKB of other geometries and truncated WKB are rejected.
+func TestDecodeWKBPolygonInvalid(t *testing.T) {
+	point := binary.LittleEndian.AppendUint32([]byte{1}, 1)
+	if _, err := DecodeWKBPolygon(point); !errors.Is(err, ErrUnsupportedGeometry) {
+		t.Errorf("Expected a WKB Point to be rejected, but got %v", err)
+	}
+
+	square := encodeWKBPolygon(NewPolygon([]Point{NewPoint(0, 0), NewPoint(0, 1), NewPoint(1, 1)}), binary.LittleEndian)
+	if _, err := DecodeWKBPolygon(square[:len(square)-4]); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("Expected truncated WKB to be rejected, but got %v", err)
+	}
+
+	if _, err := DecodeWKBPolygon(nil); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("Expected empty WKB to be rejected, but got %v", err)
+	}
+}